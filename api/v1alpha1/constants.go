@@ -38,6 +38,13 @@ const (
 	// in to drain-before-roll. Set on InferenceService metadata.annotations.
 	AnnotationIdleEndpoint = "inference.llmkube.dev/idle-endpoint"
 
+	// AnnotationRestartedAt, set on InferenceService metadata.annotations,
+	// is copied onto the pod template, so changing its value (typically to
+	// the current timestamp) rolls every pod and reloads the model without
+	// a spec change, e.g. after the cached model file was replaced. This is
+	// the InferenceService counterpart of `kubectl rollout restart`.
+	AnnotationRestartedAt = "inference.llmkube.dev/restartedAt"
+
 	// DefaultAgentHeartbeatInterval is how often the metal-agent re-asserts
 	// its registrations (which also self-heals any missed update, #657).
 	DefaultAgentHeartbeatInterval = 30 * time.Second
@@ -28,6 +28,13 @@ const (
 	ServingModeRerank    = "rerank"
 )
 
+// Endpoint API styles accepted in spec.endpoint.apiStyle.
+const (
+	APIStyleChat        = "chat"
+	APIStyleCompletions = "completions"
+	APIStyleLegacy      = "legacy"
+)
+
 // InferenceServiceSpec defines the desired state of InferenceService
 // RopeScalingType selects the RoPE context-extension method. Mirrors
 // llama.cpp's --rope-scaling values.
@@ -80,6 +87,26 @@ type SpeculativeDecodingSpec struct {
 	NDraftMax *int32 `json:"nDraftMax,omitempty"`
 }
 
+// LoRASpec is a LoRA adapter applied on top of the base model by llama-server.
+// The adapter GGUF is downloaded by its own init container into the same
+// volume as the model and passed as --lora, or --lora-scaled when Scale is set.
+type LoRASpec struct {
+	// Name identifies the adapter within the InferenceService. It names the
+	// adapter's downloader init container ("lora-<name>").
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Source is the http(s) URL of the adapter GGUF file.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Source string `json:"source"`
+
+	// Scale is the adapter strength (--lora-scaled). Omit to apply the
+	// adapter at llama.cpp's default scale of 1.0 (--lora).
+	// +optional
+	Scale *float64 `json:"scale,omitempty"`
+}
+
 // ModelCacheSpec points this InferenceService's model cache at a user-managed
 // PVC instead of the operator's shared/perService cache PVC. The operator
 // mounts and populates the claim through the same prep + download init
@@ -154,6 +181,30 @@ type InferenceServiceSpec struct {
 	// +optional
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
 
+	// MinReadySeconds is how long a new inference Pod must stay Ready before
+	// the Deployment counts it as available. Servers that pass readiness and
+	// then briefly fail while warming would otherwise flap the service
+	// between Ready and NotReady. When set, the service's ready replica
+	// count follows the Deployment's available replicas. Unset or 0 counts
+	// Pods as soon as they are Ready.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// WarmStandby keeps one extra inference Pod running with the model
+	// loaded but out of the Service endpoints, so losing a serving replica
+	// fails over without a cold model load. The Pods carry a readiness gate
+	// (inference.llmkube.dev/serving) that the controller sets only on
+	// spec.replicas of them; when a serving Pod stops being ready the
+	// standby's gate is flipped and it starts receiving traffic, and the
+	// Pod that replaces or recovers the failed one becomes the next
+	// standby. A rolling update uses maxUnavailable 1 and hands traffic to
+	// each ready Pod of the new revision before an old one is removed.
+	// Costs one replica's worth of GPU. Cannot be combined with autoscaling
+	// or maxPodLifetimeSeconds. Defaults to false.
+	// +optional
+	WarmStandby bool `json:"warmStandby,omitempty"`
+
 	// Autoscaling configures horizontal pod autoscaling for the inference service.
 	// When set, the controller creates and manages an HPA resource targeting the
 	// inference Deployment. Requires Prometheus Adapter for custom metrics.
@@ -204,7 +255,9 @@ type InferenceServiceSpec struct {
 	// TopologySpreadConstraints control how inference Pods are spread across
 	// topology domains (e.g. one model server per GPU node). Passthrough to the
 	// Pod spec; combine with PodLabels so the constraint's labelSelector can
-	// match sibling GPU workloads for a soft, cross-app spread.
+	// match sibling GPU workloads for a soft, cross-app spread. When empty,
+	// Pods are soft-spread across nodes (topologyKey kubernetes.io/hostname,
+	// maxSkew 1, ScheduleAnyway).
 	// +optional
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
@@ -228,6 +281,13 @@ type InferenceServiceSpec struct {
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
 
+	// SchedulerName dispatches inference Pods to a scheduler other than the
+	// cluster default, e.g. volcano, kai-scheduler, or Run:ai, for gang
+	// scheduling or GPU bin-packing. Unset uses the default scheduler. Maps
+	// directly to PodSpec.SchedulerName.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
 	// ContextSize sets the context window size for the llama.cpp server (-c flag).
 	// Larger values allow processing longer inputs but require more memory.
 	// If not specified, llama.cpp uses its default (typically 512 or 2048).
@@ -268,6 +328,16 @@ type InferenceServiceSpec struct {
 	// +optional
 	FlashAttention *bool `json:"flashAttention,omitempty"`
 
+	// MemoryLock pins the model weights in RAM so they cannot be swapped out
+	// on memory-pressured nodes. Maps to llama.cpp --mlock flag; the
+	// container needs enough memlock ulimit (or IPC_LOCK) to lock the model.
+	//
+	// On Apple Silicon (Metal agent path) the default is true when this field
+	// is unset, because mlock keeps macOS's wired collector from evicting the
+	// model; set false to opt out.
+	// +optional
+	MemoryLock *bool `json:"memoryLock,omitempty"`
+
 	// Jinja enables Jinja2 chat template rendering for tool/function calling support.
 	// Required when using the OpenAI-compatible API with tools. Maps to llama.cpp --jinja flag.
 	// +optional
@@ -339,6 +409,26 @@ type InferenceServiceSpec struct {
 	// +optional
 	SpeculativeDecoding *SpeculativeDecodingSpec `json:"speculativeDecoding,omitempty"`
 
+	// DraftModelRef names a second, smaller Model CR in the same namespace to
+	// serve as the speculative-decoding draft model. The draft is downloaded
+	// into the model cache alongside ModelRef and passed to llama-server as
+	// --model-draft; the InferenceService waits for it to be Ready. Set the
+	// draft token count with speculativeDecoding.nDraftMax. Only the
+	// "llamacpp" runtime supports this field, and it requires the persistent
+	// model cache.
+	// +optional
+	DraftModelRef string `json:"draftModelRef,omitempty"`
+
+	// LoRAAdapters are LoRA adapters llama-server applies to the base model
+	// at startup. Each adapter is downloaded into the model volume by its own
+	// init container; download progress is reported in the LoRAAdaptersReady
+	// condition. Only the "llamacpp" runtime supports this field.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	LoRAAdapters []LoRASpec `json:"loraAdapters,omitempty"`
+
 	// ReasoningBudget caps the number of reasoning tokens the model is allowed to
 	// emit per response. Zero disables visible thinking output entirely; the model
 	// still reasons internally but does not emit thinking tokens. Critical for
@@ -443,6 +533,12 @@ type InferenceServiceSpec struct {
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
+	// EnvFrom populates the inference container's environment from
+	// ConfigMaps or Secrets, e.g. a shared set of LLAMA_ARG_* or
+	// HSA_OVERRIDE_GFX_VERSION settings. Keys defined in Env take precedence.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
 	// ExtraVolumes adds additional Volumes to the inference Pod, appended
 	// after the model-storage volumes built from ModelRef. Useful for a
 	// runtime-owned cache (e.g. a JIT kernel cache) that is unrelated to
@@ -457,6 +553,19 @@ type InferenceServiceSpec struct {
 	// +optional
 	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
 
+	// ExtraInitContainers run before the managed model-downloader init
+	// container, e.g. to decrypt or convert weights or to fetch a tokenizer.
+	// Each one gets the model volume mounted read-write at /models unless it
+	// already mounts that volume or that path itself. Names must not collide
+	// with the managed init containers (model-cache-prep, model-downloader,
+	// and the draft-/lora- prefixed ones) or the inference container.
+	// The containers are not validated by the CRD schema, to keep it small;
+	// the API server validates them when the Deployment is created.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ExtraInitContainers []corev1.Container `json:"extraInitContainers,omitempty"`
+
 	// ContainerPort overrides the primary container port.
 	// Each runtime has its own default (llamacpp: 8080).
 	// +kubebuilder:validation:Minimum=1
@@ -513,9 +622,21 @@ type InferenceServiceSpec struct {
 	SGLangConfig *SGLangConfig `json:"sglangConfig,omitempty"`
 
 	// ImagePullSecrets for pulling container images from private registries.
+	// Set on the Pod, so they cover the runtime image and the init containers
+	// (model download, cache prep) alike.
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 
+	// ImagePullPolicy is applied to the inference container and the init
+	// containers (model download, cache prep, draft model, LoRA adapters).
+	// Defaults to the controller's --default-image-pull-policy, and to the
+	// Kubernetes default (Always for :latest tags) when that is unset.
+	// IfNotPresent avoids a registry round trip on every pod start, which
+	// matters behind air-gapped mirrors.
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
 	// Priority determines scheduling priority for GPU allocation.
 	// Higher priority services can preempt lower priority ones when GPUs are scarce.
 	// +kubebuilder:validation:Enum=critical;high;normal;low;batch
@@ -547,6 +668,10 @@ type InferenceServiceSpec struct {
 	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
 
 	// SecurityContext defines container-level security attributes for the inference container.
+	// When unset, the operator applies a hardened default (no privilege escalation,
+	// all capabilities dropped, RuntimeDefault seccomp) and, for CPU-only llama.cpp
+	// pods without a podSecurityContext, runs as non-root UID 65532. Set this to
+	// replace the default entirely, e.g. to run GPU pods as non-root.
 	// +optional
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 
@@ -664,11 +789,29 @@ type EndpointSpec struct {
 	// +optional
 	Port int32 `json:"port,omitempty"`
 
-	// Path is the HTTP path for the inference endpoint
-	// +kubebuilder:default="/v1/chat/completions"
+	// Path is the route reported in status.endpoint. The Service always
+	// exposes the whole server, so this only picks which route clients are
+	// pointed at. It is normalized to a single leading slash with no
+	// trailing slash. For llama.cpp InferenceServices it should be a route
+	// llama-server serves (e.g. /v1/chat/completions, /v1/completions,
+	// /v1/embeddings, /v1/rerank, /v1); any other path sets
+	// EndpointRouteKnown=False with a Warning event. When unset it follows
+	// the serving mode:
+	// /v1/embeddings for embedding, /v1/rerank for rerank, and otherwise the
+	// route for APIStyle.
 	// +optional
 	Path string `json:"path,omitempty"`
 
+	// APIStyle picks the route reported in status.endpoint for chat serving
+	// when Path is unset: "chat" (default) for /v1/chat/completions,
+	// "completions" for the OpenAI text-completion /v1/completions, and
+	// "legacy" for llama-server's native /completion. llama-server serves all
+	// three, so no server flags change; "legacy" is only valid for the
+	// llama.cpp runtimes. ModelRouter ignores this field.
+	// +kubebuilder:validation:Enum=chat;completions;legacy
+	// +optional
+	APIStyle string `json:"apiStyle,omitempty"`
+
 	// Type is the Kubernetes service type (ClusterIP, NodePort, LoadBalancer)
 	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
 	// +kubebuilder:default=ClusterIP
@@ -692,6 +835,46 @@ type EndpointSpec struct {
 	// are a documented prerequisite; LLMKube does not install or own them.
 	// +optional
 	Gateway *GatewaySpec `json:"gateway,omitempty"`
+
+	// Ingress exposes this InferenceService through a networking.k8s.io/v1
+	// Ingress for cluster-external access by hostname. The operator owns the
+	// Ingress (it is deleted with the InferenceService) and reports the
+	// ingress URL in status.endpoint. Metal-backed services are skipped.
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+}
+
+// IngressSpec configures the Ingress generated for an InferenceService.
+type IngressSpec struct {
+	// Host is the hostname the Ingress routes to the Service, e.g.
+	// "llm.example.com".
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Path is the path prefix routed to the Service. The default "/" exposes
+	// every route the server serves; any other prefix needs the ingress
+	// controller to strip it (e.g. a rewrite annotation), since the server
+	// itself only answers under /.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +kubebuilder:default="/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// IngressClassName selects the ingress controller. Unset uses the
+	// cluster's default IngressClass.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// TLSSecretName is a kubernetes.io/tls Secret in the InferenceService's
+	// namespace holding the certificate for Host. When set, the Ingress
+	// terminates TLS and status.endpoint uses https.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations are added to the Ingress, e.g. controller-specific proxy
+	// timeouts for long generations or a cert-manager issuer.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // GatewaySpec opts an InferenceService into Envoy AI Gateway exposure.
@@ -791,6 +974,19 @@ type InferenceResourceRequirements struct {
 	// +optional
 	Memory string `json:"memory,omitempty"`
 
+	// CPULimit caps the inference container's CPU (e.g., "4" or "4000m").
+	// It must be at least the CPU request. Unset leaves the container without a
+	// CPU limit.
+	// +optional
+	CPULimit string `json:"cpuLimit,omitempty"`
+
+	// MemoryLimit caps the inference container's memory (e.g., "8Gi"), so a
+	// runaway llama-server is OOM-killed instead of starving the node. It must
+	// be at least the memory request (HostMemory or Memory). Unset leaves the
+	// container without a memory limit.
+	// +optional
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+
 	// HostMemory specifies the system RAM required for hybrid GPU/CPU offloading (e.g., "64Gi").
 	// Used when MoE expert weights or KV cache are offloaded to CPU via moeCPUOffload or noKvOffload.
 	// Translated to pod resources.requests.memory, taking precedence over Memory when set.
@@ -875,9 +1071,20 @@ type AutoscalingSpec struct {
 	MaxReplicas int32 `json:"maxReplicas"`
 
 	// Metrics defines the scaling metrics and target values.
-	// If empty, defaults to llamacpp:requests_processing with target average value of 2.
+	// If empty (and TargetCPUUtilization is unset), defaults to
+	// llamacpp:requests_processing with target average value of 2.
 	// +optional
 	Metrics []MetricSpec `json:"metrics,omitempty"`
+
+	// TargetCPUUtilization is shorthand for a Resource metric on cpu with this
+	// average utilization percentage. It is added alongside Metrics and
+	// replaces the default metric when Metrics is empty. Utilization is
+	// measured against the container's CPU request, so resources.cpu must be
+	// set.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
 }
 
 // MetricSpec defines a single metric for HPA scaling.
@@ -26,13 +26,15 @@ type ModelSpec struct {
 	// Source defines where to obtain the model.
 	// For GGUF models: URL or path to a .gguf file.
 	// For MLX models: local directory path containing the model (config.json, weights).
-	// Supported schemes: http://, https://, file://, pvc://, hf://, s3://, or absolute paths.
+	// Supported schemes: http://, https://, file://, pvc://, hf://, s3://, gs://, or absolute paths.
 	// Examples:
 	//   - https://huggingface.co/org/repo/resolve/main/model.gguf
 	//   - file:///mnt/models/model.gguf
 	//   - /mnt/models/model.gguf (air-gapped deployments)
 	//   - pvc://my-models-pvc/path/to/model.gguf (pre-staged on a PersistentVolumeClaim)
 	//   - s3://my-bucket/models/llama-3.1-8b-q4_k_m.gguf (S3-compatible object store)
+	//   - gs://my-bucket/models/llama-3.1-8b-q4_k_m.gguf (Google Cloud Storage, HMAC keys)
+	//   - https://example.com/qwen-72b-00001-of-00003.gguf (first shard of a split GGUF; every shard is staged)
 	//   - /mnt/models/Llama-3.2-3B-Instruct-4bit (MLX model directory)
 	//
 	// file:// caveat for hybrid topologies: the controller pod must be
@@ -45,11 +47,14 @@ type ModelSpec struct {
 	// equivalent https://huggingface.co/.../<filename>.gguf URL which
 	// the runtime/init container resolves at deploy time.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=`^(https?|file|pvc|hf|s3)://.*|^/[^\s]+$|^[a-zA-Z0-9][\w\-\.\/]+$`
+	// +kubebuilder:validation:Pattern=`^(https?|file|pvc|hf|s3|gs)://.*|^/[^\s]+$|^[a-zA-Z0-9][\w\-\.\/]+$`
 	Source string `json:"source"`
 
 	// SHA256 is the expected SHA256 hash of the model file for integrity verification.
-	// When set, the controller verifies the downloaded/copied file matches this hash.
+	// When set, the controller verifies the downloaded/copied file matches this hash,
+	// and the InferenceService model-downloader init container verifies the file
+	// it fetches, exiting non-zero on a mismatch. A mismatch seen by the controller
+	// marks the Model Degraded with reason ChecksumMismatch.
 	// +kubebuilder:validation:Pattern=`^[a-fA-F0-9]{64}$`
 	// +optional
 	SHA256 string `json:"sha256,omitempty"`
@@ -59,6 +64,12 @@ type ModelSpec struct {
 	// sources for S3-compatible credentials/endpoint: AWS_ACCESS_KEY_ID,
 	// AWS_SECRET_ACCESS_KEY, AWS_REGION, AWS_ENDPOINT_URL (path-style, e.g.
 	// https://minio.internal:9000 or https://s3.us-east-1.amazonaws.com).
+	// gs:// sources use the same keys holding a GCS HMAC key pair;
+	// AWS_ENDPOINT_URL defaults to https://storage.googleapis.com.
+	// For private or gated Hugging Face models, an HF_TOKEN key is sent as a
+	// bearer Authorization header by the downloader and by the controller's
+	// metadata reads. A missing Secret marks the Model Degraded with reason
+	// SourceSecretNotFound.
 	// +optional
 	SourceSecretRef *corev1.LocalObjectReference `json:"sourceSecretRef,omitempty"`
 
@@ -93,6 +104,13 @@ type ModelSpec struct {
 	// +optional
 	Prefetch bool `json:"prefetch,omitempty"`
 
+	// CacheTTL is how long this Model's entry in the shared model cache PVC
+	// is kept once no Model references it any more, after which the
+	// controller evicts it. Overrides the controller-wide --model-cache-ttl;
+	// unset uses that default, and a zero TTL keeps the entry forever.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+
 	// Format specifies the model file format.
 	// "gguf" is used with the llama-server runtime; "mlx" is used with the oMLX runtime;
 	// "safetensors", "pytorch", and "custom" are used with the generic runtime.
@@ -137,7 +155,10 @@ type HardwareSpec struct {
 	// it resolves to devic.es/dri-render rather than amd.com/gpu, since
 	// the two runtimes share the same physical device on single-GPU AMD
 	// nodes. The tiers differ only in the container image they select.
-	// +kubebuilder:validation:Enum=cpu;metal;cuda;rocm;intel;vulkan
+	// "sycl" (or "intel") runs llama.cpp's SYCL backend on Intel GPUs and
+	// requests gpu.intel.com/i915; "vulkan" with gpu.vendor intel selects
+	// the upstream Vulkan llama.cpp image on the same resource.
+	// +kubebuilder:validation:Enum=cpu;metal;cuda;rocm;intel;vulkan;sycl
 	// +kubebuilder:default=cpu
 	// +optional
 	Accelerator string `json:"accelerator,omitempty"`
@@ -232,6 +253,26 @@ type GPUSpec struct {
 	// +optional
 	Runtime string `json:"runtime,omitempty"`
 
+	// VisibleDevices pins the inference container to specific GPU indices on
+	// the node by setting CUDA_VISIBLE_DEVICES (nvidia) or HIP_VISIBLE_DEVICES
+	// (amd). Intended for nodes where the device plugin exposes every GPU to
+	// the pod. When set, it must list exactly as many indices as the resolved
+	// GPU count. Not supported for intel.
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:Minimum=0
+	// +listType=set
+	// +optional
+	VisibleDevices []int32 `json:"visibleDevices,omitempty"`
+
+	// MainGPU selects the GPU that holds intermediate results and, under
+	// "tensor"/"row" sharding, the KV cache. It is an index into the devices
+	// the container sees, so when VisibleDevices is set it is a position in
+	// that list (0 = the first listed device), not a node-level GPU index.
+	// Must be less than the resolved GPU count. Maps to llama.cpp --main-gpu.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MainGPU *int32 `json:"mainGpu,omitempty"`
+
 	// Layers specifies layer offloading configuration for multi-GPU
 	// Format: number of layers to offload to GPU (e.g., 32 for full offload on 7B model)
 	// -1 means auto-detect optimal layer split
@@ -244,6 +285,37 @@ type GPUSpec struct {
 	// +optional
 	Sharding *GPUShardingSpec `json:"sharding,omitempty"`
 
+	// RowSplitThreshold lets the controller choose the split mode when
+	// sharding.strategy is unset: models with at least this many billion
+	// parameters (read from the GGUF header) run with row split on 2-4 GPUs,
+	// everything else with layer split. Row split shares each layer's
+	// compute across GPUs, which pays off once layers are large enough to
+	// outweigh the per-token synchronization; past 4 GPUs that traffic
+	// dominates, so layer split is kept. Ignored when sharding.strategy is
+	// set and when splitKVCache is enabled, which requires layer split.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RowSplitThreshold *int32 `json:"rowSplitThreshold,omitempty"`
+
+	// SplitKVCache distributes the KV cache across GPUs along with the model
+	// weights, so very long contexts are not bounded by a single device's
+	// VRAM. Only takes effect when the resolved GPU count is greater than 1.
+	// Requires sharding.strategy "layer" (the default) or "pipeline": with
+	// "tensor"/"row" llama.cpp keeps the whole KV cache on the main GPU, and
+	// "none" uses a single GPU. Incompatible with InferenceService
+	// spec.noKvOffload. Maps to llama.cpp --split-mode layer --kv-offload.
+	// +optional
+	SplitKVCache *bool `json:"splitKVCache,omitempty"`
+
+	// CudaGraphs toggles CUDA graph capture in llama.cpp's CUDA backend.
+	// Graphs cut kernel launch overhead and usually speed up decode, but a
+	// few model architectures and driver combinations misbehave with them.
+	// Unset keeps the image default (enabled); false disables them through
+	// GGML_CUDA_DISABLE_GRAPHS. Ignored for non-NVIDIA GPUs and runtimes
+	// other than llama.cpp.
+	// +optional
+	CudaGraphs *bool `json:"cudaGraphs,omitempty"`
+
 	// ResourceClaims defines DRA (Dynamic Resource Allocation) claims for GPU devices.
 	// Uses resource.k8s.io/v1 PodResourceClaim format. Each claim must have exactly
 	// one of resourceClaimName or resourceClaimTemplateName set.
@@ -320,6 +392,11 @@ type GGUFMetadata struct {
 	// +optional
 	TensorCount uint64 `json:"tensorCount,omitempty"`
 
+	// ParameterCount is the total number of model parameters, summed over
+	// the tensor shapes
+	// +optional
+	ParameterCount uint64 `json:"parameterCount,omitempty"`
+
 	// FileVersion is the GGUF file format version
 	// +optional
 	FileVersion uint32 `json:"fileVersion,omitempty"`
@@ -341,6 +418,13 @@ type ModelStatus struct {
 	// +optional
 	Size string `json:"size,omitempty"`
 
+	// EstimatedMemory is the memory the model needs to serve (weights plus
+	// runtime overhead, excluding the per-service KV cache), checked against
+	// the largest node when the Model is reconciled (see the FitsNode
+	// condition)
+	// +optional
+	EstimatedMemory string `json:"estimatedMemory,omitempty"`
+
 	// Path represents the local path where the model is stored
 	// +optional
 	Path string `json:"path,omitempty"`
@@ -355,6 +439,12 @@ type ModelStatus struct {
 	// +optional
 	SHA256 string `json:"sha256,omitempty"`
 
+	// SourceType is the kind of source the model is fetched from, as detected
+	// from spec.source: http, huggingface, s3, gcs, pvc, or local.
+	// +kubebuilder:validation:Enum=http;huggingface;s3;gcs;pvc;local
+	// +optional
+	SourceType string `json:"sourceType,omitempty"`
+
 	// StagedFiles lists the repo-relative paths staged in the model cache.
 	// Populated when spec.files or spec.mmproj are set.
 	// +optional
@@ -413,6 +503,9 @@ type ModelStatus struct {
 // +kubebuilder:printcolumn:name="Size",type=string,JSONPath=`.status.size`
 // +kubebuilder:printcolumn:name="Accelerator",type=string,JSONPath=`.spec.hardware.accelerator`
 // +kubebuilder:printcolumn:name="Arch",type=string,JSONPath=`.status.gguf.architecture`,priority=1
+// +kubebuilder:printcolumn:name="Quant",type=string,JSONPath=`.status.gguf.quantization`,priority=1
+// +kubebuilder:printcolumn:name="Context",type=integer,JSONPath=`.status.gguf.contextLength`,priority=1
+// +kubebuilder:printcolumn:name="Params",type=integer,JSONPath=`.status.gguf.parameterCount`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:resource:shortName=mdl
 
@@ -86,9 +86,20 @@ type ModelRouterSpec struct {
 
 	// Backends are the candidate destinations the router can dispatch to.
 	// Order is not significant; selection is rule-driven. At least one
-	// backend must be declared.
-	// +kubebuilder:validation:MinItems=1
-	Backends []RouterBackend `json:"backends"`
+	// backend must be declared unless DiscoverInferenceServices is set.
+	// +optional
+	Backends []RouterBackend `json:"backends,omitempty"`
+
+	// DiscoverInferenceServices turns the router into a single OpenAI
+	// endpoint for its namespace: every Ready InferenceService in the
+	// ModelRouter's namespace is added as a local backend named after the
+	// service, published on /v1/models, and reachable by sending its name
+	// as the request "model". Declared backends keep precedence over a
+	// discovered service of the same name. Discovered routers resolve
+	// unmatched requests with BackendNameMatch whatever DefaultRouteStrategy
+	// says. Supported with the Proxy data plane only.
+	// +optional
+	DiscoverInferenceServices bool `json:"discoverInferenceServices,omitempty"`
 
 	// Rules are evaluated in declaration order. The first matching rule wins.
 	// If no rule matches, the fallback is governed by DefaultRouteStrategy,
@@ -119,7 +130,8 @@ type ModelRouterSpec struct {
 	Policy *RouterPolicy `json:"policy,omitempty"`
 
 	// Endpoint defines the Kubernetes Service the router-proxy is exposed
-	// through. Mirrors the shape used by InferenceService.
+	// through. Mirrors the shape used by InferenceService; endpoint.ingress
+	// is not supported on a router and fails validation.
 	// +optional
 	Endpoint *EndpointSpec `json:"endpoint,omitempty"`
 
@@ -56,6 +56,11 @@ func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
@@ -195,6 +200,11 @@ func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
 		*out = new(GatewaySpec)
 		**out = **in
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSpec.
@@ -383,11 +393,36 @@ func (in *GPUSharingSpec) DeepCopy() *GPUSharingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUSpec) DeepCopyInto(out *GPUSpec) {
 	*out = *in
+	if in.VisibleDevices != nil {
+		in, out := &in.VisibleDevices, &out.VisibleDevices
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.MainGPU != nil {
+		in, out := &in.MainGPU, &out.MainGPU
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Sharding != nil {
 		in, out := &in.Sharding, &out.Sharding
 		*out = new(GPUShardingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RowSplitThreshold != nil {
+		in, out := &in.RowSplitThreshold, &out.RowSplitThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SplitKVCache != nil {
+		in, out := &in.SplitKVCache, &out.SplitKVCache
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CudaGraphs != nil {
+		in, out := &in.CudaGraphs, &out.CudaGraphs
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ResourceClaims != nil {
 		in, out := &in.ResourceClaims, &out.ResourceClaims
 		*out = make([]corev1.PodResourceClaim, len(*in))
@@ -650,6 +685,11 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MemoryLock != nil {
+		in, out := &in.MemoryLock, &out.MemoryLock
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Jinja != nil {
 		in, out := &in.Jinja, &out.Jinja
 		*out = new(bool)
@@ -680,6 +720,13 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 		*out = new(SpeculativeDecodingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LoRAAdapters != nil {
+		in, out := &in.LoRAAdapters, &out.LoRAAdapters
+		*out = make([]LoRASpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ReasoningBudget != nil {
 		in, out := &in.ReasoningBudget, &out.ReasoningBudget
 		*out = new(int32)
@@ -747,6 +794,13 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ExtraVolumes != nil {
 		in, out := &in.ExtraVolumes, &out.ExtraVolumes
 		*out = make([]corev1.Volume, len(*in))
@@ -761,6 +815,13 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtraInitContainers != nil {
+		in, out := &in.ExtraInitContainers, &out.ExtraInitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ContainerPort != nil {
 		in, out := &in.ContainerPort, &out.ContainerPort
 		*out = new(int32)
@@ -889,6 +950,33 @@ func (in *InferenceServiceStatus) DeepCopy() *InferenceServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWTAuthSpec) DeepCopyInto(out *JWTAuthSpec) {
 	*out = *in
@@ -1005,6 +1093,26 @@ func (in *LoRAAdapterStatus) DeepCopy() *LoRAAdapterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoRASpec) DeepCopyInto(out *LoRASpec) {
+	*out = *in
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoRASpec.
+func (in *LoRASpec) DeepCopy() *LoRASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoRASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalInferenceServiceReference) DeepCopyInto(out *LocalInferenceServiceReference) {
 	*out = *in
@@ -1303,6 +1411,11 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Hardware != nil {
 		in, out := &in.Hardware, &out.Hardware
 		*out = new(HardwareSpec)
@@ -36,6 +36,7 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -118,6 +119,11 @@ func main() {
 	var modelCacheClass string
 	var modelCacheAccessMode string
 	var modelCacheMode string
+	var modelCacheCleanup bool
+	var modelCacheTTL time.Duration
+	var modelCacheExpandIncrement string
+	var modelCacheExpandMaxSize string
+	var modelCacheExpandThreshold int
 	var allowedHostPathRoots string
 	var allowedRemoteHosts string
 	var gpuSharingSharedPoolSelector string
@@ -126,7 +132,9 @@ func main() {
 	var modelRevalidateInterval time.Duration
 	var caCertConfigMap string
 	var initContainerImage string
+	var defaultImagePullPolicy string
 	var defaultFSGroup int64
+	var defaultContextSizeMax int
 	var routerProxyImage string
 	var defaultLiteLLMURL string
 	var tlsOpts []func(*tls.Config)
@@ -144,6 +152,22 @@ func main() {
 			"(cross-isvc dedup, cache list works; use an RWX class on multi-node clusters); "+
 			"perService gives each InferenceService its own RWO, WaitForFirstConsumer cache PVC "+
 			"that binds on the serving node (opt-in escape hatch for multi-node clusters without RWX).")
+	flag.BoolVar(&modelCacheCleanup, "model-cache-cleanup", true,
+		"Remove a deleted Model's entry from the shared model cache PVC (via a short-lived Job) "+
+			"unless another Model in the namespace uses the same cache key. Only applies with --model-cache-mode=shared.")
+	flag.DurationVar(&modelCacheTTL, "model-cache-ttl", 0,
+		"Evict shared model cache entries no Model has referenced for this long (e.g. 168h). A Model's "+
+			"spec.cacheTTL overrides it for that Model's entry. 0 (default) keeps unreferenced entries and only "+
+			"scans namespaces where a Model sets spec.cacheTTL. "+
+			"Only applies with --model-cache-mode=shared.")
+	flag.StringVar(&modelCacheExpandIncrement, "model-cache-expand-increment", "",
+		"Grow an operator-created model cache PVC by this quantity (e.g. 50Gi) when the model downloader reports "+
+			"usage at or above --model-cache-expand-threshold. Requires a StorageClass with allowVolumeExpansion. "+
+			"Empty disables automatic expansion.")
+	flag.StringVar(&modelCacheExpandMaxSize, "model-cache-expand-max-size", "500Gi",
+		"Upper bound for automatic model cache PVC expansion.")
+	flag.IntVar(&modelCacheExpandThreshold, "model-cache-expand-threshold", 85,
+		"Model cache usage percentage that triggers automatic expansion.")
 	flag.StringVar(&runtimeImages, "runtime-images", "",
 		"Fleet-wide runtime image overrides as runtime=image[,runtime=image] with runtimes "+
 			"llamacpp|vllm|sglang|tgi (chart value runtimeImages.*). Overrides the built-in "+
@@ -173,12 +197,20 @@ func main() {
 		"Name of the ConfigMap containing a custom CA certificate to trust for model downloads.")
 	flag.StringVar(&initContainerImage, "init-container-image", "docker.io/curlimages/curl:8.18.0",
 		"Container image for the model downloader init container.")
+	flag.StringVar(&defaultImagePullPolicy, "default-image-pull-policy", "",
+		"Image pull policy (Always, IfNotPresent or Never) for the inference and model "+
+			"downloader containers when an InferenceService leaves spec.imagePullPolicy unset. "+
+			"Empty keeps the Kubernetes default (Always for :latest tags).")
 	flag.Int64Var(&defaultFSGroup, "default-fsgroup", 102,
 		"Default fsGroup for inference pods when Spec.PodSecurityContext is not set. "+
 			"102 matches curlimages/curl curl_group GID and lets the init container "+
 			"write to a freshly-provisioned PVC. Set to 0 to disable on OpenShift, "+
 			"where the restricted-v2 SCC injects fsGroup from the namespace's allocated "+
 			"range and rejects pods with explicit values outside that range.")
+	flag.IntVar(&defaultContextSizeMax, "default-context-size-max", 32768,
+		"Upper bound for the llama.cpp --ctx-size defaulted from a Model's trained context "+
+			"length when an InferenceService leaves spec.contextSize unset. "+
+			"Set to 0 to disable the default and let llama-server choose.")
 	flag.StringVar(&routerProxyImage, "router-proxy-image", "",
 		"Default container image for ModelRouter-managed router-proxy pods. "+
 			"Empty falls back to the controller's compiled-in default. "+
@@ -270,6 +302,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	// A cap past 16M tokens is a typo, not a real KV cache budget.
+	if defaultContextSizeMax < 0 || defaultContextSizeMax > 1<<24 {
+		setupLog.Error(fmt.Errorf("value %d out of range [0, %d]", defaultContextSizeMax, 1<<24),
+			"invalid --default-context-size-max")
+		os.Exit(1)
+	}
+
+	switch corev1.PullPolicy(defaultImagePullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		setupLog.Error(fmt.Errorf("invalid value %q", defaultImagePullPolicy),
+			"invalid --default-image-pull-policy: must be Always, IfNotPresent or Never")
+		os.Exit(1)
+	}
+
 	runtimeImageOverrides, err := controller.ParseRuntimeImageOverrides(runtimeImages)
 	if err != nil {
 		setupLog.Error(err, "invalid --runtime-images")
@@ -411,37 +458,58 @@ func main() {
 	}
 
 	if err := (&controller.ModelReconciler{
-		Client:               mgr.GetClient(),
-		Scheme:               mgr.GetScheme(),
-		StoragePath:          modelCachePath,
-		RevalidateInterval:   modelRevalidateInterval,
-		AllowedHostPathRoots: allowedHostPathRootList,
-		AllowedRemoteHosts:   allowedRemoteHostList,
-		InitContainerImage:   initContainerImage,
-		CACertConfigMap:      caCertConfigMap,
-		DefaultFSGroup:       defaultFSGroup,
-		ModelCacheSize:       modelCacheSize,
-		ModelCacheClass:      modelCacheClass,
-		ModelCacheAccessMode: modelCacheAccessMode,
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		StoragePath:            modelCachePath,
+		RevalidateInterval:     modelRevalidateInterval,
+		AllowedHostPathRoots:   allowedHostPathRootList,
+		AllowedRemoteHosts:     allowedRemoteHostList,
+		InitContainerImage:     initContainerImage,
+		CACertConfigMap:        caCertConfigMap,
+		DefaultFSGroup:         defaultFSGroup,
+		DefaultImagePullPolicy: corev1.PullPolicy(defaultImagePullPolicy),
+		ModelCacheSize:         modelCacheSize,
+		ModelCacheClass:        modelCacheClass,
+		ModelCacheAccessMode:   modelCacheAccessMode,
+		CacheCleanup:           modelCacheCleanup && modelCacheMode == controller.ModelCacheModeShared && modelCachePath != "",
+		Recorder:               mgr.GetEventRecorder("model-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Model")
 		os.Exit(1)
 	}
+	if modelCacheMode == controller.ModelCacheModeShared && modelCachePath != "" {
+		if err := mgr.Add(&controller.CacheTTLEvictor{
+			Client:             mgr.GetClient(),
+			DefaultTTL:         modelCacheTTL,
+			InitContainerImage: initContainerImage,
+			DefaultFSGroup:     defaultFSGroup,
+			Recorder:           mgr.GetEventRecorder("model-cache-ttl"),
+			Log:                ctrl.Log.WithName("model-cache-ttl"),
+		}); err != nil {
+			setupLog.Error(err, "unable to create runnable", "runnable", "CacheTTLEvictor")
+			os.Exit(1)
+		}
+	}
 	if err := (&controller.InferenceServiceReconciler{
-		Client:                mgr.GetClient(),
-		Scheme:                mgr.GetScheme(),
-		Recorder:              mgr.GetEventRecorder("inferenceservice-controller"),
-		ModelCachePath:        modelCachePath,
-		ModelCacheSize:        modelCacheSize,
-		ModelCacheClass:       modelCacheClass,
-		ModelCacheAccessMode:  modelCacheAccessMode,
-		ModelCacheMode:        modelCacheMode,
-		CACertConfigMap:       caCertConfigMap,
-		InitContainerImage:    initContainerImage,
-		DefaultFSGroup:        defaultFSGroup,
-		AllowedHostPathRoots:  allowedHostPathRootList,
-		GPUSharingSharedPool:  gpuSharingSharedPool,
-		RuntimeImageOverrides: runtimeImageOverrides,
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		Recorder:                  mgr.GetEventRecorder("inferenceservice-controller"),
+		ModelCachePath:            modelCachePath,
+		ModelCacheSize:            modelCacheSize,
+		ModelCacheClass:           modelCacheClass,
+		ModelCacheAccessMode:      modelCacheAccessMode,
+		ModelCacheMode:            modelCacheMode,
+		ModelCacheExpandIncrement: modelCacheExpandIncrement,
+		ModelCacheExpandMaxSize:   modelCacheExpandMaxSize,
+		ModelCacheExpandThreshold: modelCacheExpandThreshold,
+		CACertConfigMap:           caCertConfigMap,
+		InitContainerImage:        initContainerImage,
+		DefaultFSGroup:            defaultFSGroup,
+		AllowedHostPathRoots:      allowedHostPathRootList,
+		GPUSharingSharedPool:      gpuSharingSharedPool,
+		RuntimeImageOverrides:     runtimeImageOverrides,
+		DefaultContextSizeMax:     int32(defaultContextSizeMax),
+		DefaultImagePullPolicy:    corev1.PullPolicy(defaultImagePullPolicy),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "InferenceService")
 		os.Exit(1)
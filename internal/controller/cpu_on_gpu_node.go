@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// ConditionGPUUtilized is set False when a llama.cpp service running the CPU
+// image has a pod on a node with allocatable GPUs. Informational only, and
+// removed once the service requests a GPU or its pods leave GPU nodes.
+const ConditionGPUUtilized = "GPUUtilized"
+
+// nodeGPUResources are the device-plugin resources that mark a node as
+// having GPUs a pod could request.
+var nodeGPUResources = []corev1.ResourceName{
+	nvidiaGPUResourceName,
+	amdGPUResourceName,
+	intelGPUResourceNameI915,
+	intelGPUResourceNameXE,
+	vulkanDRIResourceName,
+}
+
+// nodeAllocatableGPUs returns the GPU resource a node advertises and how many
+// of it are allocatable, or ("", 0) when the node has none.
+func nodeAllocatableGPUs(node *corev1.Node) (corev1.ResourceName, int64) {
+	for _, res := range nodeGPUResources {
+		if q, ok := node.Status.Allocatable[res]; ok && !q.IsZero() {
+			return res, q.Value()
+		}
+	}
+	return "", 0
+}
+
+// reconcileGPUUtilizedCondition sets GPUUtilized to False when a llama.cpp
+// service that requests no GPU (and so runs the CPU image) has a pod
+// scheduled onto a node that advertises GPUs. The service works, but
+// typically an order of magnitude slower than it would with the GPU it is
+// sitting next to. A Warning event is emitted on the transition into the
+// False state only. Best-effort: list and get failures are logged and leave
+// the condition as it was.
+func (r *InferenceServiceReconciler) reconcileGPUUtilizedCondition(ctx context.Context, isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) {
+	existing := meta.FindStatusCondition(isvc.Status.Conditions, ConditionGPUUtilized)
+	_, llamaCpp := resolveBackend(isvc).(*LlamaCppBackend)
+	if !llamaCpp || isMetalModel(model) || hasGPUPresent(isvc, model) {
+		if existing != nil {
+			meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionGPUUtilized)
+		}
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(isvc.Namespace), client.MatchingLabels{
+		"app":                           isvc.Name,
+		"inference.llmkube.dev/service": isvc.Name,
+	}); err != nil {
+		logger.V(1).Info("Skipping CPU-on-GPU-node check: listing pods failed", "error", err)
+		return
+	}
+
+	message := ""
+	checked := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" || checked[nodeName] {
+			continue
+		}
+		checked[nodeName] = true
+
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			logger.V(1).Info("Skipping CPU-on-GPU-node check: getting node failed", "node", nodeName, "error", err)
+			return
+		}
+		if res, count := nodeAllocatableGPUs(node); count > 0 {
+			message = fmt.Sprintf("Pod %s runs the CPU image on node %s, which has %d allocatable %s; "+
+				"set hardware.gpu on Model %q (or spec.resources.gpu) to use the GPU",
+				pod.Name, nodeName, count, res, model.Name)
+			break
+		}
+	}
+	if message == "" {
+		if existing != nil {
+			meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionGPUUtilized)
+		}
+		return
+	}
+
+	if r.Recorder != nil && (existing == nil || existing.Status != metav1.ConditionFalse) {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "CPUImageOnGPUNode", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+		Type:               ConditionGPUUtilized,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: isvc.Generation,
+		Reason:             "CPUImageOnGPUNode",
+		Message:            message,
+	})
+}
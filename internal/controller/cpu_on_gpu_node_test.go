@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestReconcileGPUUtilizedCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	node := func(name string, gpus int64) *corev1.Node {
+		n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if gpus > 0 {
+			n.Status.Allocatable = corev1.ResourceList{nvidiaGPUResourceName: *resource.NewQuantity(gpus, resource.DecimalSI)}
+		}
+		return n
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc-abc", Namespace: "default", Labels: map[string]string{
+			"app": "svc", "inference.llmkube.dev/service": "svc",
+		}},
+		Spec: corev1.PodSpec{NodeName: "worker"},
+	}
+	isvc := &inferencev1alpha1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"}}
+	cpuModel := &inferencev1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"}}
+	gpuModel := cpuModel.DeepCopy()
+	gpuModel.Spec.Hardware = &inferencev1alpha1.HardwareSpec{GPU: &inferencev1alpha1.GPUSpec{Enabled: true, Count: 1}}
+
+	tests := []struct {
+		name     string
+		nodeGPUs int64
+		model    *inferencev1alpha1.Model
+		wantWarn bool
+	}{
+		{"CPU image on a GPU node", 2, cpuModel, true},
+		{"CPU image on a CPU node", 0, cpuModel, false},
+		{"GPU service on a GPU node", 2, gpuModel, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := events.NewFakeRecorder(10)
+			r := &InferenceServiceReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(node("worker", tt.nodeGPUs), pod.DeepCopy()).Build(),
+				Recorder: recorder,
+			}
+
+			svc := isvc.DeepCopy()
+
+			r.reconcileGPUUtilizedCondition(context.Background(), svc, tt.model)
+
+			cond := meta.FindStatusCondition(svc.Status.Conditions, ConditionGPUUtilized)
+			if tt.wantWarn != (cond != nil && cond.Status == metav1.ConditionFalse) {
+				t.Errorf("GPUUtilized condition = %+v, want False: %v", cond, tt.wantWarn)
+			}
+			select {
+			case ev := <-recorder.Events:
+				if !tt.wantWarn {
+					t.Fatalf("unexpected event %q", ev)
+				}
+				if !strings.HasPrefix(ev, "Warning CPUImageOnGPUNode") || !strings.Contains(ev, "2 allocatable nvidia.com/gpu") {
+					t.Errorf("unexpected event %q", ev)
+				}
+			default:
+				if tt.wantWarn {
+					t.Error("expected a CPUImageOnGPUNode warning event")
+				}
+			}
+
+			r.reconcileGPUUtilizedCondition(context.Background(), svc, tt.model)
+			select {
+			case ev := <-recorder.Events:
+				t.Errorf("expected no repeat event while the condition is unchanged, got %q", ev)
+			default:
+			}
+		})
+	}
+
+	t.Run("the condition clears once the service requests a GPU", func(t *testing.T) {
+		r := &InferenceServiceReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(node("worker", 2), pod.DeepCopy()).Build(),
+			Recorder: events.NewFakeRecorder(10),
+		}
+		svc := isvc.DeepCopy()
+		r.reconcileGPUUtilizedCondition(context.Background(), svc, cpuModel)
+		if meta.FindStatusCondition(svc.Status.Conditions, ConditionGPUUtilized) == nil {
+			t.Fatal("expected the GPUUtilized condition to be set")
+		}
+
+		r.reconcileGPUUtilizedCondition(context.Background(), svc, gpuModel)
+		if cond := meta.FindStatusCondition(svc.Status.Conditions, ConditionGPUUtilized); cond != nil {
+			t.Errorf("expected the GPUUtilized condition to be removed, got %+v", cond)
+		}
+	})
+}
@@ -71,6 +71,12 @@ func resolveRuntimeImage(backend RuntimeBackend, model *inferencev1alpha1.Model,
 	if _, ok := backend.(*LlamaCppBackend); ok && isROCmAMDModel(model) {
 		return llamaCppROCmImage
 	}
+	if _, ok := backend.(*LlamaCppBackend); ok && isIntelVulkanModel(model) {
+		return llamaCppIntelVulkanImage
+	}
+	if _, ok := backend.(*LlamaCppBackend); ok && isSYCLModel(model) {
+		return llamaCppSYCLImage
+	}
 	if _, ok := backend.(*LlamaCppBackend); ok && isNVIDIAGPUModel(model) {
 		return llamaCppCUDAImage
 	}
@@ -113,6 +119,39 @@ func isNVIDIAGPUModel(model *inferencev1alpha1.Model) bool {
 	return vendor == "" || vendor == "nvidia"
 }
 
+// isIntelGPUModel reports whether the Model declares a GPU that resolves to
+// Intel: the intel vendor, or an unset vendor with the intel/sycl accelerator
+// (mirroring apiutil.GPUResourceName).
+func isIntelGPUModel(model *inferencev1alpha1.Model) bool {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil {
+		return false
+	}
+	gpu := model.Spec.Hardware.GPU
+	if !gpu.Enabled && gpu.Count <= 0 {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(gpu.Vendor)) {
+	case acceleratorIntel:
+		return true
+	case "":
+		accel := strings.ToLower(strings.TrimSpace(model.Spec.Hardware.Accelerator))
+		return accel == acceleratorIntel || accel == acceleratorSYCL
+	}
+	return false
+}
+
+// isIntelVulkanModel reports whether the Model runs an Intel GPU through
+// llama.cpp's Vulkan backend (accelerator vulkan).
+func isIntelVulkanModel(model *inferencev1alpha1.Model) bool {
+	return isIntelGPUModel(model) && strings.EqualFold(strings.TrimSpace(model.Spec.Hardware.Accelerator), acceleratorVulkan)
+}
+
+// isSYCLModel reports whether the Model runs an Intel GPU through llama.cpp's
+// SYCL backend, the default for Intel unless accelerator is vulkan.
+func isSYCLModel(model *inferencev1alpha1.Model) bool {
+	return isIntelGPUModel(model) && !isIntelVulkanModel(model)
+}
+
 // isAMDROCmModel reports whether the Model requests the AMD vendor. ROCm vs
 // Vulkan is not distinguished here — SGLang ships ROCm images, not Vulkan.
 func isAMDROCmModel(model *inferencev1alpha1.Model) bool {
@@ -187,16 +226,47 @@ func inferPodSecurityContext(isvc *inferencev1alpha1.InferenceService, defaultFS
 	return psc
 }
 
-func inferContainerSecurityContext(isvc *inferencev1alpha1.InferenceService) *corev1.SecurityContext {
+// defaultRunAsUser is the UID the inference container runs as when
+// inferContainerSecurityContext pins a non-root identity. 65532 is the
+// conventional "nonroot" UID of distroless images; the model volume stays
+// readable through the pod's fsGroup.
+const defaultRunAsUser int64 = 65532
+
+// inferContainerSecurityContext returns spec.securityContext when set,
+// otherwise a hardened default for the inference container: no privilege
+// escalation, all capabilities dropped and the RuntimeDefault seccomp profile.
+//
+// It also pins runAsNonRoot (with defaultRunAsUser, since the upstream
+// llama.cpp images run as root) when that cannot break the pod:
+//   - only for llama.cpp, whose server writes nothing outside its volumes;
+//     vLLM/SGLang/TGI images write caches under a root-owned $HOME;
+//   - not for GPU or DRA pods, where device node access can depend on root or
+//     on host groups the operator cannot infer;
+//   - not when spec.podSecurityContext is set, so the user's identity wins;
+//   - not with the fsGroup default disabled (defaultFSGroup <= 0), the
+//     OpenShift setting, where the SCC assigns the UID and rejects explicit
+//     ones outside the namespace range.
+//
+// Override with spec.securityContext (e.g. to run a GPU pod as non-root under
+// PSA "restricted").
+func inferContainerSecurityContext(isvc *inferencev1alpha1.InferenceService, backend RuntimeBackend, acceleratorPod bool, defaultFSGroup int64) *corev1.SecurityContext {
 	if isvc.Spec.SecurityContext != nil {
 		return isvc.Spec.SecurityContext
 	}
-	return &corev1.SecurityContext{
+	sc := &corev1.SecurityContext{
 		AllowPrivilegeEscalation: boolPtr(false),
 		Capabilities: &corev1.Capabilities{
 			Drop: []corev1.Capability{"ALL"},
 		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
 	}
+	if _, ok := backend.(*LlamaCppBackend); ok && !acceleratorPod && isvc.Spec.PodSecurityContext == nil && defaultFSGroup > 0 {
+		sc.RunAsNonRoot = boolPtr(true)
+		sc.RunAsUser = int64Ptr(defaultRunAsUser)
+	}
+	return sc
 }
 
 // deploymentSelectorLabels returns the immutable subset of operator-managed
@@ -278,10 +348,17 @@ func shouldProtectFromDisruption(isvc *inferencev1alpha1.InferenceService) bool
 }
 
 // buildPodAnnotations merges the user's podAnnotations with the operator's
-// disruption-protection annotation. User-provided values always win on
-// collision.
+// disruption-protection annotation and the service's restartedAt
+// annotation. User-provided values always win on collision with the
+// disruption annotation.
 func buildPodAnnotations(isvc *inferencev1alpha1.InferenceService) map[string]string {
 	annotations := copyMap(isvc.Spec.PodAnnotations)
+	if restartedAt := isvc.Annotations[inferencev1alpha1.AnnotationRestartedAt]; restartedAt != "" {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[inferencev1alpha1.AnnotationRestartedAt] = restartedAt
+	}
 	if shouldProtectFromDisruption(isvc) {
 		if annotations == nil {
 			annotations = make(map[string]string)
@@ -308,12 +385,24 @@ func servedModelPath(isvc *inferencev1alpha1.InferenceService, model *inferencev
 	return sc.modelPath
 }
 
+// containerImage is the inference container image: spec.image when set,
+// otherwise resolveRuntimeImage.
+func (r *InferenceServiceReconciler) containerImage(backend RuntimeBackend, isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) string {
+	if isvc.Spec.Image != "" {
+		return isvc.Spec.Image
+	}
+	return resolveRuntimeImage(backend, model, r.RuntimeImageOverrides)
+}
+
 func (r *InferenceServiceReconciler) constructDeployment(
 	isvc *inferencev1alpha1.InferenceService,
 	model *inferencev1alpha1.Model,
 	replicas int32,
 ) *appsv1.Deployment {
 	backend := resolveBackend(isvc)
+	if llama, ok := backend.(*LlamaCppBackend); ok {
+		llama.DefaultContextSizeMax = r.DefaultContextSizeMax
+	}
 
 	labels := map[string]string{
 		"app":                           isvc.Name,
@@ -322,10 +411,7 @@ func (r *InferenceServiceReconciler) constructDeployment(
 		"inference.llmkube.dev/runtime": runtimeNameLabel(isvc),
 	}
 
-	image := resolveRuntimeImage(backend, model, r.RuntimeImageOverrides)
-	if isvc.Spec.Image != "" {
-		image = isvc.Spec.Image
-	}
+	image := r.containerImage(backend, isvc, model)
 
 	port := backend.DefaultPort()
 	if isvc.Spec.ContainerPort != nil {
@@ -341,6 +427,9 @@ func (r *InferenceServiceReconciler) constructDeployment(
 	if backend.NeedsModelInit() && !skipInit {
 		useCache := effectiveModelCacheKey(model) != "" && r.ModelCachePath != ""
 		storageConfig = buildModelStorageConfig(model, isvc, isvc.Namespace, useCache, r.ModelCacheMode, r.CACertConfigMap, r.InitContainerImage, r.DefaultFSGroup, r.AllowedHostPathRoots)
+		if useCache && r.ModelCacheExpandIncrement != "" && userModelCacheClaimName(isvc) == "" {
+			addCacheUsageReport(storageConfig.initContainers)
+		}
 		modelPath = servedModelPath(isvc, model, storageConfig)
 	}
 
@@ -359,10 +448,11 @@ func (r *InferenceServiceReconciler) constructDeployment(
 		}
 	}
 
+	acceleratorPod := resolveGPUCount(isvc, model) > 0 || len(modelResourceClaims(model)) > 0
 	container := corev1.Container{
 		Name:            backend.ContainerName(),
 		Image:           image,
-		SecurityContext: inferContainerSecurityContext(isvc),
+		SecurityContext: inferContainerSecurityContext(isvc, backend, acceleratorPod, r.DefaultFSGroup),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "http",
@@ -391,9 +481,18 @@ func (r *InferenceServiceReconciler) constructDeployment(
 	if eb, ok := backend.(EnvBuilder); ok {
 		container.Env = append(container.Env, eb.BuildEnv(isvc)...)
 	}
+	if env := visibleDevicesEnv(model); env != nil {
+		container.Env = append(container.Env, *env)
+	}
+	if env := cudaGraphsEnv(backend, model); env != nil {
+		container.Env = append(container.Env, *env)
+	}
 	if len(isvc.Spec.Env) > 0 {
 		container.Env = append(container.Env, isvc.Spec.Env...)
 	}
+	if len(isvc.Spec.EnvFrom) > 0 {
+		container.EnvFrom = append(container.EnvFrom, isvc.Spec.EnvFrom...)
+	}
 
 	gpuCount := resolveGPUCount(isvc, model)
 	// Resolve the gpuSharing tier to its scheduling mechanism (resource name,
@@ -420,6 +519,7 @@ func (r *InferenceServiceReconciler) constructDeployment(
 		Spec: appsv1.DeploymentSpec{
 			Replicas:             &replicas,
 			RevisionHistoryLimit: isvc.Spec.RevisionHistoryLimit,
+			MinReadySeconds:      isvc.Spec.MinReadySeconds,
 			Selector: &metav1.LabelSelector{
 				// Selector uses the immutable subset only; the model label
 				// is allowed to change when the user edits spec.modelRef
@@ -433,11 +533,12 @@ func (r *InferenceServiceReconciler) constructDeployment(
 				},
 				Spec: corev1.PodSpec{
 					SecurityContext:    inferPodSecurityContext(isvc, r.DefaultFSGroup),
-					InitContainers:     storageConfig.initContainers,
+					InitContainers:     withExtraInitContainers(storageConfig.initContainers, isvc.Spec.ExtraInitContainers, storageConfig.volumeMounts),
 					Containers:         []corev1.Container{container},
 					Volumes:            storageConfig.volumes,
 					PriorityClassName:  r.resolvePriorityClassName(isvc),
 					RuntimeClassName:   isvc.Spec.RuntimeClassName,
+					SchedulerName:      isvc.Spec.SchedulerName,
 					ImagePullSecrets:   isvc.Spec.ImagePullSecrets,
 					EnableServiceLinks: resolveEnableServiceLinks(backend),
 					ResourceClaims:     modelResourceClaims(model),
@@ -490,6 +591,10 @@ func (r *InferenceServiceReconciler) constructDeployment(
 	// DRA: apply nodeSelector and tolerations (no auto GPU taint for DRA)
 	if len(modelResourceClaims(model)) > 0 {
 		applyDRAPodScheduling(deployment, isvc)
+	} else if gpuCount == 0 {
+		// CPU-only: no accelerator taint to tolerate, but the user's
+		// nodeSelector and tolerations still pin the pod to a node pool.
+		applyUserNodeScheduling(deployment, isvc)
 	}
 
 	// TopologySpreadConstraints and Affinity are general pod-scheduling
@@ -497,6 +602,10 @@ func (r *InferenceServiceReconciler) constructDeployment(
 	// path above — e.g. soft-spreading model servers one-per-node.
 	if len(isvc.Spec.TopologySpreadConstraints) > 0 {
 		deployment.Spec.Template.Spec.TopologySpreadConstraints = isvc.Spec.TopologySpreadConstraints
+	} else {
+		// Applied whatever the replica count (it is a no-op for one pod) so
+		// scaling does not change the pod template and roll every pod.
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = defaultTopologySpreadConstraints(isvc)
 	}
 	if isvc.Spec.Affinity != nil {
 		deployment.Spec.Template.Spec.Affinity = isvc.Spec.Affinity
@@ -505,6 +614,49 @@ func (r *InferenceServiceReconciler) constructDeployment(
 	return deployment
 }
 
+// resolveImagePullPolicy returns spec.imagePullPolicy, falling back to the
+// controller-wide default. Empty leaves the Kubernetes default in place.
+func (r *InferenceServiceReconciler) resolveImagePullPolicy(isvc *inferencev1alpha1.InferenceService) corev1.PullPolicy {
+	if isvc.Spec.ImagePullPolicy != "" {
+		return isvc.Spec.ImagePullPolicy
+	}
+	return r.DefaultImagePullPolicy
+}
+
+// applyImagePullPolicy sets policy on every init and regular container of
+// podSpec. An empty policy is a no-op. Init containers that already set a
+// policy (spec.extraInitContainers) keep their own.
+func applyImagePullPolicy(podSpec *corev1.PodSpec, policy corev1.PullPolicy) {
+	if policy == "" {
+		return
+	}
+	for i := range podSpec.InitContainers {
+		if podSpec.InitContainers[i].ImagePullPolicy == "" {
+			podSpec.InitContainers[i].ImagePullPolicy = policy
+		}
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].ImagePullPolicy = policy
+	}
+}
+
+// defaultTopologySpreadConstraints soft-spreads a service's Pods across
+// nodes so one node failure does not take out every replica.
+// ScheduleAnyway keeps replicas schedulable on clusters with fewer eligible
+// nodes than replicas (e.g. a single GPU node).
+func defaultTopologySpreadConstraints(isvc *inferencev1alpha1.InferenceService) []corev1.TopologySpreadConstraint {
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelHostname,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: deploymentSelectorLabels(isvc),
+			},
+		},
+	}
+}
+
 // applyDRAPodScheduling configures pod-level scheduling for a DRA workload.
 // The DRA claim itself drives placement, but an explicit nodeSelector and any
 // user tolerations are still honored. Recreate strategy is used to avoid the
@@ -514,6 +666,13 @@ func applyDRAPodScheduling(deployment *appsv1.Deployment, isvc *inferencev1alpha
 	deployment.Spec.Strategy = appsv1.DeploymentStrategy{
 		Type: appsv1.RecreateDeploymentStrategyType,
 	}
+	applyUserNodeScheduling(deployment, isvc)
+}
+
+// applyUserNodeScheduling copies the InferenceService's nodeSelector and
+// tolerations onto the pod spec as-is, for paths that add no scheduling
+// constraints of their own.
+func applyUserNodeScheduling(deployment *appsv1.Deployment, isvc *inferencev1alpha1.InferenceService) {
 	if len(isvc.Spec.NodeSelector) > 0 {
 		deployment.Spec.Template.Spec.NodeSelector = isvc.Spec.NodeSelector
 	}
@@ -531,9 +690,54 @@ func modelResourceClaims(model *inferencev1alpha1.Model) []corev1.PodResourceCla
 	return nil
 }
 
+// validateResources rejects spec.resources quantities that do not parse, and
+// limits below their request, which the API server would reject on the
+// Deployment anyway. buildContainerResources relies on this having run, so an
+// invalid quantity fails the service instead of panicking the controller.
+func validateResources(isvc *inferencev1alpha1.InferenceService) error {
+	spec := isvc.Spec.Resources
+	if spec == nil {
+		return nil
+	}
+	parsed := map[string]resource.Quantity{}
+	for _, f := range []struct{ field, value string }{
+		{"cpu", spec.CPU},
+		{"memory", spec.Memory},
+		{"hostMemory", spec.HostMemory},
+		{"cpuLimit", spec.CPULimit},
+		{"memoryLimit", spec.MemoryLimit},
+	} {
+		if f.value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(f.value)
+		if err != nil {
+			return fmt.Errorf("%s %q is not a valid quantity: %w", f.field, f.value, err)
+		}
+		parsed[f.field] = q
+	}
+
+	if limit, ok := parsed["cpuLimit"]; ok {
+		if request, ok := parsed["cpu"]; ok && limit.Cmp(request) < 0 {
+			return fmt.Errorf("cpuLimit %s is below the cpu request %s", spec.CPULimit, spec.CPU)
+		}
+	}
+	if limit, ok := parsed["memoryLimit"]; ok {
+		// The memory request is hostMemory when set, else memory.
+		requestField, requestValue := "memory", spec.Memory
+		if spec.HostMemory != "" {
+			requestField, requestValue = "hostMemory", spec.HostMemory
+		}
+		if request, ok := parsed[requestField]; ok && limit.Cmp(request) < 0 {
+			return fmt.Errorf("memoryLimit %s is below the %s request %s", spec.MemoryLimit, requestField, requestValue)
+		}
+	}
+	return nil
+}
+
 // buildContainerResources assembles the ResourceRequirements for the inference
 // container, handling the device-plugin GPU limit path, DRA resource claims,
-// and user-supplied CPU / memory requests.
+// and user-supplied CPU / memory requests and limits.
 func buildContainerResources(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model, gpuCount int32, gpuResourceName corev1.ResourceName) corev1.ResourceRequirements {
 	var res corev1.ResourceRequirements
 
@@ -564,6 +768,12 @@ func buildContainerResources(isvc *inferencev1alpha1.InferenceService, model *in
 		} else if isvc.Spec.Resources.Memory != "" {
 			res.Requests[corev1.ResourceMemory] = resource.MustParse(isvc.Spec.Resources.Memory)
 		}
+		if isvc.Spec.Resources.CPULimit != "" {
+			res.Limits[corev1.ResourceCPU] = resource.MustParse(isvc.Spec.Resources.CPULimit)
+		}
+		if isvc.Spec.Resources.MemoryLimit != "" {
+			res.Limits[corev1.ResourceMemory] = resource.MustParse(isvc.Spec.Resources.MemoryLimit)
+		}
 	}
 
 	return res
@@ -17,9 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
@@ -165,6 +169,18 @@ func amdModel(runtime string) *inferencev1alpha1.Model {
 	}
 }
 
+// intelModel builds a one-GPU Model with the given accelerator and vendor.
+func intelModel(accelerator, vendor string) *inferencev1alpha1.Model {
+	return &inferencev1alpha1.Model{
+		Spec: inferencev1alpha1.ModelSpec{
+			Hardware: &inferencev1alpha1.HardwareSpec{
+				Accelerator: accelerator,
+				GPU:         &inferencev1alpha1.GPUSpec{Enabled: true, Count: 1, Vendor: vendor},
+			},
+		},
+	}
+}
+
 func TestResolveRuntimeImage(t *testing.T) {
 	stockLlamaCpp := (&LlamaCppBackend{}).DefaultImage()
 
@@ -228,6 +244,24 @@ func TestResolveRuntimeImage(t *testing.T) {
 			},
 			expected: stockLlamaCpp,
 		},
+		{
+			name:     "llamacpp intel vendor defaults to the SYCL image",
+			backend:  &LlamaCppBackend{},
+			model:    intelModel("", "intel"),
+			expected: llamaCppSYCLImage,
+		},
+		{
+			name:     "llamacpp sycl accelerator with unset vendor selects the SYCL image",
+			backend:  &LlamaCppBackend{},
+			model:    intelModel("sycl", ""),
+			expected: llamaCppSYCLImage,
+		},
+		{
+			name:     "llamacpp intel vulkan selects the upstream vulkan image",
+			backend:  &LlamaCppBackend{},
+			model:    intelModel("vulkan", "intel"),
+			expected: llamaCppIntelVulkanImage,
+		},
 		{
 			name:     "non-llamacpp backend ignores vulkan and uses its default image",
 			backend:  &VLLMBackend{},
@@ -688,3 +722,373 @@ func TestParseRuntimeImageOverrides(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *inferencev1alpha1.InferenceResourceRequirements
+		wantErr   string
+	}{
+		{"unset", nil, ""},
+		{"limits above requests", &inferencev1alpha1.InferenceResourceRequirements{CPU: "2", Memory: "4Gi", CPULimit: "4", MemoryLimit: "8Gi"}, ""},
+		{"limits without requests", &inferencev1alpha1.InferenceResourceRequirements{CPULimit: "500m", MemoryLimit: "1Gi"}, ""},
+		{"unparseable cpu limit", &inferencev1alpha1.InferenceResourceRequirements{CPULimit: "four"}, `cpuLimit "four" is not a valid quantity`},
+		{"unparseable memory request", &inferencev1alpha1.InferenceResourceRequirements{Memory: "8 GB"}, `memory "8 GB" is not a valid quantity`},
+		{"cpu limit below request", &inferencev1alpha1.InferenceResourceRequirements{CPU: "2", CPULimit: "1500m"}, "cpuLimit 1500m is below the cpu request 2"},
+		{"memory limit below request", &inferencev1alpha1.InferenceResourceRequirements{Memory: "8Gi", MemoryLimit: "4Gi"}, "below the memory request 8Gi"},
+		{"memory limit below hostMemory", &inferencev1alpha1.InferenceResourceRequirements{Memory: "4Gi", HostMemory: "64Gi", MemoryLimit: "32Gi"}, "below the hostMemory request 64Gi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{Spec: inferencev1alpha1.InferenceServiceSpec{Resources: tt.resources}}
+			err := validateResources(isvc)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConstructDeploymentTopologySpread(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "spread-model", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/model.gguf"},
+	}
+	newISVC := func(tsc []corev1.TopologySpreadConstraint) *inferencev1alpha1.InferenceService {
+		return &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "spread-service", Namespace: "default"},
+			Spec: inferencev1alpha1.InferenceServiceSpec{
+				ModelRef:                  model.Name,
+				TopologySpreadConstraints: tsc,
+			},
+		}
+	}
+	r := &InferenceServiceReconciler{}
+
+	t.Run("replica count does not change the pod template", func(t *testing.T) {
+		one := r.constructDeployment(newISVC(nil), model, 1)
+		three := r.constructDeployment(newISVC(nil), model, 3)
+		if !reflect.DeepEqual(one.Spec.Template, three.Spec.Template) {
+			t.Fatalf("expected the same pod template at 1 and 3 replicas, got %v and %v",
+				one.Spec.Template.Spec.TopologySpreadConstraints, three.Spec.Template.Spec.TopologySpreadConstraints)
+		}
+	})
+
+	t.Run("multiple replicas default to a soft hostname spread", func(t *testing.T) {
+		isvc := newISVC(nil)
+		deployment := r.constructDeployment(isvc, model, 3)
+		tsc := deployment.Spec.Template.Spec.TopologySpreadConstraints
+		if len(tsc) != 1 {
+			t.Fatalf("expected one default constraint, got %v", tsc)
+		}
+		c := tsc[0]
+		if c.MaxSkew != 1 || c.TopologyKey != corev1.LabelHostname || c.WhenUnsatisfiable != corev1.ScheduleAnyway {
+			t.Errorf("unexpected default constraint %+v", c)
+		}
+		if c.LabelSelector == nil {
+			t.Fatal("expected a labelSelector on the default constraint")
+		}
+		for k, v := range c.LabelSelector.MatchLabels {
+			if deployment.Spec.Template.Labels[k] != v {
+				t.Errorf("selector %s=%s does not match the pod template labels %v", k, v, deployment.Spec.Template.Labels)
+			}
+		}
+	})
+
+	t.Run("explicit constraints override the default", func(t *testing.T) {
+		custom := []corev1.TopologySpreadConstraint{{
+			MaxSkew:           2,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		}}
+		deployment := r.constructDeployment(newISVC(custom), model, 3)
+		tsc := deployment.Spec.Template.Spec.TopologySpreadConstraints
+		if len(tsc) != 1 || tsc[0].TopologyKey != corev1.LabelTopologyZone || tsc[0].MaxSkew != 2 {
+			t.Fatalf("expected the explicit constraint to be used as-is, got %v", tsc)
+		}
+	})
+}
+
+func TestConstructDeploymentImagePullSecrets(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-model", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/model.gguf"},
+	}
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-service", Namespace: "default"},
+		Spec: inferencev1alpha1.InferenceServiceSpec{
+			ModelRef: model.Name,
+			Image:    "registry.example.com/llama.cpp:server-custom",
+		},
+	}
+	r := &InferenceServiceReconciler{InitContainerImage: "registry.example.com/curl:8.18.0"}
+
+	t.Run("defaults to no secrets", func(t *testing.T) {
+		deployment := r.constructDeployment(isvc, model, 1)
+		if secrets := deployment.Spec.Template.Spec.ImagePullSecrets; len(secrets) != 0 {
+			t.Fatalf("expected no imagePullSecrets, got %v", secrets)
+		}
+	})
+
+	t.Run("applies to the pod and so its init containers", func(t *testing.T) {
+		withSecrets := isvc.DeepCopy()
+		withSecrets.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry-creds"}}
+		podSpec := r.constructDeployment(withSecrets, model, 1).Spec.Template.Spec
+		if len(podSpec.ImagePullSecrets) != 1 || podSpec.ImagePullSecrets[0].Name != "registry-creds" {
+			t.Fatalf("expected imagePullSecrets [registry-creds], got %v", podSpec.ImagePullSecrets)
+		}
+		if len(podSpec.InitContainers) == 0 {
+			t.Fatal("expected a model download init container sharing the pod's pull secrets")
+		}
+	})
+}
+
+func TestImagePullPolicyAppliesToServerAndDownloader(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirrored-model", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/model.gguf"},
+	}
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirrored-service", Namespace: "default"},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: model.Name},
+	}
+
+	cases := []struct {
+		name       string
+		specPolicy corev1.PullPolicy
+		defPolicy  corev1.PullPolicy
+		want       corev1.PullPolicy
+	}{
+		{name: "unset keeps the Kubernetes default", want: ""},
+		{name: "controller default", defPolicy: corev1.PullIfNotPresent, want: corev1.PullIfNotPresent},
+		{name: "spec wins over controller default", specPolicy: corev1.PullNever, defPolicy: corev1.PullIfNotPresent,
+			want: corev1.PullNever},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &InferenceServiceReconciler{DefaultImagePullPolicy: tc.defPolicy}
+			svc := isvc.DeepCopy()
+			svc.Spec.ImagePullPolicy = tc.specPolicy
+
+			deployment := r.constructDeployment(svc, model, 1)
+			applyImagePullPolicy(&deployment.Spec.Template.Spec, r.resolveImagePullPolicy(svc))
+			podSpec := deployment.Spec.Template.Spec
+
+			if len(podSpec.InitContainers) == 0 {
+				t.Fatal("expected a model download init container")
+			}
+			for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+				if c.ImagePullPolicy != tc.want {
+					t.Errorf("container %q imagePullPolicy = %q, want %q", c.Name, c.ImagePullPolicy, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestIntelAcceleratorResourceKeys(t *testing.T) {
+	cases := []struct {
+		name        string
+		accelerator string
+		vendor      string
+	}{
+		{name: "sycl", accelerator: "sycl"},
+		{name: "intel", accelerator: "intel"},
+		{name: "vulkan on intel", accelerator: "vulkan", vendor: "intel"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := intelModel(tc.accelerator, tc.vendor)
+			if got := gpuResourceNameForSpec(model); got != intelGPUResourceNameI915 {
+				t.Errorf("scheduling resource = %q, want %q", got, intelGPUResourceNameI915)
+			}
+			if got := resolveGPUResourceName(model); got != intelGPUResourceNameI915 {
+				t.Errorf("readiness resource = %q, want %q", got, intelGPUResourceNameI915)
+			}
+		})
+	}
+}
+
+func TestInferContainerSecurityContext(t *testing.T) {
+	isvc := &inferencev1alpha1.InferenceService{}
+
+	t.Run("CPU llama.cpp runs hardened and non-root", func(t *testing.T) {
+		sc := inferContainerSecurityContext(isvc, &LlamaCppBackend{}, false, 102)
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			t.Error("expected allowPrivilegeEscalation=false")
+		}
+		if sc.Capabilities == nil || len(sc.Capabilities.Drop) != 1 || sc.Capabilities.Drop[0] != "ALL" {
+			t.Errorf("expected all capabilities dropped, got %+v", sc.Capabilities)
+		}
+		if sc.SeccompProfile == nil || sc.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+			t.Errorf("expected RuntimeDefault seccomp, got %+v", sc.SeccompProfile)
+		}
+		if sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot || sc.RunAsUser == nil || *sc.RunAsUser != defaultRunAsUser {
+			t.Errorf("expected runAsNonRoot with UID %d, got %v/%v", defaultRunAsUser, sc.RunAsNonRoot, sc.RunAsUser)
+		}
+	})
+
+	exceptions := []struct {
+		name           string
+		isvc           *inferencev1alpha1.InferenceService
+		backend        RuntimeBackend
+		acceleratorPod bool
+		defaultFSGroup int64
+	}{
+		{name: "GPU pod", isvc: isvc, backend: &LlamaCppBackend{}, acceleratorPod: true, defaultFSGroup: 102},
+		{name: "non-llama.cpp runtime", isvc: isvc, backend: &VLLMBackend{}, defaultFSGroup: 102},
+		{name: "fsGroup default disabled", isvc: isvc, backend: &LlamaCppBackend{}},
+		{
+			name:           "user podSecurityContext",
+			isvc:           &inferencev1alpha1.InferenceService{Spec: inferencev1alpha1.InferenceServiceSpec{PodSecurityContext: &corev1.PodSecurityContext{}}},
+			backend:        &LlamaCppBackend{},
+			defaultFSGroup: 102,
+		},
+	}
+	for _, tc := range exceptions {
+		t.Run(tc.name+" keeps the image user", func(t *testing.T) {
+			sc := inferContainerSecurityContext(tc.isvc, tc.backend, tc.acceleratorPod, tc.defaultFSGroup)
+			if sc.RunAsNonRoot != nil || sc.RunAsUser != nil {
+				t.Errorf("expected no pinned identity, got runAsNonRoot=%v runAsUser=%v", sc.RunAsNonRoot, sc.RunAsUser)
+			}
+			if sc.SeccompProfile == nil || sc.AllowPrivilegeEscalation == nil {
+				t.Error("expected the rest of the hardened default to still apply")
+			}
+		})
+	}
+
+	t.Run("spec.securityContext overrides the default", func(t *testing.T) {
+		override := &corev1.SecurityContext{Privileged: boolPtr(true)}
+		withOverride := &inferencev1alpha1.InferenceService{Spec: inferencev1alpha1.InferenceServiceSpec{SecurityContext: override}}
+		if sc := inferContainerSecurityContext(withOverride, &LlamaCppBackend{}, false, 102); sc != override {
+			t.Errorf("expected the spec.securityContext verbatim, got %+v", sc)
+		}
+	})
+
+	t.Run("downloader runs non-root only with a numeric UID", func(t *testing.T) {
+		sc := initContainerSecurityContext(isvc)
+		if sc.RunAsNonRoot != nil || sc.SeccompProfile == nil {
+			t.Errorf("expected a seccomp profile and no runAsNonRoot for the image's named user, got %+v", sc)
+		}
+		pinned := &inferencev1alpha1.InferenceService{Spec: inferencev1alpha1.InferenceServiceSpec{
+			PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: int64Ptr(101)},
+		}}
+		if sc := initContainerSecurityContext(pinned); sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+			t.Errorf("expected runAsNonRoot with runAsUser 101, got %v", sc.RunAsNonRoot)
+		}
+		root := &inferencev1alpha1.InferenceService{Spec: inferencev1alpha1.InferenceServiceSpec{
+			PodSecurityContext: &corev1.PodSecurityContext{RunAsUser: int64Ptr(0)},
+		}}
+		if sc := initContainerSecurityContext(root); sc.RunAsNonRoot != nil {
+			t.Errorf("expected no runAsNonRoot for an explicit root UID, got %v", *sc.RunAsNonRoot)
+		}
+	})
+}
+
+func TestRestartedAtAnnotationRollsPodTemplate(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/model.gguf"},
+	}
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: model.Name},
+		Status:     inferencev1alpha1.InferenceServiceStatus{Phase: PhaseReady},
+	}
+	r := &InferenceServiceReconciler{}
+	templateHash := func(isvc *inferencev1alpha1.InferenceService) (string, map[string]string) {
+		template := r.constructDeployment(isvc, model, 1).Spec.Template
+		return desiredTemplateHash(template), template.Annotations
+	}
+
+	before, annotations := templateHash(isvc)
+	if _, ok := annotations[inferencev1alpha1.AnnotationRestartedAt]; ok {
+		t.Fatalf("pod template has %s without the service annotation", inferencev1alpha1.AnnotationRestartedAt)
+	}
+
+	restarted := isvc.DeepCopy()
+	restarted.Annotations = map[string]string{inferencev1alpha1.AnnotationRestartedAt: "2026-10-16T09:00:00Z"}
+	first, annotations := templateHash(restarted)
+	if got := annotations[inferencev1alpha1.AnnotationRestartedAt]; got != "2026-10-16T09:00:00Z" {
+		t.Errorf("pod template %s = %q, want the service's value", inferencev1alpha1.AnnotationRestartedAt, got)
+	}
+	if first == before {
+		t.Error("setting restartedAt left the pod template hash unchanged; no rollout would happen")
+	}
+	if again, _ := templateHash(restarted); again != first {
+		t.Error("an unchanged restartedAt changed the pod template hash; every reconcile would roll")
+	}
+
+	restarted.Annotations[inferencev1alpha1.AnnotationRestartedAt] = "2026-10-16T10:30:00Z"
+	if second, _ := templateHash(restarted); second == first {
+		t.Error("changing restartedAt left the pod template hash unchanged; no rollout would happen")
+	}
+}
+
+func TestCudaGraphsEnv(t *testing.T) {
+	cases := []struct {
+		name        string
+		vendor      string
+		accelerator string
+		runtime     string
+		cudaGraphs  *bool
+		wantDisable bool
+	}{
+		{name: "unset keeps the default", vendor: "nvidia"},
+		{name: "true keeps the default", vendor: "nvidia", cudaGraphs: ptr.To(true)},
+		{name: "false disables graphs", vendor: "nvidia", cudaGraphs: ptr.To(false), wantDisable: true},
+		{name: "unset vendor defaults to nvidia", cudaGraphs: ptr.To(false), wantDisable: true},
+		{name: "ignored for amd", vendor: "amd", cudaGraphs: ptr.To(false)},
+		{name: "ignored for intel", vendor: "intel", cudaGraphs: ptr.To(false)},
+		{name: "ignored for vulkan", vendor: "nvidia", accelerator: "vulkan", cudaGraphs: ptr.To(false)},
+		{name: "ignored for vllm", vendor: "nvidia", runtime: "vllm", cudaGraphs: ptr.To(false)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source: "https://example.com/model.gguf",
+					Hardware: &inferencev1alpha1.HardwareSpec{
+						Accelerator: tc.accelerator,
+						GPU: &inferencev1alpha1.GPUSpec{
+							Enabled: true, Count: 1, Vendor: tc.vendor, CudaGraphs: tc.cudaGraphs,
+						},
+					},
+				},
+			}
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+				Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: model.Name, Runtime: tc.runtime},
+			}
+			if tc.runtime == "vllm" {
+				isvc.Spec.Image = "vllm/vllm-openai:latest"
+			}
+
+			r := &InferenceServiceReconciler{}
+			container := r.constructDeployment(isvc, model, 1).Spec.Template.Spec.Containers[0]
+			var got *corev1.EnvVar
+			for i := range container.Env {
+				if container.Env[i].Name == "GGML_CUDA_DISABLE_GRAPHS" {
+					got = &container.Env[i]
+				}
+			}
+			if tc.wantDisable {
+				if got == nil || got.Value != "1" {
+					t.Fatalf("GGML_CUDA_DISABLE_GRAPHS = %v, want 1", got)
+				}
+				return
+			}
+			if got != nil {
+				t.Errorf("GGML_CUDA_DISABLE_GRAPHS set to %q, want it unset", got.Value)
+			}
+		})
+	}
+}
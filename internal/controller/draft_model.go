@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// Speculative decoding with a draft model (spec.draftModelRef). The draft is a
+// second Model CR staged into the same model cache as the main model by its
+// own init container, then handed to llama-server via --model-draft. Only the
+// single-model llama.cpp runtime has a draft-model flag; router mode loads
+// models on demand and has nowhere to attach one.
+
+// errDraftModelNotReady signals that spec.draftModelRef resolves to a Model
+// that exists but is not Ready yet, so the InferenceService should wait.
+var errDraftModelNotReady = errors.New("draft Model is not Ready")
+
+// getDraftModel resolves spec.draftModelRef. It returns nil when the field is
+// unset, errDraftModelNotReady while the draft Model is still being prepared,
+// and any other error for a spec that can never work as written.
+func (r *InferenceServiceReconciler) getDraftModel(
+	ctx context.Context,
+	isvc *inferencev1alpha1.InferenceService,
+	model *inferencev1alpha1.Model,
+) (*inferencev1alpha1.Model, error) {
+	ref := isvc.Spec.DraftModelRef
+	if ref == "" {
+		return nil, nil
+	}
+	if ref == isvc.Spec.ModelRef {
+		return nil, fmt.Errorf("draftModelRef %q must name a different Model than modelRef", ref)
+	}
+	if _, ok := resolveBackend(isvc).(*LlamaCppBackend); !ok {
+		return nil, fmt.Errorf("draftModelRef is only supported by the llamacpp runtime")
+	}
+	if isvc.Spec.SkipModelInit != nil && *isvc.Spec.SkipModelInit {
+		return nil, fmt.Errorf("draftModelRef requires the model init container; unset skipModelInit")
+	}
+	if r.ModelCachePath == "" || effectiveModelCacheKey(model) == "" {
+		return nil, fmt.Errorf("draftModelRef requires the persistent model cache")
+	}
+
+	draft := &inferencev1alpha1.Model{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref, Namespace: isvc.Namespace}, draft); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("draft Model %q not found", ref)
+		}
+		return nil, fmt.Errorf("failed to get draft Model %q: %w", ref, err)
+	}
+	if err := validateLocalSourceAllowed(draft.Spec.Source, r.AllowedHostPathRoots); err != nil {
+		return nil, fmt.Errorf("draft Model %q: %w", ref, err)
+	}
+	if draft.Status.Phase != PhaseReady {
+		return nil, errDraftModelNotReady
+	}
+	if effectiveModelCacheKey(draft) == "" {
+		return nil, fmt.Errorf("draft Model %q has no cache key; it must be cacheable", ref)
+	}
+	return draft, nil
+}
+
+// addDraftModel stages the draft Model into the Deployment's model cache and
+// points llama-server at it. The draft's own cache-prep is dropped (the main
+// model's already prepared the shared mount) and its downloader is renamed so
+// both init containers coexist.
+func (r *InferenceServiceReconciler) addDraftModel(
+	deployment *appsv1.Deployment,
+	isvc *inferencev1alpha1.InferenceService,
+	model *inferencev1alpha1.Model,
+	draft *inferencev1alpha1.Model,
+) {
+	storage := buildModelStorageConfig(draft, isvc, isvc.Namespace, true, r.ModelCacheMode,
+		r.CACertConfigMap, r.InitContainerImage, r.DefaultFSGroup, r.AllowedHostPathRoots)
+	podSpec := &deployment.Spec.Template.Spec
+
+	for _, c := range storage.initContainers {
+		if c.Name == "model-cache-prep" {
+			continue
+		}
+		c.Name = "draft-" + c.Name
+		podSpec.InitContainers = append(podSpec.InitContainers, c)
+	}
+	for _, v := range storage.volumes {
+		if !slices.ContainsFunc(podSpec.Volumes, func(existing corev1.Volume) bool { return existing.Name == v.Name }) {
+			podSpec.Volumes = append(podSpec.Volumes, v)
+		}
+	}
+
+	containerName := resolveBackend(isvc).ContainerName()
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if c.Name != containerName {
+			continue
+		}
+		for _, m := range storage.volumeMounts {
+			if !slices.ContainsFunc(c.VolumeMounts, func(existing corev1.VolumeMount) bool { return existing.Name == m.Name }) {
+				c.VolumeMounts = append(c.VolumeMounts, m)
+			}
+		}
+		c.Args = append(c.Args, "--model-draft", storage.modelPath)
+		if hasGPUPresent(isvc, model) {
+			c.Args = append(c.Args, "--n-gpu-layers-draft", "99")
+		}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func newDraftTestModel(name, source, cacheKey string) *inferencev1alpha1.Model {
+	return &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: source},
+		Status:     inferencev1alpha1.ModelStatus{Phase: PhaseReady, CacheKey: cacheKey},
+	}
+}
+
+func TestAddDraftModel(t *testing.T) {
+	model := newDraftTestModel("qwen-32b", "https://example.com/qwen-32b.gguf", "aaaa")
+	draft := newDraftTestModel("qwen-0.5b", "https://example.com/qwen-0.5b.gguf", "bbbb")
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "qwen", Namespace: "default"},
+		Spec: inferencev1alpha1.InferenceServiceSpec{
+			ModelRef:      model.Name,
+			DraftModelRef: draft.Name,
+			SpeculativeDecoding: &inferencev1alpha1.SpeculativeDecodingSpec{
+				Type:      "draft",
+				NDraftMax: int32Ptr(8),
+			},
+		},
+	}
+	r := &InferenceServiceReconciler{
+		ModelCachePath:     "/models",
+		InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+		DefaultFSGroup:     102,
+	}
+
+	deployment := r.constructDeployment(isvc, model, 1)
+	r.addDraftModel(deployment, isvc, model, draft)
+	podSpec := deployment.Spec.Template.Spec
+
+	var names []string
+	for _, c := range podSpec.InitContainers {
+		names = append(names, c.Name)
+	}
+	if want := []string{"model-cache-prep", "model-downloader", "draft-model-downloader"}; !slices.Equal(names, want) {
+		t.Fatalf("init containers = %v, want %v", names, want)
+	}
+	draftInit := podSpec.InitContainers[2]
+	var draftSource string
+	for _, e := range draftInit.Env {
+		if e.Name == "MODEL_SOURCE" {
+			draftSource = e.Value
+		}
+	}
+	if draftSource != draft.Spec.Source {
+		t.Errorf("expected the draft downloader to fetch %s, got %q", draft.Spec.Source, draftSource)
+	}
+
+	cacheVolumes := 0
+	for _, v := range podSpec.Volumes {
+		if v.Name == "model-cache" {
+			cacheVolumes++
+		}
+	}
+	if cacheVolumes != 1 {
+		t.Errorf("expected the model cache volume once, got %d", cacheVolumes)
+	}
+
+	args := podSpec.Containers[0].Args
+	i := slices.Index(args, "--model-draft")
+	if i < 0 || i+1 >= len(args) || !strings.HasPrefix(args[i+1], "/models/bbbb/") {
+		t.Errorf("expected --model-draft under the draft's cache dir, got %v", args)
+	}
+	if j := slices.Index(args, "--model"); j < 0 || !strings.HasPrefix(args[j+1], "/models/aaaa/") {
+		t.Errorf("expected the main model to stay under its own cache dir, got %v", args)
+	}
+	if k := slices.Index(args, "--draft-n-max"); k < 0 || args[k+1] != "8" {
+		t.Errorf("expected --draft-n-max 8, got %v", args)
+	}
+}
+
+func TestGetDraftModel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	ctx := context.Background()
+
+	model := newDraftTestModel("main", "https://example.com/main.gguf", "aaaa")
+	ready := newDraftTestModel("draft", "https://example.com/draft.gguf", "bbbb")
+	pending := newDraftTestModel("draft-pending", "https://example.com/draft.gguf", "")
+	pending.Status.Phase = "Downloading"
+
+	r := &InferenceServiceReconciler{
+		Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(ready, pending).Build(),
+		ModelCachePath: "/models",
+	}
+	newISVC := func(draftRef, runtimeName string) *inferencev1alpha1.InferenceService {
+		return &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+			Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: "main", DraftModelRef: draftRef, Runtime: runtimeName},
+		}
+	}
+
+	if got, err := r.getDraftModel(ctx, newISVC("", ""), model); got != nil || err != nil {
+		t.Errorf("unset draftModelRef: got %v, %v; want nil, nil", got, err)
+	}
+	if got, err := r.getDraftModel(ctx, newISVC("draft", ""), model); err != nil || got == nil || got.Name != "draft" {
+		t.Errorf("ready draft: got %v, %v", got, err)
+	}
+	if _, err := r.getDraftModel(ctx, newISVC("draft-pending", ""), model); !errors.Is(err, errDraftModelNotReady) {
+		t.Errorf("pending draft: expected errDraftModelNotReady, got %v", err)
+	}
+
+	for name, isvc := range map[string]*inferencev1alpha1.InferenceService{
+		"missing draft":   newISVC("absent", ""),
+		"same as model":   newISVC("main", ""),
+		"non-llamacpp":    newISVC("draft", "vllm"),
+		"router llamacpp": newISVC("draft", "llamacpp-router"),
+	} {
+		_, err := r.getDraftModel(ctx, isvc, model)
+		if err == nil || errors.Is(err, errDraftModelNotReady) {
+			t.Errorf("%s: expected a terminal error, got %v", name, err)
+		}
+	}
+}
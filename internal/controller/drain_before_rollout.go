@@ -393,7 +393,8 @@ func podTemplatesDiffer(existing, desired corev1.PodTemplateSpec) bool {
 	// Skip server-side defaulted fields that cause false positives:
 	//   TerminationGracePeriodSeconds (default 30s), DNSPolicy (default ClusterFirst),
 	//   RestartPolicy (default Always), ServiceAccountName (default "default"),
-	//   AutomountServiceAccountToken, SchedulerName (default "default-scheduler").
+	//   AutomountServiceAccountToken. SchedulerName is only normalized when it is
+	//   the API default ("default-scheduler") so spec.schedulerName changes roll.
 	a.TerminationGracePeriodSeconds = nil
 	b.TerminationGracePeriodSeconds = nil
 	a.DNSPolicy = ""
@@ -404,8 +405,12 @@ func podTemplatesDiffer(existing, desired corev1.PodTemplateSpec) bool {
 	b.ServiceAccountName = ""
 	a.AutomountServiceAccountToken = nil
 	b.AutomountServiceAccountToken = nil
-	a.SchedulerName = ""
-	b.SchedulerName = ""
+	if a.SchedulerName == corev1.DefaultSchedulerName {
+		a.SchedulerName = ""
+	}
+	if b.SchedulerName == corev1.DefaultSchedulerName {
+		b.SchedulerName = ""
+	}
 	// Additional pod-level fields defaulted by the API server.
 	a.HostNetwork = false
 	b.HostNetwork = false
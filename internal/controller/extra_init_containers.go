@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// Extra init containers (spec.extraInitContainers). They run after
+// model-cache-prep has made the cache writable and before model-downloader,
+// so a user step can seed, decrypt, or convert files on the shared model
+// volume before the managed download checks for them.
+
+// managedInitContainerNames are the init container names the controller
+// itself may add to an inference pod.
+var managedInitContainerNames = []string{"model-cache-prep", "model-downloader"}
+
+// validateExtraInitContainers rejects extra init containers without a name or
+// with a name the controller uses for its own containers, including the
+// draft-/lora- prefixed downloaders that are added after the pod is built.
+func validateExtraInitContainers(isvc *inferencev1alpha1.InferenceService) error {
+	if len(isvc.Spec.ExtraInitContainers) == 0 {
+		return nil
+	}
+	mainContainer := resolveBackend(isvc).ContainerName()
+	seen := make(map[string]bool, len(isvc.Spec.ExtraInitContainers))
+	for _, c := range isvc.Spec.ExtraInitContainers {
+		switch {
+		case c.Name == "":
+			return fmt.Errorf("every container needs a name")
+		case seen[c.Name]:
+			return fmt.Errorf("duplicate container name %q", c.Name)
+		case slices.Contains(managedInitContainerNames, c.Name) || c.Name == mainContainer:
+			return fmt.Errorf("container name %q is reserved for a managed container", c.Name)
+		case strings.HasPrefix(c.Name, "draft-") || strings.HasPrefix(c.Name, loraInitContainerPrefix):
+			return fmt.Errorf("container name %q uses a prefix reserved for managed init containers (draft-, %s)",
+				c.Name, loraInitContainerPrefix)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+// withExtraInitContainers returns the managed init containers with the
+// user's inserted just before model-downloader, or appended when there is no
+// downloader. modelMounts are the inference container's model mounts; the
+// one at /models is added read-write to every extra container that does not
+// already mount that volume or path.
+func withExtraInitContainers(
+	managed []corev1.Container, extra []corev1.Container, modelMounts []corev1.VolumeMount,
+) []corev1.Container {
+	if len(extra) == 0 {
+		return managed
+	}
+
+	var modelVolume string
+	for _, m := range modelMounts {
+		if m.MountPath == "/models" {
+			modelVolume = m.Name
+		}
+	}
+
+	user := make([]corev1.Container, len(extra))
+	for i := range extra {
+		c := *extra[i].DeepCopy()
+		mounted := slices.ContainsFunc(c.VolumeMounts, func(m corev1.VolumeMount) bool {
+			return m.Name == modelVolume || m.MountPath == "/models"
+		})
+		if modelVolume != "" && !mounted {
+			c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: modelVolume, MountPath: "/models"})
+		}
+		user[i] = c
+	}
+
+	at := slices.IndexFunc(managed, func(c corev1.Container) bool { return c.Name == "model-downloader" })
+	if at < 0 {
+		at = len(managed)
+	}
+	return slices.Concat(managed[:at], user, managed[at:])
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestExtraInitContainers(t *testing.T) {
+	model := newDraftTestModel("base", "https://example.com/base.gguf", "aaaa")
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec: inferencev1alpha1.InferenceServiceSpec{
+			ModelRef: model.Name,
+			ExtraInitContainers: []corev1.Container{
+				{Name: "decrypt", Image: "example.com/decrypt:1", ImagePullPolicy: corev1.PullAlways},
+				{
+					Name:         "tokenizer",
+					Image:        "example.com/tokenizer:1",
+					VolumeMounts: []corev1.VolumeMount{{Name: "model-cache", MountPath: "/data", ReadOnly: true}},
+				},
+			},
+		},
+	}
+	r := &InferenceServiceReconciler{
+		ModelCachePath:     "/models",
+		InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+	}
+	if err := validateExtraInitContainers(isvc); err != nil {
+		t.Fatalf("validateExtraInitContainers: %v", err)
+	}
+
+	deployment := r.constructDeployment(isvc, model, 1)
+	applyImagePullPolicy(&deployment.Spec.Template.Spec, corev1.PullIfNotPresent)
+	podSpec := deployment.Spec.Template.Spec
+
+	var names []string
+	for _, c := range podSpec.InitContainers {
+		names = append(names, c.Name)
+	}
+	if want := []string{"model-cache-prep", "decrypt", "tokenizer", "model-downloader"}; !slices.Equal(names, want) {
+		t.Fatalf("init containers = %v, want %v", names, want)
+	}
+
+	decrypt := podSpec.InitContainers[1]
+	wantMount := corev1.VolumeMount{Name: "model-cache", MountPath: "/models"}
+	if !slices.Equal(decrypt.VolumeMounts, []corev1.VolumeMount{wantMount}) {
+		t.Errorf("decrypt mounts = %v, want the model volume read-write at /models", decrypt.VolumeMounts)
+	}
+	downloader := podSpec.InitContainers[3]
+	if !slices.ContainsFunc(downloader.VolumeMounts, func(m corev1.VolumeMount) bool { return m.Name == decrypt.VolumeMounts[0].Name }) {
+		t.Errorf("expected model-downloader to share volume %q, got %v", decrypt.VolumeMounts[0].Name, downloader.VolumeMounts)
+	}
+	if tokenizer := podSpec.InitContainers[2]; len(tokenizer.VolumeMounts) != 1 || tokenizer.VolumeMounts[0].MountPath != "/data" {
+		t.Errorf("expected the user's own model volume mount to be kept, got %v", tokenizer.VolumeMounts)
+	}
+	if decrypt.ImagePullPolicy != corev1.PullAlways {
+		t.Errorf("decrypt imagePullPolicy = %q, want the user's %q", decrypt.ImagePullPolicy, corev1.PullAlways)
+	}
+	if len(isvc.Spec.ExtraInitContainers[0].VolumeMounts) != 0 {
+		t.Error("expected the InferenceService spec to be left unmodified")
+	}
+}
+
+func TestValidateExtraInitContainers(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr string
+	}{
+		{name: "managed downloader", names: []string{"model-downloader"}, wantErr: "reserved"},
+		{name: "managed cache prep", names: []string{"model-cache-prep"}, wantErr: "reserved"},
+		{name: "inference container", names: []string{"llama-server"}, wantErr: "reserved"},
+		{name: "draft prefix", names: []string{"draft-model-downloader"}, wantErr: "prefix"},
+		{name: "lora prefix", names: []string{"lora-sql"}, wantErr: "prefix"},
+		{name: "duplicate", names: []string{"prep", "prep"}, wantErr: "duplicate"},
+		{name: "empty name", names: []string{""}, wantErr: "needs a name"},
+		{name: "valid", names: []string{"decrypt", "tokenizer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{}
+			for _, n := range tt.names {
+				isvc.Spec.ExtraInitContainers = append(isvc.Spec.ExtraInitContainers, corev1.Container{Name: n, Image: "busybox"})
+			}
+			err := validateExtraInitContainers(isvc)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
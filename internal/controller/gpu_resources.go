@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -13,6 +15,7 @@ import (
 const (
 	acceleratorIntel           = "intel"
 	acceleratorVulkan          = "vulkan"
+	acceleratorSYCL            = "sycl"
 	intelGPUResourceEnvVar     = "LLMKUBE_INTEL_GPU_RESOURCE"
 	defaultInsufficientGPUHint = "Insufficient "
 )
@@ -61,7 +64,8 @@ var (
 //  3. Model.Spec.Hardware.GPU.Vendor maps to the device-plugin default for
 //     that vendor (nvidia -> nvidia.com/gpu, amd -> amd.com/gpu,
 //     intel -> gpu.intel.com/i915).
-//  4. Unset / unknown -> nvidia.com/gpu (backwards-compatible default).
+//  4. Unset vendor with accelerator intel or sycl -> gpu.intel.com/i915.
+//  5. Unset / unknown -> nvidia.com/gpu (backwards-compatible default).
 //
 // Used by the deployment builder; the accelerator-aware variant
 // resolveGPUResourceName is used by the Model reconciler's readiness check
@@ -100,7 +104,8 @@ func gpuTolerationKeyForSpec(model *inferencev1alpha1.Model) string {
 
 func resolveGPUResourceName(model *inferencev1alpha1.Model) corev1.ResourceName {
 	if model != nil && model.Spec.Hardware != nil {
-		if strings.EqualFold(model.Spec.Hardware.Accelerator, acceleratorIntel) {
+		if strings.EqualFold(model.Spec.Hardware.Accelerator, acceleratorIntel) ||
+			strings.EqualFold(model.Spec.Hardware.Accelerator, acceleratorSYCL) {
 			return resolveIntelGPUResourceName()
 		}
 
@@ -129,6 +134,103 @@ func resolveIntelGPUResourceName() corev1.ResourceName {
 	return corev1.ResourceName(configured)
 }
 
+// visibleDevicesEnv returns the env var that pins the container to the
+// Model's hardware.gpu.visibleDevices, or nil when none are configured. AMD
+// uses HIP_VISIBLE_DEVICES; every other vendor gets CUDA_VISIBLE_DEVICES.
+func visibleDevicesEnv(model *inferencev1alpha1.Model) *corev1.EnvVar {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil {
+		return nil
+	}
+	devices := model.Spec.Hardware.GPU.VisibleDevices
+	if len(devices) == 0 {
+		return nil
+	}
+	indices := make([]string, len(devices))
+	for i, d := range devices {
+		indices[i] = strconv.Itoa(int(d))
+	}
+	name := "CUDA_VISIBLE_DEVICES"
+	if strings.EqualFold(strings.TrimSpace(model.Spec.Hardware.GPU.Vendor), "amd") {
+		name = "HIP_VISIBLE_DEVICES"
+	}
+	return &corev1.EnvVar{Name: name, Value: strings.Join(indices, ",")}
+}
+
+// cudaGraphsEnv returns GGML_CUDA_DISABLE_GRAPHS when hardware.gpu.cudaGraphs
+// is false on a llama.cpp pod running the CUDA backend, or nil otherwise.
+// llama.cpp enables graphs by default and checks only whether the variable is
+// set, so true and unset both leave it out.
+func cudaGraphsEnv(backend RuntimeBackend, model *inferencev1alpha1.Model) *corev1.EnvVar {
+	if _, ok := backend.(*LlamaCppBackend); !ok {
+		return nil
+	}
+	if !isNVIDIAGPUModel(model) || isIntelGPUModel(model) ||
+		strings.EqualFold(strings.TrimSpace(model.Spec.Hardware.Accelerator), acceleratorVulkan) {
+		return nil
+	}
+	if enabled := model.Spec.Hardware.GPU.CudaGraphs; enabled == nil || *enabled {
+		return nil
+	}
+	return &corev1.EnvVar{Name: "GGML_CUDA_DISABLE_GRAPHS", Value: "1"}
+}
+
+// validateVisibleDevices checks hardware.gpu.visibleDevices against the
+// resolved GPU count: pinning fewer or more devices than the pod requests
+// would either strand allocated GPUs or point the runtime at devices it was
+// never granted.
+func validateVisibleDevices(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) error {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil {
+		return nil
+	}
+	gpu := model.Spec.Hardware.GPU
+	if len(gpu.VisibleDevices) == 0 {
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(gpu.Vendor), acceleratorIntel) {
+		return fmt.Errorf("not supported for vendor intel")
+	}
+	if count := resolveGPUCount(isvc, model); int32(len(gpu.VisibleDevices)) != count {
+		return fmt.Errorf("lists %d device(s) but the resolved GPU count is %d", len(gpu.VisibleDevices), count)
+	}
+	return nil
+}
+
+// validateMainGPU checks hardware.gpu.mainGpu against the devices the
+// container will see. With visibleDevices set the runtime renumbers the
+// listed GPUs from 0, so mainGpu is a position in that list; a value that is
+// out of range there would either fail at startup or, if it happens to be a
+// valid node index, silently put the scratch buffers and KV cache on a
+// different card than intended.
+func validateMainGPU(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) error {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil {
+		return nil
+	}
+	gpu := model.Spec.Hardware.GPU
+	if gpu.MainGPU == nil {
+		return nil
+	}
+	mainGPU := *gpu.MainGPU
+	if len(gpu.VisibleDevices) > 0 {
+		if mainGPU < int32(len(gpu.VisibleDevices)) {
+			return nil
+		}
+		for i, d := range gpu.VisibleDevices {
+			if d == mainGPU {
+				return fmt.Errorf("%d is a node GPU index, but mainGpu is a position in visibleDevices %v; use %d to select that device", mainGPU, gpu.VisibleDevices, i)
+			}
+		}
+		return fmt.Errorf("%d is out of range for visibleDevices %v (must be between 0 and %d)", mainGPU, gpu.VisibleDevices, len(gpu.VisibleDevices)-1)
+	}
+	count := resolveGPUCount(isvc, model)
+	if count == 0 {
+		return fmt.Errorf("set but the resolved GPU count is 0")
+	}
+	if mainGPU >= count {
+		return fmt.Errorf("%d is out of range for a GPU count of %d (must be between 0 and %d)", mainGPU, count, count-1)
+	}
+	return nil
+}
+
 func detectInsufficientGPUResource(message string) (corev1.ResourceName, bool) {
 	candidates := []corev1.ResourceName{
 		nvidiaGPUResourceName,
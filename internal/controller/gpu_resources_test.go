@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"strings"
 	"testing"
 
+	"k8s.io/utils/ptr"
+
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
 
@@ -173,3 +176,50 @@ func TestDetectInsufficientGPUResource(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateMainGPU(t *testing.T) {
+	newModel := func(count int32, mainGPU *int32, devices ...int32) *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{
+			Spec: inferencev1alpha1.ModelSpec{
+				Hardware: &inferencev1alpha1.HardwareSpec{
+					GPU: &inferencev1alpha1.GPUSpec{
+						Enabled:        true,
+						Count:          count,
+						Vendor:         "nvidia",
+						VisibleDevices: devices,
+						MainGPU:        mainGPU,
+					},
+				},
+			},
+		}
+	}
+	isvc := &inferencev1alpha1.InferenceService{}
+
+	tests := []struct {
+		name    string
+		model   *inferencev1alpha1.Model
+		wantErr string
+	}{
+		{name: "unset", model: newModel(2, nil, 2, 3)},
+		{name: "position within visibleDevices", model: newModel(2, ptr.To[int32](1), 2, 3)},
+		{name: "node index instead of position", model: newModel(2, ptr.To[int32](3), 2, 3), wantErr: "use 1 to select that device"},
+		{name: "out of range for visibleDevices", model: newModel(2, ptr.To[int32](5), 2, 3), wantErr: "must be between 0 and 1"},
+		{name: "within GPU count", model: newModel(4, ptr.To[int32](3))},
+		{name: "beyond GPU count", model: newModel(2, ptr.To[int32](2)), wantErr: "GPU count of 2"},
+		{name: "no GPUs", model: newModel(0, ptr.To[int32](0)), wantErr: "resolved GPU count is 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMainGPU(isvc, tt.model)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateMainGPU() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateMainGPU() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -58,6 +58,51 @@ func resolveSplitMode(sharding *inferencev1alpha1.GPUShardingSpec) string {
 	}
 }
 
+// maxRowSplitGPUs is the largest GPU count chooseSplitMode picks row split
+// for. Row split all-reduces every layer's output across all GPUs on each
+// token, and past four devices (typically on PCIe) that traffic costs more
+// than the shared compute saves.
+const maxRowSplitGPUs = 4
+
+// chooseSplitMode is the hardware.gpu.rowSplitThreshold heuristic: row split
+// for models with at least thresholdBillions billion parameters on 2 to
+// maxRowSplitGPUs GPUs, layer split otherwise. A zero threshold (heuristic
+// off) or an unknown parameter count keeps layer split.
+func chooseSplitMode(paramCount uint64, gpuCount int32, thresholdBillions int32) string {
+	if thresholdBillions <= 0 || paramCount == 0 {
+		return splitModeLayer
+	}
+	if gpuCount < 2 || gpuCount > maxRowSplitGPUs {
+		return splitModeLayer
+	}
+	if paramCount >= uint64(thresholdBillions)*1_000_000_000 {
+		return splitModeRow
+	}
+	return splitModeLayer
+}
+
+// effectiveSplitMode returns the llama.cpp --split-mode for model on
+// gpuCount GPUs: an explicit sharding.strategy wins, then the
+// rowSplitThreshold heuristic, then layer split. splitKVCache needs layer
+// split, so it also keeps the heuristic from choosing row.
+func effectiveSplitMode(model *inferencev1alpha1.Model, gpuCount int32) string {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil {
+		return splitModeLayer
+	}
+	gpu := model.Spec.Hardware.GPU
+	if gpu.Sharding != nil && gpu.Sharding.Strategy != "" {
+		return resolveSplitMode(gpu.Sharding)
+	}
+	if gpu.RowSplitThreshold == nil || splitKVCacheEnabled(model) {
+		return resolveSplitMode(gpu.Sharding)
+	}
+	var paramCount uint64
+	if model.Status.GGUF != nil {
+		paramCount = model.Status.GGUF.ParameterCount
+	}
+	return chooseSplitMode(paramCount, gpuCount, *gpu.RowSplitThreshold)
+}
+
 // calculateTensorSplit returns comma-separated ratios for llama.cpp --tensor-split flag.
 // When sharding.LayerSplit is provided, layer ranges are converted to proportional ratios
 // (e.g., ["0-24", "25-39"] becomes "5,3"). Falls back to equal split on any error.
@@ -98,6 +143,32 @@ func calculateTensorSplit(gpuCount int32, sharding *inferencev1alpha1.GPUShardin
 	return strings.Join(ratios, ",")
 }
 
+// splitKVCacheEnabled reports whether hardware.gpu.splitKVCache is set.
+func splitKVCacheEnabled(model *inferencev1alpha1.Model) bool {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil {
+		return false
+	}
+	return model.Spec.Hardware.GPU.SplitKVCache != nil && *model.Spec.Hardware.GPU.SplitKVCache
+}
+
+// validateSplitKVCache checks hardware.gpu.splitKVCache against the split
+// mode it would run under. Only layer split gives each GPU the KV cache of
+// the layers it holds; row split keeps all of it on the main GPU, and
+// noKvOffload moves it to host RAM, so either would silently defeat the
+// toggle. A single GPU has nothing to split, so the toggle is ignored there.
+func validateSplitKVCache(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) error {
+	if !splitKVCacheEnabled(model) || resolveGPUCount(isvc, model) <= 1 {
+		return nil
+	}
+	if mode := resolveSplitMode(model.Spec.Hardware.GPU.Sharding); mode != splitModeLayer {
+		return fmt.Errorf("requires split mode %q, but sharding resolves to %q", splitModeLayer, mode)
+	}
+	if isvc.Spec.NoKvOffload != nil && *isvc.Spec.NoKvOffload {
+		return fmt.Errorf("cannot be combined with InferenceService spec.noKvOffload")
+	}
+	return nil
+}
+
 // parseLayerRange parses a "start-end" layer range string.
 func parseLayerRange(s string) (int, int, error) {
 	parts := strings.SplitN(s, "-", 2)
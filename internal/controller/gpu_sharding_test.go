@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestChooseSplitMode(t *testing.T) {
+	const billion = 1_000_000_000
+	cases := []struct {
+		name      string
+		params    uint64
+		gpus      int32
+		threshold int32
+		want      string
+	}{
+		{name: "exactly at threshold", params: 30 * billion, gpus: 2, threshold: 30, want: splitModeRow},
+		{name: "just below threshold", params: 30*billion - 1, gpus: 2, threshold: 30, want: splitModeLayer},
+		{name: "max row split GPUs", params: 70 * billion, gpus: maxRowSplitGPUs, threshold: 30, want: splitModeRow},
+		{name: "past max row split GPUs", params: 70 * billion, gpus: maxRowSplitGPUs + 1, threshold: 30,
+			want: splitModeLayer},
+		{name: "single GPU", params: 70 * billion, gpus: 1, threshold: 30, want: splitModeLayer},
+		{name: "unknown parameter count", params: 0, gpus: 2, threshold: 30, want: splitModeLayer},
+		{name: "heuristic off", params: 70 * billion, gpus: 2, threshold: 0, want: splitModeLayer},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := chooseSplitMode(tc.params, tc.gpus, tc.threshold); got != tc.want {
+				t.Errorf("chooseSplitMode(%d, %d, %d) = %q, want %q", tc.params, tc.gpus, tc.threshold, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveSplitModeHonorsOverrides(t *testing.T) {
+	model := func(gpu *inferencev1alpha1.GPUSpec) *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{
+			Spec: inferencev1alpha1.ModelSpec{Hardware: &inferencev1alpha1.HardwareSpec{GPU: gpu}},
+			Status: inferencev1alpha1.ModelStatus{
+				GGUF: &inferencev1alpha1.GGUFMetadata{ParameterCount: 70_000_000_000},
+			},
+		}
+	}
+
+	cases := []struct {
+		name string
+		gpu  *inferencev1alpha1.GPUSpec
+		want string
+	}{
+		{name: "heuristic picks row", gpu: &inferencev1alpha1.GPUSpec{RowSplitThreshold: ptr.To[int32](30)},
+			want: splitModeRow},
+		{name: "explicit strategy wins", want: splitModeLayer, gpu: &inferencev1alpha1.GPUSpec{
+			RowSplitThreshold: ptr.To[int32](30),
+			Sharding:          &inferencev1alpha1.GPUShardingSpec{Strategy: "layer"},
+		}},
+		{name: "splitKVCache keeps layer", want: splitModeLayer, gpu: &inferencev1alpha1.GPUSpec{
+			RowSplitThreshold: ptr.To[int32](30),
+			SplitKVCache:      ptr.To(true),
+		}},
+		{name: "no threshold keeps layer", gpu: &inferencev1alpha1.GPUSpec{}, want: splitModeLayer},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := effectiveSplitMode(model(tc.gpu), 2); got != tc.want {
+				t.Errorf("effectiveSplitMode() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -115,7 +115,7 @@ func (r *InferenceServiceReconciler) constructHPA(
 	// Build metrics list
 	var metrics []autoscalingv2.MetricSpec
 
-	if len(autoscaling.Metrics) == 0 {
+	if len(autoscaling.Metrics) == 0 && autoscaling.TargetCPUUtilization == nil {
 		// Use the runtime's default metric if available
 		backend := resolveBackend(isvc)
 		metricName := "llamacpp:requests_processing"
@@ -178,6 +178,19 @@ func (r *InferenceServiceReconciler) constructHPA(
 		}
 	}
 
+	if autoscaling.TargetCPUUtilization != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: autoscaling.TargetCPUUtilization,
+				},
+			},
+		})
+	}
+
 	return &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      isvc.Name,
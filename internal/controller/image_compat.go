@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// ConditionImageCompatible is False when the llama.cpp image predates the
+// first upstream build that can load the Model's GGUF architecture, so the
+// server would fail at load with "unknown model architecture". Informational
+// only; the Deployment is still reconciled, since a custom build may carry
+// the support under an older tag.
+const ConditionImageCompatible = "ImageCompatible"
+
+// llamaCppArchitectureMinBuild maps a GGUF general.architecture to the first
+// upstream llama.cpp release (bNNNN) that loads it. Only architectures newer
+// than builds still commonly pinned in the field are listed.
+var llamaCppArchitectureMinBuild = map[string]int{
+	"gemma3":   4875,
+	"llama4":   5074,
+	"qwen3":    5092,
+	"qwen3moe": 5092,
+	"gpt-oss":  6096,
+}
+
+// llamaCppBuildTag matches the build number in upstream tags such as
+// "server-cuda-b10068", "server-b5092" or plain "b4875".
+var llamaCppBuildTag = regexp.MustCompile(`(?:^|-)b(\d+)$`)
+
+// llamaCppImageBuild extracts the llama.cpp build number from an image
+// reference's tag. Floating tags (":server"), digests, and custom tags report
+// ok=false: their build is unknown, so no recommendation is made for them.
+func llamaCppImageBuild(image string) (int, bool) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return 0, false
+	}
+	m := llamaCppBuildTag.FindStringSubmatch(image[i+1:])
+	if m == nil {
+		return 0, false
+	}
+	build, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	return build, true
+}
+
+// imageUpgradeRecommendation returns a message recommending a newer llama.cpp
+// image when image's build is older than the minimum known-good build for the
+// Model's parsed GGUF architecture, or "" when there is nothing to recommend.
+func imageUpgradeRecommendation(model *inferencev1alpha1.Model, image string) string {
+	if model == nil || model.Status.GGUF == nil {
+		return ""
+	}
+	arch := strings.ToLower(model.Status.GGUF.Architecture)
+	minBuild, known := llamaCppArchitectureMinBuild[arch]
+	if !known {
+		return ""
+	}
+	build, ok := llamaCppImageBuild(image)
+	if !ok || build >= minBuild {
+		return ""
+	}
+	return fmt.Sprintf(
+		"image %q is llama.cpp b%d but architecture %q needs b%d or newer; set spec.image (or runtimeImages.llamacpp) to a newer build",
+		image, build, arch, minBuild)
+}
+
+// reconcileImageCompatibilityCondition sets ImageCompatible to False when the
+// llama.cpp image is too old for the Model's architecture. A Warning event is
+// emitted on the transition into the False state only.
+func (r *InferenceServiceReconciler) reconcileImageCompatibilityCondition(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) {
+	backend := resolveBackend(isvc)
+	if _, ok := backend.(*LlamaCppBackend); !ok {
+		meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionImageCompatible)
+		return
+	}
+
+	now := metav1.NewTime(time.Now())
+	existing := meta.FindStatusCondition(isvc.Status.Conditions, ConditionImageCompatible)
+	message := imageUpgradeRecommendation(model, r.containerImage(backend, isvc, model))
+	if message == "" {
+		if existing != nil {
+			meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+				Type:               ConditionImageCompatible,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: isvc.Generation,
+				LastTransitionTime: now,
+				Reason:             "ImageSupportsArchitecture",
+				Message:            "Runtime image supports the Model's architecture",
+			})
+		}
+		return
+	}
+
+	if r.Recorder != nil && (existing == nil || existing.Status != metav1.ConditionFalse) {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "ImageUpgradeRecommended", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+		Type:               ConditionImageCompatible,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: isvc.Generation,
+		LastTransitionTime: now,
+		Reason:             "ImageUpgradeRecommended",
+		Message:            message,
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestLlamaCppImageBuild(t *testing.T) {
+	tests := []struct {
+		image string
+		want  int
+		ok    bool
+	}{
+		{"ghcr.io/ggml-org/llama.cpp:server-cuda-b10068", 10068, true},
+		{"ghcr.io/ggml-org/llama.cpp:server-b4500", 4500, true},
+		{"registry.local:5000/llama.cpp:b4875", 4875, true},
+		{"ghcr.io/ggml-org/llama.cpp:server", 0, false},
+		{"registry.local:5000/llama.cpp", 0, false},
+		{"ghcr.io/ggml-org/llama.cpp@sha256:abc", 0, false},
+		{"ghcr.io/acme/llama.cpp:custom-build", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := llamaCppImageBuild(tt.image)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("llamaCppImageBuild(%q) = (%d, %v), want (%d, %v)", tt.image, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestImageUpgradeRecommendation(t *testing.T) {
+	modelWithArch := func(arch string) *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{
+			Status: inferencev1alpha1.ModelStatus{GGUF: &inferencev1alpha1.GGUFMetadata{Architecture: arch}},
+		}
+	}
+
+	msg := imageUpgradeRecommendation(modelWithArch("gpt-oss"), "ghcr.io/ggml-org/llama.cpp:server-cuda-b5000")
+	if !strings.Contains(msg, "b6096") || !strings.Contains(msg, "gpt-oss") {
+		t.Errorf("expected an upgrade recommendation naming b6096 and gpt-oss, got %q", msg)
+	}
+
+	for name, tc := range map[string]struct {
+		model *inferencev1alpha1.Model
+		image string
+	}{
+		"new enough image":   {modelWithArch("gpt-oss"), "ghcr.io/ggml-org/llama.cpp:server-cuda-b10068"},
+		"old architecture":   {modelWithArch("llama"), "ghcr.io/ggml-org/llama.cpp:server-b1000"},
+		"floating tag":       {modelWithArch("qwen3"), "ghcr.io/ggml-org/llama.cpp:server"},
+		"GGUF not parsed":    {&inferencev1alpha1.Model{}, "ghcr.io/ggml-org/llama.cpp:server-b1000"},
+		"architecture unset": {modelWithArch(""), "ghcr.io/ggml-org/llama.cpp:server-b1000"},
+	} {
+		if msg := imageUpgradeRecommendation(tc.model, tc.image); msg != "" {
+			t.Errorf("%s: expected no recommendation, got %q", name, msg)
+		}
+	}
+}
+
+func TestReconcileImageCompatibilityCondition(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		Status: inferencev1alpha1.ModelStatus{GGUF: &inferencev1alpha1.GGUFMetadata{Architecture: "qwen3"}},
+	}
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default", Generation: 1},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{Image: "ghcr.io/ggml-org/llama.cpp:server-b4000"},
+	}
+	recorder := events.NewFakeRecorder(10)
+	r := &InferenceServiceReconciler{Recorder: recorder}
+
+	r.reconcileImageCompatibilityCondition(isvc, model)
+	cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionImageCompatible)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "ImageUpgradeRecommended" {
+		t.Fatalf("expected False/ImageUpgradeRecommended condition, got %+v", cond)
+	}
+	select {
+	case ev := <-recorder.Events:
+		if !strings.HasPrefix(ev, "Warning ImageUpgradeRecommended") {
+			t.Errorf("unexpected event %q", ev)
+		}
+	default:
+		t.Error("expected an ImageUpgradeRecommended warning event")
+	}
+
+	// A second pass keeps the condition without repeating the event.
+	r.reconcileImageCompatibilityCondition(isvc, model)
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no repeated event, got %d", len(recorder.Events))
+	}
+
+	// Upgrading the image flips the condition back to True.
+	isvc.Spec.Image = "ghcr.io/ggml-org/llama.cpp:server-b6000"
+	r.reconcileImageCompatibilityCondition(isvc, model)
+	cond = meta.FindStatusCondition(isvc.Status.Conditions, ConditionImageCompatible)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected True condition after upgrade, got %+v", cond)
+	}
+
+	// Non-llama.cpp runtimes never carry the condition.
+	isvc.Spec.Runtime = RuntimeVLLM
+	r.reconcileImageCompatibilityCondition(isvc, model)
+	if cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionImageCompatible); cond != nil {
+		t.Errorf("expected condition removed for vllm, got %+v", cond)
+	}
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -60,9 +62,17 @@ type InferenceServiceReconciler struct {
 	// WaitForFirstConsumer PVC that binds on the serving node (#728), the opt-in
 	// escape hatch for multi-node clusters without RWX. An empty value is treated
 	// as shared (see resolveCacheMode).
-	ModelCacheMode     string
-	CACertConfigMap    string
-	InitContainerImage string
+	ModelCacheMode string
+	// ModelCacheExpandIncrement enables automatic expansion of operator-created
+	// cache PVCs: once the model downloader reports usage at or above
+	// ModelCacheExpandThreshold percent, the PVC grows by this quantity, up to
+	// ModelCacheExpandMaxSize. Empty (the default) disables expansion. See
+	// model_cache_expansion.go.
+	ModelCacheExpandIncrement string
+	ModelCacheExpandMaxSize   string
+	ModelCacheExpandThreshold int
+	CACertConfigMap           string
+	InitContainerImage        string
 	// AllowedHostPathRoots is the operator-configured allowlist of absolute
 	// path prefixes under which local (/abs and file://) model sources — and
 	// therefore the HostPathVolumeSource they generate — are permitted. Empty
@@ -97,6 +107,17 @@ type InferenceServiceReconciler struct {
 	// Empty means no shared pool exists and gpuSharing mode shared is
 	// rejected at reconcile time.
 	GPUSharingSharedPool map[string]string
+	// DefaultContextSizeMax caps the llama.cpp --ctx-size defaulted from a
+	// Model's trained context length when spec.contextSize is unset; the KV
+	// cache grows linearly with it, so a 128K-trained model is not given a
+	// 128K window unasked. Set via --default-context-size-max. Zero disables
+	// the default and leaves the context size to llama-server.
+	DefaultContextSizeMax int32
+	// DefaultImagePullPolicy is applied to every container of the inference
+	// Pod when spec.imagePullPolicy is unset. Set via
+	// --default-image-pull-policy (chart: controllerManager.defaultImagePullPolicy).
+	// Empty leaves the Kubernetes default in place.
+	DefaultImagePullPolicy corev1.PullPolicy
 }
 
 func sanitizeDNSName(name string) string {
@@ -117,6 +138,11 @@ func int64Ptr(v int64) *int64 { return &v }
 // standard curlimages/curl init image; explicit RunAsUser is only needed if
 // the operator overrides --init-container-image with one whose default user
 // differs from curl_user.
+//
+// The RuntimeDefault seccomp profile is always set. runAsNonRoot is set only
+// together with a non-zero runAsUser from podSecurityContext: curlimages/curl
+// runs as the non-numeric curl_user, which the kubelet refuses to start under
+// runAsNonRoot because it cannot verify the user is not root.
 func initContainerSecurityContext(isvc *inferencev1alpha1.InferenceService) *corev1.SecurityContext {
 	sc := &corev1.SecurityContext{
 		AllowPrivilegeEscalation: boolPtr(false),
@@ -124,12 +150,18 @@ func initContainerSecurityContext(isvc *inferencev1alpha1.InferenceService) *cor
 		Capabilities: &corev1.Capabilities{
 			Drop: []corev1.Capability{"ALL"},
 		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
 	}
 
 	// Inherit runAsUser/runAsGroup from podSecurityContext if specified
 	if isvc != nil && isvc.Spec.PodSecurityContext != nil {
 		if isvc.Spec.PodSecurityContext.RunAsUser != nil {
 			sc.RunAsUser = isvc.Spec.PodSecurityContext.RunAsUser
+			if *sc.RunAsUser != 0 {
+				sc.RunAsNonRoot = boolPtr(true)
+			}
 		}
 		if isvc.Spec.PodSecurityContext.RunAsGroup != nil {
 			sc.RunAsGroup = isvc.Spec.PodSecurityContext.RunAsGroup
@@ -144,13 +176,18 @@ func initContainerSecurityContext(isvc *inferencev1alpha1.InferenceService) *cor
 // +kubebuilder:rbac:groups=inference.llmkube.dev,resources=inferenceservices/finalizers,verbs=update
 // +kubebuilder:rbac:groups=inference.llmkube.dev,resources=models,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=patch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaims,verbs=get;list;watch
@@ -222,6 +259,9 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return r.updateStatusWithSchedulingInfo(ctx, inferenceService, PhaseFailed, modelReady, 0, desiredReplicas, "",
 				fmt.Sprintf("Failed to ensure model cache PVC: %v", err), nil)
 		}
+		if err := r.reconcileModelCacheExpansion(ctx, inferenceService); err != nil {
+			log.Error(err, "Failed to expand model cache PVC", "namespace", inferenceService.Namespace)
+		}
 	}
 
 	r.warnIgnoredModelCacheClaim(inferenceService, model)
@@ -233,6 +273,10 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			"CPU/KV offloading is enabled but resources.memory/hostMemory is not set; hybrid pods consume significant host RAM")
 	}
 
+	r.reconcileGPULayersCondition(inferenceService, model)
+
+	r.reconcileRerankSupportedCondition(inferenceService, model)
+
 	if r.Recorder != nil && shouldWarnMissingSkipModelInit(model, inferenceService) {
 		r.Recorder.Eventf(inferenceService, nil, corev1.EventTypeWarning, "MissingSkipModelInit", "Reconcile",
 			"Model source is a HuggingFace repo ID (resolved by the runtime at startup); set spec.skipModelInit=true so the init container does not run")
@@ -246,6 +290,15 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	r.reconcileGPUUtilizedCondition(ctx, inferenceService, model)
+
+	if !isMetal {
+		if err := r.reconcileWarmStandby(ctx, inferenceService, deployment, desiredReplicas); err != nil {
+			log.Error(err, "Failed to reconcile warm standby")
+			return ctrl.Result{}, err
+		}
+	}
+
 	service, result, err := r.reconcileService(ctx, inferenceService, modelReady, desiredReplicas, isMetal)
 	if err != nil || result != nil {
 		if result != nil {
@@ -258,7 +311,14 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcileIngress(ctx, inferenceService, service.Name, isMetal); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	endpoint := r.constructEndpoint(inferenceService, service)
+	if ingressSpec(inferenceService) != nil && !isMetal {
+		endpoint = ingressEndpoint(inferenceService)
+	}
 	phase, schedulingInfo := r.determinePhase(ctx, inferenceService, readyReplicas, desiredReplicas, isMetal, deployment, metalSnap)
 
 	finalResult, statusErr := r.updateStatusWithSchedulingInfo(ctx, inferenceService, phase, modelReady, readyReplicas, desiredReplicas, endpoint, "", schedulingInfo)
@@ -332,6 +392,17 @@ func (r *InferenceServiceReconciler) getModelForInferenceService(ctx context.Con
 	return model, modelReady, nil, nil
 }
 
+// deploymentReadyReplicas is the replica count the service reports as ready.
+// With spec.minReadySeconds set, a Pod only counts once the Deployment marks
+// it available, so a server that briefly drops readiness while warming does
+// not flap the phase.
+func deploymentReadyReplicas(dep *appsv1.Deployment) int32 {
+	if dep.Spec.MinReadySeconds > 0 {
+		return dep.Status.AvailableReplicas
+	}
+	return dep.Status.ReadyReplicas
+}
+
 func (r *InferenceServiceReconciler) reconcileDeployment(ctx context.Context, isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model, desiredReplicas int32, modelReady bool, isMetal bool) (*appsv1.Deployment, int32, *metalSnapshot, *ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
@@ -353,6 +424,10 @@ func (r *InferenceServiceReconciler) reconcileDeployment(ctx context.Context, is
 	// (see VLLMBackend.BuildArgs, SGLangBackend.BuildArgs).
 	r.reconcileVLLMSpecCondition(isvc)
 	r.reconcileSGLangSpecCondition(isvc)
+	r.reconcileModelCacheAccessCondition(ctx, isvc, model, desiredReplicas)
+	r.reconcileImageCompatibilityCondition(isvc, model)
+	r.reconcileEndpointRouteCondition(isvc)
+	r.reconcileLoRAAdaptersCondition(ctx, isvc)
 
 	// gpuSharing, by contrast, is fatal when invalid or unsatisfiable:
 	// building the Deployment anyway would either request an extended
@@ -364,15 +439,82 @@ func (r *InferenceServiceReconciler) reconcileDeployment(ctx context.Context, is
 		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid gpuSharing: %v", err), nil)
 		return nil, 0, nil, &result, updateErr
 	}
+	if err := validateVisibleDevices(isvc, model); err != nil {
+		log.Info("Rejecting InferenceService with invalid visibleDevices", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid hardware.gpu.visibleDevices: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+	if err := validateMainGPU(isvc, model); err != nil {
+		log.Info("Rejecting InferenceService with invalid mainGpu", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid hardware.gpu.mainGpu: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+	if err := validateEndpointPath(isvc); err != nil {
+		log.Info("Rejecting InferenceService with invalid endpoint path", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid endpoint: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+	if err := validateSplitKVCache(isvc, model); err != nil {
+		log.Info("Rejecting InferenceService with invalid splitKVCache", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid hardware.gpu.splitKVCache: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+
+	if err := validateResources(isvc); err != nil {
+		log.Info("Rejecting InferenceService with invalid resources", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid resources: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+
+	if err := validateLoRAAdapters(isvc); err != nil {
+		log.Info("Rejecting InferenceService with invalid loraAdapters", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid loraAdapters: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+
+	if err := validateExtraInitContainers(isvc); err != nil {
+		log.Info("Rejecting InferenceService with invalid extraInitContainers", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid extraInitContainers: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+
+	if err := validateWarmStandby(isvc); err != nil {
+		log.Info("Rejecting InferenceService with invalid warmStandby", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid warmStandby: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+
+	draftModel, err := r.getDraftModel(ctx, isvc, model)
+	if errors.Is(err, errDraftModelNotReady) {
+		log.Info("Draft model not ready yet", "draftModel", isvc.Spec.DraftModelRef)
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, "Pending", modelReady, 0, desiredReplicas, "", "Waiting for draft Model to be Ready", nil)
+		return nil, 0, nil, &result, updateErr
+	}
+	if err != nil {
+		log.Info("Rejecting InferenceService with invalid draftModelRef", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid draftModelRef: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
 
 	deployment := r.constructDeployment(isvc, model, desiredReplicas)
+	if draftModel != nil {
+		r.addDraftModel(deployment, isvc, model, draftModel)
+	}
+	if err := r.addLoRAAdapters(deployment, isvc); err != nil {
+		log.Info("Rejecting InferenceService with invalid loraAdapters", "reason", err.Error())
+		result, updateErr := r.updateStatusWithSchedulingInfo(ctx, isvc, PhaseFailed, modelReady, 0, desiredReplicas, "", fmt.Sprintf("Invalid loraAdapters: %v", err), nil)
+		return nil, 0, nil, &result, updateErr
+	}
+	// After the draft and LoRA downloaders are added so they are covered too.
+	applyImagePullPolicy(&deployment.Spec.Template.Spec, r.resolveImagePullPolicy(isvc))
+	applyWarmStandby(deployment, isvc)
 	if err := setControllerReferenceUnblocked(isvc, deployment, r.Scheme); err != nil {
 		log.Error(err, "Failed to set controller reference for Deployment")
 		return nil, 0, nil, nil, err
 	}
 
 	existingDeployment := &appsv1.Deployment{}
-	err := r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, existingDeployment)
+	err = r.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, existingDeployment)
 	if err != nil && apierrors.IsNotFound(err) {
 		log.Info("Creating new Deployment", "name", deployment.Name)
 		// Stamp desired-template hash on new deployment for change detection.
@@ -484,7 +626,7 @@ func (r *InferenceServiceReconciler) reconcileDeployment(ctx context.Context, is
 			// to Service/HPA/status by returning without a result. The
 			// RolloutDeferred=True condition (set by reconcileRolloutPolicy)
 			// drives a requeue in Reconcile.
-			return existingDeployment, existingDeployment.Status.ReadyReplicas, nil, nil, nil
+			return existingDeployment, deploymentReadyReplicas(existingDeployment), nil, nil, nil
 		}
 	} else if !isMetal && !templateChanged {
 		// Template no longer differs — clear any stale RolloutDeferred condition.
@@ -497,6 +639,10 @@ func (r *InferenceServiceReconciler) reconcileDeployment(ctx context.Context, is
 		}
 	}
 
+	// Always write the desired template so manual drift is reverted. On a
+	// replica-only change it defaults to the same pod template the
+	// Deployment already runs, so the pod-template-hash is unchanged and the
+	// Deployment scales in place instead of rolling every pod.
 	existingDeployment.Spec = deployment.Spec
 	existingDeployment.Spec.Template.Labels = desiredTemplateLabels
 	existingDeployment.Spec.Template.Annotations = desiredTemplateAnnotations
@@ -520,7 +666,7 @@ func (r *InferenceServiceReconciler) reconcileDeployment(ctx context.Context, is
 		return nil, 0, nil, nil, err
 	}
 
-	return deployment, existingDeployment.Status.ReadyReplicas, nil, nil, nil
+	return deployment, deploymentReadyReplicas(existingDeployment), nil, nil, nil
 }
 
 func (r *InferenceServiceReconciler) reconcileService(ctx context.Context, isvc *inferencev1alpha1.InferenceService, modelReady bool, desiredReplicas int32, isMetal bool) (*corev1.Service, *ctrl.Result, error) {
@@ -767,6 +913,7 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&networkingv1.Ingress{}).
 		Watches(
 			&corev1.Pod{},
 			handler.EnqueueRequestsFromMapFunc(r.findInferenceServiceForPod),
@@ -845,7 +992,7 @@ func (r *InferenceServiceReconciler) findInferenceServicesForModel(ctx context.C
 
 	var requests []reconcile.Request
 	for _, isvc := range inferenceServiceList.Items {
-		if isvc.Spec.ModelRef == model.Name {
+		if isvc.Spec.ModelRef == model.Name || isvc.Spec.DraftModelRef == model.Name {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      isvc.Name,
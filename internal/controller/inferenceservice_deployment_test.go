@@ -17,6 +17,8 @@ limitations under the License.
 package controller
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
@@ -512,6 +514,62 @@ var _ = Describe("Multi-GPU Deployment Construction", func() {
 		})
 	})
 
+	Context("when the Model pins spec.sha256", func() {
+		var reconciler *InferenceServiceReconciler
+
+		BeforeEach(func() {
+			reconciler = &InferenceServiceReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				ModelCachePath: "/models",
+				DefaultFSGroup: 102,
+			}
+		})
+
+		newModel := func(sha string) *inferencev1alpha1.Model {
+			return &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{Name: "checksum-model", Namespace: "default"},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source: "https://example.com/model.gguf",
+					SHA256: sha,
+				},
+				Status: inferencev1alpha1.ModelStatus{Phase: "Ready", CacheKey: "abc123"},
+			}
+		}
+		isvc := &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "checksum-svc", Namespace: "default"},
+			Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: "checksum-model"},
+		}
+		downloader := func(deployment *appsv1.Deployment) corev1.Container {
+			for _, c := range deployment.Spec.Template.Spec.InitContainers {
+				if c.Name == "model-downloader" {
+					return c
+				}
+			}
+			Fail("model-downloader init container not found")
+			return corev1.Container{}
+		}
+
+		It("should verify the downloaded file and fail the init container on mismatch", func() {
+			sha := "ABCDEF0123456789abcdef0123456789abcdef0123456789abcdef0123456789"
+			c := downloader(reconciler.constructDeployment(isvc, newModel(sha), 1))
+
+			Expect(c.Command[2]).To(ContainSubstring(`sha256sum "$MODEL_PATH"`))
+			Expect(c.Command[2]).To(ContainSubstring("ChecksumMismatch"))
+			Expect(c.Command[2]).To(ContainSubstring("exit 1"))
+			Expect(c.Env).To(ContainElement(corev1.EnvVar{Name: "MODEL_SHA256", Value: strings.ToLower(sha)}))
+		})
+
+		It("should leave the download command unchanged when sha256 is unset", func() {
+			c := downloader(reconciler.constructDeployment(isvc, newModel(""), 1))
+
+			Expect(c.Command[2]).NotTo(ContainSubstring("sha256sum"))
+			for _, e := range c.Env {
+				Expect(e.Name).NotTo(Equal("MODEL_SHA256"))
+			}
+		})
+	})
+
 	Context("when verifying revisionHistoryLimit configuration", func() {
 		var (
 			reconciler *InferenceServiceReconciler
@@ -570,6 +628,71 @@ var _ = Describe("Multi-GPU Deployment Construction", func() {
 		})
 	})
 
+	Context("when verifying minReadySeconds configuration", func() {
+		var (
+			reconciler *InferenceServiceReconciler
+			model      *inferencev1alpha1.Model
+		)
+
+		BeforeEach(func() {
+			reconciler = &InferenceServiceReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+				DefaultFSGroup:     102,
+			}
+			model = &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mrs-model",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source:       "https://example.com/model.gguf",
+					Format:       "gguf",
+					Quantization: "Q4_K_M",
+					Hardware:     &inferencev1alpha1.HardwareSpec{Accelerator: "cpu"},
+				},
+				Status: inferencev1alpha1.ModelStatus{Phase: "Ready"},
+			}
+		})
+
+		newISVC := func(minReady int32) *inferencev1alpha1.InferenceService {
+			replicas := int32(1)
+			return &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mrs-service",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef:        "mrs-model",
+					Replicas:        &replicas,
+					Image:           "ghcr.io/ggml-org/llama.cpp:server",
+					MinReadySeconds: minReady,
+				},
+			}
+		}
+
+		It("should leave minReadySeconds at 0 when unset", func() {
+			deployment := reconciler.constructDeployment(newISVC(0), model, 1)
+			Expect(deployment.Spec.MinReadySeconds).To(BeZero())
+		})
+
+		It("should carry the configured minReadySeconds onto the Deployment", func() {
+			deployment := reconciler.constructDeployment(newISVC(30), model, 1)
+			Expect(deployment.Spec.MinReadySeconds).To(Equal(int32(30)))
+		})
+
+		It("should count available rather than ready replicas when set", func() {
+			deployment := reconciler.constructDeployment(newISVC(30), model, 1)
+			deployment.Status.ReadyReplicas = 2
+			deployment.Status.AvailableReplicas = 1
+			Expect(deploymentReadyReplicas(deployment)).To(Equal(int32(1)))
+
+			deployment.Spec.MinReadySeconds = 0
+			Expect(deploymentReadyReplicas(deployment)).To(Equal(int32(2)))
+		})
+	})
+
 	Context("when setting max pod lifetime", func() {
 		var (
 			reconciler *InferenceServiceReconciler
@@ -866,6 +989,167 @@ var _ = Describe("Multi-GPU Deployment Construction", func() {
 			Expect(deployment.Spec.Template.Spec.Affinity).NotTo(BeNil())
 			Expect(deployment.Spec.Template.Spec.Affinity.NodeAffinity).NotTo(BeNil())
 		})
+
+		It("should apply node selector, tolerations and affinity to CPU-only pods", func() {
+			model := &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cpu-placement-model",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source: "https://example.com/model.gguf",
+					Format: "gguf",
+				},
+				Status: inferencev1alpha1.ModelStatus{
+					Phase: "Ready",
+					Path:  "/tmp/llmkube/models/test-model.gguf",
+				},
+			}
+
+			replicas := int32(1)
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cpu-placement-service",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef: "cpu-placement-model",
+					Replicas: &replicas,
+					Image:    "ghcr.io/ggml-org/llama.cpp:server",
+					NodeSelector: map[string]string{
+						"cloud.google.com/gke-nodepool": "cpu-pool",
+					},
+					Tolerations: []corev1.Toleration{
+						{
+							Key:      "workload",
+							Operator: corev1.TolerationOpEqual,
+							Value:    "inference",
+							Effect:   corev1.TaintEffectNoSchedule,
+						},
+					},
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchExpressions: []corev1.NodeSelectorRequirement{
+											{
+												Key:      "kubernetes.io/arch",
+												Operator: corev1.NodeSelectorOpIn,
+												Values:   []string{"amd64"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			deployment := reconciler.constructDeployment(isvc, model, 1)
+			podSpec := deployment.Spec.Template.Spec
+
+			By("verifying the user's node selector is applied without a GPU")
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"cloud.google.com/gke-nodepool": "cpu-pool"}))
+
+			By("verifying only the user's tolerations are applied (no GPU taint toleration)")
+			Expect(podSpec.Tolerations).To(HaveLen(1))
+			Expect(podSpec.Tolerations[0].Key).To(Equal("workload"))
+
+			By("verifying affinity passes through to the pod spec")
+			Expect(podSpec.Affinity).NotTo(BeNil())
+			Expect(podSpec.Affinity.NodeAffinity).NotTo(BeNil())
+
+			By("verifying CPU workloads keep the default rollout strategy")
+			Expect(deployment.Spec.Strategy.Type).NotTo(Equal(appsv1.RecreateDeploymentStrategyType))
+		})
+	})
+})
+
+var _ = Describe("Split KV Cache Configuration", func() {
+	var reconciler *InferenceServiceReconciler
+
+	BeforeEach(func() {
+		reconciler = &InferenceServiceReconciler{
+			InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+			DefaultFSGroup:     102,
+		}
+	})
+
+	newModel := func(count int32, strategy string, splitKV bool) *inferencev1alpha1.Model {
+		gpu := &inferencev1alpha1.GPUSpec{
+			Enabled:      true,
+			Count:        count,
+			Vendor:       "nvidia",
+			SplitKVCache: &splitKV,
+		}
+		if strategy != "" {
+			gpu.Sharding = &inferencev1alpha1.GPUShardingSpec{Strategy: strategy}
+		}
+		return &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "kv-model", Namespace: "default"},
+			Spec: inferencev1alpha1.ModelSpec{
+				Source:   "https://example.com/model.gguf",
+				Hardware: &inferencev1alpha1.HardwareSpec{Accelerator: "cuda", GPU: gpu},
+			},
+			Status: inferencev1alpha1.ModelStatus{
+				Phase: "Ready",
+				Path:  "/tmp/llmkube/models/kv-model.gguf",
+			},
+		}
+	}
+
+	newISVC := func() *inferencev1alpha1.InferenceService {
+		replicas := int32(1)
+		return &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "kv-service", Namespace: "default"},
+			Spec: inferencev1alpha1.InferenceServiceSpec{
+				ModelRef: "kv-model",
+				Replicas: &replicas,
+				Image:    "ghcr.io/ggml-org/llama.cpp:server-cuda13",
+			},
+		}
+	}
+
+	It("should emit layer split with KV offload when enabled on multiple GPUs", func() {
+		deployment := reconciler.constructDeployment(newISVC(), newModel(2, "", true), 1)
+
+		args := deployment.Spec.Template.Spec.Containers[0].Args
+		Expect(args).To(ContainElements("--split-mode", "layer", "--tensor-split", "1,1", "--kv-offload"))
+		Expect(args).NotTo(ContainElement("--no-kv-offload"))
+	})
+
+	It("should not emit --kv-offload when disabled or on a single GPU", func() {
+		args := reconciler.constructDeployment(newISVC(), newModel(2, "layer", false), 1).Spec.Template.Spec.Containers[0].Args
+		Expect(args).NotTo(ContainElement("--kv-offload"))
+
+		args = reconciler.constructDeployment(newISVC(), newModel(1, "", true), 1).Spec.Template.Spec.Containers[0].Args
+		Expect(args).NotTo(ContainElement("--kv-offload"))
+		Expect(validateSplitKVCache(newISVC(), newModel(1, "row", true))).To(Succeed())
+	})
+
+	It("should accept layer and pipeline sharding", func() {
+		Expect(validateSplitKVCache(newISVC(), newModel(2, "", true))).To(Succeed())
+		Expect(validateSplitKVCache(newISVC(), newModel(2, "pipeline", true))).To(Succeed())
+	})
+
+	It("should reject split modes that keep the KV cache on one device", func() {
+		for _, strategy := range []string{"row", "tensor", "none"} {
+			err := validateSplitKVCache(newISVC(), newModel(2, strategy, true))
+			Expect(err).To(HaveOccurred(), strategy)
+			Expect(err.Error()).To(ContainSubstring(`requires split mode "layer"`))
+		}
+	})
+
+	It("should reject combining splitKVCache with noKvOffload", func() {
+		isvc := newISVC()
+		noKvOffload := true
+		isvc.Spec.NoKvOffload = &noKvOffload
+
+		err := validateSplitKVCache(isvc, newModel(2, "layer", true))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("noKvOffload"))
 	})
 })
 
@@ -1282,6 +1566,66 @@ var _ = Describe("Context Size Configuration", func() {
 		})
 	})
 
+	Context("when memoryLock is configured", func() {
+		var (
+			reconciler *InferenceServiceReconciler
+			model      *inferencev1alpha1.Model
+		)
+
+		BeforeEach(func() {
+			reconciler = &InferenceServiceReconciler{
+				ModelCachePath:     "/tmp/llmkube/models",
+				InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+				DefaultFSGroup:     102,
+			}
+			model = &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mlock-model",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source: "https://example.com/model.gguf",
+				},
+				Status: inferencev1alpha1.ModelStatus{
+					Phase:    "Ready",
+					CacheKey: "test-cache-key",
+					Path:     "/tmp/llmkube/models/test-model.gguf",
+				},
+			}
+		})
+
+		newISVC := func(memoryLock *bool) *inferencev1alpha1.InferenceService {
+			replicas := int32(1)
+			return &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mlock-service",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef:   "mlock-model",
+					Replicas:   &replicas,
+					Image:      "ghcr.io/ggml-org/llama.cpp:server",
+					MemoryLock: memoryLock,
+				},
+			}
+		}
+
+		It("should include --mlock when memoryLock is true", func() {
+			memoryLock := true
+			deployment := reconciler.constructDeployment(newISVC(&memoryLock), model, 1)
+
+			Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--mlock"))
+		})
+
+		It("should NOT include --mlock when memoryLock is unset or false", func() {
+			memoryLock := false
+			for _, v := range []*bool{nil, &memoryLock} {
+				deployment := reconciler.constructDeployment(newISVC(v), model, 1)
+				Expect(deployment.Spec.Template.Spec.Containers[0].Args).NotTo(ContainElement("--mlock"))
+			}
+		})
+	})
+
 	Context("when jinja is configured", func() {
 		var (
 			reconciler *InferenceServiceReconciler
@@ -2877,6 +3221,148 @@ var _ = Describe("Context Size Configuration", func() {
 
 			Expect(deployment.Spec.Template.Spec.RuntimeClassName).To(BeNil())
 		})
+
+		It("should set PodSpec.SchedulerName when spec.schedulerName is set", func() {
+			replicas := int32(1)
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sched-service",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef:      "rcn-model",
+					Replicas:      &replicas,
+					Image:         "ghcr.io/ggml-org/llama.cpp:server-cuda13",
+					SchedulerName: "volcano",
+					Resources:     &inferencev1alpha1.InferenceResourceRequirements{GPU: 1},
+				},
+			}
+
+			deployment := reconciler.constructDeployment(isvc, model, 1)
+
+			Expect(deployment.Spec.Template.Spec.SchedulerName).To(Equal("volcano"))
+		})
+
+		It("should leave PodSpec.SchedulerName empty when spec.schedulerName is unset", func() {
+			replicas := int32(1)
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "no-sched-service",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef:  "rcn-model",
+					Replicas:  &replicas,
+					Image:     "ghcr.io/ggml-org/llama.cpp:server-cuda13",
+					Resources: &inferencev1alpha1.InferenceResourceRequirements{GPU: 1},
+				},
+			}
+
+			deployment := reconciler.constructDeployment(isvc, model, 1)
+
+			Expect(deployment.Spec.Template.Spec.SchedulerName).To(BeEmpty())
+		})
+	})
+
+	// spec.hardware.gpu.visibleDevices pins the container to specific GPU
+	// indices via the vendor's *_VISIBLE_DEVICES env var.
+	Context("when hardware.gpu.visibleDevices is configured", func() {
+		var reconciler *InferenceServiceReconciler
+
+		BeforeEach(func() {
+			reconciler = &InferenceServiceReconciler{
+				ModelCachePath:     "/tmp/llmkube/models",
+				InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+				DefaultFSGroup:     102,
+			}
+		})
+
+		// count is hardware.gpu.count, which takes precedence over the
+		// InferenceService's resources.gpu; 0 leaves it to the latter.
+		newModel := func(vendor string, count int32, devices ...int32) *inferencev1alpha1.Model {
+			return &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vd-model",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source: "https://example.com/model.gguf",
+					Hardware: &inferencev1alpha1.HardwareSpec{
+						GPU: &inferencev1alpha1.GPUSpec{
+							Count:          count,
+							Vendor:         vendor,
+							VisibleDevices: devices,
+						},
+					},
+				},
+				Status: inferencev1alpha1.ModelStatus{
+					Phase:    "Ready",
+					CacheKey: "vd-cache-key",
+					Path:     "/tmp/llmkube/models/vd-model.gguf",
+				},
+			}
+		}
+
+		newISVC := func(gpus int32) *inferencev1alpha1.InferenceService {
+			replicas := int32(1)
+			return &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vd-service",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef:  "vd-model",
+					Replicas:  &replicas,
+					Image:     "ghcr.io/ggml-org/llama.cpp:server-cuda13",
+					Resources: &inferencev1alpha1.InferenceResourceRequirements{GPU: gpus},
+				},
+			}
+		}
+
+		It("should set CUDA_VISIBLE_DEVICES to the configured indices for nvidia", func() {
+			deployment := reconciler.constructDeployment(newISVC(2), newModel("nvidia", 2, 1, 3), 1)
+
+			env := deployment.Spec.Template.Spec.Containers[0].Env
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: "CUDA_VISIBLE_DEVICES", Value: "1,3"}))
+		})
+
+		It("should set HIP_VISIBLE_DEVICES for amd", func() {
+			deployment := reconciler.constructDeployment(newISVC(1), newModel("amd", 1, 2), 1)
+
+			env := deployment.Spec.Template.Spec.Containers[0].Env
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: "HIP_VISIBLE_DEVICES", Value: "2"}))
+			for _, e := range env {
+				Expect(e.Name).NotTo(Equal("CUDA_VISIBLE_DEVICES"))
+			}
+		})
+
+		It("should not set a visible-devices env var when unset", func() {
+			deployment := reconciler.constructDeployment(newISVC(1), newModel("nvidia", 1), 1)
+
+			for _, e := range deployment.Spec.Template.Spec.Containers[0].Env {
+				Expect(e.Name).NotTo(Equal("CUDA_VISIBLE_DEVICES"))
+			}
+		})
+
+		It("should reject a device list that does not match the Model's GPU count", func() {
+			err := validateVisibleDevices(newISVC(1), newModel("nvidia", 2, 0))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("lists 1 device(s) but the resolved GPU count is 2"))
+
+			Expect(validateVisibleDevices(newISVC(1), newModel("nvidia", 2, 0, 1))).To(Succeed())
+		})
+
+		It("should reject a device list that does not match the InferenceService's GPU count", func() {
+			err := validateVisibleDevices(newISVC(2), newModel("nvidia", 0, 0))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("resolved GPU count is 2"))
+
+			Expect(validateVisibleDevices(newISVC(2), newModel("nvidia", 0, 0, 1))).To(Succeed())
+		})
+
+		It("should reject visibleDevices for intel", func() {
+			Expect(validateVisibleDevices(newISVC(1), newModel("intel", 1, 0))).NotTo(Succeed())
+		})
 	})
 
 	// Issue #326: PodAnnotations and PodLabels passthrough lets users tag
@@ -3115,9 +3601,82 @@ var _ = Describe("constructDeployment additional cases", func() {
 			},
 		}
 		deployment := reconciler.constructDeployment(isvc, model, 1)
-		requests := deployment.Spec.Template.Spec.Containers[0].Resources.Requests
-		Expect(requests[corev1.ResourceCPU]).To(Equal(resource.MustParse("2")))
-		Expect(requests[corev1.ResourceMemory]).To(Equal(resource.MustParse("4Gi")))
+		resources := deployment.Spec.Template.Spec.Containers[0].Resources
+		Expect(resources.Requests[corev1.ResourceCPU]).To(Equal(resource.MustParse("2")))
+		Expect(resources.Requests[corev1.ResourceMemory]).To(Equal(resource.MustParse("4Gi")))
+		Expect(resources.Limits).NotTo(HaveKey(corev1.ResourceCPU))
+		Expect(resources.Limits).NotTo(HaveKey(corev1.ResourceMemory))
+	})
+
+	It("should set CPU and Memory limits alongside requests and the GPU limit", func() {
+		model := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "default"},
+			Spec: inferencev1alpha1.ModelSpec{
+				Source: "https://example.com/model.gguf",
+				Hardware: &inferencev1alpha1.HardwareSpec{
+					Accelerator: "cuda",
+					GPU:         &inferencev1alpha1.GPUSpec{Enabled: true, Count: 1, Vendor: "nvidia"},
+				},
+			},
+			Status: inferencev1alpha1.ModelStatus{Phase: "Ready"},
+		}
+		isvc := &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "default"},
+			Spec: inferencev1alpha1.InferenceServiceSpec{
+				ModelRef: "m",
+				Resources: &inferencev1alpha1.InferenceResourceRequirements{
+					GPU:         1,
+					CPU:         "2",
+					Memory:      "4Gi",
+					CPULimit:    "4",
+					MemoryLimit: "8Gi",
+				},
+			},
+		}
+		deployment := reconciler.constructDeployment(isvc, model, 1)
+		resources := deployment.Spec.Template.Spec.Containers[0].Resources
+		Expect(resources.Requests[corev1.ResourceCPU]).To(Equal(resource.MustParse("2")))
+		Expect(resources.Requests[corev1.ResourceMemory]).To(Equal(resource.MustParse("4Gi")))
+		Expect(resources.Limits[corev1.ResourceCPU]).To(Equal(resource.MustParse("4")))
+		Expect(resources.Limits[corev1.ResourceMemory]).To(Equal(resource.MustParse("8Gi")))
+		Expect(resources.Limits["nvidia.com/gpu"]).To(Equal(resource.MustParse("1")))
+	})
+
+	It("should thread env and envFrom onto the llama-server container", func() {
+		model := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "default"},
+			Spec: inferencev1alpha1.ModelSpec{
+				Source:   "https://example.com/model.gguf",
+				Hardware: &inferencev1alpha1.HardwareSpec{Accelerator: "rocm"},
+			},
+			Status: inferencev1alpha1.ModelStatus{Phase: "Ready"},
+		}
+		isvc := &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "default"},
+			Spec: inferencev1alpha1.InferenceServiceSpec{
+				ModelRef: "m",
+				Env: []corev1.EnvVar{
+					{Name: "HSA_OVERRIDE_GFX_VERSION", Value: "11.0.0"},
+					{Name: "LLAMA_ARG_THREADS", Value: "8"},
+				},
+				EnvFrom: []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "llama-tuning"},
+					}},
+					{Prefix: "HF_", SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "hf-creds"},
+					}},
+				},
+			},
+		}
+		deployment := reconciler.constructDeployment(isvc, model, 1)
+		container := deployment.Spec.Template.Spec.Containers[0]
+		Expect(container.Name).To(Equal("llama-server"))
+		Expect(container.Env).To(ContainElements(
+			corev1.EnvVar{Name: "HSA_OVERRIDE_GFX_VERSION", Value: "11.0.0"},
+			corev1.EnvVar{Name: "LLAMA_ARG_THREADS", Value: "8"},
+		))
+		Expect(container.EnvFrom).To(Equal(isvc.Spec.EnvFrom))
 	})
 
 	It("should not add tolerations for CPU-only workload", func() {
@@ -1037,6 +1037,81 @@ var _ = Describe("Reconcile lifecycle", func() {
 			Expect(pvc.OwnerReferences).To(BeEmpty())
 		})
 
+		It("should scale in place and revert template drift on a replica-only change", func() {
+			modelName := "model-replica-only"
+			isvcName := "isvc-replica-only"
+
+			model := &inferencev1alpha1.Model{
+				ObjectMeta: metav1.ObjectMeta{Name: modelName, Namespace: "default"},
+				Spec: inferencev1alpha1.ModelSpec{
+					Source:   "https://example.com/model.gguf",
+					Hardware: &inferencev1alpha1.HardwareSpec{Accelerator: "cpu"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, model)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, model) }()
+
+			model.Status.Phase = PhaseReady
+			Expect(k8sClient.Status().Update(ctx, model)).To(Succeed())
+
+			replicas := int32(1)
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: isvcName, Namespace: "default"},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef: modelName,
+					Replicas: &replicas,
+					Image:    "ghcr.io/ggml-org/llama.cpp:server",
+				},
+			}
+			Expect(k8sClient.Create(ctx, isvc)).To(Succeed())
+			defer func() {
+				_ = k8sClient.Delete(ctx, isvc)
+				dep := &appsv1.Deployment{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: isvcName, Namespace: "default"}, dep); err == nil {
+					_ = k8sClient.Delete(ctx, dep)
+				}
+				svc := &corev1.Service{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: isvcName, Namespace: "default"}, svc); err == nil {
+					_ = k8sClient.Delete(ctx, svc)
+				}
+			}()
+
+			reconciler := &InferenceServiceReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: isvcName, Namespace: "default"}}
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			before := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, req.NamespacedName, before)).To(Succeed())
+			Expect(before.Annotations).To(HaveKey(AnnotationDesiredTemplateHash))
+
+			desiredTemplate := before.Spec.Template.DeepCopy()
+
+			By("drifting a live template field the operator does not diff on")
+			before.Spec.Template.Spec.TerminationGracePeriodSeconds = ptr.To(int64(45))
+			Expect(k8sClient.Update(ctx, before)).To(Succeed())
+			Expect(k8sClient.Get(ctx, req.NamespacedName, before)).To(Succeed())
+
+			By("scaling the InferenceService from 1 to 3 replicas")
+			Expect(k8sClient.Get(ctx, req.NamespacedName, isvc)).To(Succeed())
+			isvc.Spec.Replicas = ptr.To(int32(3))
+			Expect(k8sClient.Update(ctx, isvc)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			after := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, req.NamespacedName, after)).To(Succeed())
+			Expect(*after.Spec.Replicas).To(Equal(int32(3)))
+			Expect(after.Spec.Template.Spec.TerminationGracePeriodSeconds).NotTo(Equal(ptr.To(int64(45))))
+			Expect(after.Spec.Template).To(Equal(*desiredTemplate))
+			Expect(after.Annotations[AnnotationDesiredTemplateHash]).To(Equal(before.Annotations[AnnotationDesiredTemplateHash]))
+		})
+
 		It("should preserve agent-written schedulingStatus on status update", func() {
 			modelName := "model-sched-preserve"
 			isvcName := "isvc-sched-preserve"
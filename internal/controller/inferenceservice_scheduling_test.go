@@ -239,6 +239,45 @@ var _ = Describe("HPA Autoscaling", func() {
 			)
 		})
 
+		It("should add a cpu Resource metric for targetCPUUtilization", func() {
+			utilization := int32(60)
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hpa-target-cpu",
+					Namespace: "default",
+				},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					ModelRef: "test-model",
+					Autoscaling: &inferencev1alpha1.AutoscalingSpec{
+						MaxReplicas:          4,
+						TargetCPUUtilization: &utilization,
+					},
+				},
+			}
+
+			hpa := reconciler.constructHPA(isvc, "hpa-target-cpu")
+
+			By("replacing the default metric rather than adding to it")
+			Expect(hpa.Spec.Metrics).To(HaveLen(1))
+			Expect(hpa.Spec.Metrics[0].Type).To(
+				Equal(autoscalingv2.ResourceMetricSourceType),
+			)
+			Expect(hpa.Spec.Metrics[0].Resource.Name).To(Equal(corev1.ResourceCPU))
+			Expect(*hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(
+				Equal(int32(60)),
+			)
+
+			By("combining with an explicit custom metric")
+			target := "4"
+			isvc.Spec.Autoscaling.Metrics = []inferencev1alpha1.MetricSpec{
+				{Type: "Pods", Name: "vllm:num_requests_running", TargetAverageValue: &target},
+			}
+			hpa = reconciler.constructHPA(isvc, "hpa-target-cpu")
+			Expect(hpa.Spec.Metrics).To(HaveLen(2))
+			Expect(hpa.Spec.Metrics[0].Pods.Metric.Name).To(Equal("vllm:num_requests_running"))
+			Expect(hpa.Spec.Metrics[1].Resource.Name).To(Equal(corev1.ResourceCPU))
+		})
+
 		It("should set correct scaleTargetRef", func() {
 			isvc := &inferencev1alpha1.InferenceService{
 				ObjectMeta: metav1.ObjectMeta{
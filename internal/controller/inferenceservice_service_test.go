@@ -194,6 +194,52 @@ var _ = Describe("constructEndpoint", func() {
 		endpoint := reconciler.constructEndpoint(isvc, svc)
 		Expect(endpoint).To(HaveSuffix("/api/generate"))
 	})
+
+	It("should normalize the path so the reported endpoint is callable", func() {
+		isvc := &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-svc", Namespace: "default"},
+			Spec: inferencev1alpha1.InferenceServiceSpec{
+				Endpoint: &inferencev1alpha1.EndpointSpec{Path: " v1//completions/ "},
+			},
+		}
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-svc", Namespace: "default"},
+		}
+		endpoint := reconciler.constructEndpoint(isvc, svc)
+		Expect(endpoint).To(Equal("http://test-svc.default.svc.cluster.local:8080/v1/completions"))
+	})
+})
+
+var _ = Describe("unknownEndpointRoute", func() {
+	withPath := func(runtime, path string) *inferencev1alpha1.InferenceService {
+		return &inferencev1alpha1.InferenceService{
+			Spec: inferencev1alpha1.InferenceServiceSpec{
+				Runtime:  runtime,
+				Endpoint: &inferencev1alpha1.EndpointSpec{Path: path},
+			},
+		}
+	}
+
+	DescribeTable("accepts llama-server routes",
+		func(runtime, path string) {
+			Expect(unknownEndpointRoute(withPath(runtime, path))).To(BeEmpty())
+		},
+		Entry("unset", "", ""),
+		Entry("chat completions", "", "/v1/chat/completions"),
+		Entry("embeddings with trailing slash", "llamacpp", "/v1/embeddings/"),
+		Entry("rerank without leading slash", "llamacpp", "v1/rerank"),
+		Entry("OpenAI base path", "", "/v1"),
+		Entry("model list", "", "/v1/models"),
+		Entry("router runtime", RuntimeLlamaCppRouter, "/v1/completions"),
+	)
+
+	It("warns about a path llama-server does not serve", func() {
+		Expect(unknownEndpointRoute(withPath("", "/foo"))).To(ContainSubstring(`"/foo" is not a llama-server route`))
+	})
+
+	It("leaves other runtimes' paths alone", func() {
+		Expect(unknownEndpointRoute(withPath(RuntimeVLLM, "/generate"))).To(BeEmpty())
+	})
 })
 
 var _ = Describe("reconcileService Metal path", func() {
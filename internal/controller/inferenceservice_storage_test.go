@@ -383,6 +383,21 @@ var _ = Describe("buildEmptyDirStorageConfig multi-file staging", func() {
 		Expect(cmd).To(ContainSubstring("--etag-save"))
 		Expect(cmd).To(ContainSubstring("kept cached copy"))
 	})
+
+	It("wires sourceSecretRef into the multi-file downloader so HF_TOKEN reaches curl", func() {
+		model := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "gated", Namespace: "default"},
+			Spec: inferencev1alpha1.ModelSpec{
+				Source:          "hf://org/gated-repo",
+				Files:           []string{"model.gguf", "extra.gguf"},
+				SourceSecretRef: &corev1.LocalObjectReference{Name: "hf-token"},
+			},
+		}
+
+		config := buildEmptyDirStorageConfig(model, nil, "default", "", "curl:8.18.0")
+		Expect(config.initContainers[0].EnvFrom).To(HaveLen(1))
+		Expect(config.initContainers[0].EnvFrom[0].SecretRef.Name).To(Equal("hf-token"))
+	})
 })
 
 var _ = Describe("buildMultiFileInitCommand", func() {
@@ -391,7 +406,8 @@ var _ = Describe("buildMultiFileInitCommand", func() {
 		Expect(cmd).To(ContainSubstring(`mkdir -p "$CACHE_DIR"`))
 		Expect(cmd).To(ContainSubstring("printf '%s\\n' \"$MODEL_FILES\""))
 		Expect(cmd).To(ContainSubstring(`mkdir -p "$(dirname "$dest")"`))
-		Expect(cmd).To(ContainSubstring(`curl -f -L -o "$dest" "$url"`))
+		Expect(cmd).To(ContainSubstring(`-C - -o "$dest.partial" "$url"`))
+		Expect(cmd).To(ContainSubstring(`&& mv "$dest.partial" "$dest"`))
 		Expect(cmd).To(ContainSubstring("already cached, skipping download"))
 	})
 
@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// Ingress lifecycle. Runs after the Service is reconciled when
+// spec.endpoint.ingress is set; removes the operator-owned Ingress when it is
+// cleared. Metal workloads skip it: their Service is the metal-agent's, not
+// ours, so there is nothing for the operator to front.
+
+// ingressSpec returns spec.endpoint.ingress, or nil when unset.
+func ingressSpec(isvc *inferencev1alpha1.InferenceService) *inferencev1alpha1.IngressSpec {
+	if isvc.Spec.Endpoint == nil {
+		return nil
+	}
+	return isvc.Spec.Endpoint.Ingress
+}
+
+func (r *InferenceServiceReconciler) reconcileIngress(
+	ctx context.Context,
+	isvc *inferencev1alpha1.InferenceService,
+	serviceName string,
+	isMetal bool,
+) error {
+	logger := logf.FromContext(ctx)
+	ingressName := types.NamespacedName{
+		Name:      sanitizeDNSName(isvc.Name),
+		Namespace: isvc.Namespace,
+	}
+
+	if ingressSpec(isvc) == nil || isMetal {
+		existing := &networkingv1.Ingress{}
+		if err := r.Get(ctx, ingressName, existing); err == nil && metav1.IsControlledBy(existing, isvc) {
+			logger.Info("Ingress removed from spec, deleting Ingress", "name", existing.Name)
+			if err := r.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete Ingress: %w", err)
+			}
+		}
+		return nil
+	}
+
+	ingress := r.constructIngress(isvc, serviceName)
+	if err := setControllerReferenceUnblocked(isvc, ingress, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference on Ingress: %w", err)
+	}
+
+	existing := &networkingv1.Ingress{}
+	if err := r.Get(ctx, ingressName, existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Creating Ingress", "name", ingress.Name, "host", ingressSpec(isvc).Host)
+			return r.Create(ctx, ingress)
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, isvc) {
+		return fmt.Errorf("ingress %s/%s already exists and is not owned by this InferenceService", existing.Namespace, existing.Name)
+	}
+
+	existing.Spec = ingress.Spec
+	existing.Labels = ingress.Labels
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	for k, v := range ingress.Annotations {
+		existing.Annotations[k] = v
+	}
+	return r.Update(ctx, existing)
+}
+
+// constructIngress builds the Ingress routing spec.endpoint.ingress.host (and
+// path prefix) to the InferenceService's Service port.
+func (r *InferenceServiceReconciler) constructIngress(isvc *inferencev1alpha1.InferenceService, serviceName string) *networkingv1.Ingress {
+	spec := ingressSpec(isvc)
+
+	port := int32(8080)
+	if isvc.Spec.Endpoint.Port > 0 {
+		port = isvc.Spec.Endpoint.Port
+	}
+	path := spec.Path
+	if path == "" {
+		path = "/"
+	}
+	pathType := networkingv1.PathTypePrefix
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sanitizeDNSName(isvc.Name),
+			Namespace: isvc.Namespace,
+			Labels: map[string]string{
+				"app":                           isvc.Name,
+				"inference.llmkube.dev/service": isvc.Name,
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.IngressClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: spec.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: serviceName,
+									Port: networkingv1.ServiceBackendPort{Number: port},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if len(spec.Annotations) > 0 {
+		ingress.Annotations = make(map[string]string, len(spec.Annotations))
+		for k, v := range spec.Annotations {
+			ingress.Annotations[k] = v
+		}
+	}
+	if spec.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{
+			Hosts:      []string{spec.Host},
+			SecretName: spec.TLSSecretName,
+		}}
+	}
+	return ingress
+}
+
+// ingressEndpoint is the status.endpoint URL when an Ingress fronts the
+// service: the ingress host and path prefix joined with the normalized
+// serving-mode endpoint path, over https when TLS is configured.
+func ingressEndpoint(isvc *inferencev1alpha1.InferenceService) string {
+	spec := ingressSpec(isvc)
+	scheme := "http"
+	if spec.TLSSecretName != "" {
+		scheme = "https"
+	}
+	prefix := strings.TrimSuffix(spec.Path, "/")
+	return fmt.Sprintf("%s://%s%s%s", scheme, spec.Host, prefix, servingModeEndpointPath(isvc))
+}
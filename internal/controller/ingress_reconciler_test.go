@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func newIngressTestService(ingress *inferencev1alpha1.IngressSpec) *inferencev1alpha1.InferenceService {
+	return &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama.chat", Namespace: "default", UID: "isvc-uid"},
+		Spec: inferencev1alpha1.InferenceServiceSpec{
+			ModelRef: "m",
+			Endpoint: &inferencev1alpha1.EndpointSpec{Port: 9000, Ingress: ingress},
+		},
+	}
+}
+
+func TestConstructIngress(t *testing.T) {
+	className := "nginx"
+	isvc := newIngressTestService(&inferencev1alpha1.IngressSpec{
+		Host:             "llm.example.com",
+		IngressClassName: &className,
+		TLSSecretName:    "llm-tls",
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/proxy-read-timeout": "600"},
+	})
+
+	ingress := (&InferenceServiceReconciler{}).constructIngress(isvc, "llama-chat")
+
+	if ingress.Name != "llama-chat" || ingress.Namespace != "default" {
+		t.Errorf("unexpected Ingress name %s/%s", ingress.Namespace, ingress.Name)
+	}
+	if ingress.Spec.IngressClassName == nil || *ingress.Spec.IngressClassName != "nginx" {
+		t.Errorf("expected ingressClassName nginx, got %v", ingress.Spec.IngressClassName)
+	}
+	if got := ingress.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"]; got != "600" {
+		t.Errorf("expected the proxy timeout annotation, got %q", got)
+	}
+
+	if len(ingress.Spec.Rules) != 1 || ingress.Spec.Rules[0].Host != "llm.example.com" {
+		t.Fatalf("expected one rule for llm.example.com, got %+v", ingress.Spec.Rules)
+	}
+	paths := ingress.Spec.Rules[0].HTTP.Paths
+	if len(paths) != 1 {
+		t.Fatalf("expected one path, got %d", len(paths))
+	}
+	if paths[0].Path != "/" || paths[0].PathType == nil || *paths[0].PathType != networkingv1.PathTypePrefix {
+		t.Errorf("expected Prefix path /, got %q %v", paths[0].Path, paths[0].PathType)
+	}
+	backend := paths[0].Backend.Service
+	if backend == nil || backend.Name != "llama-chat" || backend.Port.Number != 9000 {
+		t.Errorf("expected backend llama-chat:9000, got %+v", backend)
+	}
+
+	if len(ingress.Spec.TLS) != 1 || ingress.Spec.TLS[0].SecretName != "llm-tls" ||
+		len(ingress.Spec.TLS[0].Hosts) != 1 || ingress.Spec.TLS[0].Hosts[0] != "llm.example.com" {
+		t.Errorf("expected TLS for llm.example.com from llm-tls, got %+v", ingress.Spec.TLS)
+	}
+}
+
+func TestIngressEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingress inferencev1alpha1.IngressSpec
+		path    string
+		want    string
+	}{
+		{
+			name:    "plain http at the root",
+			ingress: inferencev1alpha1.IngressSpec{Host: "llm.example.com", Path: "/"},
+			want:    "http://llm.example.com/v1/chat/completions",
+		},
+		{
+			name:    "https with a prefix and custom route",
+			ingress: inferencev1alpha1.IngressSpec{Host: "llm.example.com", Path: "/llama/", TLSSecretName: "tls"},
+			path:    "/v1/embeddings",
+			want:    "https://llm.example.com/llama/v1/embeddings",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := newIngressTestService(&tt.ingress)
+			isvc.Spec.Endpoint.Path = tt.path
+			if got := ingressEndpoint(isvc); got != tt.want {
+				t.Errorf("ingressEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileIngress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "llama-chat", Namespace: "default"}
+
+	isvc := newIngressTestService(&inferencev1alpha1.IngressSpec{Host: "llm.example.com"})
+	r := &InferenceServiceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+
+	if err := r.reconcileIngress(ctx, isvc, "llama-chat", false); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	ingress := &networkingv1.Ingress{}
+	if err := r.Get(ctx, key, ingress); err != nil {
+		t.Fatalf("expected an Ingress: %v", err)
+	}
+	if !metav1.IsControlledBy(ingress, isvc) {
+		t.Errorf("expected the Ingress to be controlled by the InferenceService, got %+v", ingress.OwnerReferences)
+	}
+
+	isvc.Spec.Endpoint.Ingress.Host = "chat.example.com"
+	if err := r.reconcileIngress(ctx, isvc, "llama-chat", false); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := r.Get(ctx, key, ingress); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got := ingress.Spec.Rules[0].Host; got != "chat.example.com" {
+		t.Errorf("expected the host change to be synced, got %q", got)
+	}
+
+	isvc.Spec.Endpoint.Ingress = nil
+	if err := r.reconcileIngress(ctx, isvc, "llama-chat", false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := r.Get(ctx, key, &networkingv1.Ingress{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the Ingress to be deleted once removed from spec, got err=%v", err)
+	}
+}
+
+func TestReconcileIngressLeavesForeignIngressAlone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	ctx := context.Background()
+
+	foreign := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "llama-chat", Namespace: "default"}}
+	r := &InferenceServiceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(foreign).Build(),
+		Scheme: scheme,
+	}
+
+	withIngress := newIngressTestService(&inferencev1alpha1.IngressSpec{Host: "llm.example.com"})
+	if err := r.reconcileIngress(ctx, withIngress, "llama-chat", false); err == nil {
+		t.Error("expected an error rather than adopting an Ingress the InferenceService does not own")
+	}
+
+	without := newIngressTestService(nil)
+	if err := r.reconcileIngress(ctx, without, "llama-chat", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama-chat", Namespace: "default"}, &networkingv1.Ingress{}); err != nil {
+		t.Errorf("expected the unowned Ingress to be kept, got err=%v", err)
+	}
+}
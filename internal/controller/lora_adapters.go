@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+	"github.com/defilantech/llmkube/pkg/cachekey"
+)
+
+// LoRA adapters (spec.loraAdapters). Each adapter gets a "lora-<name>" init
+// container that downloads it into the same /models volume the model lives
+// on, and llama-server loads it with --lora / --lora-scaled (see
+// appendLoRAArgs). Adapters land under /models/lora/<cacheKey>/ keyed by the
+// source URL, so InferenceServices sharing the model cache share downloads.
+
+// ConditionLoRAAdaptersReady reports whether every spec.loraAdapters entry
+// has been downloaded. It is absent when the spec lists no adapters.
+const ConditionLoRAAdaptersReady = "LoRAAdaptersReady"
+
+// loraInitContainerPrefix prefixes each adapter's downloader init container.
+const loraInitContainerPrefix = "lora-"
+
+// loraDownloadScript fetches $LORA_SOURCE to $LORA_PATH unless it is already
+// cached. Like the model download it retries, resumes into a .partial file,
+// and renames it into place only once curl succeeds, so an interrupted fetch
+// is never served as a truncated adapter.
+const loraDownloadScript = `mkdir -p "$(dirname "$LORA_PATH")" && ` +
+	`if [ ! -f "$LORA_PATH" ]; then echo 'Downloading LoRA adapter...'; ` +
+	`curl -f -L ` + curlRetryArgs + ` -C - -o "$LORA_PATH.partial" "$LORA_SOURCE" && mv "$LORA_PATH.partial" "$LORA_PATH" && echo 'LoRA adapter downloaded successfully'; ` +
+	`else echo 'LoRA adapter already cached, skipping download'; fi`
+
+// loraCacheDir is the /models subdirectory holding the adapters. It sits
+// beside the model cache entries, so the cache TTL scan must skip it.
+const loraCacheDir = "lora"
+
+// loraAdapterPath is where an adapter is staged inside the model volume.
+func loraAdapterPath(adapter inferencev1alpha1.LoRASpec) string {
+	return fmt.Sprintf("/models/%s/%s/adapter.gguf", loraCacheDir, cachekey.Compute(adapter.Source))
+}
+
+// validateLoRAAdapters rejects spec.loraAdapters on runtimes that cannot load
+// them or when there is no model init container to stage them alongside.
+func validateLoRAAdapters(isvc *inferencev1alpha1.InferenceService) error {
+	if len(isvc.Spec.LoRAAdapters) == 0 {
+		return nil
+	}
+	if _, ok := resolveBackend(isvc).(*LlamaCppBackend); !ok {
+		return fmt.Errorf("loraAdapters is only supported by the llamacpp runtime")
+	}
+	if isvc.Spec.SkipModelInit != nil && *isvc.Spec.SkipModelInit {
+		return fmt.Errorf("loraAdapters requires the model init container; unset skipModelInit")
+	}
+	return nil
+}
+
+// addLoRAAdapters appends one downloader init container per adapter, writing
+// into whichever volume the runtime container mounts at /models. The
+// --lora args themselves come from BuildArgs.
+func (r *InferenceServiceReconciler) addLoRAAdapters(deployment *appsv1.Deployment, isvc *inferencev1alpha1.InferenceService) error {
+	if len(isvc.Spec.LoRAAdapters) == 0 {
+		return nil
+	}
+	podSpec := &deployment.Spec.Template.Spec
+
+	containerName := resolveBackend(isvc).ContainerName()
+	var modelVolume string
+	for _, c := range podSpec.Containers {
+		if c.Name != containerName {
+			continue
+		}
+		for _, m := range c.VolumeMounts {
+			if m.MountPath == "/models" {
+				modelVolume = m.Name
+			}
+		}
+	}
+	if modelVolume == "" {
+		return fmt.Errorf("loraAdapters requires a model volume at /models; pvc:// model sources are not supported")
+	}
+
+	for _, adapter := range isvc.Spec.LoRAAdapters {
+		cmd := loraDownloadScript
+		var volumes []corev1.Volume
+		mounts := []corev1.VolumeMount{{Name: modelVolume, MountPath: "/models"}}
+		addCACertVolume(&volumes, &mounts, &cmd, r.CACertConfigMap)
+		for _, v := range volumes {
+			if !slices.ContainsFunc(podSpec.Volumes, func(existing corev1.Volume) bool { return existing.Name == v.Name }) {
+				podSpec.Volumes = append(podSpec.Volumes, v)
+			}
+		}
+
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:    loraInitContainerPrefix + adapter.Name,
+			Image:   r.InitContainerImage,
+			Command: []string{"sh", "-c", cmd},
+			Env: []corev1.EnvVar{
+				{Name: "LORA_SOURCE", Value: adapter.Source},
+				{Name: "LORA_PATH", Value: loraAdapterPath(adapter)},
+			},
+			VolumeMounts:    mounts,
+			SecurityContext: initContainerSecurityContext(isvc),
+		})
+	}
+	return nil
+}
+
+// loraDownloadState summarizes one adapter's downloader across the
+// InferenceService's pods: done when any pod finished it, otherwise the most
+// recent failure, if there was one.
+func loraDownloadState(pods []corev1.Pod, containerName string) (done bool, failure string) {
+	for i := range pods {
+		for _, status := range pods[i].Status.InitContainerStatuses {
+			if status.Name != containerName {
+				continue
+			}
+			if term := status.State.Terminated; term != nil && term.ExitCode == 0 {
+				return true, ""
+			}
+			for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+				if term := state.Terminated; term != nil && term.ExitCode != 0 {
+					failure = fmt.Sprintf("exit code %d", term.ExitCode)
+					if term.Reason != "" {
+						failure = fmt.Sprintf("%s (%s)", term.Reason, failure)
+					}
+				}
+			}
+		}
+	}
+	return false, failure
+}
+
+// reconcileLoRAAdaptersCondition reports the adapter downloads in the
+// LoRAAdaptersReady condition. Informational only; a failing downloader
+// already holds the pod in Init until it succeeds.
+func (r *InferenceServiceReconciler) reconcileLoRAAdaptersCondition(ctx context.Context, isvc *inferencev1alpha1.InferenceService) {
+	if len(isvc.Spec.LoRAAdapters) == 0 {
+		meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionLoRAAdaptersReady)
+		return
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{"inference.llmkube.dev/service": isvc.Name}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list pods for LoRA adapter status")
+		return
+	}
+
+	var pending, failed []string
+	for _, adapter := range isvc.Spec.LoRAAdapters {
+		done, failure := loraDownloadState(pods.Items, loraInitContainerPrefix+adapter.Name)
+		switch {
+		case done:
+		case failure != "":
+			failed = append(failed, fmt.Sprintf("%s: %s", adapter.Name, failure))
+		default:
+			pending = append(pending, adapter.Name)
+		}
+	}
+
+	cond := metav1.Condition{
+		Type:               ConditionLoRAAdaptersReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: isvc.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "Downloaded",
+		Message:            fmt.Sprintf("%d LoRA adapter(s) downloaded", len(isvc.Spec.LoRAAdapters)),
+	}
+	switch {
+	case len(failed) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "DownloadFailed"
+		cond.Message = "LoRA adapter download failed: " + strings.Join(failed, "; ")
+	case len(pending) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Downloading"
+		cond.Message = "Waiting for LoRA adapter download: " + strings.Join(pending, ", ")
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, cond)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestAppendLoRAArgs(t *testing.T) {
+	half := 0.5
+	adapters := []inferencev1alpha1.LoRASpec{
+		{Name: "sql", Source: "https://example.com/sql.gguf"},
+		{Name: "tone", Source: "https://example.com/tone.gguf", Scale: &half},
+		{Name: "style", Source: "https://example.com/style.gguf"},
+	}
+
+	got := appendLoRAArgs([]string{"--port", "8080"}, adapters)
+	want := []string{
+		"--port", "8080",
+		"--lora", loraAdapterPath(adapters[0]),
+		"--lora-scaled", loraAdapterPath(adapters[1]), "0.5",
+		"--lora", loraAdapterPath(adapters[2]),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("appendLoRAArgs() = %v, want %v", got, want)
+	}
+	if loraAdapterPath(adapters[0]) == loraAdapterPath(adapters[2]) {
+		t.Error("expected adapters with different sources to get different paths")
+	}
+	if got := appendLoRAArgs([]string{"--port", "8080"}, nil); len(got) != 2 {
+		t.Errorf("expected no args without adapters, got %v", got)
+	}
+
+	isvc := &inferencev1alpha1.InferenceService{
+		Spec: inferencev1alpha1.InferenceServiceSpec{LoRAAdapters: adapters},
+	}
+	args := (&LlamaCppBackend{}).BuildArgs(isvc, &inferencev1alpha1.Model{}, "/models/m.gguf", 8080)
+	if n := strings.Count(strings.Join(args, " "), "--lora"); n != 3 {
+		t.Errorf("expected BuildArgs to load all 3 adapters, got %d in %v", n, args)
+	}
+}
+
+func TestAddLoRAAdapters(t *testing.T) {
+	model := newDraftTestModel("base", "https://example.com/base.gguf", "aaaa")
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec: inferencev1alpha1.InferenceServiceSpec{
+			ModelRef: model.Name,
+			LoRAAdapters: []inferencev1alpha1.LoRASpec{
+				{Name: "sql", Source: "https://example.com/sql.gguf"},
+				{Name: "tone", Source: "https://example.com/tone.gguf"},
+			},
+		},
+	}
+	r := &InferenceServiceReconciler{
+		ModelCachePath:     "/models",
+		InitContainerImage: "docker.io/curlimages/curl:8.18.0",
+		CACertConfigMap:    "corp-ca",
+	}
+
+	deployment := r.constructDeployment(isvc, model, 1)
+	if err := r.addLoRAAdapters(deployment, isvc); err != nil {
+		t.Fatalf("addLoRAAdapters: %v", err)
+	}
+	podSpec := deployment.Spec.Template.Spec
+
+	var names []string
+	for _, c := range podSpec.InitContainers {
+		names = append(names, c.Name)
+	}
+	if want := []string{"model-cache-prep", "model-downloader", "lora-sql", "lora-tone"}; !slices.Equal(names, want) {
+		t.Fatalf("init containers = %v, want %v", names, want)
+	}
+	lora := podSpec.InitContainers[2]
+	if lora.VolumeMounts[0].Name != "model-cache" || lora.VolumeMounts[0].ReadOnly {
+		t.Errorf("expected the adapter to be written into the model cache, got %v", lora.VolumeMounts)
+	}
+	if !strings.Contains(lora.Command[2], curlRetryArgs) || !strings.Contains(lora.Command[2], `-o "$LORA_PATH.partial"`) {
+		t.Errorf("expected a retried download into a .partial file, got %q", lora.Command[2])
+	}
+	if !strings.Contains(lora.Command[2], "CURL_CA_BUNDLE") {
+		t.Errorf("expected the custom CA bundle to be used, got %q", lora.Command[2])
+	}
+	caVolumes := 0
+	for _, v := range podSpec.Volumes {
+		if v.Name == "custom-ca-cert" {
+			caVolumes++
+		}
+	}
+	if caVolumes != 1 {
+		t.Errorf("expected the CA cert volume once, got %d", caVolumes)
+	}
+
+	isvc.Spec.Runtime = "vllm"
+	if err := validateLoRAAdapters(isvc); err == nil {
+		t.Error("expected loraAdapters to be rejected on vllm")
+	}
+}
+
+func TestReconcileLoRAAdaptersCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	newPod := func(statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc-abc",
+				Namespace: "default",
+				Labels:    map[string]string{"inference.llmkube.dev/service": "svc"},
+			},
+			Status: corev1.PodStatus{InitContainerStatuses: statuses},
+		}
+	}
+	terminated := func(name string, code int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: code, Reason: "Error"}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		pod        *corev1.Pod
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{"all downloaded", newPod(terminated("lora-sql", 0), terminated("lora-tone", 0)), metav1.ConditionTrue, "Downloaded"},
+		{"one still downloading", newPod(terminated("lora-sql", 0)), metav1.ConditionFalse, "Downloading"},
+		{"one failed", newPod(terminated("lora-sql", 0), terminated("lora-tone", 22)), metav1.ConditionFalse, "DownloadFailed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+				Spec: inferencev1alpha1.InferenceServiceSpec{
+					LoRAAdapters: []inferencev1alpha1.LoRASpec{
+						{Name: "sql", Source: "https://example.com/sql.gguf"},
+						{Name: "tone", Source: "https://example.com/tone.gguf"},
+					},
+				},
+			}
+			r := &InferenceServiceReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pod).Build(),
+			}
+			r.reconcileLoRAAdaptersCondition(context.Background(), isvc)
+
+			cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionLoRAAdaptersReady)
+			if cond == nil {
+				t.Fatal("expected the LoRAAdaptersReady condition to be set")
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("condition = %s/%s (%s), want %s/%s", cond.Status, cond.Reason, cond.Message, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// ConditionModelCacheAccessible is False when the model cache PVC's access
+// mode cannot serve every replica: a ReadWriteOnce volume attaches to a single
+// node, so replicas the scheduler spreads elsewhere sit Pending on the mount.
+// Informational only; the Deployment is still reconciled.
+const ConditionModelCacheAccessible = "ModelCacheAccessible"
+
+// hostnameLabel is the well-known node label used to pin Pods to one node.
+const hostnameLabel = "kubernetes.io/hostname"
+
+// isPinnedToSingleNode reports whether the pod spec can only schedule onto one
+// node, via a hostname nodeSelector or a required node affinity that allows a
+// single hostname. Replicas pinned this way can all share an RWO volume.
+func isPinnedToSingleNode(isvc *inferencev1alpha1.InferenceService) bool {
+	if _, ok := isvc.Spec.NodeSelector[hostnameLabel]; ok {
+		return true
+	}
+	aff := isvc.Spec.Affinity
+	if aff == nil || aff.NodeAffinity == nil || aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	terms := aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return false
+	}
+	// Terms are ORed, so every term must pin to the same single host.
+	host := ""
+	for _, term := range terms {
+		pinned := false
+		for _, expr := range term.MatchExpressions {
+			if expr.Key != hostnameLabel || expr.Operator != corev1.NodeSelectorOpIn || len(expr.Values) != 1 {
+				continue
+			}
+			if host != "" && host != expr.Values[0] {
+				return false
+			}
+			host = expr.Values[0]
+			pinned = true
+		}
+		if !pinned {
+			return false
+		}
+	}
+	return true
+}
+
+// singleNodeAccessMode reports whether every access mode on the claim limits
+// it to a single node.
+func singleNodeAccessMode(modes []corev1.PersistentVolumeAccessMode) bool {
+	if len(modes) == 0 {
+		return false
+	}
+	for _, m := range modes {
+		if m != corev1.ReadWriteOnce && m != corev1.ReadWriteOncePod {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileModelCacheAccessCondition sets ModelCacheAccessible to False when
+// the cache PVC is single-node (RWO/RWOP), more than one replica is desired,
+// and nothing pins the replicas to one node. The check runs before Pods exist
+// so the misconfiguration surfaces before replicas get stuck Pending. A
+// Warning event is emitted on the transition into the False state only.
+func (r *InferenceServiceReconciler) reconcileModelCacheAccessCondition(ctx context.Context, isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model, replicas int32) {
+	if effectiveModelCacheKey(model) == "" || r.ModelCachePath == "" {
+		meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionModelCacheAccessible)
+		return
+	}
+
+	pvcName := modelCachePVCName(isvc, r.ModelCacheMode)
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: isvc.Namespace}, pvc); err != nil {
+		// ensureModelCachePVC owns surfacing a missing claim.
+		return
+	}
+
+	now := metav1.NewTime(time.Now())
+	existing := meta.FindStatusCondition(isvc.Status.Conditions, ConditionModelCacheAccessible)
+	if replicas <= 1 || !singleNodeAccessMode(pvc.Spec.AccessModes) || isPinnedToSingleNode(isvc) {
+		if existing != nil {
+			meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+				Type:               ConditionModelCacheAccessible,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: isvc.Generation,
+				LastTransitionTime: now,
+				Reason:             "AccessModeSufficient",
+				Message:            "Model cache PVC can be mounted by every replica",
+			})
+		}
+		return
+	}
+
+	message := fmt.Sprintf(
+		"model cache PVC %q is %s but %d replicas may schedule onto different nodes; replicas off the PVC's node will stay Pending. Use a ReadWriteMany storage class (--model-cache-access-mode=ReadWriteMany) or pin the replicas to one node",
+		pvcName, pvc.Spec.AccessModes[0], replicas)
+	if r.Recorder != nil && (existing == nil || existing.Status != metav1.ConditionFalse) {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "ModelCacheReadWriteOnce", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+		Type:               ConditionModelCacheAccessible,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: isvc.Generation,
+		LastTransitionTime: now,
+		Reason:             "ReadWriteOnceMultiReplica",
+		Message:            message,
+	})
+}
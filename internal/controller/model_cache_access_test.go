@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestReconcileModelCacheAccessCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	cachePVC := func(mode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: ModelCachePVCName, Namespace: "default"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{mode},
+			},
+		}
+	}
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "default"},
+		Status:     inferencev1alpha1.ModelStatus{CacheKey: "abc123"},
+	}
+	newISVC := func() *inferencev1alpha1.InferenceService {
+		return &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default", Generation: 1},
+		}
+	}
+	newReconciler := func(pvc *corev1.PersistentVolumeClaim) (*InferenceServiceReconciler, *events.FakeRecorder) {
+		recorder := events.NewFakeRecorder(10)
+		return &InferenceServiceReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(pvc).Build(),
+			Recorder:       recorder,
+			ModelCachePath: "/models",
+		}, recorder
+	}
+
+	t.Run("RWO cache with multiple replicas sets a warning condition", func(t *testing.T) {
+		r, recorder := newReconciler(cachePVC(corev1.ReadWriteOnce))
+		isvc := newISVC()
+
+		r.reconcileModelCacheAccessCondition(context.Background(), isvc, model, 3)
+
+		cond := findCondition(isvc.Status.Conditions, ConditionModelCacheAccessible)
+		if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "ReadWriteOnceMultiReplica" {
+			t.Fatalf("expected False/ReadWriteOnceMultiReplica condition, got %+v", cond)
+		}
+		if !strings.Contains(cond.Message, "ReadWriteMany") {
+			t.Errorf("message should advise RWX, got %q", cond.Message)
+		}
+		select {
+		case ev := <-recorder.Events:
+			if !strings.Contains(ev, "ModelCacheReadWriteOnce") {
+				t.Errorf("unexpected event %q", ev)
+			}
+		default:
+			t.Error("expected a ModelCacheReadWriteOnce warning event")
+		}
+
+		// A second pass keeps the condition without repeating the event.
+		r.reconcileModelCacheAccessCondition(context.Background(), isvc, model, 3)
+		if len(recorder.Events) != 0 {
+			t.Errorf("expected no repeated event, got %d", len(recorder.Events))
+		}
+	})
+
+	t.Run("single replica does not warn", func(t *testing.T) {
+		r, _ := newReconciler(cachePVC(corev1.ReadWriteOnce))
+		isvc := newISVC()
+
+		r.reconcileModelCacheAccessCondition(context.Background(), isvc, model, 1)
+
+		if cond := findCondition(isvc.Status.Conditions, ConditionModelCacheAccessible); cond != nil {
+			t.Errorf("expected no condition, got %+v", cond)
+		}
+	})
+
+	t.Run("RWX cache does not warn", func(t *testing.T) {
+		r, _ := newReconciler(cachePVC(corev1.ReadWriteMany))
+		isvc := newISVC()
+
+		r.reconcileModelCacheAccessCondition(context.Background(), isvc, model, 3)
+
+		if cond := findCondition(isvc.Status.Conditions, ConditionModelCacheAccessible); cond != nil {
+			t.Errorf("expected no condition, got %+v", cond)
+		}
+	})
+
+	t.Run("hostname pinning clears a prior warning", func(t *testing.T) {
+		r, _ := newReconciler(cachePVC(corev1.ReadWriteOnce))
+		isvc := newISVC()
+		isvc.Status.Conditions = []metav1.Condition{{
+			Type:   ConditionModelCacheAccessible,
+			Status: metav1.ConditionFalse,
+			Reason: "ReadWriteOnceMultiReplica",
+		}}
+		isvc.Spec.NodeSelector = map[string]string{hostnameLabel: "gpu-node-1"}
+
+		r.reconcileModelCacheAccessCondition(context.Background(), isvc, model, 3)
+
+		cond := findCondition(isvc.Status.Conditions, ConditionModelCacheAccessible)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			t.Errorf("expected condition to flip True, got %+v", cond)
+		}
+	})
+}
+
+func TestIsPinnedToSingleNode(t *testing.T) {
+	hostAffinity := func(hosts ...string) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      hostnameLabel,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   hosts,
+					}},
+				}},
+			},
+		}}
+	}
+
+	cases := []struct {
+		name string
+		spec inferencev1alpha1.InferenceServiceSpec
+		want bool
+	}{
+		{"no constraints", inferencev1alpha1.InferenceServiceSpec{}, false},
+		{"pool selector", inferencev1alpha1.InferenceServiceSpec{NodeSelector: map[string]string{"pool": "gpu"}}, false},
+		{"hostname selector", inferencev1alpha1.InferenceServiceSpec{NodeSelector: map[string]string{hostnameLabel: "n1"}}, true},
+		{"single-host affinity", inferencev1alpha1.InferenceServiceSpec{Affinity: hostAffinity("n1")}, true},
+		{"multi-host affinity", inferencev1alpha1.InferenceServiceSpec{Affinity: hostAffinity("n1", "n2")}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{Spec: tc.spec}
+			if got := isPinnedToSingleNode(isvc); got != tc.want {
+				t.Errorf("isPinnedToSingleNode() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
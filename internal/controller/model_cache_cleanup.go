@@ -0,0 +1,265 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// Model cache cleanup: a deleted Model's /models/<cacheKey> entry on the
+// namespace's shared cache PVC would otherwise be orphaned forever (see
+// `llmkube cache list --orphaned`). The finalizer holds the Model until a
+// short-lived Job mounting the PVC has removed the entry, unless another
+// live Model in the namespace still resolves to the same cache key.
+//
+// perService cache PVCs are owned by their InferenceService and go away with
+// it, so only the shared PVC is cleaned; when it does not exist there is
+// nothing to do.
+
+// modelCacheCleanupFinalizer holds a deleted Model until its cache entry is
+// removed.
+const modelCacheCleanupFinalizer = "inference.llmkube.dev/cache-cleanup"
+
+const (
+	// cacheCleanupJobDeadline bounds how long the cleanup Job may run,
+	// including time its pod spends Pending (e.g. a Multi-Attach wait on the
+	// RWO shared PVC).
+	cacheCleanupJobDeadline = 5 * time.Minute
+	// cacheCleanupTimeout is how long after deletion the finalizer is held
+	// at most. Past it the Model is released with a Warning event and the
+	// entry is left to `llmkube cache list --orphaned`.
+	cacheCleanupTimeout = 10 * time.Minute
+)
+
+// cacheKeyPattern guards the path handed to rm -rf: cache keys are the
+// 16-hex-char cachekey.Compute output, never anything with a separator.
+var cacheKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// cacheCleanupJobName returns the deterministic Job name for a Model's cache
+// cleanup.
+func cacheCleanupJobName(model *inferencev1alpha1.Model) string {
+	return model.Name + "-cache-cleanup"
+}
+
+// ensureCacheCleanupFinalizer adds the cleanup finalizer to a live Model when
+// cleanup is enabled. The Update refreshes model in place, so the reconcile
+// carries on with the persisted object.
+func (r *ModelReconciler) ensureCacheCleanupFinalizer(ctx context.Context, model *inferencev1alpha1.Model) error {
+	if !r.CacheCleanup || controllerutil.ContainsFinalizer(model, modelCacheCleanupFinalizer) {
+		return nil
+	}
+	controllerutil.AddFinalizer(model, modelCacheCleanupFinalizer)
+	if err := r.Update(ctx, model); err != nil {
+		return fmt.Errorf("adding cache cleanup finalizer: %w", err)
+	}
+	return nil
+}
+
+// reconcileCacheCleanup drives the deletion side: decide whether the cache
+// entry may be removed, run the cleanup Job, then release the finalizer.
+func (r *ModelReconciler) reconcileCacheCleanup(ctx context.Context, model *inferencev1alpha1.Model) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	cacheKey := effectiveModelCacheKey(model)
+	if !r.CacheCleanup || cacheKey == "" || !cacheKeyPattern.MatchString(cacheKey) {
+		return ctrl.Result{}, r.removeCacheCleanupFinalizer(ctx, model)
+	}
+
+	shared, err := r.cacheKeySharedWithLiveModel(ctx, model, cacheKey)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if shared {
+		logger.Info("Cache entry still referenced by another Model, keeping it", "cacheKey", cacheKey)
+		return ctrl.Result{}, r.removeCacheCleanupFinalizer(ctx, model)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ModelCachePVCName, Namespace: model.Namespace}, pvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.removeCacheCleanupFinalizer(ctx, model)
+		}
+		return ctrl.Result{}, fmt.Errorf("checking shared model cache PVC: %w", err)
+	}
+
+	// Holding the Model forever would block namespace deletion over a few
+	// stale files, so every path below ends in releasing it; an entry that
+	// was not removed is left to `llmkube cache list --orphaned`.
+	timedOut := model.DeletionTimestamp != nil && time.Since(model.DeletionTimestamp.Time) > cacheCleanupTimeout
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Name: cacheCleanupJobName(model), Namespace: model.Namespace}, job)
+	switch {
+	case apierrors.IsNotFound(err) && timedOut:
+		r.cacheCleanupWarning(ctx, model, "CacheCleanupTimedOut",
+			"Cache entry %s was not removed within %s; leaving it orphaned", cacheKey, cacheCleanupTimeout)
+		return ctrl.Result{}, r.removeCacheCleanupFinalizer(ctx, model)
+	case apierrors.IsNotFound(err):
+		if err := r.Create(ctx, r.buildCacheCleanupJob(model, cacheKey)); err != nil && !apierrors.IsAlreadyExists(err) {
+			// A terminating namespace forbids new Jobs; retrying would
+			// hold the namespace deletion forever.
+			if apierrors.IsForbidden(err) || apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+				r.cacheCleanupWarning(ctx, model, "CacheCleanupSkipped",
+					"Cannot create the cleanup job for cache entry %s, leaving it orphaned: %v", cacheKey, err)
+				return ctrl.Result{}, r.removeCacheCleanupFinalizer(ctx, model)
+			}
+			return ctrl.Result{}, fmt.Errorf("creating cache cleanup job: %w", err)
+		}
+		logger.Info("Created cache cleanup job", "cacheKey", cacheKey)
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("checking cache cleanup job: %w", err)
+	}
+
+	switch {
+	case jobSucceeded(job):
+		logger.Info("Removed cache entry for deleted Model", "cacheKey", cacheKey)
+	case jobFailed(job):
+		r.cacheCleanupWarning(ctx, model, "CacheCleanupFailed",
+			"Cache cleanup job %s failed; leaving cache entry %s orphaned", job.Name, cacheKey)
+	case timedOut:
+		r.cacheCleanupWarning(ctx, model, "CacheCleanupTimedOut",
+			"Cache cleanup job %s did not finish within %s; leaving cache entry %s orphaned", job.Name, cacheCleanupTimeout, cacheKey)
+	default:
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// Delete the finished Job so a re-created Model of the same name does not
+	// mistake it for its own completed cleanup.
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("deleting cache cleanup job: %w", err)
+	}
+	return ctrl.Result{}, r.removeCacheCleanupFinalizer(ctx, model)
+}
+
+// cacheKeySharedWithLiveModel reports whether another Model in the namespace
+// that is not itself being deleted resolves to cacheKey.
+func (r *ModelReconciler) cacheKeySharedWithLiveModel(ctx context.Context, model *inferencev1alpha1.Model, cacheKey string) (bool, error) {
+	models := &inferencev1alpha1.ModelList{}
+	if err := r.List(ctx, models, client.InNamespace(model.Namespace)); err != nil {
+		return false, fmt.Errorf("listing models: %w", err)
+	}
+	for i := range models.Items {
+		other := &models.Items[i]
+		if other.Name == model.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if effectiveModelCacheKey(other) == cacheKey {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// cacheCleanupWarning logs and records a Warning event on the deleted Model
+// when its cache entry is left behind.
+func (r *ModelReconciler) cacheCleanupWarning(ctx context.Context, model *inferencev1alpha1.Model, reason, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	logf.FromContext(ctx).Info(message, "reason", reason)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(model, nil, corev1.EventTypeWarning, reason, "Cleanup", "%s", message)
+	}
+}
+
+func (r *ModelReconciler) removeCacheCleanupFinalizer(ctx context.Context, model *inferencev1alpha1.Model) error {
+	if !controllerutil.RemoveFinalizer(model, modelCacheCleanupFinalizer) {
+		return nil
+	}
+	if err := r.Update(ctx, model); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("removing cache cleanup finalizer: %w", err)
+	}
+	return nil
+}
+
+// buildCacheCleanupJob assembles the Job that removes /models/<cacheKey>
+// from the shared cache PVC. The key travels as an env var rather than being
+// spliced into the script.
+func (r *ModelReconciler) buildCacheCleanupJob(model *inferencev1alpha1.Model, cacheKey string) *batchv1.Job {
+	backoff := int32(2)
+	deadline := int64(cacheCleanupJobDeadline.Seconds())
+	ttl := int32(60 * 60) // normally deleted on completion; TTL is the backstop
+
+	var podSecurity *corev1.PodSecurityContext
+	if r.DefaultFSGroup > 0 {
+		fs := r.DefaultFSGroup
+		podSecurity = &corev1.PodSecurityContext{FSGroup: &fs}
+	}
+
+	image := r.InitContainerImage
+	if image == "" {
+		image = defaultPrefetchImage
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cacheCleanupJobName(model),
+			Namespace: model.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "llmkube",
+				"app.kubernetes.io/component":  "model-cache-cleanup",
+				"app.kubernetes.io/managed-by": "llmkube-controller",
+				"inference.llmkube.dev/model":  model.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoff,
+			ActiveDeadlineSeconds:   &deadline,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/component": "model-cache-cleanup",
+						"inference.llmkube.dev/model": model.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:   corev1.RestartPolicyNever,
+					SecurityContext: podSecurity,
+					Containers: []corev1.Container{{
+						Name:            "cache-cleanup",
+						Image:           image,
+						Command:         []string{"sh", "-c", `rm -rf "/models/${CACHE_KEY}" && echo "Removed /models/${CACHE_KEY}"`},
+						Env:             []corev1.EnvVar{{Name: "CACHE_KEY", Value: cacheKey}},
+						VolumeMounts:    []corev1.VolumeMount{{Name: "model-cache", MountPath: "/models"}},
+						SecurityContext: initContainerSecurityContext(nil),
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "model-cache",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: ModelCachePVCName},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
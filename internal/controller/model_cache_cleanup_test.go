@@ -0,0 +1,260 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestReconcileCacheCleanup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	const cacheKey = "0123456789abcdef"
+	now := metav1.Now()
+	newModel := func(name string, deleting bool) *inferencev1alpha1.Model {
+		m := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/model.gguf"},
+			Status:     inferencev1alpha1.ModelStatus{CacheKey: cacheKey},
+		}
+		if deleting {
+			m.DeletionTimestamp = &now
+			m.Finalizers = []string{modelCacheCleanupFinalizer}
+		}
+		return m
+	}
+	cachePVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: ModelCachePVCName, Namespace: "default"},
+	}
+	newReconciler := func(objs ...client.Object) *ModelReconciler {
+		return &ModelReconciler{
+			Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Scheme:       scheme,
+			CacheCleanup: true,
+		}
+	}
+	modelGone := func(t *testing.T, r *ModelReconciler, name string) bool {
+		t.Helper()
+		err := r.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &inferencev1alpha1.Model{})
+		return apierrors.IsNotFound(err)
+	}
+	cleanupJob := func(r *ModelReconciler, model *inferencev1alpha1.Model) (*batchv1.Job, error) {
+		job := &batchv1.Job{}
+		err := r.Get(context.Background(), types.NamespacedName{Name: cacheCleanupJobName(model), Namespace: "default"}, job)
+		return job, err
+	}
+
+	t.Run("shared cache key is kept", func(t *testing.T) {
+		deleting := newModel("old", true)
+		r := newReconciler(deleting, newModel("other", false), cachePVC)
+
+		if _, err := r.reconcileCacheCleanup(context.Background(), deleting); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cleanupJob(r, deleting); !apierrors.IsNotFound(err) {
+			t.Errorf("expected no cleanup job while another Model uses the key, got err=%v", err)
+		}
+		if !modelGone(t, r, "old") {
+			t.Error("expected the finalizer to be released so the Model is deleted")
+		}
+	})
+
+	t.Run("sole owner removes the cache entry before releasing", func(t *testing.T) {
+		deleting := newModel("solo", true)
+		r := newReconciler(deleting, cachePVC)
+
+		result, err := r.reconcileCacheCleanup(context.Background(), deleting)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.RequeueAfter == 0 {
+			t.Error("expected a requeue to poll the cleanup job")
+		}
+		job, err := cleanupJob(r, deleting)
+		if err != nil {
+			t.Fatalf("expected a cleanup job: %v", err)
+		}
+		c := job.Spec.Template.Spec.Containers[0]
+		if !strings.Contains(c.Command[2], `rm -rf "/models/${CACHE_KEY}"`) {
+			t.Errorf("unexpected cleanup command %q", c.Command[2])
+		}
+		if len(c.Env) != 1 || c.Env[0].Value != cacheKey {
+			t.Errorf("expected CACHE_KEY=%s, got %+v", cacheKey, c.Env)
+		}
+		if claim := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim; claim == nil || claim.ClaimName != ModelCachePVCName {
+			t.Errorf("expected the shared cache PVC mounted, got %+v", job.Spec.Template.Spec.Volumes)
+		}
+		if d := job.Spec.ActiveDeadlineSeconds; d == nil || *d != int64(cacheCleanupJobDeadline.Seconds()) {
+			t.Errorf("expected activeDeadlineSeconds %v, got %v", cacheCleanupJobDeadline.Seconds(), d)
+		}
+		if modelGone(t, r, "solo") {
+			t.Fatal("Model released before the cleanup job finished")
+		}
+
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := r.Status().Update(context.Background(), job); err != nil {
+			t.Fatalf("marking job complete: %v", err)
+		}
+		if _, err := r.reconcileCacheCleanup(context.Background(), deleting); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !modelGone(t, r, "solo") {
+			t.Error("expected the finalizer to be released after the job completed")
+		}
+		if _, err := cleanupJob(r, deleting); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the finished cleanup job to be deleted, got err=%v", err)
+		}
+	})
+
+	t.Run("a Model also being deleted does not hold the entry", func(t *testing.T) {
+		deleting := newModel("a", true)
+		r := newReconciler(deleting, newModel("b", true), cachePVC)
+
+		if _, err := r.reconcileCacheCleanup(context.Background(), deleting); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cleanupJob(r, deleting); err != nil {
+			t.Errorf("expected a cleanup job, got err=%v", err)
+		}
+	})
+
+	t.Run("forbidden job creation releases with a warning", func(t *testing.T) {
+		deleting := newModel("terminating", true)
+		recorder := events.NewFakeRecorder(10)
+		r := newReconciler(deleting, cachePVC)
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*batchv1.Job); ok {
+					return apierrors.NewForbidden(batchv1.Resource("jobs"), obj.GetName(),
+						errors.New("unable to create new content in namespace default because it is being terminated"))
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+		r.Recorder = recorder
+
+		if _, err := r.reconcileCacheCleanup(context.Background(), deleting); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !modelGone(t, r, "terminating") {
+			t.Error("expected the finalizer to be released when the job cannot be created")
+		}
+		if got := <-recorder.Events; !strings.Contains(got, "CacheCleanupSkipped") {
+			t.Errorf("expected a CacheCleanupSkipped event, got %q", got)
+		}
+	})
+
+	t.Run("a job that never finishes is abandoned after the timeout", func(t *testing.T) {
+		deleting := newModel("stuck", true)
+		recorder := events.NewFakeRecorder(10)
+		r := newReconciler(deleting, cachePVC)
+		r.Recorder = recorder
+
+		if _, err := r.reconcileCacheCleanup(context.Background(), deleting); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if modelGone(t, r, "stuck") {
+			t.Fatal("Model released before the timeout")
+		}
+
+		job, err := cleanupJob(r, deleting)
+		if err != nil {
+			t.Fatalf("expected a cleanup job: %v", err)
+		}
+
+		// The deletion timestamp is immutable, so restart from a store where
+		// the Model has been deleting for longer than the timeout.
+		stale := newModel("stuck", true)
+		past := metav1.NewTime(time.Now().Add(-cacheCleanupTimeout - time.Minute))
+		stale.DeletionTimestamp = &past
+		job.ResourceVersion = ""
+		r = newReconciler(stale, cachePVC, job)
+		r.Recorder = recorder
+		if _, err := r.reconcileCacheCleanup(context.Background(), stale); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !modelGone(t, r, "stuck") {
+			t.Error("expected the finalizer to be released after the timeout")
+		}
+		if _, err := cleanupJob(r, deleting); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the stuck cleanup job to be deleted, got err=%v", err)
+		}
+		if got := <-recorder.Events; !strings.Contains(got, "CacheCleanupTimedOut") {
+			t.Errorf("expected a CacheCleanupTimedOut event, got %q", got)
+		}
+	})
+
+	t.Run("no shared cache PVC releases immediately", func(t *testing.T) {
+		deleting := newModel("nopvc", true)
+		r := newReconciler(deleting)
+
+		if _, err := r.reconcileCacheCleanup(context.Background(), deleting); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !modelGone(t, r, "nopvc") {
+			t.Error("expected the finalizer to be released without a cache PVC")
+		}
+	})
+}
+
+func TestEnsureCacheCleanupFinalizer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	model := &inferencev1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(model).Build()
+
+	disabled := &ModelReconciler{Client: c, Scheme: scheme}
+	if err := disabled.ensureCacheCleanupFinalizer(context.Background(), model); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(model.Finalizers) != 0 {
+		t.Errorf("expected no finalizer when cleanup is disabled, got %v", model.Finalizers)
+	}
+
+	enabled := &ModelReconciler{Client: c, Scheme: scheme, CacheCleanup: true}
+	if err := enabled.ensureCacheCleanupFinalizer(context.Background(), model); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored := &inferencev1alpha1.Model{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "m", Namespace: "default"}, stored); err != nil {
+		t.Fatalf("get model: %v", err)
+	}
+	if len(stored.Finalizers) != 1 || stored.Finalizers[0] != modelCacheCleanupFinalizer {
+		t.Errorf("expected %s persisted, got %v", modelCacheCleanupFinalizer, stored.Finalizers)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// Model cache auto-expansion: a full cache PVC otherwise surfaces as a
+// cryptic curl write failure in the model-downloader init container. When
+// --model-cache-expand-increment is set, the downloader reports the cache
+// filesystem's usage in its termination message (success or failure), and the
+// reconciler grows the operator-created cache PVC by the increment, up to
+// --model-cache-expand-max-size, once a report crosses the threshold. This
+// only works when the PVC's StorageClass sets allowVolumeExpansion; user-owned
+// claims (spec.modelCache.claimName) are never touched.
+
+// cacheUsageReportPrefix marks the downloader's usage line in its termination
+// message.
+const cacheUsageReportPrefix = "llmkube-cache-usage"
+
+// cacheExpandedAtAnnotation records when the operator last expanded a cache
+// PVC. Usage reports written before it describe the old size and are ignored,
+// so one near-full report cannot trigger repeated expansions.
+const cacheExpandedAtAnnotation = "inference.llmkube.dev/cache-expanded-at"
+
+// defaultCacheExpandThreshold is the usage percentage that triggers an
+// expansion when --model-cache-expand-threshold is unset.
+const defaultCacheExpandThreshold = 85
+
+// cacheUsageReportScript writes "<prefix> used=<KiB> size=<KiB>" for /models
+// to the termination log. busybox df in the curl image supports -P.
+const cacheUsageReportScript = `df -Pk /models | awk 'NR==2 {print "` + cacheUsageReportPrefix +
+	` used="$3" size="$2}' > /dev/termination-log`
+
+// cacheUsage is a parsed downloader usage report, in KiB.
+type cacheUsage struct {
+	usedKiB  int64
+	sizeKiB  int64
+	reported time.Time
+}
+
+// addCacheUsageReport wraps the model-downloader command so it reports cache
+// usage whether the download succeeded or failed, preserving the exit code.
+// A full filesystem fails the download, and the report on that failure is
+// what lets the reconciler recover by expanding the PVC.
+func addCacheUsageReport(initContainers []corev1.Container) {
+	for i := range initContainers {
+		c := &initContainers[i]
+		if c.Name != "model-downloader" || len(c.Command) != 3 {
+			continue
+		}
+		c.Command[2] = fmt.Sprintf("( %s ); rc=$?; %s; exit $rc", c.Command[2], cacheUsageReportScript)
+	}
+}
+
+// parseCacheUsageReport extracts the usage line from a termination message.
+func parseCacheUsageReport(message string) (used, size int64, ok bool) {
+	for line := range strings.SplitSeq(message, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != cacheUsageReportPrefix {
+			continue
+		}
+		var err error
+		if used, err = strconv.ParseInt(strings.TrimPrefix(fields[1], "used="), 10, 64); err != nil {
+			return 0, 0, false
+		}
+		if size, err = strconv.ParseInt(strings.TrimPrefix(fields[2], "size="), 10, 64); err != nil || size <= 0 {
+			return 0, 0, false
+		}
+		return used, size, true
+	}
+	return 0, 0, false
+}
+
+// latestCacheUsage returns the most recent downloader usage report among the
+// InferenceService's pods, or nil when none has reported.
+func latestCacheUsage(pods []corev1.Pod) *cacheUsage {
+	var latest *cacheUsage
+	for i := range pods {
+		for _, status := range pods[i].Status.InitContainerStatuses {
+			if status.Name != "model-downloader" {
+				continue
+			}
+			for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+				term := state.Terminated
+				if term == nil {
+					continue
+				}
+				used, size, ok := parseCacheUsageReport(term.Message)
+				if !ok {
+					continue
+				}
+				if latest == nil || term.FinishedAt.After(latest.reported) {
+					latest = &cacheUsage{usedKiB: used, sizeKiB: size, reported: term.FinishedAt.Time}
+				}
+			}
+		}
+	}
+	return latest
+}
+
+// nextCacheSize returns the size to expand the PVC to, or false when the cap
+// is already reached.
+func nextCacheSize(current, increment, maxSize resource.Quantity) (resource.Quantity, bool) {
+	if current.Cmp(maxSize) >= 0 {
+		return current, false
+	}
+	next := current.DeepCopy()
+	next.Add(increment)
+	if next.Cmp(maxSize) > 0 {
+		next = maxSize.DeepCopy()
+	}
+	return next, true
+}
+
+// reconcileModelCacheExpansion grows the InferenceService's operator-created
+// cache PVC when the downloader reported usage at or above the threshold.
+func (r *InferenceServiceReconciler) reconcileModelCacheExpansion(ctx context.Context, isvc *inferencev1alpha1.InferenceService) error {
+	if r.ModelCacheExpandIncrement == "" || userModelCacheClaimName(isvc) != "" {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+
+	increment, err := resource.ParseQuantity(r.ModelCacheExpandIncrement)
+	if err != nil {
+		return fmt.Errorf("invalid cache expand increment %q: %w", r.ModelCacheExpandIncrement, err)
+	}
+	maxSize, err := resource.ParseQuantity(r.ModelCacheExpandMaxSize)
+	if err != nil {
+		return fmt.Errorf("invalid cache expand max size %q: %w", r.ModelCacheExpandMaxSize, err)
+	}
+	threshold := int64(r.ModelCacheExpandThreshold)
+	if threshold <= 0 || threshold > 100 {
+		threshold = defaultCacheExpandThreshold
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvcName := modelCachePVCName(isvc, r.ModelCacheMode)
+	if err := r.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: isvc.Namespace}, pvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get model cache PVC: %w", err)
+	}
+	if pvc.Labels["app.kubernetes.io/managed-by"] != "llmkube-controller" {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{"inference.llmkube.dev/service": isvc.Name}); err != nil {
+		return fmt.Errorf("failed to list pods for cache usage: %w", err)
+	}
+	usage := latestCacheUsage(pods.Items)
+	if usage == nil || usage.usedKiB*100 < threshold*usage.sizeKiB {
+		return nil
+	}
+	if at, err := time.Parse(time.RFC3339, pvc.Annotations[cacheExpandedAtAnnotation]); err == nil && !usage.reported.After(at) {
+		return nil
+	}
+
+	// A previous expansion the storage provider has not finished yet: the
+	// next report after it lands decides whether another step is needed.
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(current) < 0 {
+		return nil
+	}
+
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		log.Info("Model cache PVC is nearly full but has no StorageClass to expand through", "pvc", pvcName)
+		return nil
+	}
+	sc := &storagev1.StorageClass{}
+	if err := r.Get(ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, sc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get StorageClass %q: %w", *pvc.Spec.StorageClassName, err)
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		log.Info("Model cache PVC is nearly full but its StorageClass does not allow volume expansion",
+			"pvc", pvcName, "storageClass", sc.Name)
+		return nil
+	}
+
+	next, ok := nextCacheSize(current, increment, maxSize)
+	if !ok {
+		if r.Recorder != nil {
+			r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "ModelCacheAtMaxSize", "Reconcile",
+				"model cache PVC %q is %d%% full and already at the %s expansion cap",
+				pvcName, usage.usedKiB*100/usage.sizeKiB, maxSize.String())
+		}
+		return nil
+	}
+
+	patch := client.MergeFrom(pvc.DeepCopy())
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = next
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[cacheExpandedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, pvc, patch); err != nil {
+		return fmt.Errorf("failed to expand model cache PVC: %w", err)
+	}
+
+	log.Info("Expanded model cache PVC", "pvc", pvcName, "from", current.String(), "to", next.String())
+	if r.Recorder != nil {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeNormal, "ModelCacheExpanded", "Reconcile",
+			"model cache PVC %q was %d%% full; expanded from %s to %s",
+			pvcName, usage.usedKiB*100/usage.sizeKiB, current.String(), next.String())
+	}
+	return nil
+}
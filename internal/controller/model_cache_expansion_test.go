@@ -0,0 +1,192 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestAddCacheUsageReport(t *testing.T) {
+	containers := []corev1.Container{
+		{Name: "cache-prep", Command: []string{"sh", "-c", "chown 102 /models"}},
+		{Name: "model-downloader", Command: []string{"sh", "-c", "curl -o x y"}},
+	}
+	addCacheUsageReport(containers)
+
+	if containers[0].Command[2] != "chown 102 /models" {
+		t.Errorf("expected cache-prep to be left alone, got %q", containers[0].Command[2])
+	}
+	cmd := containers[1].Command[2]
+	if !strings.HasPrefix(cmd, "( curl -o x y ); rc=$?; ") || !strings.HasSuffix(cmd, "exit $rc") {
+		t.Errorf("expected the download to be wrapped with its exit code preserved, got %q", cmd)
+	}
+	if !strings.Contains(cmd, "/dev/termination-log") {
+		t.Errorf("expected the usage report to go to the termination log, got %q", cmd)
+	}
+}
+
+func TestParseCacheUsageReport(t *testing.T) {
+	used, size, ok := parseCacheUsageReport("llmkube-cache-usage used=90 size=100\n")
+	if !ok || used != 90 || size != 100 {
+		t.Errorf("parseCacheUsageReport() = %d, %d, %v; want 90, 100, true", used, size, ok)
+	}
+	for _, msg := range []string{"", "curl: (23) Failure writing output", "llmkube-cache-usage used=x size=100", "llmkube-cache-usage used=1 size=0"} {
+		if _, _, ok := parseCacheUsageReport(msg); ok {
+			t.Errorf("expected %q to be rejected", msg)
+		}
+	}
+}
+
+func TestReconcileModelCacheExpansion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = storagev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	ctx := context.Background()
+
+	reportedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	allow, deny := true, false
+	class := "expandable"
+
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+	}
+	newPVC := func(size string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ModelCachePVCName,
+				Namespace:   "default",
+				Labels:      map[string]string{"app.kubernetes.io/managed-by": "llmkube-controller"},
+				Annotations: annotations,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &class,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+		}
+	}
+	newPod := func(message string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc-abc",
+				Namespace: "default",
+				Labels:    map[string]string{"inference.llmkube.dev/service": "svc"},
+			},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{
+					Name: "model-downloader",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   23,
+						Message:    message,
+						FinishedAt: metav1.NewTime(reportedAt),
+					}},
+				}},
+			},
+		}
+	}
+	storageClass := func(allowExpansion *bool) *storagev1.StorageClass {
+		return &storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: class},
+			Provisioner:          "example.com/csi",
+			AllowVolumeExpansion: allowExpansion,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pvc      *corev1.PersistentVolumeClaim
+		pod      *corev1.Pod
+		class    *storagev1.StorageClass
+		wantSize string
+	}{
+		{
+			name:     "expands by the increment above the threshold",
+			pvc:      newPVC("100Gi", nil),
+			pod:      newPod("llmkube-cache-usage used=95 size=100"),
+			class:    storageClass(&allow),
+			wantSize: "150Gi",
+		},
+		{
+			name:     "leaves the PVC alone below the threshold",
+			pvc:      newPVC("100Gi", nil),
+			pod:      newPod("llmkube-cache-usage used=50 size=100"),
+			class:    storageClass(&allow),
+			wantSize: "100Gi",
+		},
+		{
+			name:     "leaves the PVC alone when the class cannot expand",
+			pvc:      newPVC("100Gi", nil),
+			pod:      newPod("llmkube-cache-usage used=95 size=100"),
+			class:    storageClass(&deny),
+			wantSize: "100Gi",
+		},
+		{
+			name:     "caps the expansion at the max size",
+			pvc:      newPVC("180Gi", nil),
+			pod:      newPod("llmkube-cache-usage used=95 size=100"),
+			class:    storageClass(&allow),
+			wantSize: "200Gi",
+		},
+		{
+			name: "ignores reports from before the last expansion",
+			pvc: newPVC("100Gi", map[string]string{
+				cacheExpandedAtAnnotation: reportedAt.Add(time.Second).UTC().Format(time.RFC3339),
+			}),
+			pod:      newPod("llmkube-cache-usage used=95 size=100"),
+			class:    storageClass(&allow),
+			wantSize: "100Gi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &InferenceServiceReconciler{
+				Client:                    fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pvc, tt.pod, tt.class).Build(),
+				Scheme:                    scheme,
+				ModelCacheExpandIncrement: "50Gi",
+				ModelCacheExpandMaxSize:   "200Gi",
+				ModelCacheExpandThreshold: 90,
+			}
+			if err := r.reconcileModelCacheExpansion(ctx, isvc); err != nil {
+				t.Fatalf("reconcileModelCacheExpansion: %v", err)
+			}
+
+			got := &corev1.PersistentVolumeClaim{}
+			if err := r.Get(ctx, types.NamespacedName{Name: ModelCachePVCName, Namespace: "default"}, got); err != nil {
+				t.Fatalf("get PVC: %v", err)
+			}
+			size := got.Spec.Resources.Requests[corev1.ResourceStorage]
+			if want := resource.MustParse(tt.wantSize); size.Cmp(want) != 0 {
+				t.Errorf("expected PVC request %s, got %s", tt.wantSize, size.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// Model cache TTL: the finalizer-driven cleanup (model_cache_cleanup.go)
+// removes an entry when its Model is deleted, but entries outlive their
+// Models whenever that cleanup is disabled, fails, or predates the Model's
+// deletion. CacheTTLEvictor is the time-based backstop: it periodically
+// stamps every entry that a live Model still resolves to, and removes
+// entries left unreferenced for longer than their TTL (Model spec.cacheTTL,
+// else --model-cache-ttl).
+//
+// The entries live on each namespace's shared cache PVC, not on the
+// operator's own mount, so every scan runs as a short-lived Job per
+// namespace that mounts the PVC. The Job reports what it evicted in its
+// termination message, which the next scan turns into CacheEvicted events.
+
+// cacheUsageFile is the per-entry marker. Its mtime is when the entry was
+// last referenced; its content is "<ttlSeconds> [namespace/name ...]", the
+// TTL and Models of that reference (0 means the controller default). The dot
+// prefix keeps it out of the *.gguf lookup in findCachedModelFile.
+const cacheUsageFile = ".llmkube-cache-usage"
+
+// defaultCacheTTLInterval is how often the evictor scans the cache.
+const defaultCacheTTLInterval = 10 * time.Minute
+
+// cacheTTLJobName is the per-namespace scan Job.
+const cacheTTLJobName = "llmkube-cache-ttl"
+
+// cacheTTLJobDeadline bounds one scan, including time its pod spends
+// Pending on an RWO cache PVC attached to another node.
+const cacheTTLJobDeadline = 5 * time.Minute
+
+// cacheEvictedReportPrefix marks an eviction line in the scan Job's
+// termination message: "<prefix> <cacheKey> <idleSeconds> [models...]".
+const cacheEvictedReportPrefix = "llmkube-cache-evicted"
+
+// cacheTTLScript stamps the entries listed in REFERENCED (one
+// "<key> <ttlSeconds> [models...]" per line), starts the clock on unmarked
+// entries, and removes the others once their marker is older than its TTL
+// (DEFAULT_TTL when the marker records none). The LoRA adapter directory is
+// not a cache entry and is never touched. Keys and Model names arrive as
+// data and are never evaluated by the shell. busybox in the curl image
+// provides stat -c.
+const cacheTTLScript = `set -u
+now=$(date +%s)
+referenced=" "
+while read -r key rest; do
+  [ -n "$key" ] || continue
+  referenced="$referenced$key "
+  [ -d "/models/$key" ] && printf '%s\n' "$rest" > "/models/$key/` + cacheUsageFile + `"
+done <<EOF
+$REFERENCED
+EOF
+for dir in /models/*/; do
+  [ -d "$dir" ] || continue
+  key=$(basename "$dir")
+  case "$key" in ` + loraCacheDir + `|*[!A-Za-z0-9._-]*) continue ;; esac
+  case "$referenced" in *" $key "*) continue ;; esac
+  marker="/models/$key/` + cacheUsageFile + `"
+  if [ ! -f "$marker" ]; then
+    echo 0 > "$marker"
+    continue
+  fi
+  ttl=0 models=""
+  read -r ttl models < "$marker" || true
+  case "$ttl" in ''|*[!0-9]*) ttl=0 ;; esac
+  [ "$ttl" -gt 0 ] || ttl=$DEFAULT_TTL
+  idle=$((now - $(stat -c %Y "$marker")))
+  if [ "$ttl" -gt 0 ] && [ "$idle" -gt "$ttl" ]; then
+    rm -rf "/models/$key" && echo "` + cacheEvictedReportPrefix + ` $key $idle $models" >> /dev/termination-log
+  fi
+done
+`
+
+// cacheEviction is one entry a scan Job removed.
+type cacheEviction struct {
+	Key  string
+	Idle time.Duration
+	// Models are the namespace/name of the Models that last referenced the
+	// entry.
+	Models []string
+}
+
+// parseCacheEvictions extracts the eviction lines from a scan Job's
+// termination message. A line cut short by the message size limit may lose
+// Models, so those evictions just record fewer events.
+func parseCacheEvictions(message string) []cacheEviction {
+	var evictions []cacheEviction
+	for line := range strings.SplitSeq(message, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != cacheEvictedReportPrefix {
+			continue
+		}
+		idle, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		evictions = append(evictions, cacheEviction{
+			Key:    fields[1],
+			Idle:   time.Duration(idle) * time.Second,
+			Models: fields[3:],
+		})
+	}
+	return evictions
+}
+
+// cacheReference is what the live Models resolving to an entry record on it.
+type cacheReference struct {
+	// TTL is the longest spec.cacheTTL among the Models; zero means the
+	// controller default.
+	TTL    time.Duration
+	Models []string
+}
+
+// CacheTTLEvictor evicts model cache entries that no Model has referenced
+// for longer than their TTL. It runs as a manager Runnable.
+type CacheTTLEvictor struct {
+	Client client.Client
+	// DefaultTTL applies to entries whose Models set no spec.cacheTTL; zero
+	// keeps such entries forever.
+	DefaultTTL time.Duration
+	// Interval between scans; zero means defaultCacheTTLInterval.
+	Interval time.Duration
+	// InitContainerImage runs the scan Job; empty uses defaultPrefetchImage.
+	InitContainerImage string
+	// DefaultFSGroup is set on the scan pod so it can write markers on a
+	// cache the model downloader populated.
+	DefaultFSGroup int64
+	Recorder       events.EventRecorder
+	Log            logr.Logger
+}
+
+// NeedLeaderElection keeps a single replica evicting.
+func (e *CacheTTLEvictor) NeedLeaderElection() bool { return true }
+
+// +kubebuilder:rbac:groups=inference.llmkube.dev,resources=models,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Start scans once immediately and then on Interval until ctx is cancelled.
+// Scan failures are logged; the next tick tries again.
+func (e *CacheTTLEvictor) Start(ctx context.Context) error {
+	interval := e.Interval
+	if interval <= 0 {
+		interval = defaultCacheTTLInterval
+	}
+	e.tick(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			e.tick(ctx)
+		}
+	}
+}
+
+// tick collects the previous scan of every namespace with a shared cache PVC
+// and starts the next one with the entries its live Models reference. With
+// no DefaultTTL, only namespaces where a live Model sets spec.cacheTTL are
+// scanned; elsewhere no entry can expire, so a Job would only compete for the
+// cache PVC.
+func (e *CacheTTLEvictor) tick(ctx context.Context) {
+	log := e.logger()
+
+	models := &inferencev1alpha1.ModelList{}
+	if err := e.Client.List(ctx, models); err != nil {
+		log.Error(err, "Skipping cache TTL scan: listing models failed")
+		return
+	}
+	referenced := map[string]map[string]cacheReference{}
+	withTTL := map[string]bool{}
+	for i := range models.Items {
+		m := &models.Items[i]
+		key := effectiveModelCacheKey(m)
+		if key == "" || !cacheKeyPattern.MatchString(key) || !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if referenced[m.Namespace] == nil {
+			referenced[m.Namespace] = map[string]cacheReference{}
+		}
+		ref := referenced[m.Namespace][key]
+		ref.Models = append(ref.Models, m.Namespace+"/"+m.Name)
+		if m.Spec.CacheTTL != nil && m.Spec.CacheTTL.Duration > ref.TTL {
+			ref.TTL = m.Spec.CacheTTL.Duration
+			withTTL[m.Namespace] = true
+		}
+		referenced[m.Namespace][key] = ref
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := e.Client.List(ctx, pvcs); err != nil {
+		log.Error(err, "Skipping cache TTL scan: listing PVCs failed")
+		return
+	}
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if pvc.Name != ModelCachePVCName || !pvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		start := e.DefaultTTL > 0 || withTTL[pvc.Namespace]
+		if err := e.scanNamespace(ctx, pvc.Namespace, referenced[pvc.Namespace], start); err != nil {
+			log.Error(err, "Cache TTL scan failed", "namespace", pvc.Namespace)
+		}
+	}
+}
+
+// scanNamespace reports and removes the namespace's finished scan Job, then
+// starts a new one when start is set. A scan that is still running is left
+// alone.
+func (e *CacheTTLEvictor) scanNamespace(ctx context.Context, namespace string, referenced map[string]cacheReference, start bool) error {
+	job := &batchv1.Job{}
+	err := e.Client.Get(ctx, types.NamespacedName{Name: cacheTTLJobName, Namespace: namespace}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("checking cache TTL job: %w", err)
+	case !jobSucceeded(job) && !jobFailed(job):
+		return nil
+	default:
+		if err := e.reportEvictions(ctx, job); err != nil {
+			return err
+		}
+		if err := e.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting cache TTL job: %w", err)
+		}
+	}
+
+	if !start {
+		return nil
+	}
+	if err := e.Client.Create(ctx, e.buildCacheTTLJob(namespace, referenced)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating cache TTL job: %w", err)
+	}
+	return nil
+}
+
+// reportEvictions logs and records the evictions listed in the termination
+// messages of a finished scan Job's pods.
+func (e *CacheTTLEvictor) reportEvictions(ctx context.Context, job *batchv1.Job) error {
+	pods := &corev1.PodList{}
+	if err := e.Client.List(ctx, pods, client.InNamespace(job.Namespace),
+		client.MatchingLabels{"app.kubernetes.io/component": "model-cache-ttl"}); err != nil {
+		return fmt.Errorf("listing cache TTL pods: %w", err)
+	}
+	for i := range pods.Items {
+		if !metav1.IsControlledBy(&pods.Items[i], job) {
+			continue
+		}
+		for _, status := range pods.Items[i].Status.ContainerStatuses {
+			if status.Name != "cache-ttl" || status.State.Terminated == nil {
+				continue
+			}
+			for _, eviction := range parseCacheEvictions(status.State.Terminated.Message) {
+				e.logger().Info("Evicted expired cache entry", "namespace", job.Namespace, "cacheKey", eviction.Key,
+					"idle", eviction.Idle.Round(time.Minute), "models", eviction.Models)
+				e.recordEviction(eviction)
+			}
+		}
+	}
+	return nil
+}
+
+// recordEviction emits a CacheEvicted event on each Model that last
+// referenced the entry. The Models are gone, so the event's regarding
+// reference is rebuilt from the recorded namespace/name; the event stays
+// listable in that namespace.
+func (e *CacheTTLEvictor) recordEviction(eviction cacheEviction) {
+	if e.Recorder == nil {
+		return
+	}
+	for _, ref := range eviction.Models {
+		namespace, name, ok := strings.Cut(ref, "/")
+		if !ok || namespace == "" || name == "" {
+			continue
+		}
+		model := &inferencev1alpha1.Model{
+			TypeMeta:   metav1.TypeMeta{APIVersion: inferencev1alpha1.GroupVersion.String(), Kind: "Model"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		}
+		e.Recorder.Eventf(model, nil, corev1.EventTypeNormal, "CacheEvicted", "Evict",
+			"Evicted model cache entry %s after %s without a referencing Model", eviction.Key, eviction.Idle.Round(time.Minute))
+	}
+}
+
+func (e *CacheTTLEvictor) logger() logr.Logger {
+	if e.Log.IsZero() {
+		return logr.Discard()
+	}
+	return e.Log
+}
+
+// buildCacheTTLJob assembles the scan Job for one namespace's shared cache
+// PVC. The referenced entries travel as an env var rather than being spliced
+// into the script.
+func (e *CacheTTLEvictor) buildCacheTTLJob(namespace string, referenced map[string]cacheReference) *batchv1.Job {
+	backoff := int32(0) // the next tick is the retry
+	deadline := int64(cacheTTLJobDeadline.Seconds())
+	ttl := int32(60 * 60) // normally deleted by the next scan; TTL is the backstop
+
+	keys := make([]string, 0, len(referenced))
+	for key := range referenced {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var lines strings.Builder
+	for _, key := range keys {
+		ref := referenced[key]
+		fmt.Fprintf(&lines, "%s %d %s\n", key, int64(ref.TTL.Seconds()), strings.Join(ref.Models, " "))
+	}
+
+	var podSecurity *corev1.PodSecurityContext
+	if e.DefaultFSGroup > 0 {
+		fs := e.DefaultFSGroup
+		podSecurity = &corev1.PodSecurityContext{FSGroup: &fs}
+	}
+
+	image := e.InitContainerImage
+	if image == "" {
+		image = defaultPrefetchImage
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cacheTTLJobName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "llmkube",
+				"app.kubernetes.io/component":  "model-cache-ttl",
+				"app.kubernetes.io/managed-by": "llmkube-controller",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoff,
+			ActiveDeadlineSeconds:   &deadline,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app.kubernetes.io/component": "model-cache-ttl"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:   corev1.RestartPolicyNever,
+					SecurityContext: podSecurity,
+					Containers: []corev1.Container{{
+						Name:    "cache-ttl",
+						Image:   image,
+						Command: []string{"sh", "-c", cacheTTLScript},
+						Env: []corev1.EnvVar{
+							{Name: "REFERENCED", Value: lines.String()},
+							{Name: "DEFAULT_TTL", Value: strconv.FormatInt(int64(e.DefaultTTL.Seconds()), 10)},
+						},
+						VolumeMounts:    []corev1.VolumeMount{{Name: "model-cache", MountPath: "/models"}},
+						SecurityContext: initContainerSecurityContext(nil),
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "model-cache",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: ModelCachePVCName},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
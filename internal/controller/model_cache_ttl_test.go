@@ -0,0 +1,250 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestParseCacheEvictions(t *testing.T) {
+	message := "llmkube-cache-evicted 00000000000000bb 7260 default/old team/other\n" +
+		"llmkube-cache-evicted 00000000000000cc 3700 \n" +
+		"llmkube-cache-evicted 00000000000000dd notanumber default/x\n" +
+		"unrelated output\n" +
+		"llmkube-cache-evicted 00000000000000ee 40"
+
+	got := parseCacheEvictions(message)
+	want := []cacheEviction{
+		{Key: "00000000000000bb", Idle: 7260 * time.Second, Models: []string{"default/old", "team/other"}},
+		{Key: "00000000000000cc", Idle: 3700 * time.Second, Models: []string{}},
+		{Key: "00000000000000ee", Idle: 40 * time.Second, Models: []string{}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseCacheEvictions() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Key != want[i].Key || got[i].Idle != want[i].Idle || !slices.Equal(got[i].Models, want[i].Models) {
+			t.Errorf("eviction %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCacheTTLScriptSkipsLoRAAdapters(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	root := t.TempDir()
+	terminationLog := filepath.Join(root, "termination-log")
+	models := filepath.Join(root, "models")
+	expired := time.Now().Add(-2 * time.Hour)
+	for _, dir := range []string{"00000000000000bb", loraCacheDir} {
+		if err := os.MkdirAll(filepath.Join(models, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		marker := filepath.Join(models, dir, cacheUsageFile)
+		if err := os.WriteFile(marker, []byte("0\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(marker, expired, expired); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	script := strings.ReplaceAll(cacheTTLScript, "/dev/termination-log", terminationLog)
+	script = strings.ReplaceAll(script, "/models", models)
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Env = append(os.Environ(), "REFERENCED=", "DEFAULT_TTL=3600")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("script failed: %v\n%s", err, out)
+	}
+
+	if _, err := os.Stat(filepath.Join(models, "00000000000000bb")); !os.IsNotExist(err) {
+		t.Errorf("expected the expired cache entry to be evicted, got err=%v", err)
+	}
+	if _, err := os.Stat(filepath.Join(models, loraCacheDir)); err != nil {
+		t.Errorf("expected the LoRA adapter directory to be kept: %v", err)
+	}
+	report, _ := os.ReadFile(terminationLog)
+	if evictions := parseCacheEvictions(string(report)); len(evictions) != 1 || evictions[0].Key != "00000000000000bb" {
+		t.Errorf("evictions = %+v, want only 00000000000000bb", evictions)
+	}
+}
+
+func TestCacheTTLEvictorTick(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	const liveKey = "00000000000000aa"
+	cachePVC := func(namespace string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: ModelCachePVCName, Namespace: namespace}}
+	}
+	live := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "default"},
+		Spec: inferencev1alpha1.ModelSpec{
+			Source:   "https://example.com/model.gguf",
+			CacheTTL: &metav1.Duration{Duration: 48 * time.Hour},
+		},
+		Status: inferencev1alpha1.ModelStatus{CacheKey: liveKey},
+	}
+	getJob := func(t *testing.T, e *CacheTTLEvictor, namespace string) *batchv1.Job {
+		t.Helper()
+		job := &batchv1.Job{}
+		if err := e.Client.Get(context.Background(), types.NamespacedName{Name: cacheTTLJobName, Namespace: namespace}, job); err != nil {
+			t.Fatalf("expected a cache TTL job in %s: %v", namespace, err)
+		}
+		return job
+	}
+	jobEnv := func(job *batchv1.Job, name string) string {
+		for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+		return ""
+	}
+
+	t.Run("scans every namespace with a shared cache PVC", func(t *testing.T) {
+		other := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "apps"}}
+		e := &CacheTTLEvictor{
+			Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(live, cachePVC("default"), cachePVC("team"), other).Build(),
+			DefaultTTL: time.Hour,
+		}
+		e.tick(context.Background())
+
+		job := getJob(t, e, "default")
+		if got, want := jobEnv(job, "REFERENCED"), liveKey+" 172800 default/live\n"; got != want {
+			t.Errorf("REFERENCED = %q, want %q", got, want)
+		}
+		if got := jobEnv(job, "DEFAULT_TTL"); got != "3600" {
+			t.Errorf("DEFAULT_TTL = %q, want 3600", got)
+		}
+		if claim := job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim; claim == nil || claim.ClaimName != ModelCachePVCName {
+			t.Errorf("expected the namespace's shared cache PVC mounted, got %+v", job.Spec.Template.Spec.Volumes)
+		}
+		if d := job.Spec.ActiveDeadlineSeconds; d == nil || *d != int64(cacheTTLJobDeadline.Seconds()) {
+			t.Errorf("expected activeDeadlineSeconds %v, got %v", cacheTTLJobDeadline.Seconds(), d)
+		}
+		if got := jobEnv(getJob(t, e, "team"), "REFERENCED"); got != "" {
+			t.Errorf("expected no referenced entries in a namespace without Models, got %q", got)
+		}
+		if err := e.Client.Get(context.Background(), types.NamespacedName{Name: cacheTTLJobName, Namespace: "apps"}, &batchv1.Job{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected no scan in a namespace without the shared cache PVC, got err=%v", err)
+		}
+	})
+
+	t.Run("without a default TTL only namespaces with a Model cacheTTL are scanned", func(t *testing.T) {
+		noTTL := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "team"},
+			Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/other.gguf"},
+			Status:     inferencev1alpha1.ModelStatus{CacheKey: "00000000000000cc"},
+		}
+		finished := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: cacheTTLJobName, Namespace: "idle"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}},
+		}
+		e := &CacheTTLEvictor{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(live, noTTL, cachePVC("default"), cachePVC("team"), cachePVC("idle"), finished).Build(),
+		}
+		e.tick(context.Background())
+
+		getJob(t, e, "default")
+		for _, namespace := range []string{"team", "idle"} {
+			err := e.Client.Get(context.Background(), types.NamespacedName{Name: cacheTTLJobName, Namespace: namespace}, &batchv1.Job{})
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("expected no scan in %s where no TTL applies, got err=%v", namespace, err)
+			}
+		}
+	})
+
+	t.Run("a running scan is left alone", func(t *testing.T) {
+		running := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: cacheTTLJobName, Namespace: "default", UID: "running"}}
+		e := &CacheTTLEvictor{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(live, cachePVC("default"), running).Build(),
+		}
+		e.tick(context.Background())
+
+		if job := getJob(t, e, "default"); job.UID != "running" {
+			t.Error("expected the running scan job to be kept")
+		}
+	})
+
+	t.Run("a finished scan is reported and replaced", func(t *testing.T) {
+		finished := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: cacheTTLJobName, Namespace: "default", UID: "finished"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}},
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cacheTTLJobName + "-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"app.kubernetes.io/component": "model-cache-ttl"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "batch/v1", Kind: "Job", Name: cacheTTLJobName, UID: "finished", Controller: boolPtr(true),
+				}},
+			},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "cache-ttl",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Message: "llmkube-cache-evicted 00000000000000bb 7260 default/old\n",
+				}},
+			}}},
+		}
+		recorder := events.NewFakeRecorder(10)
+		e := &CacheTTLEvictor{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(live, cachePVC("default"), finished, pod).Build(),
+			Recorder: recorder,
+		}
+		e.tick(context.Background())
+
+		if job := getJob(t, e, "default"); job.UID == "finished" {
+			t.Error("expected the finished scan job to be replaced by a new scan")
+		}
+		select {
+		case got := <-recorder.Events:
+			if !strings.Contains(got, "CacheEvicted") || !strings.Contains(got, "00000000000000bb") {
+				t.Errorf("unexpected event %q", got)
+			}
+		default:
+			t.Error("expected a CacheEvicted event for the Model that last used the entry")
+		}
+	})
+}
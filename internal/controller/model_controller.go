@@ -36,8 +36,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
@@ -123,12 +125,20 @@ type ModelReconciler struct {
 	// Prefetch (#904) configuration, mirrored from the InferenceService
 	// reconciler's cache settings so the prefetch Job writes into the same
 	// shared cache PVC the serving path mounts.
-	InitContainerImage   string
-	CACertConfigMap      string
-	DefaultFSGroup       int64
-	ModelCacheSize       string
-	ModelCacheClass      string
-	ModelCacheAccessMode string
+	InitContainerImage     string
+	CACertConfigMap        string
+	DefaultFSGroup         int64
+	DefaultImagePullPolicy corev1.PullPolicy
+	ModelCacheSize         string
+	ModelCacheClass        string
+	ModelCacheAccessMode   string
+
+	// CacheCleanup adds the cache-cleanup finalizer to Models so a deleted
+	// Model's shared cache entry is removed (see reconcileCacheCleanup).
+	CacheCleanup bool
+	// Recorder emits Warning events when a deleted Model's cache entry is
+	// left behind. Optional.
+	Recorder events.EventRecorder
 
 	// metadataHTTPClient is the SSRF-guarded client used for all controller-
 	// side requests to Model.spec.source (metadata reads and revalidation
@@ -154,6 +164,7 @@ func (r *ModelReconciler) metadataClient() *http.Client {
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reconcileStart := time.Now()
@@ -180,6 +191,18 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		r.StoragePath = DefaultModelCachePath
 	}
 
+	// A deleted Model only needs its cache entry released; nothing else in
+	// the reconcile applies to it.
+	if !model.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(model, modelCacheCleanupFinalizer) {
+			return r.reconcileCacheCleanup(ctx, model)
+		}
+		return ctrl.Result{}, nil
+	}
+	if err := r.ensureCacheCleanupFinalizer(ctx, model); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Host-path allowlist gate (GHSA-jw3m-8q7m-f35r): a local source outside
 	// the operator-configured roots must never reach copyLocalModel/os.Open —
 	// including the metal local-path branch in reconcileBySourceType, so this
@@ -199,7 +222,7 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 
 	// Sources that need no controller-side download (PVC, HuggingFace repo,
-	// remote HTTP, Metal local-path) are dispatched here. handled=false means
+	// remote HTTP, S3/GCS object storage, Metal local-path) are dispatched here. handled=false means
 	// the source is a local path the controller must copy itself.
 	if handled, result, err := r.reconcileBySourceType(ctx, model); handled {
 		return result, err
@@ -296,7 +319,11 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		_ = os.Remove(downloadPath)
 		llmkubemetrics.ReconcileTotal.WithLabelValues("model", "error").Inc()
 		model.Status.Phase = PhaseFailed
-		if statusErr := r.updateStatus(ctx, model, ConditionDegraded, metav1.ConditionTrue, "IntegrityCheckFailed", err.Error()); statusErr != nil {
+		reason := "IntegrityCheckFailed"
+		if isChecksumMismatch(err) {
+			reason = ReasonChecksumMismatch
+		}
+		if statusErr := r.updateStatus(ctx, model, ConditionDegraded, metav1.ConditionTrue, reason, err.Error()); statusErr != nil {
 			logger.Error(statusErr, "Failed to update status after integrity check failure")
 		}
 		return ctrl.Result{}, err
@@ -316,6 +343,8 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		finalPath = downloadPath
 	}
 
+	r.checkModelFits(ctx, model, size)
+
 	model.Status.Phase = PhaseReady
 	model.Status.Path = finalPath
 	model.Status.Size = formatBytes(size)
@@ -384,6 +413,8 @@ func (r *ModelReconciler) reconcileCachedModelFile(ctx context.Context, model *i
 			finalPath = existingPath
 		}
 
+		r.checkModelFits(ctx, model, fileInfo.Size())
+
 		model.Status.Phase = PhaseReady
 		model.Status.Path = finalPath
 		model.Status.Size = formatBytes(fileInfo.Size())
@@ -607,6 +638,15 @@ func (r *ModelReconciler) reconcileBySourceType(
 			ctx, model, computeCacheKey(model.Spec.Source))
 		return true, result, err
 
+	// Object storage (s3://, gs://): fetched by the init container with the
+	// sourceSecretRef credentials, exactly like remote HTTP. The controller
+	// has no credentials-aware client for these, so it only marks the Model
+	// Ready.
+	case isS3Source(model.Spec.Source) || isGCSSource(model.Spec.Source):
+		result, err = r.reconcileRuntimeResolvedSource(
+			ctx, model, computeCacheKey(model.Spec.Source))
+		return true, result, err
+
 	// Metal-accelerated models with a local-path source live on the Metal
 	// node's own filesystem and are loaded directly by the host metal-agent.
 	// The in-cluster controller cannot see that path, so it neither downloads
@@ -644,6 +684,22 @@ func (r *ModelReconciler) reconcileRuntimeResolvedSource(ctx context.Context, mo
 		return ctrl.Result{}, nil
 	}
 
+	// The token is also what the init container authenticates with, so a
+	// missing Secret would only resurface later as a failed pod download.
+	if _, err := r.sourceToken(ctx, model); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		logger.Info("Referenced source Secret not found", "secret", model.Spec.SourceSecretRef.Name)
+		model.Status.Phase = PhaseFailed
+		msg := fmt.Sprintf("sourceSecretRef Secret %q not found in namespace %q", model.Spec.SourceSecretRef.Name, model.Namespace)
+		if statusErr := r.updateStatus(ctx, model, ConditionDegraded, metav1.ConditionTrue, ReasonSourceSecretNotFound, msg); statusErr != nil {
+			logger.Error(statusErr, "Failed to update status")
+		}
+		llmkubemetrics.ReconcileTotal.WithLabelValues("model", "error").Inc()
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
 	isMetal := isMetalModel(model)
 
 	reason := "RuntimeResolved"
@@ -679,6 +735,8 @@ func (r *ModelReconciler) reconcileRuntimeResolvedSource(ctx context.Context, mo
 			return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
 		}
 		model.Status.StagedFiles = plan.Files
+	} else if _, plan := splitSourcePlan(model.Spec.Source); plan != nil {
+		model.Status.StagedFiles = plan.Files
 	} else {
 		model.Status.StagedFiles = nil
 	}
@@ -694,14 +752,32 @@ func (r *ModelReconciler) reconcileRuntimeResolvedSource(ctx context.Context, mo
 	// fetched only by the per-isvc init container. Non-fatal: a metadata read
 	// failure (air-gapped, unreachable, non-GGUF) must not block the model from
 	// reaching Ready, since the workload still resolves the source itself.
-	if isRemoteHTTPSource(model.Spec.Source) && model.Status.GGUF == nil {
-		if ggufMeta, size, err := r.parseRemoteGGUFMetadata(ctx, model.Spec.Source); err != nil {
+	//
+	// The exception is a source that answers but is not a GGUF at all (bad
+	// magic or version, e.g. an HTML error page served with 200): the init
+	// container would fetch it and llama-server would fail to load it, so the
+	// Model is failed with an InvalidGGUF Degraded condition instead.
+	//
+	// A Model found too large re-reads the metadata so the size is known and
+	// the fit is checked again.
+	if isRemoteHTTPSource(model.Spec.Source) && (model.Status.GGUF == nil || isModelTooLarge(model)) {
+		if ggufMeta, size, err := r.parseRemoteGGUFMetadata(ctx, model); err != nil {
+			if isGGUFFormat(model) && isInvalidGGUF(err) {
+				logger.Info("Remote source is not a valid GGUF file", "source", model.Spec.Source, "error", err)
+				model.Status.Phase = PhaseFailed
+				if statusErr := r.updateStatus(ctx, model, ConditionDegraded, metav1.ConditionTrue, ReasonInvalidGGUF, err.Error()); statusErr != nil {
+					logger.Error(statusErr, "Failed to update status after GGUF validation failure")
+				}
+				llmkubemetrics.ReconcileTotal.WithLabelValues("model", "error").Inc()
+				return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+			}
 			logger.Info("Failed to read remote GGUF metadata (non-fatal)", "source", model.Spec.Source, "error", err)
 		} else {
 			model.Status.GGUF = ggufMeta
 			if size > 0 {
 				model.Status.Size = formatBytes(size)
 			}
+			r.checkModelFits(ctx, model, size)
 		}
 	}
 
@@ -724,6 +800,20 @@ func (r *ModelReconciler) reconcileRuntimeResolvedSource(ctx context.Context, mo
 	return ctrl.Result{}, nil
 }
 
+// ReasonChecksumMismatch is the Degraded reason set when a fetched model file
+// does not match spec.sha256.
+const ReasonChecksumMismatch = "ChecksumMismatch"
+
+// ReasonInvalidGGUF is the Degraded reason set when a gguf-format source
+// does not start with a valid GGUF header.
+const ReasonInvalidGGUF = "InvalidGGUF"
+
+// isGGUFFormat reports whether the Model declares the gguf format (the CRD
+// default when spec.format is unset).
+func isGGUFFormat(model *inferencev1alpha1.Model) bool {
+	return model.Spec.Format == "" || strings.EqualFold(model.Spec.Format, "gguf")
+}
+
 // verifySHA256 computes the SHA256 hash of the file and verifies it against the
 // spec if provided. The computed hash is always stored in status.
 func (r *ModelReconciler) verifySHA256(ctx context.Context, model *inferencev1alpha1.Model, filePath string) error {
@@ -739,7 +829,7 @@ func (r *ModelReconciler) verifySHA256(ctx context.Context, model *inferencev1al
 
 	if model.Spec.SHA256 != "" {
 		if !strings.EqualFold(computedHash, model.Spec.SHA256) {
-			return fmt.Errorf("SHA256 mismatch: expected %s, got %s", model.Spec.SHA256, computedHash)
+			return fmt.Errorf("%w: expected %s, got %s", errChecksumMismatch, model.Spec.SHA256, computedHash)
 		}
 		logger.Info("SHA256 integrity check passed")
 	}
@@ -881,6 +971,10 @@ func (r *ModelReconciler) clearProgressConditions(ctx context.Context, model *in
 }
 
 func (r *ModelReconciler) updateStatus(ctx context.Context, model *inferencev1alpha1.Model, condType string, status metav1.ConditionStatus, reason, message string) error {
+	// Every status write carries the detected source type, so it is filled
+	// in on whichever path first persists status.
+	model.Status.SourceType = modelSourceType(model.Spec.Source)
+
 	condition := metav1.Condition{
 		Type:               condType,
 		Status:             status,
@@ -1052,18 +1146,24 @@ func (r *ModelReconciler) parseGGUFMetadata(path string) (*inferencev1alpha1.GGU
 		return nil, fmt.Errorf("failed to parse GGUF: %w", err)
 	}
 
+	return ggufMetadataStatus(parsed), nil
+}
+
+// ggufMetadataStatus maps a parsed GGUF header onto the Model status fields.
+func ggufMetadataStatus(parsed *gguf.GGUFFile) *inferencev1alpha1.GGUFMetadata {
 	return &inferencev1alpha1.GGUFMetadata{
-		Architecture:  parsed.Architecture(),
-		ModelName:     parsed.Name(),
-		Quantization:  parsed.Quantization(),
-		ContextLength: parsed.ContextLength(),
-		EmbeddingSize: parsed.EmbeddingLength(),
-		LayerCount:    parsed.BlockCount(),
-		HeadCount:     parsed.HeadCount(),
-		TensorCount:   parsed.Header.TensorCount,
-		FileVersion:   parsed.Header.Version,
-		License:       license.Normalize(parsed.License()),
-	}, nil
+		Architecture:   parsed.Architecture(),
+		ModelName:      parsed.Name(),
+		Quantization:   parsed.Quantization(),
+		ContextLength:  parsed.ContextLength(),
+		EmbeddingSize:  parsed.EmbeddingLength(),
+		LayerCount:     parsed.BlockCount(),
+		HeadCount:      parsed.HeadCount(),
+		TensorCount:    parsed.Header.TensorCount,
+		ParameterCount: parsed.ParameterCount(),
+		FileVersion:    parsed.Header.Version,
+		License:        license.Normalize(parsed.License()),
+	}
 }
 
 // parseRemoteGGUFMetadata reads GGUF metadata from a remote http(s) URL using a
@@ -1076,40 +1176,32 @@ func (r *ModelReconciler) parseGGUFMetadata(path string) (*inferencev1alpha1.GGU
 // contexts carry no default deadline).
 const remoteMetadataTimeout = 30 * time.Second
 
-func (r *ModelReconciler) parseRemoteGGUFMetadata(ctx context.Context, source string) (*inferencev1alpha1.GGUFMetadata, int64, error) {
+func (r *ModelReconciler) parseRemoteGGUFMetadata(ctx context.Context, model *inferencev1alpha1.Model) (*inferencev1alpha1.GGUFMetadata, int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, remoteMetadataTimeout)
 	defer cancel()
-	parsed, err := gguf.ParseFromURLWithClient(ctx, r.metadataClient(), source)
+	client, err := r.sourceHTTPClient(ctx, model)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to parse remote GGUF: %w", err)
+		return nil, 0, err
 	}
-
-	meta := &inferencev1alpha1.GGUFMetadata{
-		Architecture:  parsed.Architecture(),
-		ModelName:     parsed.Name(),
-		Quantization:  parsed.Quantization(),
-		ContextLength: parsed.ContextLength(),
-		EmbeddingSize: parsed.EmbeddingLength(),
-		LayerCount:    parsed.BlockCount(),
-		HeadCount:     parsed.HeadCount(),
-		TensorCount:   parsed.Header.TensorCount,
-		FileVersion:   parsed.Header.Version,
-		License:       license.Normalize(parsed.License()),
+	source := model.Spec.Source
+	parsed, err := gguf.ParseFromURLWithClient(ctx, client, source)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse remote GGUF: %w", err)
 	}
 
-	return meta, r.remoteContentLength(ctx, source), nil
+	return ggufMetadataStatus(parsed), remoteContentLength(ctx, client, source), nil
 }
 
 // remoteContentLength returns the object size from a HEAD request, or 0 when the
 // server does not report a usable Content-Length. Best-effort: any error yields
 // 0 so the caller leaves Status.Size untouched rather than failing the reconcile.
 // The request goes through the SSRF-guarded client (GHSA-jw3m-8q7m-f35r).
-func (r *ModelReconciler) remoteContentLength(ctx context.Context, source string) int64 {
+func remoteContentLength(ctx context.Context, client *http.Client, source string) int64 {
 	req, err := http.NewRequestWithContext(ctx, http.MethodHead, source, nil)
 	if err != nil {
 		return 0
 	}
-	resp, err := r.metadataClient().Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return 0
 	}
@@ -33,6 +33,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -1721,7 +1722,7 @@ var _ = Describe("SHA256 Verification", func() {
 
 		var hasIntegrity bool
 		for _, cond := range updated.Status.Conditions {
-			if cond.Type == ConditionDegraded && cond.Reason == "IntegrityCheckFailed" {
+			if cond.Type == ConditionDegraded && cond.Reason == ReasonChecksumMismatch {
 				hasIntegrity = true
 			}
 		}
@@ -2067,6 +2068,112 @@ var _ = Describe("Model Controller remote GGUF metadata (#728, Task 2)", func()
 		Expect(bytesServed).To(BeNumerically("<", int64(len(ggufBytes))/2))
 	})
 
+	It("fails a gguf Model whose source serves a non-GGUF page with InvalidGGUF", func() {
+		tempDir, err := os.MkdirTemp("", "llmkube-invalid-gguf-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = os.RemoveAll(tempDir) }()
+
+		// A 404 page served with 200, as a misconfigured mirror might.
+		page := []byte("<!DOCTYPE html><html><body>Not Found</body></html>")
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "model.gguf", time.Time{}, bytes.NewReader(page))
+		}))
+		defer srv.Close()
+
+		modelName := "remote-invalid-gguf"
+		model := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: modelName, Namespace: "default"},
+			Spec:       inferencev1alpha1.ModelSpec{Source: srv.URL + "/model.gguf", Format: "gguf"},
+		}
+		Expect(k8sClient.Create(ctx, model)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, model) }()
+
+		reconciler := &ModelReconciler{
+			Client:               k8sClient,
+			Scheme:               k8sClient.Scheme(),
+			StoragePath:          tempDir,
+			AllowedHostPathRoots: testLocalRoots,
+			AllowedRemoteHosts:   testRemoteHosts,
+		}
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: modelName, Namespace: "default"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+		updated := &inferencev1alpha1.Model{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: modelName, Namespace: "default"}, updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(PhaseFailed))
+		Expect(updated.Status.GGUF).To(BeNil())
+
+		var degraded *metav1.Condition
+		for i := range updated.Status.Conditions {
+			if updated.Status.Conditions[i].Type == ConditionDegraded {
+				degraded = &updated.Status.Conditions[i]
+			}
+		}
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(ReasonInvalidGGUF))
+		Expect(degraded.Message).To(ContainSubstring("invalid GGUF magic number"))
+	})
+
+	It("warns with FitsNode=False when a Model's estimated memory exceeds the largest node", func() {
+		tempDir, err := os.MkdirTemp("", "llmkube-too-large-*")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = os.RemoveAll(tempDir) }()
+
+		// A small cluster: one CPU node with 256Mi allocatable, less than
+		// the fixed serving overhead alone.
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "small-node"}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, node) }()
+		node.Status.Allocatable = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}
+		node.Status.Capacity = node.Status.Allocatable
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		ggufBytes := buildTestGGUF("llama", "too-large-model", 24, 1<<20)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "model.gguf", time.Time{}, bytes.NewReader(ggufBytes))
+		}))
+		defer srv.Close()
+
+		modelName := "remote-too-large"
+		model := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: modelName, Namespace: "default"},
+			Spec:       inferencev1alpha1.ModelSpec{Source: srv.URL + "/model.gguf", Format: "gguf"},
+		}
+		Expect(k8sClient.Create(ctx, model)).To(Succeed())
+		defer func() { _ = k8sClient.Delete(ctx, model) }()
+
+		reconciler := &ModelReconciler{
+			Client:               k8sClient,
+			Scheme:               k8sClient.Scheme(),
+			StoragePath:          tempDir,
+			AllowedHostPathRoots: testLocalRoots,
+			AllowedRemoteHosts:   testRemoteHosts,
+		}
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: modelName, Namespace: "default"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		updated := &inferencev1alpha1.Model{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: modelName, Namespace: "default"}, updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(PhaseReady))
+		Expect(updated.Status.EstimatedMemory).To(Equal(formatBytes(int64(len(ggufBytes)) + modelServingOverheadBytes)))
+		Expect(meta.FindStatusCondition(updated.Status.Conditions, ConditionDegraded)).To(BeNil())
+
+		fits := meta.FindStatusCondition(updated.Status.Conditions, ConditionFitsNode)
+		Expect(fits).NotTo(BeNil())
+		Expect(fits.Status).To(Equal(metav1.ConditionFalse))
+		Expect(fits.Reason).To(Equal(ReasonModelTooLarge))
+		Expect(fits.Message).To(ContainSubstring(updated.Status.EstimatedMemory))
+		Expect(fits.Message).To(ContainSubstring("small-node"))
+		Expect(fits.Message).To(ContainSubstring("256.0 MiB of allocatable memory"))
+	})
+
 	It("refuses to probe private-range sources when no remote host is allowlisted (SSRF guard)", func() {
 		tempDir, err := os.MkdirTemp("", "llmkube-ssrf-guard-*")
 		Expect(err).NotTo(HaveOccurred())
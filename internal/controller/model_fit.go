@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// ConditionFitsNode reports whether a Model's estimated memory fits on the
+// largest node in the cluster. False is a warning, not a failure: the Model
+// still becomes Ready, since the estimate cannot account for everything a
+// runtime can do to squeeze a model in.
+const ConditionFitsNode = "FitsNode"
+
+// ReasonModelTooLarge is the FitsNode=False reason set when a Model's
+// estimated memory exceeds what the largest node in the cluster can give one
+// pod. Older releases set it on a Degraded condition and failed the Model.
+const ReasonModelTooLarge = "ModelTooLarge"
+
+// gpuMemoryLabel is the per-device memory, in MiB, that NVIDIA GPU Feature
+// Discovery labels GPU nodes with.
+const gpuMemoryLabel = "nvidia.com/gpu.memory"
+
+// modelServingOverheadBytes is the fixed runtime overhead (compute buffers,
+// CUDA context) added to the weights, matching the metal-agent estimate.
+const modelServingOverheadBytes = int64(512 << 20)
+
+// estimateModelMemoryBytes estimates the memory a GGUF model needs to serve:
+// its weights plus a fixed overhead. The KV cache is left out because its
+// size depends on each InferenceService's context length, so the estimate is
+// a lower bound and exceeding a node with it is conclusive. Returns 0 when
+// the model is not a parsed GGUF or its size is unknown.
+func estimateModelMemoryBytes(model *inferencev1alpha1.Model, weightsBytes int64) int64 {
+	if model.Status.GGUF == nil || weightsBytes <= 0 {
+		return 0
+	}
+	return weightsBytes + modelServingOverheadBytes
+}
+
+// largestNodeMemory returns the most memory any single node can give one pod
+// of the model and that node's name: GPU memory (per-device memory times the
+// devices the pod requests) for a GPU model, allocatable memory for a CPU
+// model. ok=false means the capacity is unknown and the fit check must be
+// skipped: no matching nodes, a GPU node without the memory label, or a
+// model whose placement the operator cannot see (metal, DRA claims).
+func largestNodeMemory(nodes []corev1.Node, model *inferencev1alpha1.Model) (bytes int64, nodeName string, ok bool) {
+	if isMetalModel(model) {
+		return 0, "", false
+	}
+	if hw := model.Spec.Hardware; hw != nil && hw.GPU != nil && len(hw.GPU.ResourceClaims) > 0 {
+		return 0, "", false
+	}
+
+	gpus := int64(resolveGPUCount(nil, model))
+	if gpus == 0 {
+		for i := range nodes {
+			if q, found := nodes[i].Status.Allocatable[corev1.ResourceMemory]; found && q.Value() > bytes {
+				bytes, nodeName = q.Value(), nodes[i].Name
+			}
+		}
+		return bytes, nodeName, bytes > 0
+	}
+
+	res := resolveGPUResourceName(model)
+	if override := strings.TrimSpace(model.Spec.Hardware.GPU.ResourceName); override != "" {
+		res = corev1.ResourceName(override)
+	}
+	for i := range nodes {
+		q, found := nodes[i].Status.Allocatable[res]
+		if !found || q.IsZero() {
+			continue
+		}
+		perDeviceMiB, err := strconv.ParseInt(nodes[i].Labels[gpuMemoryLabel], 10, 64)
+		if err != nil || perDeviceMiB <= 0 {
+			return 0, "", false
+		}
+		mem := min(gpus, q.Value()) * perDeviceMiB << 20
+		if mem > bytes {
+			bytes, nodeName = mem, nodes[i].Name
+		}
+	}
+	return bytes, nodeName, bytes > 0
+}
+
+// isModelTooLarge reports whether an earlier fit check found the Model too
+// large for any node.
+func isModelTooLarge(model *inferencev1alpha1.Model) bool {
+	c := meta.FindStatusCondition(model.Status.Conditions, ConditionFitsNode)
+	return c != nil && c.Status == metav1.ConditionFalse
+}
+
+// cpuOffloadConfigured reports why part of the model may be served from host
+// memory, which the weights-versus-largest-node estimate cannot account for:
+// a partial or automatic spec.hardware.gpu.layers on the Model, or MoE or
+// weight offload on an InferenceService that references it. Empty means no
+// offload. A failed InferenceService list counts as offload, keeping the
+// check fail-open.
+func (r *ModelReconciler) cpuOffloadConfigured(ctx context.Context, model *inferencev1alpha1.Model) string {
+	if hw := model.Spec.Hardware; hw != nil && hw.GPU != nil && hw.GPU.Layers != 0 {
+		blocks := uint64(0)
+		if model.Status.GGUF != nil {
+			blocks = model.Status.GGUF.LayerCount
+		}
+		if hw.GPU.Layers < 0 || blocks == 0 || uint64(hw.GPU.Layers) < blocks {
+			return "spec.hardware.gpu.layers"
+		}
+	}
+
+	var services inferencev1alpha1.InferenceServiceList
+	if err := r.List(ctx, &services, client.InNamespace(model.Namespace)); err != nil {
+		return "unknown InferenceService settings"
+	}
+	for i := range services.Items {
+		spec := &services.Items[i].Spec
+		if spec.ModelRef != model.Name {
+			continue
+		}
+		switch {
+		case spec.MoeCPUOffload != nil && *spec.MoeCPUOffload:
+			return "moeCPUOffload on InferenceService " + services.Items[i].Name
+		case spec.MoeCPULayers != nil && *spec.MoeCPULayers > 0:
+			return "moeCPULayers on InferenceService " + services.Items[i].Name
+		case spec.PersonaPlexConfig != nil && spec.PersonaPlexConfig.CPUOffload != nil && *spec.PersonaPlexConfig.CPUOffload:
+			return "cpuOffload on InferenceService " + services.Items[i].Name
+		}
+	}
+	return ""
+}
+
+// checkModelFits records status.estimatedMemory and sets the FitsNode
+// condition in memory, for the caller's next status write. When the estimate
+// does not fit on the largest node, FitsNode=False carries the required and
+// available figures, so a likely Pending pod is explained on the Model. The
+// check is skipped, and the condition dropped, when the capacity is unknown
+// (including a node-list error) or any CPU offload is configured.
+func (r *ModelReconciler) checkModelFits(ctx context.Context, model *inferencev1alpha1.Model, weightsBytes int64) {
+	logger := log.FromContext(ctx)
+
+	// Releases that failed a too-large Model left a Degraded condition.
+	if c := meta.FindStatusCondition(model.Status.Conditions, ConditionDegraded); c != nil && c.Reason == ReasonModelTooLarge {
+		meta.RemoveStatusCondition(&model.Status.Conditions, ConditionDegraded)
+	}
+
+	required := estimateModelMemoryBytes(model, weightsBytes)
+	if required == 0 {
+		return
+	}
+	model.Status.EstimatedMemory = formatBytes(required)
+
+	if offload := r.cpuOffloadConfigured(ctx, model); offload != "" {
+		logger.V(1).Info("Skipping model fit check: CPU offload configured", "via", offload)
+		meta.RemoveStatusCondition(&model.Status.Conditions, ConditionFitsNode)
+		return
+	}
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		logger.V(1).Info("Skipping model fit check: listing nodes failed", "error", err)
+		meta.RemoveStatusCondition(&model.Status.Conditions, ConditionFitsNode)
+		return
+	}
+	available, nodeName, ok := largestNodeMemory(nodes.Items, model)
+	if !ok {
+		meta.RemoveStatusCondition(&model.Status.Conditions, ConditionFitsNode)
+		return
+	}
+	if required <= available {
+		meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
+			Type:               ConditionFitsNode,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: model.Generation,
+			Reason:             "Fits",
+			Message:            fmt.Sprintf("estimated %s fits on node %s", formatBytes(required), nodeName),
+		})
+		return
+	}
+
+	kind := "allocatable memory"
+	if resolveGPUCount(nil, model) > 0 {
+		kind = "GPU memory"
+	}
+	msg := fmt.Sprintf("model needs an estimated %s but the largest node (%s) offers %s of %s",
+		formatBytes(required), nodeName, formatBytes(available), kind)
+	logger.Info("Model does not fit on any node", "required", required, "available", available, "node", nodeName)
+	meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
+		Type:               ConditionFitsNode,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: model.Generation,
+		Reason:             ReasonModelTooLarge,
+		Message:            msg,
+	})
+}
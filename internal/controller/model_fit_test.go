@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestLargestNodeMemory(t *testing.T) {
+	cpuNode := func(name, mem string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Allocatable: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(mem)}},
+		}
+	}
+	gpuNode := func(name string, gpus int64, memLabel string) corev1.Node {
+		n := cpuNode(name, "256Gi")
+		n.Status.Allocatable[nvidiaGPUResourceName] = *resource.NewQuantity(gpus, resource.DecimalSI)
+		if memLabel != "" {
+			n.Labels = map[string]string{gpuMemoryLabel: memLabel}
+		}
+		return n
+	}
+	gpuModel := func(count int32) *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{Spec: inferencev1alpha1.ModelSpec{Hardware: &inferencev1alpha1.HardwareSpec{
+			Accelerator: acceleratorCUDA,
+			GPU:         &inferencev1alpha1.GPUSpec{Enabled: true, Count: count},
+		}}}
+	}
+	cpuModel := &inferencev1alpha1.Model{}
+
+	tests := []struct {
+		name     string
+		nodes    []corev1.Node
+		model    *inferencev1alpha1.Model
+		want     int64
+		wantNode string
+		wantOK   bool
+	}{
+		{"CPU model takes the largest allocatable memory", []corev1.Node{cpuNode("a", "8Gi"), cpuNode("b", "16Gi")}, cpuModel, 16 << 30, "b", true},
+		{"GPU model counts only the devices it requests", []corev1.Node{gpuNode("g", 8, "24576")}, gpuModel(2), 48 << 30, "g", true},
+		{"GPU model is capped by the devices a node has", []corev1.Node{gpuNode("g", 1, "24576")}, gpuModel(4), 24 << 30, "g", true},
+		{"GPU node without the memory label is unknown", []corev1.Node{gpuNode("g", 1, "")}, gpuModel(1), 0, "", false},
+		{"no GPU nodes is unknown", []corev1.Node{cpuNode("a", "8Gi")}, gpuModel(1), 0, "", false},
+		{"no nodes is unknown", nil, cpuModel, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, node, ok := largestNodeMemory(tt.nodes, tt.model)
+			if got != tt.want || node != tt.wantNode || ok != tt.wantOK {
+				t.Errorf("largestNodeMemory() = (%d, %q, %v), want (%d, %q, %v)", got, node, ok, tt.want, tt.wantNode, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCheckModelFits(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	smallNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "small"},
+		Status:     corev1.NodeStatus{Allocatable: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+	}
+	newModel := func() *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "default"},
+			Status: inferencev1alpha1.ModelStatus{
+				Phase: PhaseReady,
+				GGUF:  &inferencev1alpha1.GGUFMetadata{LayerCount: 32},
+				Conditions: []metav1.Condition{{
+					Type: ConditionDegraded, Status: metav1.ConditionTrue, Reason: ReasonModelTooLarge,
+				}},
+			},
+		}
+	}
+	offloading := func(spec inferencev1alpha1.InferenceServiceSpec) *inferencev1alpha1.InferenceService {
+		spec.ModelRef = "big"
+		return &inferencev1alpha1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"}, Spec: spec}
+	}
+	on, layers := true, int32(8)
+
+	tests := []struct {
+		name    string
+		layers  int32
+		objs    []client.Object
+		wantFit *metav1.ConditionStatus
+	}{
+		{name: "too large warns without failing", wantFit: ptr.To(metav1.ConditionFalse)},
+		{name: "partial GPU layers skip the check", layers: 16},
+		{name: "automatic GPU layers skip the check", layers: -1},
+		{name: "full GPU layers keep the check", layers: 32, wantFit: ptr.To(metav1.ConditionFalse)},
+		{name: "moeCPUOffload skips the check", objs: []client.Object{offloading(inferencev1alpha1.InferenceServiceSpec{MoeCPUOffload: &on})}},
+		{name: "moeCPULayers skips the check", objs: []client.Object{offloading(inferencev1alpha1.InferenceServiceSpec{MoeCPULayers: &layers})}},
+		{name: "cpuOffload skips the check", objs: []client.Object{offloading(inferencev1alpha1.InferenceServiceSpec{
+			PersonaPlexConfig: &inferencev1alpha1.PersonaPlexConfig{CPUOffload: &on},
+		})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := newModel()
+			if tt.layers != 0 {
+				model.Spec.Hardware = &inferencev1alpha1.HardwareSpec{GPU: &inferencev1alpha1.GPUSpec{Layers: tt.layers}}
+			}
+			r := &ModelReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.objs, smallNode)...).Build(),
+			}
+			r.checkModelFits(context.Background(), model, 4<<30)
+
+			if model.Status.Phase != PhaseReady {
+				t.Errorf("phase = %q, want the Model left %q", model.Status.Phase, PhaseReady)
+			}
+			if meta.FindStatusCondition(model.Status.Conditions, ConditionDegraded) != nil {
+				t.Error("expected the legacy Degraded ModelTooLarge condition to be dropped")
+			}
+			fit := meta.FindStatusCondition(model.Status.Conditions, ConditionFitsNode)
+			switch {
+			case tt.wantFit == nil && fit != nil:
+				t.Errorf("expected no FitsNode condition, got %+v", fit)
+			case tt.wantFit != nil && (fit == nil || fit.Status != *tt.wantFit):
+				t.Errorf("FitsNode = %+v, want status %s", fit, *tt.wantFit)
+			}
+		})
+	}
+}
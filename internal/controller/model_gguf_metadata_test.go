@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildGGUFWithTensors is buildTestGGUF plus the hyperparameters the Model
+// status surfaces and two tensor infos, so ParameterCount is non-zero.
+func buildGGUFWithTensors() []byte {
+	buf := &bytes.Buffer{}
+	wLE := func(v any) { _ = binary.Write(buf, binary.LittleEndian, v) }
+	wStr := func(s string) {
+		wLE(uint64(len(s)))
+		buf.WriteString(s)
+	}
+	wKVString := func(k, v string) {
+		wStr(k)
+		wLE(uint32(8))
+		wStr(v)
+	}
+	wKVU32 := func(k string, v uint32) {
+		wStr(k)
+		wLE(uint32(4))
+		wLE(v)
+	}
+	wTensor := func(name string, dims ...uint64) {
+		wStr(name)
+		wLE(uint32(len(dims)))
+		for _, d := range dims {
+			wLE(d)
+		}
+		wLE(uint32(0)) // F32
+		wLE(uint64(0)) // offset
+	}
+
+	wLE(uint32(0x46554747))
+	wLE(uint32(3))
+	wLE(uint64(2)) // tensor count
+	wLE(uint64(6)) // metadata kv count
+	wKVString("general.architecture", "llama")
+	wKVString("general.name", "status-test")
+	wKVU32("general.file_type", 15) // Q4_K_M
+	wKVU32("llama.context_length", 131072)
+	wKVU32("llama.embedding_length", 4096)
+	wKVU32("llama.block_count", 32)
+	wTensor("token_embd.weight", 4096, 32000)
+	wTensor("output_norm.weight", 4096)
+	return buf.Bytes()
+}
+
+func TestParseGGUFMetadataPopulatesStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.gguf")
+	if err := os.WriteFile(path, buildGGUFWithTensors(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := (&ModelReconciler{}).parseGGUFMetadata(path)
+	if err != nil {
+		t.Fatalf("parseGGUFMetadata: %v", err)
+	}
+	if meta.Architecture != "llama" {
+		t.Errorf("Architecture = %q, want llama", meta.Architecture)
+	}
+	if meta.Quantization != "Q4_K_M" {
+		t.Errorf("Quantization = %q, want Q4_K_M", meta.Quantization)
+	}
+	if meta.ContextLength != 131072 {
+		t.Errorf("ContextLength = %d, want 131072", meta.ContextLength)
+	}
+	if meta.EmbeddingSize != 4096 {
+		t.Errorf("EmbeddingSize = %d, want 4096", meta.EmbeddingSize)
+	}
+	if meta.LayerCount != 32 {
+		t.Errorf("LayerCount = %d, want 32", meta.LayerCount)
+	}
+	if want := uint64(4096*32000 + 4096); meta.ParameterCount != want {
+		t.Errorf("ParameterCount = %d, want %d", meta.ParameterCount, want)
+	}
+	if meta.TensorCount != 2 {
+		t.Errorf("TensorCount = %d, want 2", meta.TensorCount)
+	}
+}
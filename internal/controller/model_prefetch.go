@@ -214,7 +214,7 @@ func (r *ModelReconciler) buildPrefetchJob(model *inferencev1alpha1.Model) (*bat
 		image = defaultPrefetchImage
 	}
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      prefetchJobName(model),
 			Namespace: model.Namespace,
@@ -248,7 +248,9 @@ func (r *ModelReconciler) buildPrefetchJob(model *inferencev1alpha1.Model) (*bat
 				},
 			},
 		},
-	}, nil
+	}
+	applyImagePullPolicy(&job.Spec.Template.Spec, r.DefaultImagePullPolicy)
+	return job, nil
 }
 
 // jobSucceeded / jobFailed read the Job's terminal conditions.
@@ -96,7 +96,7 @@ func (r *ModelReconciler) revalidateSource(
 		return revalidateSkipped
 	}
 
-	fp, ok := r.probeSource(ctx, model.Spec.Source)
+	fp, ok := r.probeSource(ctx, model)
 	if !ok {
 		// Cannot determine upstream state. Keep serving the cache, do not flag
 		// drift. Do not advance LastRevalidated: a transient failure should not
@@ -143,10 +143,16 @@ func fingerprintMatches(status inferencev1alpha1.ModelStatus, fp sourceFingerpri
 // probeSource fetches an upstream fingerprint. It returns ok=false when the
 // fingerprint cannot be determined (the caller treats this as "keep the
 // cache"). HTTP sources are probed with HEAD; local sources are stat'd.
-func (r *ModelReconciler) probeSource(ctx context.Context, source string) (sourceFingerprint, bool) {
+func (r *ModelReconciler) probeSource(ctx context.Context, model *inferencev1alpha1.Model) (sourceFingerprint, bool) {
+	source := model.Spec.Source
 	switch {
 	case isRemoteHTTPSource(source):
-		return r.probeHTTPSource(ctx, source)
+		client, err := r.sourceHTTPClient(ctx, model)
+		if err != nil {
+			log.FromContext(ctx).Info("Failed to resolve source credentials for revalidation (non-fatal)", "source", source, "error", err.Error())
+			return sourceFingerprint{}, false
+		}
+		return probeHTTPSource(ctx, client, source)
 	case isLocalSource(source):
 		return probeLocalSource(source)
 	default:
@@ -155,7 +161,7 @@ func (r *ModelReconciler) probeSource(ctx context.Context, source string) (sourc
 	}
 }
 
-func (r *ModelReconciler) probeHTTPSource(ctx context.Context, source string) (sourceFingerprint, bool) {
+func probeHTTPSource(ctx context.Context, client *http.Client, source string) (sourceFingerprint, bool) {
 	logger := log.FromContext(ctx)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodHead, source, nil)
@@ -164,7 +170,7 @@ func (r *ModelReconciler) probeHTTPSource(ctx context.Context, source string) (s
 		return sourceFingerprint{}, false
 	}
 
-	resp, err := r.metadataClient().Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		logger.Info("HEAD request failed for revalidation (non-fatal)", "source", source, "error", err.Error())
 		return sourceFingerprint{}, false
@@ -206,7 +212,7 @@ func (r *ModelReconciler) probeHTTPSource(ctx context.Context, source string) (s
 // revalidation has a current baseline. A failed probe leaves the previous
 // values untouched and is non-fatal.
 func (r *ModelReconciler) recordSourceFingerprint(ctx context.Context, model *inferencev1alpha1.Model) {
-	fp, ok := r.probeSource(ctx, model.Spec.Source)
+	fp, ok := r.probeSource(ctx, model)
 	if !ok {
 		return
 	}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// hfTokenSecretKey is the sourceSecretRef key holding a Hugging Face access
+// token. The downloader sees it as $HF_TOKEN through modelEnvFrom.
+const hfTokenSecretKey = "HF_TOKEN"
+
+// ReasonSourceSecretNotFound is the Degraded reason set when a Model's
+// sourceSecretRef names a Secret that does not exist.
+const ReasonSourceSecretNotFound = "SourceSecretNotFound"
+
+// sourceToken returns the HF_TOKEN value from the Model's sourceSecretRef, or
+// "" when no ref is set or the Secret has no such key (S3-only credentials).
+// A missing Secret is returned as the Get error so callers can surface it.
+func (r *ModelReconciler) sourceToken(ctx context.Context, model *inferencev1alpha1.Model) (string, error) {
+	if model.Spec.SourceSecretRef == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: model.Spec.SourceSecretRef.Name, Namespace: model.Namespace}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return "", err
+	}
+	return string(secret.Data[hfTokenSecretKey]), nil
+}
+
+// sourceHTTPClient returns the client for controller-side requests to
+// Model.spec.source. With an HF_TOKEN it wraps the SSRF-guarded client so the
+// bearer token is sent to the source host only; redirects to another host
+// (the Hugging Face CDN) go out without it, matching the init container's curl.
+func (r *ModelReconciler) sourceHTTPClient(ctx context.Context, model *inferencev1alpha1.Model) (*http.Client, error) {
+	token, err := r.sourceToken(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	client := r.metadataClient()
+	if token == "" {
+		return client, nil
+	}
+	u, err := url.Parse(model.Spec.Source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source URL: %w", err)
+	}
+	authed := *client
+	authed.Transport = &bearerTokenTransport{base: client.Transport, host: u.Host, token: token}
+	return &authed, nil
+}
+
+// bearerTokenTransport adds an Authorization header to requests for host.
+type bearerTokenTransport struct {
+	base  http.RoundTripper
+	host  string
+	token string
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.URL.Host != t.host {
+		return base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return base.RoundTrip(req)
+}
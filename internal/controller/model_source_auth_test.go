@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestBearerTokenTransportScopesToSourceHost(t *testing.T) {
+	var gotSource, gotOther string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotOther = r.Header.Get("Authorization")
+	}))
+	defer other.Close()
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSource = r.Header.Get("Authorization")
+		http.Redirect(w, r, other.URL+"/blob", http.StatusFound)
+	}))
+	defer source.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, source.URL+"/model.gguf", nil)
+	client := &http.Client{Transport: &bearerTokenTransport{host: req.URL.Host, token: "hf_secret"}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if gotSource != "Bearer hf_secret" {
+		t.Errorf("source host Authorization = %q, want bearer token", gotSource)
+	}
+	// httptest servers share 127.0.0.1 but differ by port, so Host differs.
+	if gotOther != "" {
+		t.Errorf("redirect target received Authorization %q, want none", gotOther)
+	}
+}
+
+func TestReconcileRuntimeResolvedSourceMissingSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "gated", Namespace: "default"},
+		Spec: inferencev1alpha1.ModelSpec{
+			Source:          "hf://meta-llama/Llama-3.1-8B-Instruct",
+			SourceSecretRef: &corev1.LocalObjectReference{Name: "hf-token"},
+		},
+	}
+	r := &ModelReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(model).WithStatusSubresource(model).Build(),
+		Scheme: scheme,
+	}
+
+	result, err := r.reconcileRuntimeResolvedSource(context.Background(), model, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Error("expected a requeue so the Model recovers once the Secret exists")
+	}
+	if model.Status.Phase != PhaseFailed {
+		t.Errorf("phase = %q, want %q", model.Status.Phase, PhaseFailed)
+	}
+	cond := meta.FindStatusCondition(model.Status.Conditions, ConditionDegraded)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ReasonSourceSecretNotFound {
+		t.Fatalf("expected Degraded/%s condition, got %+v", ReasonSourceSecretNotFound, cond)
+	}
+}
+
+func TestSourceTokenReadsHFTokenKey(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "default"},
+		Data:       map[string][]byte{hfTokenSecretKey: []byte("hf_abc")},
+	}
+	r := &ModelReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()}
+
+	model := &inferencev1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	if tok, err := r.sourceToken(context.Background(), model); err != nil || tok != "" {
+		t.Errorf("no ref: got (%q, %v), want empty token", tok, err)
+	}
+
+	model.Spec.SourceSecretRef = &corev1.LocalObjectReference{Name: "hf-token"}
+	if tok, err := r.sourceToken(context.Background(), model); err != nil || tok != "hf_abc" {
+		t.Errorf("with ref: got (%q, %v), want hf_abc", tok, err)
+	}
+}
+
+func TestObjectStorageSourcesAreWorkloadResolved(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	for source, wantType := range map[string]string{
+		"s3://models/llama.gguf": SourceTypeS3,
+		"gs://models/llama.gguf": SourceTypeGCS,
+	} {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bucket-creds", Namespace: "default"},
+			Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("id"), "AWS_SECRET_ACCESS_KEY": []byte("key")},
+		}
+		model := &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "bucket", Namespace: "default"},
+			Spec: inferencev1alpha1.ModelSpec{
+				Source:          source,
+				SourceSecretRef: &corev1.LocalObjectReference{Name: "bucket-creds"},
+			},
+		}
+		r := &ModelReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(model, secret).WithStatusSubresource(model).Build(),
+			Scheme: scheme,
+		}
+
+		handled, _, err := r.reconcileBySourceType(context.Background(), model)
+		if err != nil || !handled {
+			t.Fatalf("%s: handled=%v err=%v, want handled without error", source, handled, err)
+		}
+		if model.Status.Phase != PhaseReady {
+			t.Errorf("%s: phase = %q, want %q", source, model.Status.Phase, PhaseReady)
+		}
+		if model.Status.CacheKey != computeCacheKey(source) {
+			t.Errorf("%s: cacheKey = %q, want %q", source, model.Status.CacheKey, computeCacheKey(source))
+		}
+		if model.Status.SourceType != wantType {
+			t.Errorf("%s: sourceType = %q, want %q", source, model.Status.SourceType, wantType)
+		}
+	}
+}
@@ -19,6 +19,8 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -32,6 +34,7 @@ import (
 
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 	"github.com/defilantech/llmkube/pkg/cachekey"
+	"github.com/defilantech/llmkube/pkg/gguf"
 )
 
 // Model storage wiring. The controller has three paths for making a model
@@ -164,30 +167,82 @@ func addCACertVolume(volumes *[]corev1.Volume, mounts *[]corev1.VolumeMount, cmd
 	*cmd = fmt.Sprintf("export CURL_CA_BUNDLE=/custom-certs/$(ls /custom-certs | grep -v '^\\.' | head -n 1) && %s", *cmd)
 }
 
+// hfAuthHeaderArg expands to a bearer Authorization header when the model's
+// sourceSecretRef Secret carries an HF_TOKEN key (wired in via modelEnvFrom),
+// and to nothing otherwise, so public sources are fetched exactly as before.
+// curl drops the header when -L follows a redirect to another host, so the
+// token is not forwarded to the Hugging Face CDN.
+const hfAuthHeaderArg = `${HF_TOKEN:+-H "Authorization: Bearer $HF_TOKEN"}`
+
+// curlRetryArgs retries a dropped or refused connection instead of failing
+// the init container (and crash-looping the pod) on the first network blip.
+// --retry-all-errors also covers a connection reset mid-transfer and HTTP
+// errors such as a CDN 5xx, which plain --retry gives up on.
+const curlRetryArgs = `--retry 5 --retry-delay 10 --retry-connrefused --retry-all-errors`
+
+// httpDownloadCmd fetches $MODEL_SOURCE into "$MODEL_PATH.partial", resuming
+// from whatever an earlier attempt left there (-C -), and renames it into
+// place only once curl succeeds. The cache checks test for $MODEL_PATH, so a
+// truncated download is never mistaken for a cached model.
+const httpDownloadCmd = `curl -f -L ` + curlRetryArgs + ` -C - -o "$MODEL_PATH.partial" "$MODEL_SOURCE" ` + hfAuthHeaderArg +
+	` && mv "$MODEL_PATH.partial" "$MODEL_PATH"`
+
+// s3DownloadCmd is the SigV4-signed S3 fetch. It retries and renames into
+// place like httpDownloadCmd but does not resume: each attempt rewrites the
+// partial file from the start.
+const s3DownloadCmd = `curl --aws-sigv4 "aws:amz:${AWS_REGION}:s3" -u "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" -f -L ` + curlRetryArgs +
+	` -o "$MODEL_PATH.partial" "${AWS_ENDPOINT_URL}/${S3_BUCKET}/${S3_KEY}" && mv "$MODEL_PATH.partial" "$MODEL_PATH"`
+
 func buildModelInitCommand(isLocal, isS3, useCache bool, refreshPolicy string) string {
 	if useCache {
 		if isLocal {
 			return `mkdir -p "$CACHE_DIR" && if [ ! -f "$MODEL_PATH" ]; then echo 'Copying model from local source...'; cp /host-model/model.gguf "$MODEL_PATH" && echo 'Model copied successfully'; else echo 'Model already cached, skipping copy'; fi`
 		}
 		if isS3 {
-			return `mkdir -p "$CACHE_DIR" && if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model from S3...'; curl --aws-sigv4 "aws:amz:${AWS_REGION}:s3" -u "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" -f -L -o "$MODEL_PATH" "${AWS_ENDPOINT_URL}/${S3_BUCKET}/${S3_KEY}" && echo 'Model downloaded successfully'; else echo 'Model already cached, skipping download'; fi`
+			return `mkdir -p "$CACHE_DIR" && if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model from S3...'; ` + s3DownloadCmd + ` && echo 'Model downloaded successfully'; else echo 'Model already cached, skipping download'; fi`
 		}
 		if refreshPolicy == RefreshPolicyOnChange {
 			return "mkdir -p \"$CACHE_DIR\" && " + remoteRevalidateScript
 		}
-		return `mkdir -p "$CACHE_DIR" && if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model...'; curl -f -L -o "$MODEL_PATH" "$MODEL_SOURCE" && echo 'Model downloaded successfully'; else echo 'Model already cached, skipping download'; fi`
+		return `mkdir -p "$CACHE_DIR" && if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model...'; ` + httpDownloadCmd + ` && echo 'Model downloaded successfully'; else echo 'Model already cached, skipping download'; fi`
 	}
 
 	if isLocal {
 		return `echo 'ERROR: Local model source requires model cache to be configured.'; exit 1`
 	}
 	if isS3 {
-		return `if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model from S3...'; curl --aws-sigv4 "aws:amz:${AWS_REGION}:s3" -u "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" -f -L -o "$MODEL_PATH" "${AWS_ENDPOINT_URL}/${S3_BUCKET}/${S3_KEY}" && echo 'Model downloaded successfully'; else echo 'Model already exists, skipping download'; fi`
+		return `if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model from S3...'; ` + s3DownloadCmd + ` && echo 'Model downloaded successfully'; else echo 'Model already exists, skipping download'; fi`
 	}
 	if refreshPolicy == RefreshPolicyOnChange {
 		return remoteRevalidateScript
 	}
-	return `if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model...'; curl -f -L -o "$MODEL_PATH" "$MODEL_SOURCE" && echo 'Model downloaded successfully'; else echo 'Model already exists, skipping download'; fi`
+	return `if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model...'; ` + httpDownloadCmd + ` && echo 'Model downloaded successfully'; else echo 'Model already exists, skipping download'; fi`
+}
+
+// gcsDownloadCmd fetches gs://$GCS_BUCKET/$GCS_OBJECT through the GCS XML
+// API, which accepts AWS SigV4 requests signed with a GCS HMAC key. This keeps
+// GCS on the same curl image as every other source instead of pulling the
+// multi-GB cloud-sdk image into each pod start. Region "auto" is what GCS
+// expects in the signature scope.
+const gcsDownloadCmd = `curl --aws-sigv4 "aws:amz:${AWS_REGION:-auto}:s3" -u "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" -f -L ` + curlRetryArgs +
+	` -o "$MODEL_PATH.partial" "${AWS_ENDPOINT_URL:-https://storage.googleapis.com}/${GCS_BUCKET}/${GCS_OBJECT}" && mv "$MODEL_PATH.partial" "$MODEL_PATH"`
+
+// buildGCSInitCommand is the gs:// counterpart of the S3 branch of
+// buildModelInitCommand. Like S3 it ignores RefreshPolicy: a cached object is
+// kept until the cache entry is removed.
+func buildGCSInitCommand(useCache bool) string {
+	if useCache {
+		return `mkdir -p "$CACHE_DIR" && if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model from GCS...'; ` + gcsDownloadCmd + ` && echo 'Model downloaded successfully'; else echo 'Model already cached, skipping download'; fi`
+	}
+	return `if [ ! -f "$MODEL_PATH" ]; then echo 'Downloading model from GCS...'; ` + gcsDownloadCmd + ` && echo 'Model downloaded successfully'; else echo 'Model already exists, skipping download'; fi`
+}
+
+// singleFileInitCommand picks the downloader script for a single-file source.
+func singleFileInitCommand(model *inferencev1alpha1.Model, useCache bool) string {
+	if isGCSSource(model.Spec.Source) {
+		return buildGCSInitCommand(useCache)
+	}
+	return buildModelInitCommand(isLocalModelSource(model.Spec.Source), isS3Source(model.Spec.Source), useCache, model.Spec.RefreshPolicy)
 }
 
 // remoteRevalidateScript implements RefreshPolicy=OnChange for http/https
@@ -199,23 +254,54 @@ func buildModelInitCommand(isLocal, isS3, useCache bool, refreshPolicy string) s
 //
 // Robustness: the init container gates pod startup, so a transient network
 // failure (air-gapped, upstream 5xx, DNS) must not take down an
-// InferenceService on pod restart. If revalidation fails but a cached copy
-// already exists, the script logs and exits 0, keeping the cached file. Only a
-// genuinely-missing file (nothing cached and the fetch failed) fails the init
-// container.
+// InferenceService on pod restart. curl retries like the IfNotPresent
+// download, and a changed file lands in "$MODEL_PATH.partial" first, so an
+// interrupted transfer never replaces the cached copy. The new ETag is only
+// recorded once the file is in place; a marker saved before a failed transfer
+// would make the next attempt see a 304 and keep the stale copy. If
+// revalidation fails but a cached copy already exists, the script logs and
+// exits 0, keeping the cached file. Only a genuinely-missing file (nothing
+// cached and the fetch failed) fails the init container.
 //
 // curlimages/curl 8.x supports --etag-compare/--etag-save (added in curl
 // 7.68.0), so no HEAD-compare fallback is needed for the default image.
 const remoteRevalidateScript = `ETAG_MARKER="$(dirname "$MODEL_PATH")/.$(basename "$MODEL_PATH").etag"; ` +
 	`echo 'Revalidating model against upstream (RefreshPolicy=OnChange)...'; ` +
-	`if curl -fsSL --etag-compare "$ETAG_MARKER" --etag-save "$ETAG_MARKER" -o "$MODEL_PATH" "$MODEL_SOURCE"; then ` +
+	`[ -f "$MODEL_PATH" ] || rm -f "$ETAG_MARKER"; ` +
+	`rm -f "$MODEL_PATH.partial" "$ETAG_MARKER.new"; ` +
+	`if curl -fsSL ` + curlRetryArgs + ` --etag-compare "$ETAG_MARKER" --etag-save "$ETAG_MARKER.new" -o "$MODEL_PATH.partial" "$MODEL_SOURCE" ` + hfAuthHeaderArg + `; then ` +
+	`if [ -s "$MODEL_PATH.partial" ]; then mv "$MODEL_PATH.partial" "$MODEL_PATH"; else rm -f "$MODEL_PATH.partial"; fi; ` +
+	`mv "$ETAG_MARKER.new" "$ETAG_MARKER" 2>/dev/null || true; ` +
 	`echo 'Model revalidated (downloaded or unchanged)'; ` +
 	`elif [ -f "$MODEL_PATH" ]; then ` +
+	`rm -f "$MODEL_PATH.partial" "$ETAG_MARKER.new"; ` +
 	`echo 'Revalidation unreachable; kept cached copy'; exit 0; ` +
 	`else ` +
 	`echo 'ERROR: model missing and revalidation failed'; exit 1; ` +
 	`fi`
 
+// modelChecksumVerifyScript fails the init container when $MODEL_PATH does not
+// hash to $MODEL_SHA256, deleting the file so a truncated or tampered download
+// is re-fetched on the next attempt instead of served from the cache. It runs
+// on cache hits as well, so a corrupt cached copy is caught too.
+const modelChecksumVerifyScript = `echo 'Verifying model sha256...'; ` +
+	`actual="$(sha256sum "$MODEL_PATH" | cut -d ' ' -f 1)"; ` +
+	`if [ "$actual" != "$MODEL_SHA256" ]; then ` +
+	`echo "ERROR: ChecksumMismatch - expected sha256 $MODEL_SHA256, got $actual"; rm -f "$MODEL_PATH"; exit 1; ` +
+	`fi; ` +
+	`echo 'Model sha256 verified'`
+
+// addChecksumVerification appends the spec.sha256 check to a single-file
+// download command. No-op when the Model does not pin a hash, so existing
+// Deployments render unchanged.
+func addChecksumVerification(cmd *string, env *[]corev1.EnvVar, model *inferencev1alpha1.Model) {
+	if model.Spec.SHA256 == "" {
+		return
+	}
+	*env = append(*env, corev1.EnvVar{Name: "MODEL_SHA256", Value: strings.ToLower(model.Spec.SHA256)})
+	*cmd = fmt.Sprintf("%s && { %s; }", *cmd, modelChecksumVerifyScript)
+}
+
 func modelInitEnvVars(source, cacheDir, modelPath string) []corev1.EnvVar {
 	envs := []corev1.EnvVar{
 		{Name: "MODEL_SOURCE", Value: source},
@@ -228,6 +314,12 @@ func modelInitEnvVars(source, cacheDir, modelPath string) []corev1.EnvVar {
 			envs = append(envs, corev1.EnvVar{Name: "S3_BUCKET", Value: bucket}, corev1.EnvVar{Name: "S3_KEY", Value: key})
 		}
 	}
+	if isGCSSource(source) {
+		bucket, object, err := parseGCSSource(source)
+		if err == nil {
+			envs = append(envs, corev1.EnvVar{Name: "GCS_BUCKET", Value: bucket}, corev1.EnvVar{Name: "GCS_OBJECT", Value: object})
+		}
+	}
 	return envs
 }
 
@@ -271,11 +363,17 @@ func effectiveModelCacheKey(model *inferencev1alpha1.Model) string {
 }
 
 // modelStagingPlan resolves the model's declared files into a staging plan.
-// Returns nil when there is no multi-file staging. Returns an error when
-// multi-file fields are set but resolution fails (fail-closed).
+// A remote URL naming the first shard of a split GGUF is staged as a multi-file
+// set of all its shards. Returns nil when there is no multi-file staging.
+// Returns an error when multi-file fields are set but resolution fails
+// (fail-closed).
 func modelStagingPlan(model *inferencev1alpha1.Model) (*StagingPlan, error) {
 	if !hasMultiFileStaging(model) {
-		return nil, nil
+		if model == nil {
+			return nil, nil
+		}
+		_, plan := splitSourcePlan(model.Spec.Source)
+		return plan, nil
 	}
 	plan, err := ResolveFileSet(model.Spec.Files, model.Spec.Mmproj, nil)
 	if err != nil {
@@ -287,6 +385,40 @@ func modelStagingPlan(model *inferencev1alpha1.Model) (*StagingPlan, error) {
 	return plan, nil
 }
 
+// splitSourcePlan expands an http(s) source that names the first shard of a
+// split GGUF (".../model-00001-of-00003.gguf") into a staging plan covering
+// every shard, and returns the directory URL the shard names are joined onto.
+// llama.cpp loads the remaining shards from next to the first one, so all of
+// them must be staged side by side under their original names. Returns a nil
+// plan for any other source.
+func splitSourcePlan(source string) (string, *StagingPlan) {
+	if !isRemoteHTTPSource(source) {
+		return "", nil
+	}
+	u, err := url.Parse(source)
+	if err != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", nil
+	}
+	shards, ok := gguf.SplitShardNames(path.Base(u.Path))
+	if !ok {
+		return "", nil
+	}
+	base := source[:strings.LastIndex(source, "/")]
+	return base, &StagingPlan{Primary: shards[0], Files: shards}
+}
+
+// stagingSource returns the source the multi-file downloader joins staged file
+// names onto: the repo source for spec.files, or the shard directory for a
+// split GGUF URL.
+func stagingSource(model *inferencev1alpha1.Model) string {
+	if !hasMultiFileStaging(model) {
+		if base, plan := splitSourcePlan(model.Spec.Source); plan != nil {
+			return base
+		}
+	}
+	return model.Spec.Source
+}
+
 // invalidFileSetInitContainer returns an init container that immediately exits
 // with a clear error message when multi-file staging is requested but
 // ResolveFileSet fails. This prevents silent fallback to legacy single-file
@@ -395,9 +527,13 @@ func buildMultiFileInitCommand(useCache bool, refreshPolicy string) string {
 			`mkdir -p "$(dirname "$dest")"; ` +
 			`url="${SOURCE%/}/$rel"; ` +
 			`etag="$(dirname "$dest")/.$(basename "$dest").etag"; ` +
-			`if curl -fsSL --etag-compare "$etag" --etag-save "$etag" -o "$dest" "$url"; then ` +
+			`[ -f "$dest" ] || rm -f "$etag"; ` +
+			`rm -f "$dest.partial" "$etag.new"; ` +
+			`if curl -fsSL ` + curlRetryArgs + ` --etag-compare "$etag" --etag-save "$etag.new" -o "$dest.partial" "$url" ` + hfAuthHeaderArg + `; then ` +
+			`if [ -s "$dest.partial" ]; then mv "$dest.partial" "$dest"; else rm -f "$dest.partial"; fi; ` +
+			`mv "$etag.new" "$etag" 2>/dev/null || true; ` +
 			`echo "Model artifact $rel revalidated"; ` +
-			`elif [ -f "$dest" ]; then echo "Revalidation unreachable for $rel; kept cached copy"; ` +
+			`elif [ -f "$dest" ]; then rm -f "$dest.partial" "$etag.new"; echo "Revalidation unreachable for $rel; kept cached copy"; ` +
 			`else echo "ERROR: model artifact $rel missing and revalidation failed"; exit 1; fi; ` +
 			`done`
 		return prefix + body
@@ -412,7 +548,7 @@ func buildMultiFileInitCommand(useCache bool, refreshPolicy string) string {
 		`url="${SOURCE%/}/$rel"; ` +
 		`if [ ! -f "$dest" ]; then ` +
 		`echo "Downloading model artifact $rel..."; ` +
-		`curl -f -L -o "$dest" "$url" || { echo "ERROR: failed to download $rel"; exit 1; }; ` +
+		`{ curl -f -L ` + curlRetryArgs + ` -C - -o "$dest.partial" "$url" ` + hfAuthHeaderArg + ` && mv "$dest.partial" "$dest"; } || { echo "ERROR: failed to download $rel"; exit 1; }; ` +
 		`else echo "Model artifact $rel already cached, skipping download"; fi; ` +
 		`done`
 	return prefix + body
@@ -533,7 +669,7 @@ func buildCachedStorageConfig(model *inferencev1alpha1.Model, isvc *inferencev1a
 	if plan != nil {
 		modelPath := stagedCachePath(cacheDir, plan.Primary)
 		cmd := buildMultiFileInitCommand(true, model.Spec.RefreshPolicy)
-		env := multiFileInitEnvVars(model.Spec.Source, cacheDir, plan.Files)
+		env := multiFileInitEnvVars(stagingSource(model), cacheDir, plan.Files)
 
 		initVolumeMounts := []corev1.VolumeMount{
 			{Name: "model-cache", MountPath: "/models"},
@@ -559,6 +695,7 @@ func buildCachedStorageConfig(model *inferencev1alpha1.Model, isvc *inferencev1a
 				Image:           initContainerImage,
 				Command:         []string{"sh", "-c", cmd},
 				Env:             env,
+				EnvFrom:         modelEnvFrom(model),
 				VolumeMounts:    initVolumeMounts,
 				SecurityContext: initContainerSecurityContext(isvc),
 			},
@@ -619,8 +756,9 @@ func buildCachedStorageConfig(model *inferencev1alpha1.Model, isvc *inferencev1a
 		})
 	}
 
-	cmd := buildModelInitCommand(isLocalModelSource(model.Spec.Source), isS3Source(model.Spec.Source), true, model.Spec.RefreshPolicy)
+	cmd := singleFileInitCommand(model, true)
 	env := modelInitEnvVars(model.Spec.Source, cacheDir, modelPath)
+	addChecksumVerification(&cmd, &env, model)
 	addCACertVolume(&volumes, &initVolumeMounts, &cmd, caCertConfigMap)
 
 	initContainers := []corev1.Container{
@@ -663,7 +801,7 @@ func buildEmptyDirStorageConfig(model *inferencev1alpha1.Model, isvc *inferencev
 		stagedDir := fmt.Sprintf("/models/%s-%s", namespace, model.Name)
 		modelPath := fmt.Sprintf("%s/%s", stagedDir, plan.Primary)
 		cmd := buildMultiFileInitCommand(false, model.Spec.RefreshPolicy)
-		env := multiFileInitEnvVars(model.Spec.Source, stagedDir, plan.Files)
+		env := multiFileInitEnvVars(stagingSource(model), stagedDir, plan.Files)
 
 		initVolumeMounts := []corev1.VolumeMount{{Name: "model-storage", MountPath: "/models"}}
 		volumes := []corev1.Volume{
@@ -683,6 +821,7 @@ func buildEmptyDirStorageConfig(model *inferencev1alpha1.Model, isvc *inferencev
 				Image:           initContainerImage,
 				Command:         []string{"sh", "-c", cmd},
 				Env:             env,
+				EnvFrom:         modelEnvFrom(model),
 				VolumeMounts:    initVolumeMounts,
 				SecurityContext: initContainerSecurityContext(isvc),
 			}},
@@ -702,8 +841,9 @@ func buildEmptyDirStorageConfig(model *inferencev1alpha1.Model, isvc *inferencev
 		},
 	}
 
-	cmd := buildModelInitCommand(isLocalModelSource(model.Spec.Source), isS3Source(model.Spec.Source), false, model.Spec.RefreshPolicy)
+	cmd := singleFileInitCommand(model, false)
 	env := modelInitEnvVars(model.Spec.Source, "", modelPath)
+	addChecksumVerification(&cmd, &env, model)
 	addCACertVolume(&volumes, &initVolumeMounts, &cmd, caCertConfigMap)
 
 	return modelStorageConfig{
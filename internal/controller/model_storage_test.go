@@ -46,7 +46,40 @@ var _ = Describe("buildModelInitCommand (s3)", func() {
 	It("should NOT emit --aws-sigv4 for non-s3 source", func() {
 		cmd := buildModelInitCommand(false, false, true, "")
 		Expect(cmd).ToNot(ContainSubstring("aws-sigv4"))
-		Expect(cmd).To(ContainSubstring("curl -f -L -o \"$MODEL_PATH\" \"$MODEL_SOURCE\""))
+		Expect(cmd).To(ContainSubstring(`-o "$MODEL_PATH.partial" "$MODEL_SOURCE"`))
+	})
+
+	It("should retry and resume HTTP downloads into a .partial file renamed on success", func() {
+		for _, useCache := range []bool{true, false} {
+			cmd := buildModelInitCommand(false, false, useCache, "")
+			Expect(cmd).To(ContainSubstring("--retry 5 --retry-delay 10 --retry-connrefused --retry-all-errors"))
+			Expect(cmd).To(ContainSubstring(`-C - -o "$MODEL_PATH.partial"`))
+			Expect(cmd).To(ContainSubstring(`&& mv "$MODEL_PATH.partial" "$MODEL_PATH"`))
+			Expect(cmd).ToNot(ContainSubstring(`-o "$MODEL_PATH" `))
+		}
+	})
+
+	It("should retry S3 downloads into a .partial file without resuming", func() {
+		cmd := buildModelInitCommand(false, true, true, "")
+		Expect(cmd).To(ContainSubstring("--retry 5 --retry-delay 10 --retry-connrefused --retry-all-errors"))
+		Expect(cmd).To(ContainSubstring(`&& mv "$MODEL_PATH.partial" "$MODEL_PATH"`))
+		Expect(cmd).ToNot(ContainSubstring("-C -"))
+	})
+
+	It("should retry OnChange revalidation into a .partial file and commit the ETag after the rename", func() {
+		for _, cmd := range []string{
+			buildModelInitCommand(false, false, true, RefreshPolicyOnChange),
+			buildMultiFileInitCommand(true, RefreshPolicyOnChange),
+		} {
+			Expect(cmd).To(ContainSubstring("--retry 5 --retry-delay 10 --retry-connrefused --retry-all-errors"))
+			Expect(cmd).To(ContainSubstring(`.partial"`))
+			Expect(cmd).To(ContainSubstring(`.new"`))
+			Expect(cmd).To(ContainSubstring("kept cached copy"))
+		}
+		single := buildModelInitCommand(false, false, true, RefreshPolicyOnChange)
+		Expect(single).To(ContainSubstring(`--etag-save "$ETAG_MARKER.new" -o "$MODEL_PATH.partial"`))
+		Expect(single).To(ContainSubstring(`mv "$MODEL_PATH.partial" "$MODEL_PATH"`))
+		Expect(single).NotTo(ContainSubstring(`-o "$MODEL_PATH" `))
 	})
 
 	It("should emit the --aws-sigv4 curl line for s3 source with OnChange refresh", func() {
@@ -56,6 +89,58 @@ var _ = Describe("buildModelInitCommand (s3)", func() {
 	})
 })
 
+var _ = Describe("singleFileInitCommand (per scheme)", func() {
+	model := func(source string) *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{Spec: inferencev1alpha1.ModelSpec{Source: source}}
+	}
+
+	It("should sign gs:// downloads against the GCS XML API", func() {
+		cmd := singleFileInitCommand(model("gs://models/llama.gguf"), true)
+		Expect(cmd).To(ContainSubstring(`curl --aws-sigv4 "aws:amz:${AWS_REGION:-auto}:s3"`))
+		Expect(cmd).To(ContainSubstring(`${AWS_ENDPOINT_URL:-https://storage.googleapis.com}/${GCS_BUCKET}/${GCS_OBJECT}`))
+		Expect(cmd).To(ContainSubstring("Downloading model from GCS"))
+		Expect(cmd).To(ContainSubstring(`mkdir -p "$CACHE_DIR"`))
+	})
+
+	It("should keep the S3 and HTTP paths for their schemes", func() {
+		Expect(singleFileInitCommand(model("s3://models/llama.gguf"), true)).To(ContainSubstring("${S3_BUCKET}/${S3_KEY}"))
+		Expect(singleFileInitCommand(model("https://example.com/llama.gguf"), true)).To(ContainSubstring(`"$MODEL_SOURCE"`))
+	})
+
+	It("should run the gs:// downloader on the configured init image with bucket env and credentials", func() {
+		m := model("gs://models/llama/llama.gguf")
+		m.Spec.SourceSecretRef = &corev1.LocalObjectReference{Name: "gcs-hmac"}
+		config := buildEmptyDirStorageConfig(m, nil, "default", "", "curl:8.18.0")
+
+		c := config.initContainers[0]
+		Expect(c.Image).To(Equal("curl:8.18.0"))
+		Expect(c.Command[2]).To(ContainSubstring("${GCS_BUCKET}/${GCS_OBJECT}"))
+		Expect(c.Env).To(ContainElement(corev1.EnvVar{Name: "GCS_BUCKET", Value: "models"}))
+		Expect(c.Env).To(ContainElement(corev1.EnvVar{Name: "GCS_OBJECT", Value: "llama/llama.gguf"}))
+		Expect(c.EnvFrom).To(HaveLen(1))
+		Expect(c.EnvFrom[0].SecretRef.Name).To(Equal("gcs-hmac"))
+	})
+})
+
+var _ = Describe("buildModelInitCommand (HF_TOKEN auth)", func() {
+	It("should pass the HF_TOKEN bearer header to every non-S3 download", func() {
+		for _, cmd := range []string{
+			buildModelInitCommand(false, false, true, ""),
+			buildModelInitCommand(false, false, false, ""),
+			buildModelInitCommand(false, false, true, RefreshPolicyOnChange),
+			buildMultiFileInitCommand(true, ""),
+			buildMultiFileInitCommand(true, RefreshPolicyOnChange),
+		} {
+			Expect(cmd).To(ContainSubstring(`${HF_TOKEN:+-H "Authorization: Bearer $HF_TOKEN"}`))
+		}
+	})
+
+	It("should not add the bearer header to S3 or local sources", func() {
+		Expect(buildModelInitCommand(false, true, true, "")).ToNot(ContainSubstring("HF_TOKEN"))
+		Expect(buildModelInitCommand(true, false, true, "")).ToNot(ContainSubstring("HF_TOKEN"))
+	})
+})
+
 var _ = Describe("modelInitEnvVars (s3)", func() {
 	It("should include S3_BUCKET and S3_KEY for s3 source", func() {
 		envs := modelInitEnvVars("s3://my-bucket/models/model.gguf", "/models/cache", "/models/cache/model.gguf")
@@ -94,3 +179,39 @@ var _ = Describe("modelEnvFrom", func() {
 		Expect(envFrom[0].SecretRef.Name).To(Equal("s3-credentials"))
 	})
 })
+
+var _ = Describe("split GGUF sources", func() {
+	It("should stage every shard of a split GGUF URL next to the first", func() {
+		model := &inferencev1alpha1.Model{
+			Spec: inferencev1alpha1.ModelSpec{
+				Source: "https://example.com/models/qwen-72b-00001-of-00003.gguf",
+			},
+		}
+
+		plan, err := modelStagingPlan(model)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan).NotTo(BeNil())
+		Expect(plan.Primary).To(Equal("qwen-72b-00001-of-00003.gguf"))
+		Expect(plan.Files).To(Equal([]string{
+			"qwen-72b-00001-of-00003.gguf",
+			"qwen-72b-00002-of-00003.gguf",
+			"qwen-72b-00003-of-00003.gguf",
+		}))
+		Expect(stagingSource(model)).To(Equal("https://example.com/models"))
+	})
+
+	It("should leave single-file and non-first-shard sources alone", func() {
+		for _, source := range []string{
+			"https://example.com/models/model.gguf",
+			"https://example.com/models/qwen-72b-00002-of-00003.gguf",
+			"https://example.com/models/qwen-72b-00001-of-00003.gguf?download=true",
+			"/mnt/models/qwen-72b-00001-of-00003.gguf",
+		} {
+			model := &inferencev1alpha1.Model{Spec: inferencev1alpha1.ModelSpec{Source: source}}
+			plan, err := modelStagingPlan(model)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan).To(BeNil(), source)
+			Expect(stagingSource(model)).To(Equal(source))
+		}
+	})
+})
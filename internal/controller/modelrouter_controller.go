@@ -376,7 +376,7 @@ func (r *ModelRouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // findModelRoutersForInferenceService returns reconcile requests for every
 // ModelRouter in the same namespace that references the given
-// InferenceService by name. Mirrors the pattern used by
+// InferenceService by name or discovers the namespace's services. Mirrors the pattern used by
 // InferenceServiceReconciler.findInferenceServicesForModel.
 func (r *ModelRouterReconciler) findModelRoutersForInferenceService(ctx context.Context, obj client.Object) []reconcile.Request {
 	isvc, ok := obj.(*inferencev1alpha1.InferenceService)
@@ -391,7 +391,7 @@ func (r *ModelRouterReconciler) findModelRoutersForInferenceService(ctx context.
 
 	var requests []reconcile.Request
 	for _, mr := range routerList.Items {
-		if routerReferencesInferenceService(&mr, isvc.Name) {
+		if mr.Spec.DiscoverInferenceServices || routerReferencesInferenceService(&mr, isvc.Name) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      mr.Name,
@@ -47,7 +47,11 @@ func (e ModelRouterValidationError) String() string {
 //   - Backend tier must be consistent with its kind (local for
 //     inferenceServiceRef, cloud-or-omitted for external).
 //   - Backend names must be unique.
+//   - At least one backend is declared unless discoverInferenceServices is
+//     set, which is supported with the Proxy data plane only.
 //   - DefaultRoute, if set, names an existing backend.
+//   - endpoint.ingress is unset; it is shared with InferenceService but the
+//     router does not generate an Ingress.
 //   - Every rule's route.backends entries reference existing backends.
 //   - Rules matching sensitive classifications (pii/phi by default, or
 //     whatever policy.classification.sensitiveClassifications says) must
@@ -65,6 +69,20 @@ func validateModelRouter(mr *inferencev1alpha1.ModelRouter) []ModelRouterValidat
 	nameSet, backendsByName, backendErrs := validateBackends(spec)
 	errs = append(errs, backendErrs...)
 
+	if spec.DiscoverInferenceServices {
+		if spec.DataPlane == inferencev1alpha1.ModelRouterDataPlaneGateway {
+			errs = append(errs, ModelRouterValidationError{
+				Field:   "spec.discoverInferenceServices",
+				Message: "is supported with dataPlane=Proxy only",
+			})
+		}
+	} else if len(spec.Backends) == 0 {
+		errs = append(errs, ModelRouterValidationError{
+			Field:   "spec.backends",
+			Message: "at least one backend is required unless discoverInferenceServices is true",
+		})
+	}
+
 	if spec.DefaultRoute != "" && !nameSet[spec.DefaultRoute] {
 		errs = append(errs, ModelRouterValidationError{
 			Field:   "spec.defaultRoute",
@@ -72,6 +90,13 @@ func validateModelRouter(mr *inferencev1alpha1.ModelRouter) []ModelRouterValidat
 		})
 	}
 
+	if spec.Endpoint != nil && spec.Endpoint.Ingress != nil {
+		errs = append(errs, ModelRouterValidationError{
+			Field:   "spec.endpoint.ingress",
+			Message: "is supported on InferenceService only; expose the router Service with your own Ingress",
+		})
+	}
+
 	ruleNames, ruleErrs := validateRules(spec, nameSet, backendsByName)
 	errs = append(errs, ruleErrs...)
 	errs = append(errs, validateBudgets(spec, ruleNames)...)
@@ -150,6 +150,36 @@ func TestValidateModelRouterDuplicateBackendNames(t *testing.T) {
 	}
 }
 
+// TestValidateModelRouterBackendsOrDiscovery ensures a router needs at
+// least one declared backend unless it discovers InferenceServices, and
+// that discovery is rejected on the Gateway data plane.
+func TestValidateModelRouterBackendsOrDiscovery(t *testing.T) {
+	mr := &inferencev1alpha1.ModelRouter{}
+	if errs := validateModelRouter(mr); !errsContain(errs, "at least one backend is required") {
+		t.Errorf("expected missing-backends error, got: %s", formatValidationErrors(errs))
+	}
+
+	mr.Spec.DiscoverInferenceServices = true
+	if errs := validateModelRouter(mr); len(errs) != 0 {
+		t.Errorf("expected discovery-only router to validate, got: %s", formatValidationErrors(errs))
+	}
+
+	mr.Spec.DataPlane = inferencev1alpha1.ModelRouterDataPlaneGateway
+	if errs := validateModelRouter(mr); !errsContain(errs, "dataPlane=Proxy only") {
+		t.Errorf("expected gateway discovery error, got: %s", formatValidationErrors(errs))
+	}
+}
+
+// TestValidateModelRouterRejectsEndpointIngress ensures the InferenceService
+// endpoint.ingress field is not silently ignored on a router.
+func TestValidateModelRouterRejectsEndpointIngress(t *testing.T) {
+	mr := validRouter()
+	mr.Spec.Endpoint = &inferencev1alpha1.EndpointSpec{Ingress: &inferencev1alpha1.IngressSpec{Host: "router.example.com"}}
+	if errs := validateModelRouter(mr); !errsContain(errs, "supported on InferenceService only") {
+		t.Errorf("expected endpoint.ingress error, got: %s", formatValidationErrors(errs))
+	}
+}
+
 // TestValidateModelRouterDefaultRouteRef ensures defaultRoute must point at
 // a real backend.
 func TestValidateModelRouterDefaultRouteRef(t *testing.T) {
@@ -832,3 +832,81 @@ func TestReconcileRouterDeploymentPreservesExternalAnnotations(t *testing.T) {
 		}
 	}
 }
+
+// TestCompileRouterConfigDiscoversReadyInferenceServices verifies that a
+// router with discoverInferenceServices publishes exactly the Ready
+// InferenceServices of its own namespace as name-matched local backends,
+// alongside (and without shadowing) its declared backends, including one
+// declared under a different name than the service it references.
+func TestCompileRouterConfigDiscoversReadyInferenceServices(t *testing.T) {
+	mr := &inferencev1alpha1.ModelRouter{
+		ObjectMeta: metav1.ObjectMeta{Name: "all-models", Namespace: testBuilderNs},
+		Spec: inferencev1alpha1.ModelRouterSpec{
+			DiscoverInferenceServices: true,
+			Backends: []inferencev1alpha1.RouterBackend{
+				{Name: "qwen", InferenceServiceRef: &corev1.LocalObjectReference{Name: "qwen"}, DisplayName: "qwen-2.5-7b"},
+				{Name: "fast", InferenceServiceRef: &corev1.LocalObjectReference{Name: "llama"}},
+			},
+		},
+	}
+	isvc := func(name, namespace, phase string) *inferencev1alpha1.InferenceService {
+		return &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     inferencev1alpha1.InferenceServiceStatus{Phase: phase},
+		}
+	}
+	r := newRouterReconcilerForTest(t, mr,
+		isvc("qwen", testBuilderNs, PhaseReady),
+		isvc("phi-4", testBuilderNs, PhaseReady),
+		isvc("llama", testBuilderNs, PhaseReady),
+		isvc("mistral", testBuilderNs, "Progressing"),
+		isvc("gemma", "other-namespace", PhaseReady),
+	)
+
+	compiled, err := r.compileRouterConfig(context.Background(), mr)
+	if err != nil {
+		t.Fatalf("compileRouterConfig: %v", err)
+	}
+	var cfg router.Config
+	if err := json.Unmarshal(compiled.JSON, &cfg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	var names []string
+	for _, b := range cfg.Backends {
+		names = append(names, b.Name)
+	}
+	if got, want := strings.Join(names, ","), "qwen,fast,phi-4"; got != want {
+		t.Fatalf("backends = %s, want %s (declared first, then discovered Ready services sorted)", got, want)
+	}
+	if cfg.Backends[0].DisplayName != "qwen-2.5-7b" {
+		t.Errorf("declared backend displayName = %q, want it kept over the discovered service", cfg.Backends[0].DisplayName)
+	}
+	if cfg.Backends[2].Tier != backendTierLocal {
+		t.Errorf("discovered backend tier = %q, want %q", cfg.Backends[2].Tier, backendTierLocal)
+	}
+	if want := "http://phi-4." + testBuilderNs + ".svc.cluster.local:8080"; cfg.Backends[2].Address != want {
+		t.Errorf("discovered backend address = %q, want %q", cfg.Backends[2].Address, want)
+	}
+	if cfg.DefaultRouteStrategy != string(inferencev1alpha1.DefaultRouteStrategyBackendNameMatch) {
+		t.Errorf("defaultRouteStrategy = %q, want BackendNameMatch", cfg.DefaultRouteStrategy)
+	}
+	if len(compiled.Backends) != 3 {
+		t.Errorf("got %d backend statuses, want 3", len(compiled.Backends))
+	}
+}
+
+// TestCompileRouterConfigDiscoveryWithoutReadyServicesFails confirms a
+// discovery-only router with nothing Ready fails the compile with a
+// message naming the namespace, instead of publishing an empty config.
+func TestCompileRouterConfigDiscoveryWithoutReadyServicesFails(t *testing.T) {
+	mr := &inferencev1alpha1.ModelRouter{
+		ObjectMeta: metav1.ObjectMeta{Name: "all-models", Namespace: testBuilderNs},
+		Spec:       inferencev1alpha1.ModelRouterSpec{DiscoverInferenceServices: true},
+	}
+	r := newRouterReconcilerForTest(t, mr)
+	_, err := r.compileRouterConfig(context.Background(), mr)
+	if err == nil || !strings.Contains(err.Error(), "no Ready InferenceServices") {
+		t.Fatalf("expected no-Ready-InferenceServices error, got %v", err)
+	}
+}
@@ -49,7 +49,8 @@ type compiledConfig struct {
 	Warnings []string
 }
 
-// compileRouterConfig resolves every backend in the ModelRouter spec
+// compileRouterConfig resolves every backend in the ModelRouter spec, plus
+// the discovered ones when spec.discoverInferenceServices is set
 // (InferenceService lookups for local backends, secret-key checks for
 // external ones), translates the spec into the proxy wire shape, and
 // returns the JSON + content hash plus the BackendStatus list the
@@ -72,8 +73,23 @@ func (r *ModelRouterReconciler) compileRouterConfig(
 	statuses := make([]inferencev1alpha1.BackendStatus, 0, len(mr.Spec.Backends))
 	var warnings []string
 
-	for i := range mr.Spec.Backends {
-		b := &mr.Spec.Backends[i]
+	backends := mr.Spec.Backends
+	if mr.Spec.DiscoverInferenceServices {
+		discovered, err := r.discoverInferenceServiceBackends(ctx, mr)
+		if err != nil {
+			return nil, err
+		}
+		if len(backends)+len(discovered) == 0 {
+			return nil, fmt.Errorf("no backends declared and no Ready InferenceServices found in namespace %q", mr.Namespace)
+		}
+		backends = append(append([]inferencev1alpha1.RouterBackend(nil), backends...), discovered...)
+		// Discovered backends are only reachable by name, so name matching
+		// must run before the static default route.
+		out.DefaultRouteStrategy = string(inferencev1alpha1.DefaultRouteStrategyBackendNameMatch)
+	}
+
+	for i := range backends {
+		b := &backends[i]
 		wire, status := r.resolveBackend(ctx, mr, b)
 		if status.Message != "" {
 			warnings = append(warnings, fmt.Sprintf("backend %q: %s", b.Name, status.Message))
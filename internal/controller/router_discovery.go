@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// discoverInferenceServiceBackends returns a local backend for every Ready
+// InferenceService in the router's namespace that is not already declared
+// in spec.backends (by name or inferenceServiceRef), for ModelRouters with spec.discoverInferenceServices.
+// Each backend is named after its InferenceService, which is the model id
+// the proxy publishes on /v1/models and matches with BackendNameMatch.
+// The result is sorted by name so the compiled config, and therefore the
+// rollout hash, is stable across reconciles.
+//
+// Services that are not Ready are left out rather than reported unhealthy:
+// the router should only advertise models it can serve, and the
+// InferenceService watch re-enqueues the router once a service turns Ready.
+func (r *ModelRouterReconciler) discoverInferenceServiceBackends(
+	ctx context.Context,
+	mr *inferencev1alpha1.ModelRouter,
+) ([]inferencev1alpha1.RouterBackend, error) {
+	isvcList := &inferencev1alpha1.InferenceServiceList{}
+	if err := r.List(ctx, isvcList, client.InNamespace(mr.Namespace)); err != nil {
+		return nil, fmt.Errorf("list InferenceServices for discovery: %w", err)
+	}
+
+	// A service is already declared when a backend carries its name or
+	// points at it under another name; discovering it again would route
+	// the same model twice.
+	declared := make(map[string]bool, len(mr.Spec.Backends))
+	for _, b := range mr.Spec.Backends {
+		declared[b.Name] = true
+		if b.InferenceServiceRef != nil {
+			declared[b.InferenceServiceRef.Name] = true
+		}
+	}
+
+	var backends []inferencev1alpha1.RouterBackend
+	for i := range isvcList.Items {
+		isvc := &isvcList.Items[i]
+		if isvc.Status.Phase != PhaseReady || isvc.DeletionTimestamp != nil || declared[isvc.Name] {
+			continue
+		}
+		backends = append(backends, inferencev1alpha1.RouterBackend{
+			Name:                isvc.Name,
+			InferenceServiceRef: &corev1.LocalObjectReference{Name: isvc.Name},
+			Tier:                backendTierLocal,
+		})
+	}
+	sort.Slice(backends, func(i, j int) bool { return backends[i].Name < backends[j].Name })
+	return backends, nil
+}
@@ -101,14 +101,12 @@ func newRouterService(mr *inferencev1alpha1.ModelRouter) *corev1.Service {
 // InferenceService.
 func routerProxyEndpoint(mr *inferencev1alpha1.ModelRouter) string {
 	port := routerProxyPort
-	path := "/v1/chat/completions"
+	path := defaultEndpointPath
 	if mr.Spec.Endpoint != nil {
 		if mr.Spec.Endpoint.Port > 0 {
 			port = mr.Spec.Endpoint.Port
 		}
-		if mr.Spec.Endpoint.Path != "" {
-			path = mr.Spec.Endpoint.Path
-		}
+		path = normalizeEndpointPath(mr.Spec.Endpoint.Path)
 	}
 	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s",
 		routerProxyResourceName(mr.Name), mr.Namespace, port, path)
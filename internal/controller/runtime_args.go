@@ -18,11 +18,32 @@ package controller
 
 import (
 	"fmt"
+	"path"
+	"slices"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
 
+// ConditionEndpointRouteKnown is set False when spec.endpoint.path is not a
+// route the runtime serves, so status.endpoint likely 404s. Informational
+// only, and removed once the path is recognized again.
+const ConditionEndpointRouteKnown = "EndpointRouteKnown"
+
+// ConditionGPULayersValid is set False when the Model's hardware.gpu.layers
+// exceeds the layers its GGUF can offload. Informational only, and removed
+// once the count fits again.
+const ConditionGPULayersValid = "GPULayersValid"
+
+// ConditionRerankSupported is set False when a llama.cpp service in rerank
+// mode points at a Model whose GGUF architecture has no rerank head.
+// Informational only, and removed once the Model or mode changes.
+const ConditionRerankSupported = "RerankSupported"
+
 // Serving modes accepted in spec.mode and reported in status.mode.
 const (
 	servingModeChat      = inferencev1alpha1.ServingModeChat
@@ -65,3 +86,185 @@ func resolveServingMode(isvc *inferencev1alpha1.InferenceService) string {
 	}
 	return servingModeChat
 }
+
+// defaultEndpointPath is reported in status.endpoint when spec.endpoint.path
+// is unset.
+const defaultEndpointPath = "/v1/chat/completions"
+
+// servingModeEndpointPath is the status.endpoint path when spec.endpoint.path
+// is unset: the route that serves the resolved mode, so an embedding or rerank
+// service does not advertise a chat URL that rejects its requests.
+func servingModeEndpointPath(isvc *inferencev1alpha1.InferenceService) string {
+	if isvc.Spec.Endpoint != nil && strings.TrimSpace(isvc.Spec.Endpoint.Path) != "" {
+		return normalizeEndpointPath(isvc.Spec.Endpoint.Path)
+	}
+	switch resolveServingMode(isvc) {
+	case servingModeEmbedding:
+		return "/v1/embeddings"
+	case servingModeRerank:
+		return "/v1/rerank"
+	}
+	if isvc.Spec.Endpoint != nil {
+		switch isvc.Spec.Endpoint.APIStyle {
+		case inferencev1alpha1.APIStyleCompletions:
+			return "/v1/completions"
+		case inferencev1alpha1.APIStyleLegacy:
+			return "/completion"
+		}
+	}
+	return defaultEndpointPath
+}
+
+// llamaServerRoutes are the routes llama-server serves, plus the "/v1" base
+// that OpenAI clients append their own route to. The Service exposes the
+// whole server, so spec.endpoint.path only selects which of them
+// status.endpoint points at; anything else reports a URL that 404s.
+var llamaServerRoutes = []string{
+	"/v1",
+	"/v1/models",
+	"/models",
+	"/v1/chat/completions",
+	"/v1/completions",
+	"/v1/embeddings",
+	"/v1/rerank",
+	"/v1/reranking",
+	"/v1/responses",
+	"/v1/messages",
+	"/chat/completions",
+	"/completion",
+	"/completions",
+	"/embedding",
+	"/embeddings",
+	"/rerank",
+	"/reranking",
+	"/infill",
+}
+
+// normalizeEndpointPath trims spec.endpoint.path and cleans it to a single
+// absolute path with no trailing slash, so "v1/chat/completions/" and
+// "/v1/chat/completions" report the same endpoint. Empty means the default.
+func normalizeEndpointPath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return defaultEndpointPath
+	}
+	return path.Clean("/" + p)
+}
+
+// isLlamaServerRuntime reports whether the InferenceService is served by
+// llama-server, whose route set llamaServerRoutes describes.
+func isLlamaServerRuntime(isvc *inferencev1alpha1.InferenceService) bool {
+	switch runtimeNameLabel(isvc) {
+	case "llamacpp", RuntimeLlamaCppRouter:
+		return true
+	}
+	return false
+}
+
+// validateEndpointPath rejects the llama-server-specific "legacy" apiStyle on
+// other runtimes. The path itself is never fatal; see unknownEndpointRoute.
+func validateEndpointPath(isvc *inferencev1alpha1.InferenceService) error {
+	if isvc.Spec.Endpoint == nil || isLlamaServerRuntime(isvc) {
+		return nil
+	}
+	if isvc.Spec.Endpoint.APIStyle == inferencev1alpha1.APIStyleLegacy {
+		return fmt.Errorf("apiStyle %q (/completion) is only served by the llama.cpp runtimes", inferencev1alpha1.APIStyleLegacy)
+	}
+	return nil
+}
+
+// unknownEndpointRoute returns a warning when spec.endpoint.path, normalized,
+// is not a llama-server route, or "" when it is (or is unset). Other runtimes
+// expose their own route sets, so their path is taken as given.
+func unknownEndpointRoute(isvc *inferencev1alpha1.InferenceService) string {
+	if isvc.Spec.Endpoint == nil || strings.TrimSpace(isvc.Spec.Endpoint.Path) == "" || !isLlamaServerRuntime(isvc) {
+		return ""
+	}
+	p := normalizeEndpointPath(isvc.Spec.Endpoint.Path)
+	if slices.Contains(llamaServerRoutes, p) {
+		return ""
+	}
+	return fmt.Sprintf("endpoint path %q is not a llama-server route (e.g. /v1/chat/completions, /v1/completions, /v1/embeddings, /v1/rerank); status.endpoint may 404", p)
+}
+
+// reconcileEndpointRouteCondition sets EndpointRouteKnown to False when
+// spec.endpoint.path is not a route the runtime serves. A Warning event is
+// emitted on the transition into the False state only; the service is still
+// reconciled, since the path only affects the reported URL.
+func (r *InferenceServiceReconciler) reconcileEndpointRouteCondition(isvc *inferencev1alpha1.InferenceService) {
+	existing := meta.FindStatusCondition(isvc.Status.Conditions, ConditionEndpointRouteKnown)
+	message := unknownEndpointRoute(isvc)
+	if message == "" {
+		if existing != nil {
+			meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionEndpointRouteKnown)
+		}
+		return
+	}
+
+	if r.Recorder != nil && (existing == nil || existing.Status != metav1.ConditionFalse) {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "UnknownEndpointRoute", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+		Type:               ConditionEndpointRouteKnown,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: isvc.Generation,
+		Reason:             "UnknownEndpointRoute",
+		Message:            message,
+	})
+}
+
+// reconcileGPULayersCondition sets GPULayersValid to False when the Model's
+// hardware.gpu.layers exceeds its GGUF block count. A Warning event is emitted
+// on the transition into the False state only; llama.cpp clamps the count, so
+// the service is still reconciled.
+func (r *InferenceServiceReconciler) reconcileGPULayersCondition(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) {
+	existing := meta.FindStatusCondition(isvc.Status.Conditions, ConditionGPULayersValid)
+	layers, blocks, over := gpuLayersOverBlockCount(model)
+	if !over {
+		if existing != nil {
+			meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionGPULayersValid)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("Model %q sets hardware.gpu.layers=%d but has %d blocks (%d offloadable layers); use -1 or %d to offload all layers",
+		model.Name, layers, blocks, blocks+1, gpuLayersAllSentinel)
+	if r.Recorder != nil && (existing == nil || existing.Status != metav1.ConditionFalse) {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "GPULayersExceedBlockCount", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+		Type:               ConditionGPULayersValid,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: isvc.Generation,
+		Reason:             "GPULayersExceedBlockCount",
+		Message:            message,
+	})
+}
+
+// reconcileRerankSupportedCondition sets RerankSupported to False when the
+// service serves rerank from a Model architecture llama.cpp cannot rerank
+// with. A Warning event is emitted on the transition into the False state
+// only; llama-server still starts, so the service is still reconciled.
+func (r *InferenceServiceReconciler) reconcileRerankSupportedCondition(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) {
+	existing := meta.FindStatusCondition(isvc.Status.Conditions, ConditionRerankSupported)
+	arch, unsupported := rerankArchitectureUnsupported(isvc, model)
+	if !unsupported {
+		if existing != nil {
+			meta.RemoveStatusCondition(&isvc.Status.Conditions, ConditionRerankSupported)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("Model %q has GGUF architecture %q, which llama.cpp cannot serve with --reranking; "+
+		"use a cross-encoder reranker model (e.g. bge-reranker, Qwen3-Reranker)", model.Name, arch)
+	if r.Recorder != nil && (existing == nil || existing.Status != metav1.ConditionFalse) {
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "RerankUnsupportedArchitecture", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&isvc.Status.Conditions, metav1.Condition{
+		Type:               ConditionRerankSupported,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: isvc.Generation,
+		Reason:             "RerankUnsupportedArchitecture",
+		Message:            message,
+	})
+}
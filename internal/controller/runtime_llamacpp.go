@@ -44,8 +44,24 @@ const llamaCppROCmImage = "ghcr.io/defilantech/llmkube-llama-rocm@sha256:8da1604
 // and point runtimeImages.llamacpp (or spec.image) at it.
 const llamaCppCUDAImage = "ghcr.io/ggml-org/llama.cpp:server-cuda-b10068"
 
+// llamaCppSYCLImage is the upstream SYCL (oneAPI) llama.cpp server image for
+// Intel GPUs, selected by accelerator sycl/intel (see resolveRuntimeImage).
+// Pinned to the same upstream build as llamaCppCUDAImage.
+const llamaCppSYCLImage = "ghcr.io/ggml-org/llama.cpp:server-intel-b10068"
+
+// llamaCppIntelVulkanImage is the upstream Vulkan llama.cpp server image used
+// for Intel GPUs with accelerator vulkan. AMD Vulkan keeps LLMKube's
+// hardware-validated llamaCppVulkanImage. Same upstream build as
+// llamaCppCUDAImage.
+const llamaCppIntelVulkanImage = "ghcr.io/ggml-org/llama.cpp:server-vulkan-b10068"
+
 // LlamaCppBackend generates container configuration for the llama.cpp inference server.
-type LlamaCppBackend struct{}
+type LlamaCppBackend struct {
+	// DefaultContextSizeMax caps the --ctx-size defaulted from the Model's
+	// trained context length when spec.contextSize is unset (see
+	// resolveContextSize). Zero leaves --ctx-size to llama-server.
+	DefaultContextSizeMax int32
+}
 
 func (b *LlamaCppBackend) ContainerName() string {
 	return "llama-server"
@@ -53,8 +69,8 @@ func (b *LlamaCppBackend) ContainerName() string {
 
 // DefaultImage is the upstream CPU-only tag: correct for CPU serving and for
 // Models with no GPU section. GPU Models never see it: AMD diverts to the
-// hardware-validated Vulkan/ROCm digests and NVIDIA diverts to
-// llamaCppCUDAImage (resolveRuntimeImage).
+// hardware-validated Vulkan/ROCm digests, Intel to the upstream SYCL or
+// Vulkan builds and NVIDIA to llamaCppCUDAImage (resolveRuntimeImage).
 func (b *LlamaCppBackend) DefaultImage() string {
 	return "ghcr.io/ggml-org/llama.cpp:server"
 }
@@ -92,13 +108,16 @@ func (b *LlamaCppBackend) BuildArgs(isvc *inferencev1alpha1.InferenceService, mo
 			layers = 99
 		}
 		args = append(args, "--n-gpu-layers", fmt.Sprintf("%d", layers))
+		if model.Spec.Hardware != nil && model.Spec.Hardware.GPU != nil && model.Spec.Hardware.GPU.MainGPU != nil {
+			args = append(args, "--main-gpu", fmt.Sprintf("%d", *model.Spec.Hardware.GPU.MainGPU))
+		}
 
 		if gpuCount > 1 {
 			var sharding *inferencev1alpha1.GPUShardingSpec
 			if model.Spec.Hardware != nil && model.Spec.Hardware.GPU != nil {
 				sharding = model.Spec.Hardware.GPU.Sharding
 			}
-			splitMode := resolveSplitMode(sharding)
+			splitMode := effectiveSplitMode(model, gpuCount)
 			args = append(args, "--split-mode", splitMode)
 
 			// --tensor-split ratios only apply to layer/row modes, not none.
@@ -106,12 +125,18 @@ func (b *LlamaCppBackend) BuildArgs(isvc *inferencev1alpha1.InferenceService, mo
 				tensorSplit := calculateTensorSplit(gpuCount, sharding)
 				args = append(args, "--tensor-split", tensorSplit)
 			}
+
+			// KV cache follows the layers onto each GPU under layer split;
+			// state it explicitly so an image default cannot pin it to host RAM.
+			if splitMode == splitModeLayer && splitKVCacheEnabled(model) {
+				args = append(args, "--kv-offload")
+			}
 		}
 	}
 
 	var err error
 
-	args = appendContextSizeArgs(args, isvc.Spec.ContextSize)
+	args = appendContextSizeArgs(args, resolveContextSize(isvc, model, b.DefaultContextSizeMax))
 	args, err = appendRopeScalingArgs(args, isvc.Spec.RopeScaling, isvc.Spec.ExtraArgs)
 	if err != nil {
 		llamaCppLog.Info(
@@ -129,6 +154,7 @@ func (b *LlamaCppBackend) BuildArgs(isvc *inferencev1alpha1.InferenceService, mo
 		)
 	}
 	args = appendFlashAttentionArgs(args, isvc.Spec.FlashAttention, hasGPUPresent(isvc, model))
+	args = appendMemoryLockArgs(args, isvc.Spec.MemoryLock)
 	args = appendJinjaArgs(args, isvc.Spec.Jinja)
 	args = appendCacheTypeArgs(args, resolveCacheType(isvc.Spec.CacheTypeCustomK, isvc.Spec.CacheTypeK), resolveCacheType(isvc.Spec.CacheTypeCustomV, isvc.Spec.CacheTypeV))
 	args = appendMoeCPUOffloadArgs(args, isvc.Spec.MoeCPUOffload)
@@ -139,6 +165,7 @@ func (b *LlamaCppBackend) BuildArgs(isvc *inferencev1alpha1.InferenceService, mo
 	args = appendUBatchSizeArgs(args, isvc.Spec.UBatchSize)
 	args = appendNoWarmupArgs(args, isvc.Spec.NoWarmup)
 	args = appendSpeculativeDecodingArgs(args, isvc.Spec.SpeculativeDecoding)
+	args = appendLoRAArgs(args, isvc.Spec.LoRAAdapters)
 	args = appendReasoningBudgetArgs(args, isvc.Spec.ReasoningBudget, isvc.Spec.ReasoningBudgetMessage)
 	if model != nil && model.Spec.Mmproj != "" && modelPath != "" {
 		if plan, err := ResolveFileSet(model.Spec.Files, model.Spec.Mmproj, nil); err == nil && plan != nil && plan.Primary != "" {
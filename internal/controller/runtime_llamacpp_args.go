@@ -19,6 +19,9 @@ package controller
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
@@ -41,6 +44,84 @@ func needsOffloadMemoryWarning(isvc *inferencev1alpha1.InferenceService) bool {
 	return needsRAM && !memorySet
 }
 
+// gpuLayersAllSentinel is the conventional "offload everything" value for
+// spec.hardware.gpu.layers; it is also what the builder emits by default.
+const gpuLayersAllSentinel = 99
+
+// gpuLayersOverBlockCount reports whether the Model's spec.hardware.gpu.layers
+// exceeds what its GGUF can offload, once status.gguf.layerCount is known.
+// llama.cpp counts the output layer as one more offloadable layer, so a
+// 32-block model takes up to 33. -1 and 99 mean "all layers" and are never
+// flagged; llama.cpp clamps anything larger, which hides a typo'd count.
+func gpuLayersOverBlockCount(model *inferencev1alpha1.Model) (layers int32, blocks uint64, over bool) {
+	if model == nil || model.Spec.Hardware == nil || model.Spec.Hardware.GPU == nil ||
+		model.Status.GGUF == nil || model.Status.GGUF.LayerCount == 0 {
+		return 0, 0, false
+	}
+	layers = model.Spec.Hardware.GPU.Layers
+	blocks = model.Status.GGUF.LayerCount
+	if layers <= 0 || layers == gpuLayersAllSentinel {
+		return layers, blocks, false
+	}
+	return layers, blocks, uint64(layers) > blocks+1
+}
+
+// rerankArchitectures are the GGUF general.architecture values llama.cpp can
+// serve with --reranking: encoder families converted with a classification
+// head (XLM-RoBERTa rerankers such as bge-reranker convert as "bert") and
+// Qwen3-Reranker.
+var rerankArchitectures = []string{
+	"bert",
+	"jina-bert-v2",
+	"jina-bert-v3",
+	"modern-bert",
+	"neo-bert",
+	"nomic-bert",
+	"qwen3",
+}
+
+// rerankArchitectureUnsupported reports whether a llama.cpp InferenceService
+// serving in rerank mode points at a Model whose GGUF architecture has no
+// rerank head. llama-server then starts but rejects every /v1/rerank call, so
+// the controller warns instead of failing. Unknown metadata is never flagged.
+func rerankArchitectureUnsupported(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model) (arch string, unsupported bool) {
+	if resolveServingMode(isvc) != servingModeRerank || model == nil || model.Status.GGUF == nil {
+		return "", false
+	}
+	if _, ok := resolveBackend(isvc).(*LlamaCppBackend); !ok {
+		return "", false
+	}
+	arch = model.Status.GGUF.Architecture
+	if arch == "" {
+		return "", false
+	}
+	return arch, !slices.Contains(rerankArchitectures, strings.ToLower(arch))
+}
+
+// resolveContextSize returns spec.contextSize when set. Otherwise it defaults
+// to the Model's trained context length (status.gguf.contextLength), capped at
+// maxDefault, so capable models are not served at llama-server's small
+// built-in default. It returns nil, leaving the flag off, when maxDefault is
+// not positive, the trained length is unknown, or --ctx-size/-c is already
+// passed through extraArgs or args.
+func resolveContextSize(isvc *inferencev1alpha1.InferenceService, model *inferencev1alpha1.Model, maxDefault int32) *int32 {
+	if isvc.Spec.ContextSize != nil {
+		return isvc.Spec.ContextSize
+	}
+	if maxDefault <= 0 || model == nil || model.Status.GGUF == nil || model.Status.GGUF.ContextLength == 0 {
+		return nil
+	}
+	userArgs := append(append([]string{}, isvc.Spec.ExtraArgs...), isvc.Spec.Args...)
+	if hasMatchingExtraArg(userArgs, "ctx-size") || slices.Contains(userArgs, "-c") {
+		return nil
+	}
+	size := maxDefault
+	if model.Status.GGUF.ContextLength < uint64(maxDefault) {
+		size = int32(model.Status.GGUF.ContextLength)
+	}
+	return &size
+}
+
 func appendContextSizeArgs(args []string, contextSize *int32) []string {
 	if contextSize != nil && *contextSize > 0 {
 		return append(args, "--ctx-size", fmt.Sprintf("%d", *contextSize))
@@ -85,6 +166,13 @@ func appendFlashAttentionArgs(args []string, flashAttention *bool, gpuPresent bo
 	return args
 }
 
+func appendMemoryLockArgs(args []string, memoryLock *bool) []string {
+	if memoryLock != nil && *memoryLock {
+		return append(args, "--mlock")
+	}
+	return args
+}
+
 func appendJinjaArgs(args []string, jinja *bool) []string {
 	if jinja != nil && *jinja {
 		return append(args, "--jinja")
@@ -216,6 +304,19 @@ func appendSpeculativeDecodingArgs(args []string, spec *inferencev1alpha1.Specul
 	return args
 }
 
+// appendLoRAArgs loads each spec.loraAdapters entry from where its downloader
+// staged it: --lora at the default scale, --lora-scaled when Scale is set.
+func appendLoRAArgs(args []string, adapters []inferencev1alpha1.LoRASpec) []string {
+	for _, adapter := range adapters {
+		if adapter.Scale == nil {
+			args = append(args, "--lora", loraAdapterPath(adapter))
+			continue
+		}
+		args = append(args, "--lora-scaled", loraAdapterPath(adapter), strconv.FormatFloat(*adapter.Scale, 'f', -1, 64))
+	}
+	return args
+}
+
 func appendReasoningBudgetArgs(args []string, budget *int32, message string) []string {
 	if budget == nil {
 		return args
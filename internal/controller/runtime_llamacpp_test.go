@@ -20,7 +20,9 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
 
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
@@ -55,7 +57,7 @@ func TestLlamaCppBuildArgs(t *testing.T) {
 				Runtime:  "llama",
 				ModelRef: "test-model",
 			},
-			notContains: []string{"--ctx-size", "--parallel", "--flash-attn", "--jinja", "--cache-type-k", "--cpu-moe", "--n-cpu-moe", "--no-kv-offload", "--override-tensor", "--override-kv", "--batch-size", "--ubatch-size", "--no-warmup", "--reasoning-budget", "--reasoning-budget-message", "--mmproj"},
+			notContains: []string{"--ctx-size", "--parallel", "--flash-attn", "--mlock", "--jinja", "--cache-type-k", "--cpu-moe", "--n-cpu-moe", "--no-kv-offload", "--override-tensor", "--override-kv", "--batch-size", "--ubatch-size", "--no-warmup", "--reasoning-budget", "--reasoning-budget-message", "--mmproj"},
 		},
 		{
 			// #972: bind the dual-stack wildcard (::), not 0.0.0.0, so pods are
@@ -252,6 +254,26 @@ func TestLlamaCppBuildArgs(t *testing.T) {
 			},
 			notContains: []string{"--flash-attn"},
 		},
+		{
+			model: model,
+			name:  "memoryLock=true emits flag",
+			spec: &inferencev1alpha1.InferenceServiceSpec{
+				Runtime:    "llama",
+				ModelRef:   "test-model",
+				MemoryLock: ptrBool(true),
+			},
+			contains: []FlagCheck{{"--mlock", ""}},
+		},
+		{
+			model: model,
+			name:  "memoryLock=false does not emit flag",
+			spec: &inferencev1alpha1.InferenceServiceSpec{
+				Runtime:    "llama",
+				ModelRef:   "test-model",
+				MemoryLock: ptrBool(false),
+			},
+			notContains: []string{"--mlock"},
+		},
 		{
 			model: model,
 			name:  "jinja=true emits flag",
@@ -686,3 +708,130 @@ func TestLlamaCppBuildArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestLlamaCppBuildArgsDefaultContextSize(t *testing.T) {
+	trained := func(n uint64) *inferencev1alpha1.Model {
+		return &inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"},
+			Status: inferencev1alpha1.ModelStatus{
+				GGUF: &inferencev1alpha1.GGUFMetadata{ContextLength: n},
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		max     int32
+		model   *inferencev1alpha1.Model
+		spec    inferencev1alpha1.InferenceServiceSpec
+		wantCtx string // "" means --ctx-size must not be emitted
+	}{
+		{name: "unset uses trained length", max: 32768, model: trained(16384), wantCtx: "16384"},
+		{name: "trained length capped at max", max: 32768, model: trained(131072), wantCtx: "32768"},
+		{name: "spec.contextSize wins", max: 32768, model: trained(16384), spec: inferencev1alpha1.InferenceServiceSpec{ContextSize: ptrInt32(4096)}, wantCtx: "4096"},
+		{name: "zero max disables the default", max: 0, model: trained(16384)},
+		{name: "unknown trained length leaves flag off", max: 32768, model: trained(0)},
+		{name: "extraArgs --ctx-size is not doubled", max: 32768, model: trained(16384), spec: inferencev1alpha1.InferenceServiceSpec{ExtraArgs: []string{"--ctx-size", "2048"}}},
+		{name: "extraArgs -c is not doubled", max: 32768, model: trained(16384), spec: inferencev1alpha1.InferenceServiceSpec{ExtraArgs: []string{"-c", "2048"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := &LlamaCppBackend{DefaultContextSizeMax: tc.max}
+			isvc := &inferencev1alpha1.InferenceService{Spec: tc.spec}
+			args := backend.BuildArgs(isvc, tc.model, "/models/test", 8080)
+			if tc.wantCtx == "" {
+				// Only a --ctx-size/-c passed through extraArgs may remain.
+				if countCtxFlags(args) != countCtxFlags(tc.spec.ExtraArgs) {
+					t.Errorf("expected no defaulted --ctx-size, got %v", args)
+				}
+				return
+			}
+			if !containsArg(args, "--ctx-size", tc.wantCtx) {
+				t.Errorf("expected --ctx-size %s, got %v", tc.wantCtx, args)
+			}
+		})
+	}
+}
+
+func TestGPULayersOverBlockCount(t *testing.T) {
+	newModel := func(layers int32, blocks uint64) *inferencev1alpha1.Model {
+		m := &inferencev1alpha1.Model{
+			Spec: inferencev1alpha1.ModelSpec{
+				Hardware: &inferencev1alpha1.HardwareSpec{
+					GPU: &inferencev1alpha1.GPUSpec{Layers: layers},
+				},
+			},
+		}
+		if blocks > 0 {
+			m.Status.GGUF = &inferencev1alpha1.GGUFMetadata{LayerCount: blocks}
+		}
+		return m
+	}
+
+	cases := []struct {
+		name   string
+		model  *inferencev1alpha1.Model
+		wantOK bool
+	}{
+		{"over-specified layer count", newModel(200, 32), true},
+		{"one past the output layer", newModel(34, 32), true},
+		{"full offload including the output layer", newModel(33, 32), false},
+		{"partial offload", newModel(20, 32), false},
+		{"auto sentinel", newModel(-1, 32), false},
+		{"all-layers sentinel", newModel(99, 32), false},
+		{"block count unknown", newModel(200, 0), false},
+		{"no gpu spec", &inferencev1alpha1.Model{}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, over := gpuLayersOverBlockCount(tc.model); over != tc.wantOK {
+				t.Errorf("gpuLayersOverBlockCount() over = %v, want %v", over, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestReconcileGPULayersCondition(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: inferencev1alpha1.ModelSpec{
+			Hardware: &inferencev1alpha1.HardwareSpec{GPU: &inferencev1alpha1.GPUSpec{Layers: 200}},
+		},
+		Status: inferencev1alpha1.ModelStatus{GGUF: &inferencev1alpha1.GGUFMetadata{LayerCount: 32}},
+	}
+	isvc := &inferencev1alpha1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default", Generation: 1}}
+	recorder := events.NewFakeRecorder(10)
+	r := &InferenceServiceReconciler{Recorder: recorder}
+
+	r.reconcileGPULayersCondition(isvc, model)
+	cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionGPULayersValid)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "GPULayersExceedBlockCount" {
+		t.Fatalf("expected a False GPULayersValid condition, got %+v", cond)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected one GPULayersExceedBlockCount event, got %d", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	// A second reconcile keeps the condition without repeating the event.
+	r.reconcileGPULayersCondition(isvc, model)
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no repeated event, got %d", len(recorder.Events))
+	}
+
+	model.Spec.Hardware.GPU.Layers = -1
+	r.reconcileGPULayersCondition(isvc, model)
+	if cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionGPULayersValid); cond != nil {
+		t.Errorf("expected the condition removed once the layer count fits, got %+v", cond)
+	}
+}
+
+func countCtxFlags(args []string) int {
+	n := 0
+	for _, a := range args {
+		if a == "--ctx-size" || a == "-c" {
+			n++
+		}
+	}
+	return n
+}
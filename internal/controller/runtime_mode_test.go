@@ -1,8 +1,15 @@
 package controller
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
 
@@ -74,3 +81,216 @@ func TestAppendModeArgs(t *testing.T) {
 		}
 	})
 }
+
+func TestServingModeEndpointPath(t *testing.T) {
+	cases := []struct {
+		name string
+		mode string
+		path string
+		want string
+	}{
+		{name: "chat defaults to chat completions", want: "/v1/chat/completions"},
+		{name: "embedding defaults to embeddings", mode: servingModeEmbedding, want: "/v1/embeddings"},
+		{name: "rerank defaults to rerank", mode: servingModeRerank, want: "/v1/rerank"},
+		{name: "explicit path wins over the mode", mode: servingModeEmbedding, path: "/embedding/", want: "/embedding"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{}
+			isvc.Spec.Mode = tc.mode
+			isvc.Spec.Endpoint = &inferencev1alpha1.EndpointSpec{Port: 8080, Path: tc.path}
+			if got := servingModeEndpointPath(isvc); got != tc.want {
+				t.Fatalf("servingModeEndpointPath() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConstructDeploymentServingModeArgs(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "bge", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/bge.gguf"},
+	}
+	cases := []struct {
+		name    string
+		mode    string
+		want    []string
+		notWant []string
+	}{
+		{name: "chat", mode: servingModeChat, notWant: []string{"--embedding", "--reranking", "--pooling"}},
+		{name: "embedding", mode: servingModeEmbedding, want: []string{"--embedding", "--pooling"}, notWant: []string{"--reranking"}},
+		{name: "rerank", mode: servingModeRerank, want: []string{"--reranking", "--embedding", "--pooling"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "bge", Namespace: "default"},
+				Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: model.Name, Mode: tc.mode},
+			}
+			deployment := (&InferenceServiceReconciler{}).constructDeployment(isvc, model, 1)
+			args := deployment.Spec.Template.Spec.Containers[0].Args
+			for _, flag := range tc.want {
+				if !slices.Contains(args, flag) {
+					t.Errorf("expected %s in %v", flag, args)
+				}
+			}
+			for _, flag := range tc.notWant {
+				if slices.Contains(args, flag) {
+					t.Errorf("did not expect %s in %v", flag, args)
+				}
+			}
+		})
+	}
+}
+
+func TestConstructEndpointAPIStyle(t *testing.T) {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"}}
+	cases := []struct {
+		name     string
+		apiStyle string
+		mode     string
+		path     string
+		want     string
+	}{
+		{name: "chat by default", want: "/v1/chat/completions"},
+		{name: "completions", apiStyle: inferencev1alpha1.APIStyleCompletions, want: "/v1/completions"},
+		{name: "legacy", apiStyle: inferencev1alpha1.APIStyleLegacy, want: "/completion"},
+		{name: "explicit path wins", apiStyle: inferencev1alpha1.APIStyleLegacy, path: "/v1/chat/completions", want: "/v1/chat/completions"},
+		{name: "embedding mode wins", apiStyle: inferencev1alpha1.APIStyleCompletions, mode: servingModeEmbedding, want: "/v1/embeddings"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{}
+			isvc.Spec.Mode = tc.mode
+			isvc.Spec.Endpoint = &inferencev1alpha1.EndpointSpec{Port: 8080, Path: tc.path, APIStyle: tc.apiStyle}
+			got := (&InferenceServiceReconciler{}).constructEndpoint(isvc, svc)
+			if want := "http://llm.default.svc.cluster.local:8080" + tc.want; got != want {
+				t.Fatalf("constructEndpoint() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	legacyVLLM := &inferencev1alpha1.InferenceService{}
+	legacyVLLM.Spec.Runtime = RuntimeVLLM
+	legacyVLLM.Spec.Endpoint = &inferencev1alpha1.EndpointSpec{APIStyle: inferencev1alpha1.APIStyleLegacy}
+	if err := validateEndpointPath(legacyVLLM); err == nil {
+		t.Error("expected the legacy apiStyle to be rejected for vLLM")
+	}
+	legacyVLLM.Spec.Endpoint.APIStyle = inferencev1alpha1.APIStyleCompletions
+	if err := validateEndpointPath(legacyVLLM); err != nil {
+		t.Errorf("expected the completions apiStyle to be accepted for vLLM, got %v", err)
+	}
+}
+
+func TestReconcileEndpointRouteCondition(t *testing.T) {
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default", Generation: 1},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{Endpoint: &inferencev1alpha1.EndpointSpec{Path: "/v1/custom/"}},
+	}
+	recorder := events.NewFakeRecorder(10)
+	r := &InferenceServiceReconciler{Recorder: recorder}
+
+	// An unknown path warns but is not fatal.
+	if err := validateEndpointPath(isvc); err != nil {
+		t.Fatalf("expected an unknown path not to fail the service, got %v", err)
+	}
+	r.reconcileEndpointRouteCondition(isvc)
+	cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionEndpointRouteKnown)
+	if cond == nil || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, `"/v1/custom"`) {
+		t.Fatalf("expected a False condition naming the normalized path, got %+v", cond)
+	}
+	select {
+	case ev := <-recorder.Events:
+		if !strings.HasPrefix(ev, "Warning UnknownEndpointRoute") {
+			t.Errorf("unexpected event %q", ev)
+		}
+	default:
+		t.Error("expected an UnknownEndpointRoute warning event")
+	}
+
+	// A second pass keeps the condition without repeating the event.
+	r.reconcileEndpointRouteCondition(isvc)
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no repeated event, got %d", len(recorder.Events))
+	}
+
+	// A known route drops the condition.
+	isvc.Spec.Endpoint.Path = "/v1"
+	r.reconcileEndpointRouteCondition(isvc)
+	if cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionEndpointRouteKnown); cond != nil {
+		t.Errorf("expected the condition removed for a known route, got %+v", cond)
+	}
+}
+
+func TestRerankArchitectureUnsupported(t *testing.T) {
+	newModel := func(arch string) *inferencev1alpha1.Model {
+		m := &inferencev1alpha1.Model{}
+		if arch != "" {
+			m.Status.GGUF = &inferencev1alpha1.GGUFMetadata{Architecture: arch}
+		}
+		return m
+	}
+	cases := []struct {
+		name    string
+		mode    string
+		runtime string
+		model   *inferencev1alpha1.Model
+		want    bool
+	}{
+		{name: "bert reranker", mode: servingModeRerank, model: newModel("bert"), want: false},
+		{name: "qwen3 reranker", mode: servingModeRerank, model: newModel("qwen3"), want: false},
+		{name: "decoder-only chat model", mode: servingModeRerank, model: newModel("llama"), want: true},
+		{name: "architecture not parsed yet", mode: servingModeRerank, model: newModel(""), want: false},
+		{name: "chat mode is never flagged", mode: servingModeChat, model: newModel("llama"), want: false},
+		{name: "other runtimes are not checked", mode: servingModeRerank, runtime: RuntimeVLLM, model: newModel("llama"), want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isvc := &inferencev1alpha1.InferenceService{}
+			isvc.Spec.Mode = tc.mode
+			isvc.Spec.Runtime = tc.runtime
+			if _, got := rerankArchitectureUnsupported(isvc, tc.model); got != tc.want {
+				t.Fatalf("rerankArchitectureUnsupported() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconcileRerankSupportedCondition(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Status:     inferencev1alpha1.ModelStatus{GGUF: &inferencev1alpha1.GGUFMetadata{Architecture: "llama"}},
+	}
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default", Generation: 1},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{Mode: servingModeRerank},
+	}
+	recorder := events.NewFakeRecorder(10)
+	r := &InferenceServiceReconciler{Recorder: recorder}
+
+	r.reconcileRerankSupportedCondition(isvc, model)
+	cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionRerankSupported)
+	if cond == nil || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, `"llama"`) {
+		t.Fatalf("expected a False RerankSupported condition naming the architecture, got %+v", cond)
+	}
+	select {
+	case ev := <-recorder.Events:
+		if !strings.HasPrefix(ev, "Warning RerankUnsupportedArchitecture") {
+			t.Errorf("unexpected event %q", ev)
+		}
+	default:
+		t.Error("expected a RerankUnsupportedArchitecture warning event")
+	}
+
+	// A second pass keeps the condition without repeating the event.
+	r.reconcileRerankSupportedCondition(isvc, model)
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no repeated event, got %d", len(recorder.Events))
+	}
+
+	model.Status.GGUF.Architecture = "bert"
+	r.reconcileRerankSupportedCondition(isvc, model)
+	if cond := meta.FindStatusCondition(isvc.Status.Conditions, ConditionRerankSupported); cond != nil {
+		t.Errorf("expected the condition removed for a reranker architecture, got %+v", cond)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"io/fs"
 	"path/filepath"
 	"strings"
+
+	"github.com/defilantech/llmkube/pkg/gguf"
 )
 
 // isUnrecoverableFetchError reports whether err is the kind of failure that
@@ -53,6 +55,22 @@ func isUnrecoverableFetchError(err error) bool {
 	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission)
 }
 
+// errChecksumMismatch marks a fetched file whose hash differs from
+// spec.sha256, as opposed to a file that could not be hashed at all.
+var errChecksumMismatch = errors.New("SHA256 mismatch")
+
+// isChecksumMismatch reports whether err is a spec.sha256 mismatch.
+func isChecksumMismatch(err error) bool {
+	return errors.Is(err, errChecksumMismatch)
+}
+
+// isInvalidGGUF reports whether a GGUF parse error means the bytes are not a
+// GGUF file at all, as opposed to the source being unreachable or the parser
+// hitting a limit. Only the magic and version checks are conclusive.
+func isInvalidGGUF(err error) bool {
+	return errors.Is(err, gguf.ErrInvalidMagic) || errors.Is(err, gguf.ErrUnsupportedVersion)
+}
+
 // isPVCSource returns true if the source uses the pvc:// scheme.
 func isPVCSource(source string) bool {
 	return strings.HasPrefix(source, "pvc://")
@@ -98,6 +116,70 @@ func parseS3Source(source string) (bucket, key string, err error) {
 	return bucket, key, nil
 }
 
+// isGCSSource reports whether source is a gs:// (Google Cloud Storage) URL.
+// Case-folded like isS3Source.
+func isGCSSource(source string) bool {
+	return hasSchemeFold(source, "gs://")
+}
+
+// parseGCSSource splits gs://bucket/object into bucket and object. As with
+// S3, credentials come from the sourceSecretRef env (an HMAC key pair in
+// AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY), never from the URL.
+func parseGCSSource(source string) (bucket, object string, err error) {
+	if !isGCSSource(source) {
+		return "", "", fmt.Errorf("not a GCS source: %s", source)
+	}
+
+	rest := source[len("gs://"):]
+	if rest == "" {
+		return "", "", fmt.Errorf("empty GCS source: %s", source)
+	}
+	bucket, object, found := strings.Cut(rest, "/")
+	if !found {
+		return "", "", fmt.Errorf("GCS source must include an object: %s (expected gs://bucket/object)", source)
+	}
+	if bucket == "" {
+		return "", "", fmt.Errorf("GCS source has empty bucket: %s", source)
+	}
+	if object == "" {
+		return "", "", fmt.Errorf("GCS source has empty object: %s", source)
+	}
+	return bucket, object, nil
+}
+
+// Source types reported in Model.status.sourceType.
+const (
+	SourceTypeHTTP        = "http"
+	SourceTypeHuggingFace = "huggingface"
+	SourceTypeS3          = "s3"
+	SourceTypeGCS         = "gcs"
+	SourceTypePVC         = "pvc"
+	SourceTypeLocal       = "local"
+)
+
+// modelSourceType classifies source for status.sourceType, in the same
+// precedence the reconciler dispatches on. hf:// and bare repo IDs are
+// huggingface; https://huggingface.co URLs stay http since they are fetched
+// as plain URLs. Returns "" for a source no classifier accepts.
+func modelSourceType(source string) string {
+	switch {
+	case isPVCSource(source):
+		return SourceTypePVC
+	case isS3Source(source):
+		return SourceTypeS3
+	case isGCSSource(source):
+		return SourceTypeGCS
+	case isLocalSource(source):
+		return SourceTypeLocal
+	case isRemoteHTTPSource(source):
+		return SourceTypeHTTP
+	case isHFRepoSource(source):
+		return SourceTypeHuggingFace
+	default:
+		return ""
+	}
+}
+
 // parsePVCSource extracts the PVC claim name and file path from a pvc:// source.
 // Format: pvc://claim-name/path/to/model.gguf
 func parsePVCSource(source string) (claimName, path string, err error) {
@@ -310,7 +392,7 @@ func isHFRepoSource(source string) bool {
 	if isPVCSource(source) {
 		return false
 	}
-	if isS3Source(source) {
+	if isS3Source(source) || isGCSSource(source) {
 		return false
 	}
 	if isLocalSource(source) {
@@ -471,3 +471,61 @@ var _ = Describe("isHFRepoSource rejects s3:// (source.go)", func() {
 		Expect(isHFRepoSource("S3://my-bucket/model.gguf")).To(BeFalse())
 	})
 })
+
+var _ = Describe("isGCSSource (source.go)", func() {
+	It("should return true for gs:// sources", func() {
+		Expect(isGCSSource("gs://my-bucket/model.gguf")).To(BeTrue())
+		Expect(isGCSSource("GS://my-bucket/model.gguf")).To(BeTrue())
+	})
+	It("should return false for other schemes", func() {
+		Expect(isGCSSource("s3://my-bucket/model.gguf")).To(BeFalse())
+		Expect(isGCSSource("https://storage.googleapis.com/my-bucket/model.gguf")).To(BeFalse())
+	})
+	It("should not be treated as a Hugging Face repo", func() {
+		Expect(isHFRepoSource("gs://my-bucket/model.gguf")).To(BeFalse())
+	})
+})
+
+var _ = Describe("parseGCSSource (source.go)", func() {
+	It("should parse a nested object path", func() {
+		bucket, object, err := parseGCSSource("gs://models-bucket/llama/7b/model.gguf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bucket).To(Equal("models-bucket"))
+		Expect(object).To(Equal("llama/7b/model.gguf"))
+	})
+
+	It("should error on a missing object", func() {
+		_, _, err := parseGCSSource("gs://models-bucket")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("must include an object"))
+	})
+
+	It("should error on an empty bucket", func() {
+		_, _, err := parseGCSSource("gs:///model.gguf")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("empty bucket"))
+	})
+
+	It("should error on a non-GCS source", func() {
+		_, _, err := parseGCSSource("s3://bucket/model.gguf")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not a GCS source"))
+	})
+})
+
+var _ = Describe("modelSourceType (source.go)", func() {
+	DescribeTable("classifies each scheme",
+		func(source, want string) {
+			Expect(modelSourceType(source)).To(Equal(want))
+		},
+		Entry("https", "https://example.com/model.gguf", SourceTypeHTTP),
+		Entry("hf://", "hf://org/repo", SourceTypeHuggingFace),
+		Entry("bare repo id", "Qwen/Qwen3-8B", SourceTypeHuggingFace),
+		Entry("s3", "s3://bucket/model.gguf", SourceTypeS3),
+		Entry("gs", "gs://bucket/model.gguf", SourceTypeGCS),
+		Entry("case-variant gs", "GS://bucket/model.gguf", SourceTypeGCS),
+		Entry("pvc", "pvc://claim/model.gguf", SourceTypePVC),
+		Entry("absolute path", "/mnt/models/model.gguf", SourceTypeLocal),
+		Entry("file://", "file:///mnt/models/model.gguf", SourceTypeLocal),
+	)
+})
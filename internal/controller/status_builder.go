@@ -134,18 +134,11 @@ func setSuspendedCondition(isvc *inferencev1alpha1.InferenceService) {
 
 func (r *InferenceServiceReconciler) constructEndpoint(isvc *inferencev1alpha1.InferenceService, svc *corev1.Service) string {
 	port := int32(8080)
-	path := "/v1/chat/completions"
-
-	if isvc.Spec.Endpoint != nil {
-		if isvc.Spec.Endpoint.Port > 0 {
-			port = isvc.Spec.Endpoint.Port
-		}
-		if isvc.Spec.Endpoint.Path != "" {
-			path = isvc.Spec.Endpoint.Path
-		}
+	if isvc.Spec.Endpoint != nil && isvc.Spec.Endpoint.Port > 0 {
+		port = isvc.Spec.Endpoint.Port
 	}
 
-	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", svc.Name, svc.Namespace, port, path)
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", svc.Name, svc.Namespace, port, servingModeEndpointPath(isvc))
 }
 
 // publishInferenceServiceState exports the phase, replica and info series from
@@ -312,5 +305,35 @@ func (r *InferenceServiceReconciler) updateStatusWithSchedulingInfo(
 		return ctrl.Result{}, err
 	}
 
+	if phase != previousPhase {
+		r.recordPhaseTransition(isvc, phase, errorMsg)
+	}
+
 	return ctrl.Result{}, nil
 }
+
+// recordPhaseTransition emits an Event for the phase isvc just moved into, so
+// `kubectl describe` explains a service stuck in Pending or Creating without
+// the controller logs. Only called on an actual transition, so a service
+// sitting in one phase does not repeat the same Event every reconcile.
+func (r *InferenceServiceReconciler) recordPhaseTransition(isvc *inferencev1alpha1.InferenceService, phase, errorMsg string) {
+	if r.Recorder == nil {
+		return
+	}
+	switch phase {
+	case "Pending":
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeNormal, "ModelNotReady", "Reconcile",
+			"Waiting for Model %q to be Ready", isvc.Spec.ModelRef)
+	case PhaseCreating, "Progressing":
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeNormal, "DeploymentCreated", "Reconcile",
+			"Deployment created, %d/%d replicas ready", isvc.Status.ReadyReplicas, isvc.Status.DesiredReplicas)
+	case PhaseWaitingForGPU:
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "WaitingForGPU", "Reconcile",
+			"Waiting for GPU resources: %s", isvc.Status.WaitingFor)
+	case PhaseReady:
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeNormal, "Ready", "Reconcile",
+			"Inference service is ready and serving requests")
+	case PhaseFailed:
+		r.Recorder.Eventf(isvc, nil, corev1.EventTypeWarning, "Failed", "Reconcile", "%s", errorMsg)
+	}
+}
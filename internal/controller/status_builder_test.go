@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestUpdateStatusRecordsPhaseTransitionEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: "llama"},
+	}
+	recorder := events.NewFakeRecorder(10)
+	r := &InferenceServiceReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(isvc).WithStatusSubresource(isvc).Build(),
+		Recorder: recorder,
+	}
+
+	steps := []struct {
+		phase    string
+		errorMsg string
+		want     string
+	}{
+		{"Pending", "Waiting for Model to be Ready", `Normal ModelNotReady Waiting for Model "llama" to be Ready`},
+		{PhaseCreating, "", "Normal DeploymentCreated Deployment created, 0/1 replicas ready"},
+		// Same phase again: no repeated Event.
+		{PhaseCreating, "", ""},
+		{PhaseReady, "", "Normal Ready Inference service is ready and serving requests"},
+		{PhaseFailed, "Failed to create Service", "Warning Failed Failed to create Service"},
+	}
+	for _, step := range steps {
+		ready := int32(0)
+		if step.phase == PhaseReady {
+			ready = 1
+		}
+		if _, err := r.updateStatusWithSchedulingInfo(context.Background(), isvc, step.phase, true, ready, 1, "", step.errorMsg, nil); err != nil {
+			t.Fatalf("%s: status update failed: %v", step.phase, err)
+		}
+
+		select {
+		case ev := <-recorder.Events:
+			if ev != step.want {
+				t.Errorf("%s: event = %q, want %q", step.phase, ev, step.want)
+			}
+		default:
+			if step.want != "" {
+				t.Errorf("%s: expected event %q, got none", step.phase, step.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// PodConditionServing is the readiness gate of spec.warmStandby pods. A pod
+// only turns Ready, and so only joins the Service endpoints, once the
+// controller sets this condition True on it; the standby keeps it False
+// while its server is loaded and healthy.
+const PodConditionServing corev1.PodConditionType = "inference.llmkube.dev/serving"
+
+// deploymentRevisionAnnotation is set by the Deployment controller on a
+// Deployment and its ReplicaSets; the ReplicaSet whose value matches the
+// Deployment's is the current one.
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+func validateWarmStandby(isvc *inferencev1alpha1.InferenceService) error {
+	if !isvc.Spec.WarmStandby {
+		return nil
+	}
+	// The HPA would count the standby as capacity and size it away.
+	if isvc.Spec.Autoscaling != nil {
+		return errors.New("cannot be combined with autoscaling")
+	}
+	// Recycling waits for every pod to be Ready, which a standby never is.
+	if isvc.Spec.MaxPodLifetimeSeconds != nil {
+		return errors.New("cannot be combined with maxPodLifetimeSeconds")
+	}
+	return nil
+}
+
+// applyWarmStandby adds the standby replica and the serving readiness gate
+// to the inference Deployment. A suspended (zero-replica) service gets no
+// standby.
+//
+// The standby is never Ready, so a rolling update with the default
+// maxUnavailable of 25% (0 for small services) could never take an old pod
+// down. maxUnavailable 1 lets the Deployment remove an old pod once
+// planWarmStandby has moved its traffic to a pod of the new revision.
+// Recreate (GPU and DRA workloads) is left as is.
+func applyWarmStandby(deployment *appsv1.Deployment, isvc *inferencev1alpha1.InferenceService) {
+	if !isvc.Spec.WarmStandby {
+		return
+	}
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 0 {
+		replicas := *deployment.Spec.Replicas + 1
+		deployment.Spec.Replicas = &replicas
+	}
+	deployment.Spec.Template.Spec.ReadinessGates = append(deployment.Spec.Template.Spec.ReadinessGates,
+		corev1.PodReadinessGate{ConditionType: PodConditionServing})
+	if deployment.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+		maxUnavailable := intstr.FromInt32(1)
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable},
+		}
+	}
+}
+
+func podConditionTrue(pod *corev1.Pod, condType corev1.PodConditionType) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == condType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// planWarmStandby picks the pods whose serving gate must flip so that
+// exactly serving healthy pods (containers ready) serve traffic. Pods
+// already serving keep serving; a healthy standby of the current revision
+// (pod-template-hash) is promoted before an outdated one, then the oldest
+// first, and when a failed pod recovers and leaves one too many serving, an
+// outdated pod, then the youngest, is demoted first. During a rollout each
+// healthy current-revision standby also takes over from an outdated serving
+// pod, which is what lets the Deployment scale the old ReplicaSet down.
+// Terminating pods are ignored.
+func planWarmStandby(pods []*corev1.Pod, serving int32, revision string) (promote, demote []*corev1.Pod) {
+	var active, standby []*corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !podConditionTrue(pod, corev1.ContainersReady) {
+			continue
+		}
+		if podConditionTrue(pod, PodConditionServing) {
+			active = append(active, pod)
+		} else {
+			standby = append(standby, pod)
+		}
+	}
+	outdated := func(pod *corev1.Pod) bool {
+		return revision != "" && pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey] != revision
+	}
+	older := func(a, b *corev1.Pod) bool {
+		ta, tb := a.CreationTimestamp, b.CreationTimestamp
+		if !ta.Equal(&tb) {
+			return ta.Before(&tb)
+		}
+		return a.Name < b.Name
+	}
+	// Promotion order: current revision first, then oldest.
+	sort.Slice(standby, func(i, j int) bool {
+		if oi, oj := outdated(standby[i]), outdated(standby[j]); oi != oj {
+			return oj
+		}
+		return older(standby[i], standby[j])
+	})
+	// Demotion order: outdated first, then youngest.
+	sort.Slice(active, func(i, j int) bool {
+		if oi, oj := outdated(active[i]), outdated(active[j]); oi != oj {
+			return oi
+		}
+		return older(active[j], active[i])
+	})
+
+	switch missing := int(serving) - len(active); {
+	case missing > 0:
+		n := min(missing, len(standby))
+		promote, standby = standby[:n], standby[n:]
+	case missing < 0:
+		demote, active = active[:-missing], active[-missing:]
+	}
+	for len(standby) > 0 && !outdated(standby[0]) && len(active) > 0 && outdated(active[0]) {
+		promote = append(promote, standby[0])
+		demote = append(demote, active[0])
+		standby, active = standby[1:], active[1:]
+	}
+	return promote, demote
+}
+
+// currentRevisionHash returns the pod-template-hash of the Deployment's
+// current ReplicaSet, or "" while the Deployment controller has not created
+// it yet. deployment may be the desired object, so the revision and UID are
+// read from the live one.
+func (r *InferenceServiceReconciler) currentRevisionHash(ctx context.Context, deployment *appsv1.Deployment) (string, error) {
+	live := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), live); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	revision := live.Annotations[deploymentRevisionAnnotation]
+	if revision == "" {
+		return "", nil
+	}
+	listOpts := []client.ListOption{client.InNamespace(deployment.Namespace)}
+	if deployment.Spec.Selector != nil {
+		listOpts = append(listOpts, client.MatchingLabels(deployment.Spec.Selector.MatchLabels))
+	}
+	replicaSets := &appsv1.ReplicaSetList{}
+	if err := r.List(ctx, replicaSets, listOpts...); err != nil {
+		return "", err
+	}
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		if metav1.IsControlledBy(rs, live) && rs.Annotations[deploymentRevisionAnnotation] == revision {
+			return rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey], nil
+		}
+	}
+	return "", nil
+}
+
+// reconcileWarmStandby flips the serving gate of the Deployment's pods per
+// planWarmStandby. The Pod watch brings the controller back here whenever a
+// pod's readiness changes, which is what makes the failover prompt.
+func (r *InferenceServiceReconciler) reconcileWarmStandby(ctx context.Context, isvc *inferencev1alpha1.InferenceService, deployment *appsv1.Deployment, serving int32) error {
+	if !isvc.Spec.WarmStandby || deployment == nil {
+		return nil
+	}
+	pods, err := r.activePods(ctx, deployment)
+	if err != nil {
+		return err
+	}
+
+	revision, err := r.currentRevisionHash(ctx, deployment)
+	if err != nil {
+		return err
+	}
+
+	promote, demote := planWarmStandby(pods, serving, revision)
+	for _, pod := range promote {
+		if err := r.setServingCondition(ctx, pod, corev1.ConditionTrue, "Promoted"); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Promoted warm standby pod to serving", "pod", pod.Name)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(isvc, nil, corev1.EventTypeNormal, "StandbyPromoted", "Reconcile",
+				"Pod %s now receives traffic", pod.Name)
+		}
+	}
+	for _, pod := range demote {
+		if err := r.setServingCondition(ctx, pod, corev1.ConditionFalse, "Standby"); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Moved surplus serving pod to warm standby", "pod", pod.Name)
+	}
+	return nil
+}
+
+func (r *InferenceServiceReconciler) setServingCondition(ctx context.Context, pod *corev1.Pod, status corev1.ConditionStatus, reason string) error {
+	patch := client.StrategicMergeFrom(pod.DeepCopy())
+	condition := corev1.PodCondition{
+		Type:               PodConditionServing,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+	}
+	replaced := false
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == PodConditionServing {
+			pod.Status.Conditions[i] = condition
+			replaced = true
+		}
+	}
+	if !replaced {
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	}
+	if err := r.Status().Patch(ctx, pod, patch); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
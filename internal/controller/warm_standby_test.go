@@ -0,0 +1,304 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func TestWarmStandbyDeploymentAddsReplicaAndReadinessGate(t *testing.T) {
+	model := &inferencev1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/model.gguf"},
+	}
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: model.Name, WarmStandby: true},
+	}
+	r := &InferenceServiceReconciler{}
+
+	deployment := r.constructDeployment(isvc, model, 2)
+	applyWarmStandby(deployment, isvc)
+	if got := *deployment.Spec.Replicas; got != 3 {
+		t.Errorf("replicas = %d, want 2 serving + 1 standby", got)
+	}
+	gates := deployment.Spec.Template.Spec.ReadinessGates
+	if len(gates) != 1 || gates[0].ConditionType != PodConditionServing {
+		t.Errorf("readiness gates = %v, want %s", gates, PodConditionServing)
+	}
+	if strategy := deployment.Spec.Strategy; strategy.Type != appsv1.RollingUpdateDeploymentStrategyType ||
+		strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxUnavailable.IntValue() != 1 {
+		t.Errorf("strategy = %+v, want a rolling update with maxUnavailable 1", strategy)
+	}
+
+	recreate := r.constructDeployment(isvc, model, 2)
+	recreate.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	applyWarmStandby(recreate, isvc)
+	if recreate.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+		t.Errorf("strategy = %s, want Recreate kept", recreate.Spec.Strategy.Type)
+	}
+
+	suspended := r.constructDeployment(isvc, model, 0)
+	applyWarmStandby(suspended, isvc)
+	if got := *suspended.Spec.Replicas; got != 0 {
+		t.Errorf("suspended replicas = %d, want no standby", got)
+	}
+
+	off := isvc.DeepCopy()
+	off.Spec.WarmStandby = false
+	plain := r.constructDeployment(off, model, 2)
+	applyWarmStandby(plain, off)
+	if *plain.Spec.Replicas != 2 || len(plain.Spec.Template.Spec.ReadinessGates) != 0 {
+		t.Errorf("warmStandby off changed the Deployment: replicas=%d gates=%v",
+			*plain.Spec.Replicas, plain.Spec.Template.Spec.ReadinessGates)
+	}
+
+	withHPA := isvc.DeepCopy()
+	withHPA.Spec.Autoscaling = &inferencev1alpha1.AutoscalingSpec{MaxReplicas: 3}
+	withLifetime := isvc.DeepCopy()
+	withLifetime.Spec.MaxPodLifetimeSeconds = ptr.To[int64](3600)
+	for _, bad := range []*inferencev1alpha1.InferenceService{withHPA, withLifetime} {
+		if err := validateWarmStandby(bad); err == nil {
+			t.Errorf("expected %+v to be rejected", bad.Spec)
+		}
+	}
+}
+
+// inEndpoints mirrors the kubelet: a pod is Ready, and listed in the
+// Service endpoints, only when its containers are ready and every readiness
+// gate condition is True.
+func inEndpoints(pod *corev1.Pod) bool {
+	if !podConditionTrue(pod, corev1.ContainersReady) {
+		return false
+	}
+	for _, gate := range pod.Spec.ReadinessGates {
+		if !podConditionTrue(pod, gate.ConditionType) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWarmStandbyExcludedFromEndpointsUntilPromotion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{WarmStandby: true},
+	}
+	selector := map[string]string{"inference.llmkube.dev/service": "llama"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+	}
+
+	created := time.Date(2026, 7, 22, 12, 0, 0, 0, time.UTC)
+	var objects []client.Object
+	for i := range 3 {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("llama-%d", i), Namespace: "default", Labels: selector,
+				CreationTimestamp: metav1.NewTime(created.Add(time.Duration(i) * time.Minute)),
+			},
+			Spec: corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: PodConditionServing}}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}},
+			},
+		})
+	}
+	r := &InferenceServiceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&corev1.Pod{}).Build(),
+	}
+	ctx := context.Background()
+
+	reconcile := func() {
+		t.Helper()
+		if err := r.reconcileWarmStandby(ctx, isvc, deployment, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get := func(name string) *corev1.Pod {
+		t.Helper()
+		pod := &corev1.Pod{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, pod); err != nil {
+			t.Fatal(err)
+		}
+		return pod
+	}
+	wantServing := func(step string, want ...bool) {
+		t.Helper()
+		for i, w := range want {
+			if got := inEndpoints(get(fmt.Sprintf("llama-%d", i))); got != w {
+				t.Errorf("%s: llama-%d in endpoints = %v, want %v", step, i, got, w)
+			}
+		}
+	}
+
+	// The two oldest pods serve; the youngest loads but stays out.
+	reconcile()
+	wantServing("initial", true, true, false)
+	reconcile()
+	wantServing("steady state", true, true, false)
+
+	// A serving pod's server fails: the standby takes its place.
+	failed := get("llama-0")
+	failed.Status.Conditions[0].Status = corev1.ConditionFalse
+	if err := r.Status().Update(ctx, failed); err != nil {
+		t.Fatal(err)
+	}
+	reconcile()
+	wantServing("after failure", false, true, true)
+
+	// The failed pod recovers with its gate still set: one pod too many
+	// serves, and the youngest goes back to standby.
+	recovered := get("llama-0")
+	recovered.Status.Conditions[0].Status = corev1.ConditionTrue
+	if err := r.Status().Update(ctx, recovered); err != nil {
+		t.Fatal(err)
+	}
+	reconcile()
+	wantServing("after recovery", true, true, false)
+}
+
+func TestWarmStandbyRolloutHandsTrafficToNewRevision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	isvc := &inferencev1alpha1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.InferenceServiceSpec{WarmStandby: true},
+	}
+	selector := map[string]string{"inference.llmkube.dev/service": "llama"}
+	// The template changed: revision 2 (hash "new") is current.
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "llama", Namespace: "default", UID: "deploy-uid",
+			Annotations: map[string]string{deploymentRevisionAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+	}
+	replicaSet := func(hash, revision string) *appsv1.ReplicaSet {
+		labels := map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}
+		for k, v := range selector {
+			labels[k] = v
+		}
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "llama-" + hash, Namespace: "default", Labels: labels,
+			Annotations: map[string]string{deploymentRevisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "llama", UID: "deploy-uid", Controller: ptr.To(true),
+			}},
+		}}
+	}
+
+	created := time.Date(2026, 7, 22, 12, 0, 0, 0, time.UTC)
+	newPod := func(name, hash string, minute int, serving bool) *corev1.Pod {
+		labels := map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}
+		for k, v := range selector {
+			labels[k] = v
+		}
+		conditions := []corev1.PodCondition{{Type: corev1.ContainersReady, Status: corev1.ConditionTrue}}
+		if serving {
+			conditions = append(conditions, corev1.PodCondition{Type: PodConditionServing, Status: corev1.ConditionTrue})
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "default", Labels: labels,
+				CreationTimestamp: metav1.NewTime(created.Add(time.Duration(minute) * time.Minute)),
+			},
+			Spec:   corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: PodConditionServing}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: conditions},
+		}
+	}
+
+	// Two old pods serve, the old standby is loaded, and the surge pod of
+	// the new revision has just become healthy.
+	r := &InferenceServiceReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(deployment, replicaSet("old", "1"), replicaSet("new", "2"),
+				newPod("old-0", "old", 0, true), newPod("old-1", "old", 1, true),
+				newPod("old-2", "old", 2, false), newPod("new-0", "new", 10, false)).
+			WithStatusSubresource(&corev1.Pod{}).Build(),
+	}
+	ctx := context.Background()
+
+	// The reconciler passes the desired Deployment, which carries neither
+	// the revision annotation nor the UID.
+	desired := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       deployment.Spec,
+	}
+	reconcile := func() {
+		t.Helper()
+		if err := r.reconcileWarmStandby(ctx, isvc, desired, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wantServing := func(step string, want map[string]bool) {
+		t.Helper()
+		for name, w := range want {
+			pod := &corev1.Pod{}
+			if err := r.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, pod); err != nil {
+				t.Fatal(err)
+			}
+			if got := inEndpoints(pod); got != w {
+				t.Errorf("%s: %s in endpoints = %v, want %v", step, name, got, w)
+			}
+		}
+	}
+
+	// The new pod takes over from the youngest old one, which turns
+	// unready so the Deployment can scale the old ReplicaSet down.
+	reconcile()
+	wantServing("surge pod ready", map[string]bool{"old-0": true, "old-1": false, "old-2": false, "new-0": true})
+	reconcile()
+	wantServing("no flapping", map[string]bool{"old-0": true, "old-1": false, "old-2": false, "new-0": true})
+
+	// The Deployment replaces the unready old pods with new ones.
+	for _, name := range []string{"old-1", "old-2"} {
+		if err := r.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, name := range []string{"new-1", "new-2"} {
+		if err := r.Create(ctx, newPod(name, "new", 11+i, false)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reconcile()
+	wantServing("rollout done", map[string]bool{"old-0": false, "new-0": true, "new-1": true, "new-2": false})
+}
@@ -611,7 +611,7 @@ func buildExecutorConfig(
 		RopeScalingOrigCtx:     ropeOrigCtx,
 		Jinja:                  derefBool(isvc.Spec.Jinja),
 		FlashAttention:         base.FlashAttention,
-		Mlock:                  true,
+		Mlock:                  isvc.Spec.MemoryLock == nil || *isvc.Spec.MemoryLock,
 		BatchSize:              base.BatchSize,
 		UBatchSize:             base.UBatchSize,
 		ParallelSlots:          derefInt32(isvc.Spec.ParallelSlots),
@@ -886,9 +886,10 @@ func (a *MetalAgent) ensureProcess(ctx context.Context, isvc *inferencev1alpha1.
 	}
 
 	// Apple Silicon defaults: flash-attn and mlock both ON. The user can
-	// disable flash-attn by setting spec.flashAttention=false; mlock has no
-	// CRD opt-out because the macOS wired-collector eviction it prevents is
-	// the entire reason the Metal agent exists in the first place.
+	// disable flash-attn by setting spec.flashAttention=false and mlock by
+	// setting spec.memoryLock=false (buildExecutorConfig applies the mlock
+	// default), though the wired-collector eviction mlock prevents is the
+	// reason it is on by default.
 	flashAttn := true
 	if isvc.Spec.FlashAttention != nil {
 		flashAttn = *isvc.Spec.FlashAttention
@@ -1773,6 +1774,7 @@ func computeSpecHash(isvc *inferencev1alpha1.InferenceService) string {
 		UBatchSize             *int32
 		ParallelSlots          *int32
 		FlashAttention         *bool
+		MemoryLock             *bool
 		Jinja                  *bool
 		NoKvOffload            *bool
 		NoWarmup               *bool
@@ -1802,6 +1804,7 @@ func computeSpecHash(isvc *inferencev1alpha1.InferenceService) string {
 		UBatchSize:             isvc.Spec.UBatchSize,
 		ParallelSlots:          isvc.Spec.ParallelSlots,
 		FlashAttention:         isvc.Spec.FlashAttention,
+		MemoryLock:             isvc.Spec.MemoryLock,
 		Jinja:                  isvc.Spec.Jinja,
 		NoKvOffload:            isvc.Spec.NoKvOffload,
 		NoWarmup:               isvc.Spec.NoWarmup,
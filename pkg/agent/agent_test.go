@@ -626,6 +626,17 @@ func TestComputeSpecHash_ChangesWithSuspend(t *testing.T) {
 	}
 }
 
+func TestComputeSpecHash_ChangesWithMemoryLock(t *testing.T) {
+	off := false
+	a := &inferencev1alpha1.InferenceService{Spec: inferencev1alpha1.InferenceServiceSpec{ModelRef: "m"}}
+	b := &inferencev1alpha1.InferenceService{
+		Spec: inferencev1alpha1.InferenceServiceSpec{ModelRef: "m", MemoryLock: &off},
+	}
+	if computeSpecHash(a) == computeSpecHash(b) {
+		t.Error("hash should differ when memoryLock changes")
+	}
+}
+
 func TestComputeSpecHash_NilIsvc(t *testing.T) {
 	if computeSpecHash(nil) != "" {
 		t.Error("nil isvc should produce empty hash, not panic")
@@ -755,6 +766,28 @@ func TestBuildExecutorConfig_NilPointersAreSafe(t *testing.T) {
 	}
 }
 
+// TestBuildExecutorConfig_MemoryLock covers the Metal default: mlock stays on
+// when spec.memoryLock is unset and is only dropped by an explicit false.
+func TestBuildExecutorConfig_MemoryLock(t *testing.T) {
+	off := false
+	model := &inferencev1alpha1.Model{Spec: inferencev1alpha1.ModelSpec{Source: "x"}}
+	for _, tc := range []struct {
+		name       string
+		memoryLock *bool
+		want       bool
+	}{
+		{"unset defaults on", nil, true},
+		{"explicit false opts out", &off, false},
+	} {
+		isvc := &inferencev1alpha1.InferenceService{
+			Spec: inferencev1alpha1.InferenceServiceSpec{ModelRef: "m", MemoryLock: tc.memoryLock},
+		}
+		if got := buildExecutorConfig(isvc, model, executorBaseConfig{}).Mlock; got != tc.want {
+			t.Errorf("%s: Mlock = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
 // runEnsureProcessExpectingMapEviction sets up a metal-agent with a pre-seeded
 // healthy process at default/<name>, runs ensureProcess against the given isvc,
 // and asserts the process map entry has been removed. Pre-seeded PID -99999
@@ -716,6 +716,7 @@ func TestBuildLlamaServerArgsRuntimeArgParity(t *testing.T) {
 			ContextSize:            ptrInt32(8192),
 			ParallelSlots:          ptrInt32(4),
 			FlashAttention:         ptrBool(true),
+			MemoryLock:             ptrBool(true),
 			Jinja:                  ptrBool(true),
 			CacheTypeK:             "q8_0",
 			CacheTypeV:             "q8_0",
@@ -821,7 +822,7 @@ func buildExecutorConfigForTest(isvc *inferencev1alpha1.InferenceService) Execut
 		RopeScalingOrigCtx:     ropeOrigCtx,
 		Jinja:                  derefBool(isvc.Spec.Jinja),
 		FlashAttention:         derefBool(isvc.Spec.FlashAttention),
-		Mlock:                  true,
+		Mlock:                  isvc.Spec.MemoryLock == nil || *isvc.Spec.MemoryLock,
 		BatchSize:              derefInt32(isvc.Spec.BatchSize),
 		UBatchSize:             derefInt32(isvc.Spec.UBatchSize),
 		ParallelSlots:          derefInt32(isvc.Spec.ParallelSlots),
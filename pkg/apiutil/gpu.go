@@ -15,6 +15,9 @@ import (
 const (
 	runtimeVulkan = "vulkan"
 	runtimeROCm   = "rocm"
+
+	acceleratorIntel = "intel"
+	acceleratorSYCL  = "sycl"
 )
 
 var (
@@ -36,7 +39,8 @@ func isRuntime(runtime, want string) bool {
 //  2. amd vendor with the vulkan or rocm runtime -> devic.es/dri-render.
 //  3. Vendor default: nvidia -> nvidia.com/gpu, amd -> amd.com/gpu,
 //     intel -> gpu.intel.com/i915.
-//  4. Nil/unset/unknown -> nvidia.com/gpu.
+//  4. Unset vendor with accelerator intel or sycl -> gpu.intel.com/i915.
+//  5. Nil/unset/unknown -> nvidia.com/gpu.
 func GPUResourceName(model *inferencev1alpha1.Model) corev1.ResourceName {
 	if model != nil && model.Spec.Hardware != nil && model.Spec.Hardware.GPU != nil {
 		if override := strings.TrimSpace(model.Spec.Hardware.GPU.ResourceName); override != "" {
@@ -51,6 +55,11 @@ func GPUResourceName(model *inferencev1alpha1.Model) corev1.ResourceName {
 			return amdGPUResourceName
 		case "intel":
 			return intelGPUResourceNameI915
+		case "":
+			switch strings.ToLower(strings.TrimSpace(model.Spec.Hardware.Accelerator)) {
+			case acceleratorIntel, acceleratorSYCL:
+				return intelGPUResourceNameI915
+			}
 		}
 	}
 	return nvidiaGPUResourceName
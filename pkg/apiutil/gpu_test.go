@@ -28,6 +28,7 @@ func TestGPUResourceName(t *testing.T) {
 		{"amd rocm uses dri-render", modelWithGPU(&inferencev1alpha1.GPUSpec{Vendor: "amd", Runtime: "rocm"}), corev1.ResourceName("devic.es/dri-render")},
 		{"amd default uses amd.com/gpu", modelWithGPU(&inferencev1alpha1.GPUSpec{Vendor: "amd"}), corev1.ResourceName("amd.com/gpu")},
 		{"intel uses i915", modelWithGPU(&inferencev1alpha1.GPUSpec{Vendor: "intel"}), corev1.ResourceName("gpu.intel.com/i915")},
+		{"sycl accelerator with unset vendor uses i915", &inferencev1alpha1.Model{Spec: inferencev1alpha1.ModelSpec{Hardware: &inferencev1alpha1.HardwareSpec{Accelerator: "sycl", GPU: &inferencev1alpha1.GPUSpec{Count: 1}}}}, corev1.ResourceName("gpu.intel.com/i915")},
 		{"unknown vendor defaults to nvidia", modelWithGPU(&inferencev1alpha1.GPUSpec{Vendor: "other"}), corev1.ResourceName("nvidia.com/gpu")},
 	}
 	for _, tc := range cases {
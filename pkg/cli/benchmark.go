@@ -19,10 +19,18 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
 
 type benchmarkOptions struct {
@@ -49,9 +57,19 @@ type benchmarkOptions struct {
 	deployWait  time.Duration
 	contextSize int32
 
+	// Tear down the in-progress catalog model on SIGINT/SIGTERM
+	cleanupOnInterrupt bool
+
+	// Readiness wait on /health before the first request
+	modelLoadWait time.Duration
+
+	// Deadline for one non-streamed generation; 0 scales with maxTokens
+	generationTimeout time.Duration
+
 	// Report generation
-	report    string
-	reportDir string
+	report         string
+	reportDir      string
+	reportTemplate string
 
 	// Cache preloading
 	preload bool
@@ -60,12 +78,127 @@ type benchmarkOptions struct {
 	concurrencySweep string
 	contextSweep     string
 	tokensSweep      string
+	inputLengthSweep string
 
 	// GPU monitoring
 	monitorGPU bool
 
+	// DCGM or nvidia-smi exporter polled during the run (--gpu-metrics-url);
+	// gpuMetrics is set while a run is active
+	gpuMetricsURL string
+	gpuMetrics    *gpuMonitor
+
+	// Split latency into queue wait, prefill and decode
+	// (--latency-breakdown)
+	latencyBreakdown bool
+
 	// Test suites
 	suite string
+
+	// Keepalive health pings during long runs
+	keepaliveInterval time.Duration
+
+	// Graceful early stop for stress runs
+	stopFile string
+
+	// Periodic interim summaries during long stress runs
+	soakReportInterval time.Duration
+	soakReportFile     string
+
+	// Run history and baseline comparison
+	historyDir string
+	baseline   string
+
+	// Fail the run when it regressed against an explicitly given --baseline
+	// by more than this percentage (--regression-threshold)
+	baselineSet         bool
+	regressionThreshold float64
+
+	// shields.io endpoint badge export
+	badgeDir string
+
+	// OpenAI response conformance checks
+	validateSchema bool
+
+	// Already-deployed services benchmarked simultaneously
+	concurrentModels string
+
+	// Serving mode of the endpoint (chat or embedding); "" detects it
+	mode string
+
+	// Refuse models whose estimated weights + KV cache exceed this quantity
+	assertMaxVRAM string
+
+	// Stream one-token completions and report only time to first token
+	probeFirstTokenOnly bool
+
+	// Embedding pooling types to compare (--pool), and the one sent with
+	// each embedding request
+	pool    string
+	pooling string
+
+	// Live NDJSON feed for --output ndjson-stream; set while a run is active
+	stream *ndjsonStream
+
+	// Target prompt length in tokens (--prompt-tokens), and the synthetic
+	// prompt word count calibrated to it once the endpoint is known
+	promptTokens int
+	promptWords  int
+	promptSeq    int
+
+	// Compare latency as request prompts fill the deployed context
+	// (--compare-context-sizes)
+	compareContextSizes bool
+
+	// Open-loop target request rate in req/s (--rate); 0 runs closed-loop
+	// concurrent workers
+	rate float64
+
+	// Stream completions and record inter-token latency (--stream)
+	streaming bool
+
+	// InfluxDB line protocol file appended to after each run (--influx-file),
+	// and the real stdout that --output influx writes to while progress goes
+	// to stderr
+	influxFile string
+	influxOut  io.Writer
+
+	// Write the final result to this file (or a timestamped file in this
+	// directory) instead of stdout (--output-file)
+	outputFile string
+
+	// Also write every request's full result to this CSV file
+	// (--export-csv-per-iteration)
+	exportCSVPerIteration string
+
+	// Sampling parameters sent with every chat request (--temperature,
+	// --top-p, --top-k); zero top-p/top-k leave the server's defaults
+	temperature float64
+	topP        float64
+	topK        int
+
+	// Sampling seed sent with every request (--seed), offset by the
+	// iteration number with --seed-per-iteration; seedSet records whether
+	// --seed was given
+	seed             int64
+	seedSet          bool
+	seedPerIteration bool
+
+	// Flag catalog models whose throughput is far below their peers' for
+	// their size (--diff-against-catalog)
+	diffAgainstCatalog bool
+
+	// Flag catalog models that tokenize the shared prompt into far more
+	// tokens than the others (--warn-on-token-mismatch)
+	warnOnTokenMismatch bool
+
+	// Extra request headers (--header, --bearer-token), parsed into
+	// requestHeaders, and TLS verification opt-out (--insecure) for
+	// endpoints behind an auth proxy or with self-signed certificates
+	headers        []string
+	bearerToken    string
+	insecure       bool
+	requestHeaders http.Header
 }
 
 type BenchmarkResult struct {
@@ -73,12 +206,32 @@ type BenchmarkResult struct {
 	PromptTokens         int     `json:"prompt_tokens"`
 	CompletionTokens     int     `json:"completion_tokens"`
 	TotalTokens          int     `json:"total_tokens"`
+	PromptN              int     `json:"prompt_n,omitempty"`
 	PromptTimeMs         float64 `json:"prompt_time_ms"`
 	GenerationTimeMs     float64 `json:"generation_time_ms"`
 	TotalTimeMs          float64 `json:"total_time_ms"`
 	PromptToksPerSec     float64 `json:"prompt_tokens_per_sec"`
 	GenerationToksPerSec float64 `json:"generation_tokens_per_sec"`
 	Error                string  `json:"error,omitempty"`
+
+	// SchemaViolations lists OpenAI schema problems in an otherwise
+	// successful response (--validate-openai-schema only).
+	SchemaViolations []string `json:"schema_violations,omitempty"`
+
+	// Inter-token latency of a streamed completion (--stream only). The raw
+	// gaps feed the run-wide percentiles and are not serialized.
+	InterTokenMeanMs float64   `json:"inter_token_mean_ms,omitempty"`
+	InterTokenP95Ms  float64   `json:"inter_token_p95_ms,omitempty"`
+	InterTokenP99Ms  float64   `json:"inter_token_p99_ms,omitempty"`
+	InterTokenGapsMs []float64 `json:"-"`
+
+	// Time from sending the request to the first streamed token (--stream
+	// only)
+	TTFTMs float64 `json:"ttft_ms,omitempty"`
+
+	// ServerTimings is set when PromptTimeMs and GenerationTimeMs come from
+	// llama-server's timings rather than client-side estimates.
+	ServerTimings bool `json:"-"`
 }
 
 type BenchmarkSummary struct {
@@ -105,6 +258,30 @@ type BenchmarkSummary struct {
 	GenerationToksPerSecMin  float64 `json:"generation_toks_per_sec_min"`
 	GenerationToksPerSecMax  float64 `json:"generation_toks_per_sec_max"`
 
+	// SchemaViolations counts successful responses that failed the OpenAI
+	// schema check (--validate-openai-schema only).
+	SchemaViolations int `json:"schema_violations,omitempty"`
+
+	// Inter-token latency across every gap of every streamed completion
+	// (--stream only), in ms
+	InterTokenMean float64 `json:"inter_token_mean_ms,omitempty"`
+	InterTokenP95  float64 `json:"inter_token_p95_ms,omitempty"`
+	InterTokenP99  float64 `json:"inter_token_p99_ms,omitempty"`
+	InterTokenMax  float64 `json:"inter_token_max_ms,omitempty"`
+
+	// GPU utilization and memory polled from --gpu-metrics-url during the
+	// run; memory in MiB summed across GPUs
+	GPUSamples          int     `json:"gpu_samples,omitempty"`
+	GPUUtilMean         float64 `json:"gpu_util_mean_percent,omitempty"`
+	GPUUtilPeak         float64 `json:"gpu_util_peak_percent,omitempty"`
+	GPUMemoryUsedMeanMB float64 `json:"gpu_memory_used_mean_mb,omitempty"`
+	GPUMemoryUsedPeakMB float64 `json:"gpu_memory_used_peak_mb,omitempty"`
+	GPUMemoryTotalMB    float64 `json:"gpu_memory_total_mb,omitempty"`
+
+	// Queue wait, prefill and decode time per request
+	// (--latency-breakdown only)
+	LatencyBreakdown *LatencyBreakdown `json:"latency_breakdown,omitempty"`
+
 	Results   []BenchmarkResult `json:"results"`
 	Timestamp time.Time         `json:"timestamp"`
 	Duration  time.Duration     `json:"duration"`
@@ -133,6 +310,11 @@ type StressTestSummary struct {
 	ErrorRate        float64       `json:"error_rate"`
 	PeakToksPerSec   float64       `json:"peak_toks_per_sec"`
 	ToksPerSecStdDev float64       `json:"toks_per_sec_std_dev"`
+
+	// Open-loop (--rate) runs only: the requested and the achieved
+	// dispatch rate
+	TargetRPS   float64 `json:"target_rps,omitempty"`
+	AchievedRPS float64 `json:"achieved_rps,omitempty"`
 }
 
 type ModelBenchmark struct {
@@ -149,14 +331,31 @@ type ModelBenchmark struct {
 	TotalRequests        int64   `json:"total_requests,omitempty"`
 	RequestsPerSec       float64 `json:"requests_per_sec,omitempty"`
 	ErrorRate            float64 `json:"error_rate,omitempty"`
+
+	// Set by --diff-against-catalog when throughput is implausibly low for
+	// the model's size compared with the other models in the run
+	PerformanceWarning string `json:"performance_warning,omitempty"`
+
+	// Tokens the model's tokenizer produced for the benchmark prompt, and
+	// the --warn-on-token-mismatch warning when that is far more than the
+	// other models needed
+	PromptTokens        int    `json:"prompt_tokens,omitempty"`
+	TokenizationWarning string `json:"tokenization_warning,omitempty"`
 }
 
 type ChatCompletionRequest struct {
-	Model       string        `json:"model,omitempty"`
-	Messages    []ChatMessage `json:"messages"`
-	MaxTokens   int           `json:"max_tokens,omitempty"`
-	Temperature float64       `json:"temperature,omitempty"`
-	Stream      bool          `json:"stream,omitempty"`
+	Model     string        `json:"model,omitempty"`
+	Messages  []ChatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+	// Temperature is always sent so --temperature 0 (greedy) is honored.
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p,omitempty"`
+	TopK        int     `json:"top_k,omitempty"`
+	// Seed fixes the server's sampling RNG; nil lets the server pick one.
+	Seed   *int64 `json:"seed,omitempty"`
+	Stream bool   `json:"stream,omitempty"`
+	// StreamOptions requests a trailing usage chunk on streamed completions.
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
 }
 
 type ChatMessage struct {
@@ -194,11 +393,37 @@ type ChatCompletionResponse struct {
 	} `json:"timings"`
 }
 
-// ReportWriter handles generation of benchmark reports
+// EmbeddingRequest is an OpenAI-compatible /v1/embeddings request.
+type EmbeddingRequest struct {
+	Model string `json:"model,omitempty"`
+	Input string `json:"input"`
+	// Pooling selects how token embeddings are combined (mean, cls, last,
+	// none); servers that fix pooling at startup ignore it.
+	Pooling string `json:"pooling,omitempty"`
+}
+
+// EmbeddingResponse is the subset of a /v1/embeddings response the benchmark
+// reads. Embeddings generate no tokens, so only prompt usage is reported.
+type EmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int `json:"prompt_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// ReportWriter handles generation of benchmark reports. Sections and results
+// are collected during the run and rendered through the report template on
+// close.
 type ReportWriter struct {
 	file      *os.File
 	startTime time.Time
 	opts      *benchmarkOptions
+	template  *template.Template
+	data      ReportTemplateData
 }
 
 // SweepResult holds results from a single sweep iteration
@@ -265,9 +490,13 @@ const (
 )
 
 const (
-	outputFormatTable    = "table"
-	outputFormatJSON     = "json"
-	outputFormatMarkdown = "markdown"
+	outputFormatTable      = "table"
+	outputFormatJSON       = "json"
+	outputFormatMarkdown   = "markdown"
+	outputFormatDeltaTable = "delta-table"
+	outputFormatNDJSON     = "ndjson-stream"
+	outputFormatInflux     = "influx"
+	outputFormatCSV        = "csv"
 )
 
 const (
@@ -323,6 +552,7 @@ STRESS TEST MODE (--concurrent or --duration):
 CATALOG MODE (--catalog):
   Automatically deploy, benchmark, and compare multiple models from the catalog.
   Models are deployed sequentially, benchmarked, and optionally cleaned up.
+  Run 'llmkube catalog list' for the available model IDs.
 
 TEST SUITES (--suite):
   Run predefined comprehensive test suites. Requires --catalog for model deployment.
@@ -359,6 +589,8 @@ SWEEP MODES:
 
 REPORTING:
   Generate markdown reports with --report or --report-dir for analysis and sharing.
+  --report-template renders the report through your own Go text/template file
+  instead; it is executed with the run's sections and summary/comparison structs.
 
 Examples:
   # Basic benchmark (sequential requests)
@@ -376,12 +608,69 @@ Examples:
   # STRESS TEST: 8 concurrent requests for 30 minutes
   llmkube benchmark my-llm --concurrent 8 --duration 30m
 
+  # STRESS TEST that can be ended early with: touch /tmp/stop-bench
+  llmkube benchmark my-llm --concurrent 4 --duration 8h --stop-file /tmp/stop-bench
+
+  # SOAK TEST with an interim summary every 10 minutes, also logged as JSON lines
+  llmkube benchmark my-llm --concurrent 4 --duration 8h --soak-report-interval 10m --soak-report-file soak.jsonl
+
   # STRESS TEST with report
   llmkube benchmark my-llm --concurrent 4 --duration 1h --report stress-test.md
 
+  # Branded HTML report from your own Go text/template
+  llmkube benchmark my-llm --report report.html --report-template acme.html.tmpl
+
+  # Fixed offered load - latency percentiles at 2 req/s, open loop
+  llmkube benchmark my-llm --rate 2 --duration 10m
+
   # Concurrency sweep - test scaling with report
   llmkube benchmark my-llm --concurrency-sweep 1,2,4,8 --duration 5m --report-dir ./reports
 
+  # Live dashboard feed - one JSON line per result plus interim summaries
+  llmkube benchmark my-llm --concurrent 4 --duration 1h --output ndjson-stream | my-dashboard
+
+  # Streaming - inter-token latency percentiles to spot generation stalls
+  llmkube benchmark my-llm --stream --iterations 20
+
+  # InfluxDB/Telegraf - append line protocol points for Telegraf to tail
+  llmkube benchmark my-llm --iterations 20 --influx-file /var/lib/telegraf/llmkube.lp
+
+  # Spreadsheet analysis - one CSV row per request
+  llmkube benchmark my-llm --iterations 50 --output csv
+
+  # CI - JSON result in a file, progress on stdout
+  llmkube benchmark my-llm --iterations 20 --output json --output-file ./results/
+
+  # Endpoint behind an API gateway with a self-signed certificate
+  llmkube benchmark my-llm --endpoint https://llm.internal.corp --bearer-token $TOKEN --insecure
+
+  # CI gate - fail if generation tok/s drops or P99 rises more than 5%
+  llmkube benchmark my-llm --iterations 20 --baseline ./baseline.json --regression-threshold 5
+
+  # Reproducible throughput run - request N uses seed 42+N
+  llmkube benchmark my-llm --iterations 20 --seed 42 --seed-per-iteration
+
+  # Fast latency check - TTFT percentiles from one-token streamed requests
+  llmkube benchmark my-llm --probe-first-token-only --iterations 20
+
+  # Embedding pooling - compare latency and throughput per pooling type
+  llmkube benchmark my-embedder --mode embedding --pool mean,cls,last --iterations 20
+
+  # Controlled prompt length - a ~4096-token prompt sized by the server's tokenizer
+  llmkube benchmark my-llm --prompt-tokens 4096 --max-tokens 1
+
+  # Context fill - latency as prompts approach the deployed service's context size
+  llmkube benchmark my-llm --compare-context-sizes --iterations 5
+
+  # Input length sweep - prompt tok/s and TTFT per prompt length
+  llmkube benchmark my-llm --input-length-sweep 128,512,2048,8192 --max-tokens 1
+
+  # README badges - shields.io endpoint JSON for throughput and P99
+  llmkube benchmark my-llm --iterations 20 --export-markdown-badges ./badges
+
+  # Noisy neighbors - benchmark several deployed services at the same time
+  llmkube benchmark --concurrent-models llama-8b,qwen-7b,phi-4-mini --iterations 20
+
   # Context sweep - test different KV cache sizes
   llmkube benchmark --catalog qwen-2.5-32b --context-sweep 4096,16384,32768 --gpu
 
@@ -390,6 +679,135 @@ Examples:
 `,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.seedSet = cmd.Flags().Changed("seed")
+			opts.baselineSet = cmd.Flags().Changed("baseline")
+
+			requestHeaders, err := parseRequestHeaders(opts.headers, opts.bearerToken)
+			if err != nil {
+				return err
+			}
+			opts.requestHeaders = requestHeaders
+			if opts.insecure {
+				skipBenchmarkTLSVerify()
+			}
+
+			if opts.assertMaxVRAM != "" {
+				if _, err := parseVRAMBudget(opts.assertMaxVRAM); err != nil {
+					return err
+				}
+			}
+
+			if opts.output == outputFormatNDJSON {
+				if opts.suite != "" || opts.catalog != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.compareContextSizes ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--output %s supports single-service benchmark and stress runs only", outputFormatNDJSON)
+				}
+			}
+
+			if opts.output == outputFormatInflux || opts.influxFile != "" {
+				if opts.suite != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.compareContextSizes ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--output %s and --influx-file support benchmark, stress and catalog comparison runs only",
+						outputFormatInflux)
+				}
+			}
+			if opts.output == outputFormatInflux {
+				opts.influxOut = os.Stdout
+				defer redirectStdoutToStderr()()
+			}
+
+			if opts.reportTemplate != "" && opts.report == "" && opts.reportDir == "" {
+				return fmt.Errorf("--report-template requires --report or --report-dir")
+			}
+
+			if opts.outputFile != "" {
+				if _, ok := outputFileExtensions[opts.output]; !ok {
+					return fmt.Errorf("--output-file supports --output table, json, markdown or csv, got %q", opts.output)
+				}
+			}
+
+			if opts.exportCSVPerIteration != "" {
+				if opts.suite != "" || opts.catalog != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.compareContextSizes ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--export-csv-per-iteration supports single-service benchmark and stress runs only")
+				}
+			}
+
+			if opts.baselineSet {
+				if opts.suite != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.compareContextSizes ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--baseline supports benchmark, stress and catalog comparison runs only")
+				}
+				if opts.catalog != "" && opts.baseline == baselineLatest {
+					return fmt.Errorf("--baseline for a --catalog run must name a saved comparison report JSON file")
+				}
+			}
+			if opts.temperature < 0 || opts.topP < 0 || opts.topP > 1 || opts.topK < 0 {
+				return fmt.Errorf("invalid sampling parameters: --temperature and --top-k must be >= 0 and --top-p within [0, 1]")
+			}
+
+			if opts.gpuMetricsURL != "" {
+				if !strings.HasPrefix(opts.gpuMetricsURL, "http://") && !strings.HasPrefix(opts.gpuMetricsURL, "https://") {
+					return fmt.Errorf("--gpu-metrics-url must be an http(s) URL, got %q", opts.gpuMetricsURL)
+				}
+				if opts.suite != "" || opts.catalog != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.compareContextSizes ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--gpu-metrics-url supports single-service benchmark and stress runs only")
+				}
+			}
+
+			if opts.latencyBreakdown {
+				if opts.suite != "" || opts.catalog != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.compareContextSizes ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--latency-breakdown supports single-service benchmark and stress runs only")
+				}
+			}
+
+			if opts.diffAgainstCatalog && opts.catalog == "" {
+				return fmt.Errorf("--diff-against-catalog requires --catalog")
+			}
+			if opts.warnOnTokenMismatch && opts.catalog == "" {
+				return fmt.Errorf("--warn-on-token-mismatch requires --catalog")
+			}
+			if opts.regressionThreshold < 0 {
+				return fmt.Errorf("--regression-threshold must be positive, got %g", opts.regressionThreshold)
+			}
+
+			if opts.promptTokens < 0 {
+				return fmt.Errorf("--prompt-tokens must be positive, got %d", opts.promptTokens)
+			}
+			if opts.promptTokens > 0 {
+				if opts.promptFile != "" || opts.prompt != defaultBenchmarkPrompt {
+					return fmt.Errorf("--prompt-tokens builds its own prompt; it cannot be combined with --prompt or --prompt-file")
+				}
+				if opts.suite != "" || opts.catalog != "" || opts.concurrentModels != "" || opts.probeFirstTokenOnly || opts.pool != "" ||
+					opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+					opts.inputLengthSweep != "" {
+					return fmt.Errorf("--prompt-tokens supports single-service benchmark and stress runs only")
+				}
+			}
+
+			if opts.rate < 0 {
+				return fmt.Errorf("--rate must be positive, got %g", opts.rate)
+			}
+			if opts.rate > 0 && (opts.suite != "" || opts.catalog != "" || opts.concurrentModels != "" ||
+				opts.concurrencySweep != "" || opts.tokensSweep != "" || opts.contextSweep != "" ||
+				opts.inputLengthSweep != "") {
+				return fmt.Errorf("--rate supports single-service stress runs only")
+			}
+
 			// Suite mode (requires catalog)
 			if opts.suite != "" {
 				if opts.catalog == "" {
@@ -403,12 +821,83 @@ Examples:
 				return runCatalogBenchmark(opts)
 			}
 
+			// Concurrent-models mode benchmarks the listed services, not an arg
+			if opts.concurrentModels != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--concurrent-models lists the services; do not also pass SERVICE_NAME")
+				}
+				if opts.endpoint != "" {
+					return fmt.Errorf("--endpoint cannot be combined with --concurrent-models")
+				}
+				return runConcurrentModelsBenchmark(opts)
+			}
+
 			// Service name required for all other modes
 			if len(args) == 0 {
 				return fmt.Errorf("SERVICE_NAME is required (or use --catalog for multi-model comparison)")
 			}
 			opts.name = args[0]
 
+			if stopFileExists(opts.stopFile) {
+				return fmt.Errorf("--stop-file %s already exists; remove it before starting the run", opts.stopFile)
+			}
+
+			if opts.soakReportFile != "" && opts.soakReportInterval <= 0 {
+				return fmt.Errorf("--soak-report-file requires --soak-report-interval")
+			}
+
+			switch opts.mode {
+			case "", inferencev1alpha1.ServingModeChat, inferencev1alpha1.ServingModeEmbedding:
+			default:
+				return fmt.Errorf("--mode must be %s or %s, got %q",
+					inferencev1alpha1.ServingModeChat, inferencev1alpha1.ServingModeEmbedding, opts.mode)
+			}
+
+			if opts.streaming && (opts.mode == inferencev1alpha1.ServingModeEmbedding || opts.probeFirstTokenOnly || opts.pool != "") {
+				return fmt.Errorf("--stream cannot be combined with --mode %s, --pool or --probe-first-token-only",
+					inferencev1alpha1.ServingModeEmbedding)
+			}
+
+			if opts.probeFirstTokenOnly {
+				if opts.mode == inferencev1alpha1.ServingModeEmbedding {
+					return fmt.Errorf("--probe-first-token-only measures generated tokens; it cannot be used with --mode %s",
+						inferencev1alpha1.ServingModeEmbedding)
+				}
+				if opts.concurrent > 1 || opts.duration > 0 || opts.rate > 0 || opts.concurrencySweep != "" || opts.tokensSweep != "" ||
+					opts.contextSweep != "" || opts.inputLengthSweep != "" {
+					return fmt.Errorf("--probe-first-token-only cannot be combined with stress or sweep modes")
+				}
+				return runFirstTokenProbe(opts)
+			}
+
+			if opts.pool != "" {
+				if opts.mode != "" && opts.mode != inferencev1alpha1.ServingModeEmbedding {
+					return fmt.Errorf("--pool compares embedding pooling types; it requires --mode %s",
+						inferencev1alpha1.ServingModeEmbedding)
+				}
+				if opts.concurrent > 1 || opts.duration > 0 || opts.rate > 0 || opts.concurrencySweep != "" || opts.tokensSweep != "" ||
+					opts.contextSweep != "" || opts.inputLengthSweep != "" {
+					return fmt.Errorf("--pool cannot be combined with stress or sweep modes")
+				}
+				return runPoolingComparison(opts)
+			}
+
+			if opts.compareContextSizes {
+				if opts.mode == inferencev1alpha1.ServingModeEmbedding {
+					return fmt.Errorf("--compare-context-sizes measures generation; it cannot be used with --mode %s",
+						inferencev1alpha1.ServingModeEmbedding)
+				}
+				if opts.concurrent > 1 || opts.duration > 0 || opts.rate > 0 || opts.concurrencySweep != "" || opts.tokensSweep != "" ||
+					opts.contextSweep != "" || opts.inputLengthSweep != "" || opts.promptTokens > 0 {
+					return fmt.Errorf("--compare-context-sizes cannot be combined with stress, sweep or --prompt-tokens modes")
+				}
+				return runContextFillComparison(opts)
+			}
+
+			if opts.output == outputFormatDeltaTable && opts.historyDir == "" {
+				return fmt.Errorf("--output %s requires --history-dir", outputFormatDeltaTable)
+			}
+
 			// Sweep modes (mutually exclusive)
 			if opts.concurrencySweep != "" {
 				return runConcurrencySweep(opts)
@@ -419,6 +908,9 @@ Examples:
 			if opts.contextSweep != "" {
 				return runContextSweep(opts)
 			}
+			if opts.inputLengthSweep != "" {
+				return runInputLengthSweep(opts)
+			}
 
 			return runBenchmark(opts)
 		},
@@ -431,12 +923,42 @@ Examples:
 	cmd.Flags().StringVarP(&opts.prompt, "prompt", "p", defaultBenchmarkPrompt, "Prompt to use for benchmarking")
 	cmd.Flags().IntVar(&opts.maxTokens, "max-tokens", 50, "Maximum tokens to generate per request")
 	cmd.Flags().IntVarP(&opts.concurrent, "concurrent", "c", 1, "Number of concurrent requests for stress testing")
-	cmd.Flags().StringVarP(&opts.output, "output", "o", "table", "Output format: table, json, markdown")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "table", "Output format: table, json, markdown, csv, delta-table, ndjson-stream, influx")
+	cmd.Flags().StringVar(&opts.outputFile, "output-file", "",
+		"Write the final result in the --output format to this file instead of stdout; "+
+			"a directory gets a timestamped benchmark-YYYYMMDD-HHMMSS file (progress still goes to stdout)")
+	cmd.Flags().StringVar(&opts.exportCSVPerIteration, "export-csv-per-iteration", "",
+		"Also write one CSV row per request with every result field (tokens, prompt/generation ms, TTFT with --stream, "+
+			"tok/s, error) to this file or directory, whatever the --output format")
 	cmd.Flags().StringVar(&opts.endpoint, "endpoint", "", "Override endpoint URL (default: auto-detect from service)")
-	cmd.Flags().DurationVar(&opts.timeout, "timeout", 60*time.Second, "Request timeout")
+	cmd.Flags().StringArrayVar(&opts.headers, "header", nil,
+		"Extra header sent with every request, as \"Key: Value\" (repeatable)")
+	cmd.Flags().StringVar(&opts.bearerToken, "bearer-token", "",
+		"Send \"Authorization: Bearer TOKEN\" with every request, for endpoints behind an auth proxy")
+	cmd.Flags().BoolVar(&opts.insecure, "insecure", false,
+		"Skip TLS certificate verification (self-signed internal endpoints)")
+	cmd.Flags().StringVar(&opts.mode, "mode", "",
+		"Serving mode of the endpoint: chat or embedding (default: detected from the service's status.mode or the endpoint path)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 60*time.Second,
+		"Request timeout floor (see --generation-timeout for long generations)")
+	cmd.Flags().DurationVar(&opts.generationTimeout, "generation-timeout", 0,
+		"Deadline for a single generation; slow-but-valid responses within it are not failures "+
+			"(0 = max(--timeout, --max-tokens x 250ms))")
 	cmd.Flags().BoolVar(&opts.portForward, "port-forward", true, "Automatically set up port forwarding")
 	cmd.Flags().DurationVar(&opts.duration, "duration", 0, "Run stress test for specified duration (e.g., 30m, 2h)")
+	cmd.Flags().Float64Var(&opts.rate, "rate", 0,
+		"Open-loop stress test: send requests on a Poisson schedule at this many req/s regardless of in-flight requests (0 = closed-loop --concurrent workers)")
+	cmd.Flags().StringVar(&opts.influxFile, "influx-file", "",
+		"Append InfluxDB line protocol points for the run to this file (e.g. for Telegraf's tail input)")
 	cmd.Flags().StringVar(&opts.promptFile, "prompt-file", "", "Load prompts from file (one per line) for varied workload")
+	cmd.Flags().BoolVar(&opts.compareContextSizes, "compare-context-sizes", false,
+		"Send prompts filling 10-90% of the service's configured context and report how latency and throughput degrade")
+	cmd.Flags().IntVar(&opts.promptTokens, "prompt-tokens", 0,
+		"Use a synthetic prompt of about N tokens, sized with the server's /tokenize endpoint (0 = use --prompt)")
+	cmd.Flags().BoolVar(&opts.validateSchema, "validate-openai-schema", false,
+		"Check every response against the OpenAI chat-completion schema and report violations separately")
+	cmd.Flags().StringVar(&opts.concurrentModels, "concurrent-models", "",
+		"Comma-separated deployed services to benchmark simultaneously (reports per-service and aggregate metrics)")
 
 	// Catalog mode flags
 	cmd.Flags().StringVar(&opts.catalog, "catalog", "", "Comma-separated list of catalog model IDs to benchmark")
@@ -449,19 +971,49 @@ Examples:
 		"Hardware accelerator: cuda, metal, rocm, intel (auto-detected if --gpu is set)")
 	cmd.Flags().BoolVar(&opts.cleanup, "cleanup", true,
 		"Cleanup deployments after benchmarking (use --no-cleanup to keep)")
+	cmd.Flags().BoolVar(&opts.cleanupOnInterrupt, "cleanup-on-interrupt", true,
+		"Delete the in-progress catalog model when the run is interrupted (ignored with --no-cleanup)")
+	cmd.Flags().StringVar(&opts.assertMaxVRAM, "assert-max-vram", "",
+		"Fail a model whose GGUF-estimated weights + KV cache exceed this budget (e.g. 16Gi), before deploying it")
+	cmd.Flags().BoolVar(&opts.probeFirstTokenOnly, "probe-first-token-only", false,
+		"Fast latency check: stream one-token completions and report time-to-first-token percentiles only")
+	cmd.Flags().BoolVar(&opts.streaming, "stream", false,
+		"Stream completions and report inter-token latency (mean, P95, P99) alongside throughput")
+	cmd.Flags().Float64Var(&opts.temperature, "temperature", defaultBenchmarkTemperature,
+		"Sampling temperature for every request (0 = greedy)")
+	cmd.Flags().Float64Var(&opts.topP, "top-p", 0,
+		"Nucleus sampling top_p for every request (0 = server default)")
+	cmd.Flags().IntVar(&opts.topK, "top-k", 0,
+		"Top-k sampling for every request (0 = server default)")
+	cmd.Flags().Int64Var(&opts.seed, "seed", 0,
+		"Sampling seed sent with every request, for reproducible outputs (default: server-chosen)")
+	cmd.Flags().BoolVar(&opts.seedPerIteration, "seed-per-iteration", false,
+		"Send seed --seed+N with request N, so each request differs but repeated runs match")
 	cmd.Flags().DurationVar(&opts.deployWait, "deploy-wait", 10*time.Minute, "Timeout waiting for deployment to be ready")
+	cmd.Flags().DurationVar(&opts.modelLoadWait, "warmup-model-load-wait", 10*time.Minute,
+		"Wait up to this long for /health to return 200 before benchmarking, for any endpoint "+
+			"(0 = don't wait; endpoints without a /health route are not waited on)")
 	cmd.Flags().Int32Var(&opts.contextSize, "context", 0,
-		"Context size (KV cache) for model deployment (0 = use catalog default)")
+		"Context size (KV cache) for model deployment (0 = use catalog default); "+
+			"with --compare-context-sizes, overrides the size read from the server")
 
 	// Report generation flags
 	cmd.Flags().StringVar(&opts.report, "report", "",
 		"Generate markdown report to specified file path")
 	cmd.Flags().StringVar(&opts.reportDir, "report-dir", "",
 		"Directory for auto-timestamped reports (creates benchmark-YYYYMMDD-HHMMSS.md)")
+	cmd.Flags().StringVar(&opts.reportTemplate, "report-template", "",
+		"Go text/template file that renders the report instead of the built-in markdown layout")
 
 	// Cache preloading flag
 	cmd.Flags().BoolVar(&opts.preload, "preload", false,
 		"Preload model cache before benchmarking (catalog mode only)")
+	cmd.Flags().BoolVar(&opts.diffAgainstCatalog, "diff-against-catalog", false,
+		"Warn about catalog models whose tok/s is far below the other models' for their VRAM estimate "+
+			"(hints at wrong --gpu-layers or a CPU image)")
+	cmd.Flags().BoolVar(&opts.warnOnTokenMismatch, "warn-on-token-mismatch", false,
+		"Warn about catalog models that tokenize the benchmark prompt into far more tokens than the others, "+
+			"which makes their tok/s look better than it is")
 
 	// Sweep mode flags
 	cmd.Flags().StringVar(&opts.concurrencySweep, "concurrency-sweep", "",
@@ -470,15 +1022,47 @@ Examples:
 		"Test multiple context sizes (comma-separated, e.g., 4096,8192,16384)")
 	cmd.Flags().StringVar(&opts.tokensSweep, "tokens-sweep", "",
 		"Test multiple max-token values (comma-separated, e.g., 64,256,512,1024)")
+	cmd.Flags().StringVar(&opts.pool, "pool", "",
+		"Comma-separated embedding pooling types to compare: mean, cls, last, none (requires --mode embedding)")
+	cmd.Flags().StringVar(&opts.inputLengthSweep, "input-length-sweep", "",
+		"Measure prefill scaling with synthetic prompts of each length in tokens (comma-separated, e.g., 128,512,2048,8192)")
 
 	// GPU monitoring flag
 	cmd.Flags().BoolVar(&opts.monitorGPU, "monitor-gpu", false,
 		"Monitor GPU memory usage during benchmark (requires nvidia-smi)")
+	cmd.Flags().StringVar(&opts.gpuMetricsURL, "gpu-metrics-url", "",
+		"DCGM or nvidia-smi exporter metrics URL polled every second to report mean/peak GPU utilization and memory")
+	cmd.Flags().BoolVar(&opts.latencyBreakdown, "latency-breakdown", false,
+		"Split request latency into queue wait, prefill and decode time using the server's timings, "+
+			"showing whether latency comes from saturation or compute")
 
 	// Test suite flag
 	cmd.Flags().StringVar(&opts.suite, "suite", "",
 		"Run predefined test suite: quick, stress, full, context, scaling (requires --catalog)")
 
+	// Keepalive flag
+	cmd.Flags().DurationVar(&opts.keepaliveInterval, "keepalive-interval", 0,
+		"Ping /health at this interval during benchmark and stress runs to keep idle connections alive (0 = disabled)")
+	cmd.Flags().StringVar(&opts.stopFile, "stop-file", "",
+		"End a stress run gracefully and print the summary so far once this file exists")
+	cmd.Flags().DurationVar(&opts.soakReportInterval, "soak-report-interval", 0,
+		"Print an interim summary of the last interval at this cadence during stress runs (0 = disabled)")
+	cmd.Flags().StringVar(&opts.soakReportFile, "soak-report-file", "",
+		"Also append each interim summary as a JSON line to this file (requires --soak-report-interval)")
+
+	// History flags
+	cmd.Flags().StringVar(&opts.historyDir, "history-dir", "",
+		"Directory to store each run's summary as JSON for later comparison")
+	cmd.Flags().StringVar(&opts.baseline, "baseline", baselineLatest,
+		"Baseline run: 'latest' or a file name in --history-dir, or a saved JSON summary or comparison report; "+
+			"when given, the run fails if it regressed beyond --regression-threshold")
+	cmd.Flags().Float64Var(&opts.regressionThreshold, "regression-threshold", defaultRegressionThreshold,
+		"Percent drop in generation tok/s or rise in P99 latency against --baseline that fails the run")
+
+	// Badge flag
+	cmd.Flags().StringVar(&opts.badgeDir, "export-markdown-badges", "",
+		"Directory to write shields.io endpoint JSON badges (throughput, P99) for README embedding")
+
 	return cmd
 }
 
@@ -501,29 +1085,71 @@ func runBenchmarkIterations(ctx context.Context, endpoint string, opts *benchmar
 
 	for i := 0; i < opts.iterations; i++ {
 		result, err := sendBenchmarkRequest(ctx, endpoint, opts, i+1)
+		if ctx.Err() != nil {
+			// Interrupted: the canceled request is not a server failure.
+			fmt.Printf("   ⚠️  Interrupted after %d/%d iterations\n", i, opts.iterations)
+			break
+		}
 		if err != nil {
 			result = BenchmarkResult{
 				Iteration: i + 1,
 				Error:     err.Error(),
 			}
 			fmt.Printf("   [%d/%d] ❌ Error: %v\n", i+1, opts.iterations, err)
+		} else if opts.promptTokens > 0 {
+			fmt.Printf("   [%d/%d] ✅ %.1f tok/s (%.0fms, prompt_n %d, %.1f prompt tok/s)\n",
+				i+1, opts.iterations,
+				result.GenerationToksPerSec,
+				result.TotalTimeMs,
+				result.PromptN,
+				result.PromptToksPerSec)
+		} else if opts.streaming {
+			fmt.Printf("   [%d/%d] ✅ %.1f tok/s (%.0fms, inter-token P99 %.1fms)\n",
+				i+1, opts.iterations,
+				result.GenerationToksPerSec,
+				result.TotalTimeMs,
+				result.InterTokenP99Ms)
 		} else {
 			fmt.Printf("   [%d/%d] ✅ %.1f tok/s (%.0fms)\n",
 				i+1, opts.iterations,
 				result.GenerationToksPerSec,
 				result.TotalTimeMs)
 		}
+		opts.stream.emitResult(result)
 		results = append(results, result)
 	}
 	fmt.Println()
 	return results
 }
 
+// benchmarkEndpoint resolves the endpoint for runBenchmarkContext. It is a
+// variable so tests can substitute a fake endpoint and port-forward cleanup.
+var benchmarkEndpoint = getEndpoint
+
+// benchmarkSignalContext returns a context cancelled by SIGINT or SIGTERM.
+// Every benchmark mode runs under it instead of letting the signal kill the
+// process: in-flight requests drain, the remaining levels, phases or models
+// are skipped, the partial results are still reported, and anything the run
+// deployed is cleaned up.
+func benchmarkSignalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// runBenchmark runs the benchmark until completion or SIGINT/SIGTERM, then
+// prints the partial summary and closes the port-forward.
 func runBenchmark(opts *benchmarkOptions) error {
-	ctx := context.Background()
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
+	if err := assertServiceVRAMBudget(ctx, opts); err != nil {
+		return err
+	}
+	return runBenchmarkContext(ctx, opts)
+}
+
+func runBenchmarkContext(ctx context.Context, opts *benchmarkOptions) error {
 	startTime := time.Now()
 
-	endpoint, cleanup, err := getEndpoint(ctx, opts)
+	endpoint, cleanup, err := benchmarkEndpoint(ctx, opts)
 	if err != nil {
 		return err
 	}
@@ -548,10 +1174,34 @@ func runBenchmark(opts *benchmarkOptions) error {
 		}()
 	}
 
-	if opts.concurrent > 1 || opts.duration > 0 {
+	if opts.output == outputFormatNDJSON && opts.stream == nil {
+		opts.stream = newNDJSONStream(os.Stdout, ndjsonInterimInterval(opts))
+		defer redirectStdoutToStderr()()
+	}
+
+	resolvePromptTokens(ctx, endpoint, opts)
+
+	defer startGPUMetrics(opts)()
+
+	if opts.concurrent > 1 || opts.duration > 0 || opts.rate > 0 {
+		if opts.stream != nil {
+			opts.stream.summarize = func(results []BenchmarkResult) any {
+				summary := calculateStressSummary(opts, endpoint, results, startTime, max(opts.concurrent, 1))
+				summary.Results = nil
+				return summary
+			}
+		}
 		return runStressTestWithReport(ctx, endpoint, opts, startTime, reportWriter)
 	}
 
+	if opts.stream != nil {
+		opts.stream.summarize = func(results []BenchmarkResult) any {
+			summary := calculateSummary(opts, endpoint, results, startTime)
+			summary.Results = nil
+			return summary
+		}
+	}
+
 	fmt.Printf("\n🏁 LLMKube Benchmark\n")
 	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
 	fmt.Printf("Service:     %s\n", opts.name)
@@ -561,26 +1211,73 @@ func runBenchmark(opts *benchmarkOptions) error {
 	fmt.Printf("Max Tokens:  %d\n", opts.maxTokens)
 	fmt.Printf("═══════════════════════════════════════════════════════════════\n\n")
 
+	var keepalive *keepalivePinger
+	if opts.keepaliveInterval > 0 {
+		keepalive = newKeepalivePinger(endpoint, opts.requestHeaders)
+		keepalive.start(opts.keepaliveInterval)
+	}
+
 	if opts.warmup > 0 {
 		runWarmupRequests(ctx, endpoint, opts)
 	}
 
 	results := runBenchmarkIterations(ctx, endpoint, opts)
+	if keepalive != nil {
+		pings, failures := keepalive.stop()
+		fmt.Printf("💓 Keepalive: %d pings sent (%d failed)\n\n", pings+failures, failures)
+	}
 	summary := calculateSummary(opts, endpoint, results, startTime)
+	applyGPUMetrics(&summary, opts)
 
 	return outputBenchmarkResults(summary, opts, reportWriter)
 }
 
 func outputBenchmarkResults(summary BenchmarkSummary, opts *benchmarkOptions, reportWriter *ReportWriter) error {
-	switch opts.output {
-	case outputFormatJSON:
-		if err := outputJSON(summary); err != nil {
-			return err
+	if err := writeOutput(opts, func() error {
+		switch opts.output {
+		case outputFormatJSON:
+			if err := outputJSON(summary); err != nil {
+				return err
+			}
+		case outputFormatMarkdown:
+			outputMarkdown(summary)
+		case outputFormatCSV:
+			if err := writeResultsCSV(os.Stdout, summary.Results); err != nil {
+				return err
+			}
+		case outputFormatDeltaTable:
+			// Printed by recordBenchmarkHistory once the baseline is resolved.
+		case outputFormatNDJSON:
+			final := summary
+			final.Results = nil
+			if err := opts.stream.emitSummary(final); err != nil {
+				return err
+			}
+		case outputFormatInflux:
+			// Written by recordInfluxLines below.
+		default:
+			outputTable(summary)
 		}
-	case outputFormatMarkdown:
-		outputMarkdown(summary)
-	default:
-		outputTable(summary)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if opts.validateSchema && opts.output != outputFormatJSON && opts.output != outputFormatCSV {
+		outputSchemaConformance(os.Stdout, summary.Results)
+	}
+
+	if err := recordBenchmarkHistory(summary, opts); err != nil {
+		return err
+	}
+	if err := recordBenchmarkBadges(summary, opts); err != nil {
+		return err
+	}
+	if err := recordInfluxLines([]string{benchmarkInfluxLine(summary)}, opts); err != nil {
+		return err
+	}
+	if err := exportPerIterationCSV(summary.Results, opts); err != nil {
+		return err
 	}
 
 	if reportWriter != nil {
@@ -592,7 +1289,7 @@ func outputBenchmarkResults(summary BenchmarkSummary, opts *benchmarkOptions, re
 		}
 	}
 
-	return nil
+	return checkBenchmarkRegression(summary, opts)
 }
 
 func runStressTestWithReport(
@@ -602,16 +1299,43 @@ func runStressTestWithReport(
 	if err != nil {
 		return err
 	}
+	applyGPUMetrics(&summary.BenchmarkSummary, opts)
 
-	switch opts.output {
-	case outputFormatJSON:
-		if err := outputStressJSON(*summary); err != nil {
-			return err
+	if err := writeOutput(opts, func() error {
+		switch opts.output {
+		case outputFormatJSON:
+			if err := outputStressJSON(*summary); err != nil {
+				return err
+			}
+		case outputFormatMarkdown:
+			outputStressMarkdown(*summary)
+		case outputFormatCSV:
+			if err := writeResultsCSV(os.Stdout, summary.Results); err != nil {
+				return err
+			}
+		case outputFormatNDJSON:
+			final := *summary
+			final.Results = nil
+			if err := opts.stream.emitSummary(final); err != nil {
+				return err
+			}
+		case outputFormatInflux:
+			// Written by recordInfluxLines below.
+		default:
+			outputStressTable(*summary)
 		}
-	case outputFormatMarkdown:
-		outputStressMarkdown(*summary)
-	default:
-		outputStressTable(*summary)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if opts.validateSchema && opts.output != outputFormatJSON && opts.output != outputFormatCSV {
+		outputSchemaConformance(os.Stdout, summary.Results)
+	}
+	if err := recordInfluxLines([]string{stressInfluxLine(*summary)}, opts); err != nil {
+		return err
+	}
+	if err := exportPerIterationCSV(summary.Results, opts); err != nil {
+		return err
 	}
 
 	if reportWriter != nil {
@@ -623,5 +1347,5 @@ func runStressTestWithReport(
 		}
 	}
 
-	return nil
+	return checkBenchmarkRegression(summary.BenchmarkSummary, opts)
 }
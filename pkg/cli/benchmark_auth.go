@@ -0,0 +1,72 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Endpoints behind an auth proxy or API gateway: --header and --bearer-token
+// are sent with every request to the endpoint (health checks included), and
+// --insecure skips TLS verification for self-signed internal certificates.
+
+// parseRequestHeaders turns repeated "Key: Value" --header flags and a
+// --bearer-token into the headers sent with every benchmark request.
+func parseRequestHeaders(headers []string, bearerToken string) (http.Header, error) {
+	if len(headers) == 0 && bearerToken == "" {
+		return nil, nil
+	}
+
+	parsed := http.Header{}
+	for _, h := range headers {
+		key, value, ok := strings.Cut(h, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --header %q: expected \"Key: Value\"", h)
+		}
+		parsed.Add(key, strings.TrimSpace(value))
+	}
+	if bearerToken != "" {
+		if parsed.Get("Authorization") != "" {
+			return nil, fmt.Errorf("--bearer-token cannot be combined with an Authorization --header")
+		}
+		parsed.Set("Authorization", "Bearer "+bearerToken)
+	}
+	return parsed, nil
+}
+
+// setRequestHeaders applies headers to req, replacing any value the
+// benchmark set itself for the same key.
+func setRequestHeaders(req *http.Request, headers http.Header) {
+	for key, values := range headers {
+		req.Header.Del(key)
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+}
+
+// skipBenchmarkTLSVerify makes the shared benchmark transport accept any
+// server certificate, for --insecure.
+func skipBenchmarkTLSVerify() {
+	benchmarkTransport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec // explicit opt-in via --insecure
+	}
+}
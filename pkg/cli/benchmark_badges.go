@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	badgeColorOK      = "blue"
+	badgeColorUnknown = "lightgrey"
+)
+
+// shieldsBadge is the shields.io endpoint badge schema
+// (https://shields.io/badges/endpoint-badge). Serving one of these files from
+// any public URL lets a README render it via img.shields.io/endpoint?url=...
+type shieldsBadge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// benchmarkBadges derives the throughput and P99 latency badges from summary,
+// keyed by the suffix used in their file names. A run with no successful
+// requests produces "n/a" badges rather than advertising zeros.
+func benchmarkBadges(summary BenchmarkSummary) map[string]shieldsBadge {
+	throughput := shieldsBadge{SchemaVersion: 1, Label: "throughput", Message: "n/a", Color: badgeColorUnknown}
+	p99 := shieldsBadge{SchemaVersion: 1, Label: "p99 latency", Message: "n/a", Color: badgeColorUnknown}
+	if summary.SuccessfulRuns > 0 {
+		throughput.Message = fmt.Sprintf("%.1f tok/s", summary.GenerationToksPerSecMean)
+		throughput.Color = badgeColorOK
+		p99.Message = fmt.Sprintf("%.0f ms", summary.LatencyP99)
+		p99.Color = badgeColorOK
+	}
+	return map[string]shieldsBadge{
+		"throughput": throughput,
+		"p99":        p99,
+	}
+}
+
+// exportBenchmarkBadges writes one shields.io endpoint JSON file per badge
+// into dir and returns the written paths in a stable order.
+func exportBenchmarkBadges(dir string, summary BenchmarkSummary) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create badge directory: %w", err)
+	}
+
+	badges := benchmarkBadges(summary)
+	paths := make([]string, 0, len(badges))
+	for _, key := range []string{"throughput", "p99"} {
+		data, err := json.MarshalIndent(badges[key], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s badge: %w", key, err)
+		}
+		path := filepath.Join(dir, historyFilePrefix(summary.Namespace, summary.ServiceName)+key+".json")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s badge: %w", key, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func recordBenchmarkBadges(summary BenchmarkSummary, opts *benchmarkOptions) error {
+	if opts.badgeDir == "" {
+		return nil
+	}
+	paths, err := exportBenchmarkBadges(opts.badgeDir, summary)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n🏷️  Wrote README badges (serve them and embed with ![](https://img.shields.io/endpoint?url=<file-url>)):\n")
+	for _, p := range paths {
+		fmt.Printf("   %s\n", p)
+	}
+	return nil
+}
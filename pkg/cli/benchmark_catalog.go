@@ -19,6 +19,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -84,19 +85,33 @@ func printCatalogBenchmarkHeader(opts *benchmarkOptions, modelIDs []string, acce
 
 func outputFormattedReport(report ComparisonReport, opts *benchmarkOptions, reportWriter *ReportWriter) error {
 	fmt.Printf("\n")
-	switch opts.output {
-	case outputFormatJSON:
-		if err := outputComparisonJSON(report); err != nil {
-			return err
-		}
-	case outputFormatMarkdown:
-		if err := outputComparisonMarkdown(report); err != nil {
-			return err
-		}
-	default:
-		if err := outputComparisonTable(report); err != nil {
-			return err
+	if err := writeOutput(opts, func() error {
+		switch opts.output {
+		case outputFormatJSON:
+			if err := outputComparisonJSON(report); err != nil {
+				return err
+			}
+		case outputFormatMarkdown:
+			if err := outputComparisonMarkdown(report); err != nil {
+				return err
+			}
+		case outputFormatCSV:
+			if err := writeComparisonCSV(os.Stdout, report); err != nil {
+				return err
+			}
+		case outputFormatInflux:
+			// Written by recordInfluxLines below.
+		default:
+			if err := outputComparisonTable(report); err != nil {
+				return err
+			}
 		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := recordInfluxLines(comparisonInfluxLines(report), opts); err != nil {
+		return err
 	}
 
 	if reportWriter != nil {
@@ -107,7 +122,7 @@ func outputFormattedReport(report ComparisonReport, opts *benchmarkOptions, repo
 			return fmt.Errorf("failed to close report: %w", err)
 		}
 	}
-	return nil
+	return checkComparisonRegression(report, opts)
 }
 
 func runCatalogBenchmark(opts *benchmarkOptions) error {
@@ -115,7 +130,8 @@ func runCatalogBenchmark(opts *benchmarkOptions) error {
 		return runContextSweep(opts)
 	}
 
-	ctx := context.Background()
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
 	startTime := time.Now()
 
 	modelIDs := parseCatalogModelIDs(opts.catalog)
@@ -157,6 +173,10 @@ func runCatalogBenchmark(opts *benchmarkOptions) error {
 	}
 
 	for idx, modelID := range modelIDs {
+		if ctx.Err() != nil {
+			fmt.Printf("⚠️  Interrupted; skipping the remaining %d model(s)\n\n", len(modelIDs)-idx)
+			break
+		}
 		catalogModel := catalogModels[idx]
 
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
@@ -169,6 +189,12 @@ func runCatalogBenchmark(opts *benchmarkOptions) error {
 	}
 
 	report.Duration = time.Since(startTime)
+	if opts.diffAgainstCatalog {
+		flagUnderperformingModels(report.Models)
+	}
+	if opts.warnOnTokenMismatch {
+		flagTokenMismatch(report.Models)
+	}
 	return outputFormattedReport(report, opts, reportWriter)
 }
 
@@ -187,6 +213,17 @@ func benchmarkSingleCatalogModel(
 		VRAMEstimate: catalogModel.VRAMEstimate,
 	}
 
+	if err := assertVRAMBudget(ctx, catalogModel.Source, catalogContextSize(catalogModel, opts), opts); err != nil {
+		fmt.Printf("   ❌ %v\n\n", err)
+		modelBenchmark.Status = statusFailed
+		modelBenchmark.Error = fmt.Sprintf("vram budget: %v", err)
+		return modelBenchmark
+	}
+
+	// Registered before the deploy so a Model created just ahead of the
+	// interrupt is still removed.
+	defer cleanupInterruptedModel(ctx, k8sClient, modelID, opts)
+
 	fmt.Printf("🚀 Deploying %s...\n", modelID)
 	if err := deployModel(ctx, k8sClient, modelID, catalogModel, opts); err != nil {
 		fmt.Printf("   ❌ Deployment failed: %v\n\n", err)
@@ -235,6 +272,7 @@ func benchmarkSingleCatalogModel(
 			modelBenchmark.TotalRequests = stressSummary.TotalRequests
 			modelBenchmark.RequestsPerSec = stressSummary.RequestsPerSec
 			modelBenchmark.ErrorRate = stressSummary.ErrorRate
+			modelBenchmark.PromptTokens = stressSummary.PromptTokens
 		}
 	} else {
 		summary, benchErr := runBenchmarkInternalWithEndpoint(ctx, endpoint, opts, benchmarkStartTime)
@@ -248,6 +286,7 @@ func benchmarkSingleCatalogModel(
 			modelBenchmark.PromptToksPerSec = summary.PromptToksPerSecMean
 			modelBenchmark.LatencyP50Ms = summary.LatencyP50
 			modelBenchmark.LatencyP99Ms = summary.LatencyP99
+			modelBenchmark.PromptTokens = summary.PromptTokens
 		}
 	}
 
@@ -454,6 +493,28 @@ func waitForDeployment(ctx context.Context, k8sClient client.Client, modelID str
 	}
 }
 
+// interruptCleanupTimeout bounds the teardown after an interrupt, so a
+// second Ctrl-C is not needed when the API server is unreachable.
+const interruptCleanupTimeout = 30 * time.Second
+
+// cleanupInterruptedModel deletes modelID when ctx was cancelled by
+// SIGINT/SIGTERM. The regular cleanup paths run on ctx and fail once it is
+// cancelled, so the teardown uses a fresh, bounded context instead. It
+// respects --no-cleanup and --cleanup-on-interrupt=false.
+func cleanupInterruptedModel(ctx context.Context, k8sClient client.Client, modelID string, opts *benchmarkOptions) {
+	if ctx.Err() == nil || !opts.cleanup || !opts.cleanupOnInterrupt {
+		return
+	}
+	fmt.Printf("🧹 Interrupted; cleaning up %s...\n", modelID)
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), interruptCleanupTimeout)
+	defer cancel()
+	if err := cleanupModel(cleanupCtx, k8sClient, modelID, opts); err != nil {
+		fmt.Printf("   ⚠️  Cleanup warning: %v\n", err)
+		return
+	}
+	fmt.Printf("   ✅ Cleaned up\n")
+}
+
 func cleanupModel(ctx context.Context, k8sClient client.Client, modelID string, opts *benchmarkOptions) error {
 	isvc := &inferencev1alpha1.InferenceService{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --diff-against-catalog: token generation is memory-bandwidth bound, so a
+// model's tok/s times its weight size is roughly the bandwidth it achieved
+// and should be similar for every model on the same hardware. A model far
+// below its peers on that measure is likely misconfigured (too few
+// gpu-layers, a CPU image) rather than simply large.
+
+// underperformingRatio is the fraction of the peers' median effective
+// bandwidth below which a model is flagged.
+const underperformingRatio = 1.0 / 3
+
+// parseVRAMEstimateGB returns the midpoint, in GB, of a catalog
+// vram_estimate such as "5-8GB" or "24GB".
+func parseVRAMEstimateGB(estimate string) (float64, bool) {
+	s := strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(estimate)), "GB")
+	low, high, isRange := strings.Cut(s, "-")
+	if !isRange {
+		high = low
+	}
+	lo, err := strconv.ParseFloat(strings.TrimSpace(low), 64)
+	if err != nil || lo <= 0 {
+		return 0, false
+	}
+	hi, err := strconv.ParseFloat(strings.TrimSpace(high), 64)
+	if err != nil || hi < lo {
+		return 0, false
+	}
+	return (lo + hi) / 2, true
+}
+
+// flagUnderperformingModels sets PerformanceWarning on every successful
+// model whose effective bandwidth is below underperformingRatio of the
+// median of the other successful models. It needs at least one peer with a
+// parseable VRAM estimate; a single-model run is never flagged.
+func flagUnderperformingModels(models []ModelBenchmark) {
+	effective := make(map[int]float64)
+	for i, m := range models {
+		if m.Status != statusSuccess || m.GenerationToksPerSec <= 0 {
+			continue
+		}
+		if gb, ok := parseVRAMEstimateGB(m.VRAMEstimate); ok {
+			effective[i] = m.GenerationToksPerSec * gb
+		}
+	}
+
+	for i, eff := range effective {
+		var peers []float64
+		for j, peer := range effective {
+			if j != i {
+				peers = append(peers, peer)
+			}
+		}
+		if len(peers) == 0 {
+			continue
+		}
+		sort.Float64s(peers)
+		peerMedian := percentile(peers, 50)
+		if eff >= peerMedian*underperformingRatio {
+			continue
+		}
+		gb, _ := parseVRAMEstimateGB(models[i].VRAMEstimate)
+		models[i].PerformanceWarning = fmt.Sprintf(
+			"%.1f tok/s is %.0f%% of what similar models achieve for a %s model (expected ~%.0f tok/s); "+
+				"check --gpu-layers and that the runtime image has GPU support",
+			models[i].GenerationToksPerSec, eff/peerMedian*100, models[i].ModelSize, peerMedian/gb)
+	}
+}
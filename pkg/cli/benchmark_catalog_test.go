@@ -1,6 +1,19 @@
 package cli
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
 
 func TestResolveImage(t *testing.T) {
 	tests := []struct {
@@ -108,3 +121,73 @@ func TestValidateCatalogModels(t *testing.T) {
 		})
 	}
 }
+
+// TestBenchmarkSingleCatalogModelCleansUpOnInterrupt cancels the run context
+// while the catalog model is waiting to become ready, as SIGINT does, and
+// asserts the Model and InferenceService are still deleted. The client honours
+// cancellation like a real API client, so cleanup on the cancelled run
+// context alone would leave them behind.
+func TestBenchmarkSingleCatalogModelCleansUpOnInterrupt(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	catalogModel, err := GetModel("llama-3.1-8b")
+	if err != nil {
+		t.Fatalf("GetModel: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		cleanup     bool
+		onInterrupt bool
+		wantDeleted bool
+	}{
+		{"cleans up on interrupt", true, true, true},
+		{"--no-cleanup keeps the deployment", false, true, false},
+		{"--cleanup-on-interrupt=false keeps the deployment", true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &benchmarkOptions{
+				namespace:          "default",
+				deployWait:         time.Minute,
+				cleanup:            tt.cleanup,
+				cleanupOnInterrupt: tt.onInterrupt,
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					return c.Delete(ctx, obj, opts...)
+				},
+			}).Build()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			key := types.NamespacedName{Name: "llama-3.1-8b", Namespace: "default"}
+
+			done := make(chan ModelBenchmark)
+			go func() {
+				done <- benchmarkSingleCatalogModel(ctx, k8sClient, key.Name, catalogModel, opts, false)
+			}()
+
+			deadline := time.Now().Add(10 * time.Second)
+			for k8sClient.Get(context.Background(), key, &inferencev1alpha1.InferenceService{}) != nil {
+				if time.Now().After(deadline) {
+					t.Fatal("timed out waiting for the InferenceService to be deployed")
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+			cancel()
+
+			if result := <-done; result.Status != statusFailed {
+				t.Errorf("expected the interrupted benchmark to be reported as failed, got %q", result.Status)
+			}
+			isvcErr := k8sClient.Get(context.Background(), key, &inferencev1alpha1.InferenceService{})
+			modelErr := k8sClient.Get(context.Background(), key, &inferencev1alpha1.Model{})
+			if deleted := apierrors.IsNotFound(isvcErr) && apierrors.IsNotFound(modelErr); deleted != tt.wantDeleted {
+				t.Errorf("deleted = %v (isvc: %v, model: %v), want %v", deleted, isvcErr, modelErr, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ConcurrentModelsReport is the --concurrent-models result. Every service is
+// measured over the same wall-clock window, so comparing a service's numbers
+// here with a solo run of it shows what its neighbors cost it.
+type ConcurrentModelsReport struct {
+	Services  []BenchmarkSummary `json:"services"`
+	Aggregate BenchmarkSummary   `json:"aggregate"`
+
+	// AggregateGenerationToksPerSec is the completion tokens produced by all
+	// services divided by the wall-clock duration: the cluster's combined
+	// output rate, not a mean of per-request rates.
+	AggregateGenerationToksPerSec float64 `json:"aggregate_generation_toks_per_sec"`
+}
+
+// concurrentModelTarget is one service under test and its resolved endpoint.
+type concurrentModelTarget struct {
+	opts     *benchmarkOptions
+	endpoint string
+}
+
+func parseConcurrentModels(value string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func runConcurrentModelsBenchmark(opts *benchmarkOptions) error {
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
+	return runConcurrentModelsContext(ctx, opts)
+}
+
+func runConcurrentModelsContext(ctx context.Context, opts *benchmarkOptions) error {
+	names := parseConcurrentModels(opts.concurrentModels)
+	if len(names) < 2 {
+		return fmt.Errorf("--concurrent-models needs at least two services, got %d", len(names))
+	}
+
+	// Resolve every endpoint (port-forwards included) before sending any
+	// traffic so all services start loaded at the same moment.
+	targets := make([]concurrentModelTarget, 0, len(names))
+	for _, name := range names {
+		svcOpts := *opts
+		svcOpts.name = name
+		endpoint, cleanup, err := benchmarkEndpoint(ctx, &svcOpts)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", name, err)
+		}
+		if cleanup != nil {
+			defer cleanup()
+		}
+		targets = append(targets, concurrentModelTarget{opts: &svcOpts, endpoint: endpoint})
+	}
+
+	fmt.Printf("\n🏁 LLMKube Concurrent Models Benchmark\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Services:    %s\n", strings.Join(names, ", "))
+	fmt.Printf("Namespace:   %s\n", opts.namespace)
+	fmt.Printf("Iterations:  %d per service (+ %d warmup)\n", opts.iterations, opts.warmup)
+	fmt.Printf("Max Tokens:  %d\n", opts.maxTokens)
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n\n")
+
+	report := benchmarkConcurrentModels(ctx, targets, opts)
+
+	return writeOutput(opts, func() error {
+		if opts.output == outputFormatJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		outputConcurrentModelsTable(os.Stdout, report)
+		return nil
+	})
+}
+
+// benchmarkConcurrentModels drives every target at the same time, each with
+// its own warmup and sequential iterations, and summarizes per service and in
+// aggregate once all of them finish or ctx is canceled.
+func benchmarkConcurrentModels(ctx context.Context, targets []concurrentModelTarget, opts *benchmarkOptions) ConcurrentModelsReport {
+	startTime := time.Now()
+	perService := make([][]BenchmarkResult, len(targets))
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target concurrentModelTarget) {
+			defer wg.Done()
+			perService[i] = runConcurrentModelIterations(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	report := ConcurrentModelsReport{Services: make([]BenchmarkSummary, 0, len(targets))}
+	var all []BenchmarkResult
+	completionTokens := 0
+	for i, target := range targets {
+		report.Services = append(report.Services,
+			calculateSummary(target.opts, target.endpoint, perService[i], startTime))
+		for _, r := range perService[i] {
+			if r.Error == "" {
+				completionTokens += r.CompletionTokens
+			}
+		}
+		all = append(all, perService[i]...)
+	}
+
+	aggOpts := *opts
+	aggOpts.name = "aggregate"
+	aggOpts.iterations = len(all)
+	report.Aggregate = calculateSummary(&aggOpts, "", all, startTime)
+	// The per-service summaries already carry every result.
+	report.Aggregate.Results = nil
+	if secs := report.Aggregate.Duration.Seconds(); secs > 0 {
+		report.AggregateGenerationToksPerSec = float64(completionTokens) / secs
+	}
+	return report
+}
+
+func runConcurrentModelIterations(ctx context.Context, target concurrentModelTarget) []BenchmarkResult {
+	opts := target.opts
+	for i := 0; i < opts.warmup && ctx.Err() == nil; i++ {
+		_, _ = sendBenchmarkRequest(ctx, target.endpoint, opts, i+1)
+	}
+
+	results := make([]BenchmarkResult, 0, opts.iterations)
+	for i := 0; i < opts.iterations; i++ {
+		result, err := sendBenchmarkRequest(ctx, target.endpoint, opts, i+1)
+		if ctx.Err() != nil {
+			fmt.Printf("   [%s] ⚠️  Interrupted after %d/%d iterations\n", opts.name, i, opts.iterations)
+			break
+		}
+		if err != nil {
+			result = BenchmarkResult{Iteration: i + 1, Error: err.Error()}
+			fmt.Printf("   [%s] [%d/%d] ❌ Error: %v\n", opts.name, i+1, opts.iterations, err)
+		} else {
+			fmt.Printf("   [%s] [%d/%d] ✅ %.1f tok/s (%.0fms)\n",
+				opts.name, i+1, opts.iterations, result.GenerationToksPerSec, result.TotalTimeMs)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func outputConcurrentModelsTable(w io.Writer, report ConcurrentModelsReport) {
+	_, _ = fmt.Fprintf(w, "\n📊 Per-Service Results (measured concurrently)\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "SERVICE\tOK\tFAILED\tGEN TOK/S\tP50 (ms)\tP99 (ms)\n")
+	_, _ = fmt.Fprintf(tw, "───────\t──\t──────\t─────────\t────────\t────────\n")
+	for _, s := range report.Services {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.0f\t%.0f\n",
+			s.ServiceName, s.SuccessfulRuns, s.FailedRuns,
+			s.GenerationToksPerSecMean, s.LatencyP50, s.LatencyP99)
+	}
+	_ = tw.Flush()
+
+	agg := report.Aggregate
+	_, _ = fmt.Fprintf(w, "\n📈 Aggregate\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Services:\t%d\n", len(report.Services))
+	_, _ = fmt.Fprintf(tw, "Requests:\t%d ok / %d failed\n", agg.SuccessfulRuns, agg.FailedRuns)
+	_, _ = fmt.Fprintf(tw, "Combined output:\t%.1f tok/s\n", report.AggregateGenerationToksPerSec)
+	_, _ = fmt.Fprintf(tw, "Latency P50 / P99:\t%.0f / %.0f ms\n", agg.LatencyP50, agg.LatencyP99)
+	_, _ = fmt.Fprintf(tw, "Duration:\t%s\n", agg.Duration.Round(time.Millisecond))
+	_ = tw.Flush()
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// contextFillFractions are the shares of the configured context that
+// --compare-context-sizes fills with the prompt. The top step stays below
+// 100% so prompt plus generated tokens never overflow the slot.
+var contextFillFractions = []float64{0.1, 0.25, 0.5, 0.75, 0.9}
+
+// ContextFillResult is the measurement for one context fill level.
+type ContextFillResult struct {
+	Fraction             float64 `json:"fraction"`
+	TargetPromptTokens   int     `json:"target_prompt_tokens"`
+	PromptTokens         float64 `json:"prompt_tokens_mean"`
+	TTFTMs               float64 `json:"ttft_ms_mean"`
+	PromptToksPerSec     float64 `json:"prompt_toks_per_sec_mean"`
+	GenerationToksPerSec float64 `json:"generation_toks_per_sec_mean"`
+	LatencyMeanMs        float64 `json:"latency_mean_ms"`
+	LatencyP99Ms         float64 `json:"latency_p99_ms"`
+	SuccessfulRuns       int     `json:"successful_runs"`
+	FailedRuns           int     `json:"failed_runs"`
+}
+
+// llamaServerProps is the subset of llama.cpp's /props response that carries
+// the per-slot context size.
+type llamaServerProps struct {
+	DefaultGenerationSettings struct {
+		NCtx int `json:"n_ctx"`
+	} `json:"default_generation_settings"`
+}
+
+// serverContextSize reads the context size a llama.cpp server was started
+// with from its /props endpoint.
+func serverContextSize(ctx context.Context, endpoint string, headers http.Header) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"/props", nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	setRequestHeaders(req, headers)
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: promptTokenizeTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+	var props llamaServerProps
+	if err := json.Unmarshal(body, &props); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if props.DefaultGenerationSettings.NCtx <= 0 {
+		return 0, fmt.Errorf("server did not report n_ctx")
+	}
+	return props.DefaultGenerationSettings.NCtx, nil
+}
+
+// contextFillTargets returns the prompt token count for each fill fraction,
+// leaving room for maxTokens of generation.
+func contextFillTargets(contextSize, maxTokens int) []int {
+	targets := make([]int, 0, len(contextFillFractions))
+	for _, f := range contextFillFractions {
+		tokens := int(f*float64(contextSize)) - maxTokens
+		targets = append(targets, max(tokens, 1))
+	}
+	return targets
+}
+
+// measureContextFill sends opts.iterations prompts at each fill level and
+// aggregates latency and throughput. wordsPerToken converts a token target to
+// synthetic prompt words; each request rotates the words so the server's
+// prompt cache cannot skip the prefill.
+func measureContextFill(
+	ctx context.Context, endpoint string, opts *benchmarkOptions, targets []int, wordsPerToken float64,
+) []ContextFillResult {
+	results := make([]ContextFillResult, 0, len(targets))
+	seed := 0
+
+	for i, target := range targets {
+		fraction := contextFillFractions[i]
+		fmt.Printf("📊 Context %.0f%% full: ~%d prompt tokens (%d iterations)\n", fraction*100, target, opts.iterations)
+
+		group := ContextFillResult{Fraction: fraction, TargetPromptTokens: target}
+		words := max(int(float64(target)*wordsPerToken), 1)
+		var promptTokens, promptToks, genToks, ttft, latencies []float64
+		for j := 0; j < opts.iterations; j++ {
+			if ctx.Err() != nil {
+				break
+			}
+			seed++
+			result, err := sendBenchmarkRequestWithPrompt(ctx, endpoint, opts, j+1, syntheticPrompt(words, seed))
+			if err != nil {
+				group.FailedRuns++
+				fmt.Printf("   [%d/%d] ❌ Error: %v\n", j+1, opts.iterations, err)
+				continue
+			}
+			group.SuccessfulRuns++
+			promptTokens = append(promptTokens, float64(result.PromptTokens))
+			latencies = append(latencies, result.TotalTimeMs)
+			if result.PromptToksPerSec > 0 {
+				promptToks = append(promptToks, result.PromptToksPerSec)
+			}
+			if result.GenerationToksPerSec > 0 {
+				genToks = append(genToks, result.GenerationToksPerSec)
+			}
+			if result.PromptTimeMs > 0 {
+				ttft = append(ttft, result.PromptTimeMs)
+			} else {
+				ttft = append(ttft, result.TotalTimeMs)
+			}
+			fmt.Printf("   [%d/%d] ✅ %d prompt tokens, %.1f gen tok/s (%.0fms)\n",
+				j+1, opts.iterations, result.PromptTokens, result.GenerationToksPerSec, result.TotalTimeMs)
+		}
+
+		sort.Float64s(latencies)
+		group.PromptTokens = mean(promptTokens)
+		group.TTFTMs = mean(ttft)
+		group.PromptToksPerSec = mean(promptToks)
+		group.GenerationToksPerSec = mean(genToks)
+		group.LatencyMeanMs = mean(latencies)
+		group.LatencyP99Ms = percentile(latencies, 99)
+		results = append(results, group)
+		fmt.Println()
+	}
+
+	return results
+}
+
+// outputContextFillTable prints one row per fill level, with generation
+// throughput and mean latency relative to the least-filled level.
+func outputContextFillTable(w io.Writer, contextSize int, results []ContextFillResult) {
+	_, _ = fmt.Fprintf(w, "\n📊 Context Fill Comparison (n_ctx %d)\n", contextSize)
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n\n")
+
+	var base *ContextFillResult
+	for i := range results {
+		if results[i].SuccessfulRuns > 0 {
+			base = &results[i]
+			break
+		}
+	}
+	change := func(v, ref float64) string {
+		if ref == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%+.0f%%", (v-ref)/ref*100)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "FILL\tPROMPT TOKENS\tTTFT (ms)\tPROMPT TOK/S\tGEN TOK/S\tΔ GEN\tMEAN (ms)\tΔ MEAN\tP99 (ms)\tSTATUS\n")
+	_, _ = fmt.Fprintf(tw, "────\t─────────────\t─────────\t────────────\t─────────\t─────\t─────────\t──────\t────────\t──────\n")
+	for _, r := range results {
+		if r.SuccessfulRuns == 0 {
+			_, _ = fmt.Fprintf(tw, "%.0f%%\t%d\t-\t-\t-\t-\t-\t-\t-\t%s\n", r.Fraction*100, r.TargetPromptTokens, statusIconFailed)
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%.0f%%\t%.0f\t%.0f\t%.1f\t%.1f\t%s\t%.0f\t%s\t%.0f\t%s\n",
+			r.Fraction*100, r.PromptTokens, r.TTFTMs, r.PromptToksPerSec,
+			r.GenerationToksPerSec, change(r.GenerationToksPerSec, base.GenerationToksPerSec),
+			r.LatencyMeanMs, change(r.LatencyMeanMs, base.LatencyMeanMs),
+			r.LatencyP99Ms, statusIconSuccess)
+	}
+	_ = tw.Flush()
+}
+
+func runContextFillComparison(opts *benchmarkOptions) error {
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
+	startTime := time.Now()
+
+	endpoint, cleanup, err := benchmarkEndpoint(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	// --context overrides what the server reports, for runtimes without /props.
+	contextSize := int(opts.contextSize)
+	if contextSize <= 0 {
+		if contextSize, err = serverContextSize(ctx, endpoint, opts.requestHeaders); err != nil {
+			return fmt.Errorf("failed to read the service's context size (pass --context to set it): %w", err)
+		}
+	}
+	targets := contextFillTargets(contextSize, opts.maxTokens)
+
+	// Calibrate words per token once, at the smallest fill level; without a
+	// tokenizer endpoint assume one word per token.
+	wordsPerToken := 1.0
+	if words, tokens, err := calibratePromptWords(ctx, endpoint, opts.requestHeaders, targets[0]); err != nil {
+		fmt.Printf("⚠️  Tokenize endpoint unavailable (%v); prompt lengths are approximate\n\n", err)
+	} else {
+		wordsPerToken = float64(words) / float64(tokens)
+	}
+
+	fmt.Printf("\n🔄 Context Fill Comparison\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Service:     %s\n", opts.name)
+	fmt.Printf("Endpoint:    %s\n", endpoint)
+	fmt.Printf("Context:     %d tokens\n", contextSize)
+	fmt.Printf("Prompts:     %v tokens\n", targets)
+	fmt.Printf("Iterations:  %d per fill level\n", opts.iterations)
+	fmt.Printf("Max Tokens:  %d\n", opts.maxTokens)
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n\n")
+
+	if opts.warmup > 0 {
+		runWarmupRequests(ctx, endpoint, opts)
+	}
+
+	results := measureContextFill(ctx, endpoint, opts, targets, wordsPerToken)
+
+	if opts.output == outputFormatJSON {
+		return writeOutput(opts, func() error {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(results)
+		})
+	}
+	if err := writeOutput(opts, func() error {
+		outputContextFillTable(os.Stdout, contextSize, results)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Total Duration: %s\n", time.Since(startTime).Round(time.Second))
+	return nil
+}
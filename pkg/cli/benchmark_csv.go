@@ -0,0 +1,229 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --output csv: one row per request for benchmark and stress runs, one row
+// per model for catalog comparisons and one row per value for sweeps, each
+// with a header line. Column names match the JSON output's field names so
+// both load into pandas or R the same way.
+
+var resultCSVHeader = []string{
+	"iteration", "prompt_tokens", "completion_tokens", "total_tokens",
+	"prompt_time_ms", "generation_time_ms", "total_time_ms",
+	"prompt_tokens_per_sec", "generation_tokens_per_sec",
+	"inter_token_mean_ms", "inter_token_p95_ms", "inter_token_p99_ms", "error",
+}
+
+// perIterationCSVHeader is the --export-csv-per-iteration header: every
+// serialized BenchmarkResult field, a superset of resultCSVHeader.
+var perIterationCSVHeader = []string{
+	"iteration", "prompt_tokens", "prompt_n", "completion_tokens", "total_tokens",
+	"prompt_time_ms", "generation_time_ms", "ttft_ms", "total_time_ms",
+	"prompt_tokens_per_sec", "generation_tokens_per_sec",
+	"inter_token_mean_ms", "inter_token_p95_ms", "inter_token_p99_ms",
+	"schema_violations", "error",
+}
+
+var comparisonCSVHeader = []string{
+	"model_id", "model_name", "model_size", "status",
+	"generation_toks_per_sec", "prompt_toks_per_sec", "latency_p50_ms", "latency_p99_ms",
+	"vram_estimate", "total_requests", "requests_per_sec", "error_rate", "error",
+}
+
+var sweepCSVHeader = []string{
+	"parameter", "value", "generation_toks_per_sec_mean", "latency_p50_ms", "latency_p99_ms",
+	"total_requests", "requests_per_sec", "error_rate", "error",
+}
+
+func formatCSVFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// writeResultsCSV writes one row per benchmark request.
+func writeResultsCSV(w io.Writer, results []BenchmarkResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(resultCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			strconv.Itoa(r.Iteration),
+			strconv.Itoa(r.PromptTokens),
+			strconv.Itoa(r.CompletionTokens),
+			strconv.Itoa(r.TotalTokens),
+			formatCSVFloat(r.PromptTimeMs),
+			formatCSVFloat(r.GenerationTimeMs),
+			formatCSVFloat(r.TotalTimeMs),
+			formatCSVFloat(r.PromptToksPerSec),
+			formatCSVFloat(r.GenerationToksPerSec),
+			formatCSVFloat(r.InterTokenMeanMs),
+			formatCSVFloat(r.InterTokenP95Ms),
+			formatCSVFloat(r.InterTokenP99Ms),
+			r.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writePerIterationCSV writes one row per request with every result field.
+// TTFT is only measured for --stream runs and is left empty otherwise.
+func writePerIterationCSV(w io.Writer, results []BenchmarkResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(perIterationCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		ttft := ""
+		if r.TTFTMs > 0 {
+			ttft = formatCSVFloat(r.TTFTMs)
+		}
+		row := []string{
+			strconv.Itoa(r.Iteration),
+			strconv.Itoa(r.PromptTokens),
+			strconv.Itoa(r.PromptN),
+			strconv.Itoa(r.CompletionTokens),
+			strconv.Itoa(r.TotalTokens),
+			formatCSVFloat(r.PromptTimeMs),
+			formatCSVFloat(r.GenerationTimeMs),
+			ttft,
+			formatCSVFloat(r.TotalTimeMs),
+			formatCSVFloat(r.PromptToksPerSec),
+			formatCSVFloat(r.GenerationToksPerSec),
+			formatCSVFloat(r.InterTokenMeanMs),
+			formatCSVFloat(r.InterTokenP95Ms),
+			formatCSVFloat(r.InterTokenP99Ms),
+			strings.Join(r.SchemaViolations, "; "),
+			r.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportPerIterationCSV writes --export-csv-per-iteration, independent of
+// the --output format. A directory gets a timestamped file inside it, as
+// with --output-file.
+func exportPerIterationCSV(results []BenchmarkResult, opts *benchmarkOptions) error {
+	if opts.exportCSVPerIteration == "" {
+		return nil
+	}
+	path, err := resolveOutputFilePath(opts.exportCSVPerIteration, outputFormatCSV, time.Now())
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create per-iteration CSV: %w", err)
+	}
+	if err := writePerIterationCSV(f, results); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write per-iteration CSV: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write per-iteration CSV: %w", err)
+	}
+	fmt.Printf("📄 Per-iteration CSV: %s\n", path)
+	return nil
+}
+
+// writeComparisonCSV writes one row per catalog model. Metrics of skipped
+// and failed models are left empty rather than written as zeros.
+func writeComparisonCSV(w io.Writer, report ComparisonReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(comparisonCSVHeader); err != nil {
+		return err
+	}
+	for _, m := range report.Models {
+		row := []string{m.ModelID, m.ModelName, m.ModelSize, m.Status, "", "", "", "", m.VRAMEstimate, "", "", "", m.Error}
+		if m.Status == statusSuccess {
+			row[4] = formatCSVFloat(m.GenerationToksPerSec)
+			row[5] = formatCSVFloat(m.PromptToksPerSec)
+			row[6] = formatCSVFloat(m.LatencyP50Ms)
+			row[7] = formatCSVFloat(m.LatencyP99Ms)
+			if report.IsStressTest {
+				row[9] = strconv.FormatInt(m.TotalRequests, 10)
+				row[10] = formatCSVFloat(m.RequestsPerSec)
+				row[11] = formatCSVFloat(m.ErrorRate)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeSweepCSV writes one row per sweep value, mirroring outputSweepTable.
+func writeSweepCSV(w io.Writer, report SweepReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(sweepCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range report.Results {
+		row := []string{r.Parameter, r.Value, "", "", "", "", "", "", r.Error}
+		switch {
+		case r.Error != "":
+		case r.Stress != nil:
+			row[2] = formatCSVFloat(r.Stress.GenerationToksPerSecMean)
+			row[3] = formatCSVFloat(r.Stress.LatencyP50)
+			row[4] = formatCSVFloat(r.Stress.LatencyP99)
+			row[5] = strconv.FormatInt(r.Stress.TotalRequests, 10)
+			row[6] = formatCSVFloat(r.Stress.RequestsPerSec)
+			row[7] = formatCSVFloat(r.Stress.ErrorRate)
+		case r.Summary != nil:
+			row[2] = formatCSVFloat(r.Summary.GenerationToksPerSecMean)
+			row[3] = formatCSVFloat(r.Summary.LatencyP50)
+			row[4] = formatCSVFloat(r.Summary.LatencyP99)
+			row[5] = strconv.Itoa(r.Summary.Iterations)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// outputSweepReport prints a sweep's results as CSV for --output csv and as
+// the sweep table otherwise.
+func outputSweepReport(report SweepReport, opts *benchmarkOptions) error {
+	return writeOutput(opts, func() error {
+		if opts.output != outputFormatCSV {
+			outputSweepTable(report)
+			return nil
+		}
+		return writeSweepCSV(os.Stdout, report)
+	})
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --gpu-metrics-url: the benchmark machine rarely has nvidia-smi access to
+// the inference node, but a DCGM exporter (or an nvidia-smi based exporter)
+// usually runs there already. It is scraped every second while requests are
+// in flight and the mean and peak readings land in the summary.
+
+const (
+	gpuMetricsInterval      = time.Second
+	gpuMetricsScrapeTimeout = 900 * time.Millisecond
+)
+
+// gpuMetricNames are the utilization and memory series understood from each
+// supported exporter, with the factors converting them to percent and MiB.
+var gpuMetricNames = []struct {
+	util, memUsed, memFree, memTotal string
+	utilScale, memScale              float64
+}{
+	// dcgm-exporter: utilization in percent, framebuffer in MiB
+	{util: "DCGM_FI_DEV_GPU_UTIL", memUsed: "DCGM_FI_DEV_FB_USED", memFree: "DCGM_FI_DEV_FB_FREE",
+		utilScale: 1, memScale: 1},
+	// nvidia_gpu_exporter: utilization as a 0-1 ratio, memory in bytes
+	{util: "nvidia_smi_utilization_gpu_ratio", memUsed: "nvidia_smi_memory_used_bytes",
+		memTotal: "nvidia_smi_memory_total_bytes", utilScale: 100, memScale: 1.0 / (1 << 20)},
+}
+
+// prometheusSamples returns the value of every series of metricName in a
+// Prometheus text exposition, one per GPU for the supported exporters.
+func prometheusSamples(body, metricName string) []float64 {
+	var values []float64
+	for _, raw := range strings.Split(body, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || line[0] == '#' || !strings.HasPrefix(line, metricName) {
+			continue
+		}
+		rest := line[len(metricName):]
+		if len(rest) == 0 || (rest[0] != '{' && rest[0] != ' ') {
+			continue
+		}
+		if i := strings.LastIndexByte(rest, '}'); i >= 0 {
+			rest = rest[i+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// parseGPUMetricsExposition reads one GPUMetric from exporter output. As
+// with nvidia-smi, utilization is the busiest GPU's and memory is summed
+// across GPUs.
+func parseGPUMetricsExposition(body string) (*GPUMetric, error) {
+	for _, names := range gpuMetricNames {
+		utils := prometheusSamples(body, names.util)
+		if len(utils) == 0 {
+			continue
+		}
+
+		metric := &GPUMetric{Timestamp: time.Now()}
+		for _, u := range utils {
+			metric.UtilPercent = max(metric.UtilPercent, int(u*names.utilScale+0.5))
+		}
+		var used, total float64
+		for _, v := range prometheusSamples(body, names.memUsed) {
+			used += v
+		}
+		if names.memTotal != "" {
+			for _, v := range prometheusSamples(body, names.memTotal) {
+				total += v
+			}
+		} else {
+			total = used
+			for _, v := range prometheusSamples(body, names.memFree) {
+				total += v
+			}
+		}
+		metric.MemoryUsedMB = int(used*names.memScale + 0.5)
+		metric.MemoryTotalMB = int(total*names.memScale + 0.5)
+		return metric, nil
+	}
+	return nil, fmt.Errorf("no GPU utilization metric (%s or %s) found",
+		gpuMetricNames[0].util, gpuMetricNames[1].util)
+}
+
+// scrapeGPUMetrics fetches and parses one reading from a metrics endpoint.
+func scrapeGPUMetrics(client *http.Client, url string) (*GPUMetric, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape GPU metrics: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GPU metrics endpoint returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GPU metrics: %w", err)
+	}
+	return parseGPUMetricsExposition(string(body))
+}
+
+// newGPUMetricsMonitor polls a DCGM or nvidia-smi exporter instead of the
+// local nvidia-smi. Failed scrapes are skipped.
+func newGPUMetricsMonitor(url string) *gpuMonitor {
+	client := &http.Client{Transport: benchmarkTransport, Timeout: gpuMetricsScrapeTimeout}
+	gm := newGPUMonitor()
+	gm.sample = func() *GPUMetric {
+		metric, err := scrapeGPUMetrics(client, url)
+		if err != nil {
+			return nil
+		}
+		return metric
+	}
+	return gm
+}
+
+// startGPUMetrics begins polling --gpu-metrics-url, if set, for the rest of
+// the run. The returned function stops polling if the run ends before
+// applyGPUMetrics collected the samples.
+func startGPUMetrics(opts *benchmarkOptions) func() {
+	if opts.gpuMetricsURL == "" {
+		return func() {}
+	}
+	opts.gpuMetrics = newGPUMetricsMonitor(opts.gpuMetricsURL)
+	opts.gpuMetrics.start(gpuMetricsInterval)
+	return func() {
+		if opts.gpuMetrics != nil {
+			opts.gpuMetrics.stop()
+			opts.gpuMetrics = nil
+		}
+	}
+}
+
+// applyGPUMetrics stops polling and records the mean and peak GPU
+// utilization and memory used in summary. It does nothing without
+// --gpu-metrics-url or when no scrape succeeded.
+func applyGPUMetrics(summary *BenchmarkSummary, opts *benchmarkOptions) {
+	if opts.gpuMetrics == nil {
+		return
+	}
+	metrics := opts.gpuMetrics.stop()
+	opts.gpuMetrics = nil
+	if len(metrics) == 0 {
+		return
+	}
+
+	var utilSum, memSum float64
+	for _, m := range metrics {
+		utilSum += float64(m.UtilPercent)
+		memSum += float64(m.MemoryUsedMB)
+		summary.GPUUtilPeak = max(summary.GPUUtilPeak, float64(m.UtilPercent))
+		summary.GPUMemoryUsedPeakMB = max(summary.GPUMemoryUsedPeakMB, float64(m.MemoryUsedMB))
+		summary.GPUMemoryTotalMB = max(summary.GPUMemoryTotalMB, float64(m.MemoryTotalMB))
+	}
+	summary.GPUSamples = len(metrics)
+	summary.GPUUtilMean = utilSum / float64(len(metrics))
+	summary.GPUMemoryUsedMeanMB = memSum / float64(len(metrics))
+}
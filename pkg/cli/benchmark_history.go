@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const baselineLatest = "latest"
+
+// benchmarkDelta is one row of the delta table: a metric from the current run
+// compared against the same metric from the baseline run.
+type benchmarkDelta struct {
+	Metric         string
+	Unit           string
+	Current        float64
+	Baseline       float64
+	PercentChange  float64
+	HigherIsBetter bool
+}
+
+// historyFilePrefix returns the filename prefix shared by every stored run of
+// a service, so runs for different services can live in one directory.
+func historyFilePrefix(namespace, name string) string {
+	return fmt.Sprintf("%s-%s-", namespace, name)
+}
+
+// saveBenchmarkHistory writes summary as JSON into dir and returns the path.
+func saveBenchmarkHistory(dir string, summary BenchmarkSummary) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	filename := historyFilePrefix(summary.Namespace, summary.ServiceName) +
+		summary.Timestamp.Format("20060102-150405") + ".json"
+	path := filepath.Join(dir, filename)
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode benchmark history: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write benchmark history: %w", err)
+	}
+	return path, nil
+}
+
+// loadBaselineSummary resolves selector against the history directory. The
+// "latest" selector picks the most recent stored run for the same service;
+// any other value is treated as a file name (or path) of a stored run.
+func loadBaselineSummary(dir, selector, namespace, name string) (*BenchmarkSummary, string, error) {
+	path, err := resolveBaselinePath(dir, selector, namespace, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read baseline run: %w", err)
+	}
+	var summary BenchmarkSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, "", fmt.Errorf("failed to parse baseline run %s: %w", path, err)
+	}
+	return &summary, path, nil
+}
+
+// resolveBaselinePath returns the file selector names: the latest stored run
+// of the service for "latest", a file in dir for a bare file name, and
+// selector itself for a path.
+func resolveBaselinePath(dir, selector, namespace, name string) (string, error) {
+	if selector == "" || selector == baselineLatest {
+		return latestHistoryFile(dir, namespace, name)
+	}
+	if dir != "" && !filepath.IsAbs(selector) && !strings.ContainsRune(selector, filepath.Separator) {
+		return filepath.Join(dir, selector), nil
+	}
+	return selector, nil
+}
+
+// historyFilePattern matches exactly the run files saveBenchmarkHistory
+// writes for a service. A plain prefix glob would also pick up services whose
+// name extends this one ("my-llm-v2") and badge files ("my-llm-p99.json").
+func historyFilePattern(namespace, name string) *regexp.Regexp {
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(historyFilePrefix(namespace, name)) + `\d{8}-\d{6}\.json$`)
+}
+
+func latestHistoryFile(dir, namespace, name string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to list benchmark history: %w", err)
+	}
+	pattern := historyFilePattern(namespace, name)
+	var matches []string
+	for _, e := range entries {
+		if !e.IsDir() && pattern.MatchString(e.Name()) {
+			matches = append(matches, filepath.Join(dir, e.Name()))
+		}
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no previous runs for %s/%s in %s", namespace, name, dir)
+	}
+	// Filenames embed a sortable timestamp, so the lexically last is newest.
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+// computeBenchmarkDeltas compares the headline throughput and latency metrics
+// of current against baseline.
+func computeBenchmarkDeltas(current, baseline BenchmarkSummary) []benchmarkDelta {
+	rows := []benchmarkDelta{
+		{Metric: "Generation", Unit: "tok/s", HigherIsBetter: true,
+			Current: current.GenerationToksPerSecMean, Baseline: baseline.GenerationToksPerSecMean},
+		{Metric: "Prompt", Unit: "tok/s", HigherIsBetter: true,
+			Current: current.PromptToksPerSecMean, Baseline: baseline.PromptToksPerSecMean},
+		{Metric: "P50", Unit: "ms", Current: current.LatencyP50, Baseline: baseline.LatencyP50},
+		{Metric: "P95", Unit: "ms", Current: current.LatencyP95, Baseline: baseline.LatencyP95},
+		{Metric: "P99", Unit: "ms", Current: current.LatencyP99, Baseline: baseline.LatencyP99},
+		{Metric: "Mean", Unit: "ms", Current: current.LatencyMean, Baseline: baseline.LatencyMean},
+	}
+	for i := range rows {
+		if rows[i].Baseline != 0 {
+			rows[i].PercentChange = (rows[i].Current - rows[i].Baseline) / rows[i].Baseline * 100
+		}
+	}
+	return rows
+}
+
+// formatDelta renders a percent change with a direction arrow. Regressions
+// are flagged so a slower run stands out even when the number went up.
+func formatDelta(d benchmarkDelta) string {
+	if d.Baseline == 0 {
+		return "n/a"
+	}
+	if d.PercentChange == 0 {
+		return "  0.0%"
+	}
+	arrow := "▲"
+	if d.PercentChange < 0 {
+		arrow = "▼"
+	}
+	s := fmt.Sprintf("%s %.1f%%", arrow, math.Abs(d.PercentChange))
+	if (d.PercentChange > 0) != d.HigherIsBetter {
+		s += " (regression)"
+	}
+	return s
+}
+
+func outputDeltaTable(w io.Writer, current, baseline BenchmarkSummary, baselinePath string) {
+	_, _ = fmt.Fprintf(w, "📈 Benchmark Results vs Baseline\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n")
+	_, _ = fmt.Fprintf(w, "Baseline: %s (%s)\n\n",
+		filepath.Base(baselinePath), baseline.Timestamp.Format("2006-01-02 15:04:05"))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "METRIC\tCURRENT\tBASELINE\tDELTA\n")
+	_, _ = fmt.Fprintf(tw, "──────\t───────\t────────\t─────\n")
+	for _, d := range computeBenchmarkDeltas(current, baseline) {
+		_, _ = fmt.Fprintf(tw, "%s\t%.1f %s\t%.1f %s\t%s\n",
+			d.Metric, d.Current, d.Unit, d.Baseline, d.Unit, formatDelta(d))
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "\n═══════════════════════════════════════════════════════════════\n")
+	_, _ = fmt.Fprintf(w, "Runs: %d/%d successful | Duration: %s\n",
+		current.SuccessfulRuns, current.Iterations, current.Duration.Round(time.Second))
+}
+
+// recordBenchmarkHistory prints the delta table when requested and then stores
+// summary in the history directory. The baseline is resolved before saving so
+// "latest" never compares a run against itself.
+func recordBenchmarkHistory(summary BenchmarkSummary, opts *benchmarkOptions) error {
+	if opts.output == outputFormatDeltaTable {
+		baseline, path, err := loadBaselineSummary(opts.historyDir, opts.baseline, summary.Namespace, summary.ServiceName)
+		if err != nil {
+			fmt.Printf("⚠️  No baseline to compare against: %v\n\n", err)
+			outputTable(summary)
+		} else {
+			outputDeltaTable(os.Stdout, summary, *baseline, path)
+		}
+	}
+
+	if opts.historyDir == "" {
+		return nil
+	}
+	path, err := saveBenchmarkHistory(opts.historyDir, summary)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n🗂️  Saved run to %s\n", path)
+	return nil
+}
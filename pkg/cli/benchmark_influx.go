@@ -0,0 +1,179 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InfluxDB line protocol output (--output influx, --influx-file): one
+// llmkube_benchmark point per summary, or per model of a catalog comparison,
+// so Telegraf's tail input can ship results straight into InfluxDB.
+// https://docs.influxdata.com/influxdb/v2/reference/syntax/line-protocol/
+
+// influxMeasurement is the measurement every benchmark point is written to.
+const influxMeasurement = "llmkube_benchmark"
+
+// influxTagEscaper escapes line protocol tag keys and values.
+var influxTagEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+
+// influxField is one line protocol field. Value is a float64, int64 or
+// string.
+type influxField struct {
+	Key   string
+	Value any
+}
+
+// influxLine formats one line protocol point. Tags with empty values are
+// dropped, since line protocol has no empty tag values; tags keep the order
+// given, which callers keep sorted by key as InfluxDB recommends.
+func influxLine(tags [][2]string, fields []influxField, ts time.Time) string {
+	var b strings.Builder
+	b.WriteString(influxMeasurement)
+	for _, tag := range tags {
+		if tag[1] == "" {
+			continue
+		}
+		b.WriteString("," + influxTagEscaper.Replace(tag[0]) + "=" + influxTagEscaper.Replace(tag[1]))
+	}
+	for i, f := range fields {
+		if i == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(influxTagEscaper.Replace(f.Key) + "=")
+		switch v := f.Value.(type) {
+		case int64:
+			b.WriteString(strconv.FormatInt(v, 10) + "i")
+		case float64:
+			b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+		case string:
+			b.WriteString(strconv.Quote(v))
+		}
+	}
+	b.WriteString(" " + strconv.FormatInt(ts.UnixNano(), 10))
+	return b.String()
+}
+
+// summaryInfluxFields returns the fields shared by benchmark and stress
+// points.
+func summaryInfluxFields(summary BenchmarkSummary) []influxField {
+	fields := []influxField{
+		{"gen_tok_s", summary.GenerationToksPerSecMean},
+		{"prompt_tok_s", summary.PromptToksPerSecMean},
+		{"p50_ms", summary.LatencyP50},
+		{"p95_ms", summary.LatencyP95},
+		{"p99_ms", summary.LatencyP99},
+		{"mean_ms", summary.LatencyMean},
+		{"successful_runs", int64(summary.SuccessfulRuns)},
+		{"failed_runs", int64(summary.FailedRuns)},
+	}
+	if summary.InterTokenMean > 0 {
+		fields = append(fields,
+			influxField{"inter_token_mean_ms", summary.InterTokenMean},
+			influxField{"inter_token_p99_ms", summary.InterTokenP99})
+	}
+	return fields
+}
+
+// benchmarkInfluxLine is the point for a single-service benchmark run.
+func benchmarkInfluxLine(summary BenchmarkSummary) string {
+	tags := [][2]string{{"namespace", summary.Namespace}, {"run", "benchmark"}, {"service", summary.ServiceName}}
+	return influxLine(tags, summaryInfluxFields(summary), summary.Timestamp)
+}
+
+// stressInfluxLine is the point for a stress run, with its request rate and
+// error rate added.
+func stressInfluxLine(summary StressTestSummary) string {
+	tags := [][2]string{{"namespace", summary.Namespace}, {"run", "stress"}, {"service", summary.ServiceName}}
+	fields := append(summaryInfluxFields(summary.BenchmarkSummary),
+		influxField{"concurrency", int64(summary.Concurrency)},
+		influxField{"req_s", summary.RequestsPerSec},
+		influxField{"error_rate_pct", summary.ErrorRate})
+	return influxLine(tags, fields, summary.Timestamp)
+}
+
+// comparisonInfluxLines returns one point per benchmarked catalog model.
+// Skipped and failed models carry only their status, so a dashboard can
+// still show them without plotting zeros.
+func comparisonInfluxLines(report ComparisonReport) []string {
+	run := "benchmark"
+	if report.IsStressTest {
+		run = "stress"
+	}
+	lines := make([]string, 0, len(report.Models))
+	for _, m := range report.Models {
+		tags := [][2]string{{"model", m.ModelID}, {"run", run}, {"status", m.Status}}
+		fields := []influxField{{"success", boolToInt64(m.Status == statusSuccess)}}
+		if m.Status == statusSuccess {
+			fields = append(fields,
+				influxField{"gen_tok_s", m.GenerationToksPerSec},
+				influxField{"prompt_tok_s", m.PromptToksPerSec},
+				influxField{"p50_ms", m.LatencyP50Ms},
+				influxField{"p99_ms", m.LatencyP99Ms})
+			if report.IsStressTest {
+				fields = append(fields,
+					influxField{"req_s", m.RequestsPerSec},
+					influxField{"error_rate_pct", m.ErrorRate})
+			}
+		}
+		lines = append(lines, influxLine(tags, fields, report.Timestamp))
+	}
+	return lines
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// recordInfluxLines prints lines for --output influx and appends them to
+// --influx-file, creating it if needed, for Telegraf to tail.
+func recordInfluxLines(lines []string, opts *benchmarkOptions) error {
+	if opts.output == outputFormatInflux {
+		out := opts.influxOut
+		if out == nil {
+			out = os.Stdout
+		}
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(out, line); err != nil {
+				return fmt.Errorf("failed to write influx output: %w", err)
+			}
+		}
+	}
+	if opts.influxFile == "" {
+		return nil
+	}
+	f, err := os.OpenFile(opts.influxFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open influx file: %w", err)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to write influx file: %w", err)
+		}
+	}
+	return f.Close()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// --latency-breakdown: llama-server reports how long it spent on prefill
+// (timings.prompt_ms) and decode (timings.predicted_ms), but not how long the
+// request waited for a free slot. Whatever part of the client-measured
+// latency the server did not account for is attributed to queue wait; it
+// also absorbs the network round trip, which is small next to a slot wait.
+
+// queueBoundShare is the share of mean latency spent queued above which a
+// run is reported as saturated rather than compute-bound.
+const queueBoundShare = 0.25
+
+// LatencyBreakdown is the mean and P95 of each latency component, in ms,
+// over the successful requests that carried server timings.
+type LatencyBreakdown struct {
+	Samples       int     `json:"samples"`
+	QueueMeanMs   float64 `json:"queue_mean_ms"`
+	QueueP95Ms    float64 `json:"queue_p95_ms"`
+	PrefillMeanMs float64 `json:"prefill_mean_ms"`
+	PrefillP95Ms  float64 `json:"prefill_p95_ms"`
+	DecodeMeanMs  float64 `json:"decode_mean_ms"`
+	DecodeP95Ms   float64 `json:"decode_p95_ms"`
+	// QueueShare is the fraction of mean latency spent queued
+	QueueShare float64 `json:"queue_share"`
+}
+
+// queueTimeMs is the part of a request's latency not spent in prefill or
+// decode. TotalTimeMs is truncated to whole milliseconds, so a request that
+// never queued can come out slightly negative; that is clamped to zero.
+func queueTimeMs(r BenchmarkResult) float64 {
+	return max(0, r.TotalTimeMs-r.PromptTimeMs-r.GenerationTimeMs)
+}
+
+// calculateLatencyBreakdown returns nil when no successful request carried
+// server timings, as with a server that is not llama-server.
+func calculateLatencyBreakdown(results []BenchmarkResult) *LatencyBreakdown {
+	var queue, prefill, decode []float64
+	var total float64
+	for _, r := range results {
+		if r.Error != "" || !r.ServerTimings {
+			continue
+		}
+		queue = append(queue, queueTimeMs(r))
+		prefill = append(prefill, r.PromptTimeMs)
+		decode = append(decode, r.GenerationTimeMs)
+		total += r.TotalTimeMs
+	}
+	if len(queue) == 0 {
+		return nil
+	}
+
+	sort.Float64s(queue)
+	sort.Float64s(prefill)
+	sort.Float64s(decode)
+	breakdown := &LatencyBreakdown{
+		Samples:       len(queue),
+		QueueMeanMs:   mean(queue),
+		QueueP95Ms:    percentile(queue, 95),
+		PrefillMeanMs: mean(prefill),
+		PrefillP95Ms:  percentile(prefill, 95),
+		DecodeMeanMs:  mean(decode),
+		DecodeP95Ms:   percentile(decode, 95),
+	}
+	if total > 0 {
+		breakdown.QueueShare = breakdown.QueueMeanMs / (total / float64(len(queue)))
+	}
+	return breakdown
+}
+
+// latencyBreakdownVerdict names what dominates the latency of a run.
+func latencyBreakdownVerdict(b *LatencyBreakdown) string {
+	switch {
+	case b.QueueShare >= queueBoundShare:
+		return "saturated: requests wait for a free slot; add replicas or parallel slots"
+	case b.PrefillMeanMs > b.DecodeMeanMs:
+		return "compute-bound in prefill: long prompts dominate"
+	default:
+		return "compute-bound in decode: token generation dominates"
+	}
+}
+
+// outputLatencyBreakdown prints the breakdown block of a --latency-breakdown
+// run; it prints nothing when the server reported no timings.
+func outputLatencyBreakdown(summary BenchmarkSummary) {
+	b := summary.LatencyBreakdown
+	if b == nil {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "LATENCY BREAKDOWN (%d requests)\t\n", b.Samples)
+	_, _ = fmt.Fprintf(w, "─────────────────\t\n")
+	_, _ = fmt.Fprintf(w, "Queue wait:\t%.0f ms (mean)\t%.0f ms (P95)\n", b.QueueMeanMs, b.QueueP95Ms)
+	_, _ = fmt.Fprintf(w, "Prefill:\t%.0f ms (mean)\t%.0f ms (P95)\n", b.PrefillMeanMs, b.PrefillP95Ms)
+	_, _ = fmt.Fprintf(w, "Decode:\t%.0f ms (mean)\t%.0f ms (P95)\n", b.DecodeMeanMs, b.DecodeP95Ms)
+	_ = w.Flush()
+	fmt.Printf("%.0f%% of latency queued: %s\n", b.QueueShare*100, latencyBreakdownVerdict(b))
+}
+
+func outputLatencyBreakdownMarkdown(summary BenchmarkSummary) {
+	b := summary.LatencyBreakdown
+	if b == nil {
+		return
+	}
+	fmt.Printf("\n## Latency Breakdown\n\n")
+	fmt.Printf("| Component | Mean (ms) | P95 (ms) |\n")
+	fmt.Printf("|-----------|-----------|----------|\n")
+	fmt.Printf("| Queue wait | %.0f | %.0f |\n", b.QueueMeanMs, b.QueueP95Ms)
+	fmt.Printf("| Prefill | %.0f | %.0f |\n", b.PrefillMeanMs, b.PrefillP95Ms)
+	fmt.Printf("| Decode | %.0f | %.0f |\n", b.DecodeMeanMs, b.DecodeP95Ms)
+	fmt.Printf("\n%.0f%% of latency queued over %d requests: %s\n", b.QueueShare*100, b.Samples, latencyBreakdownVerdict(b))
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// --output ndjson-stream: a live feed for dashboards that tail a run. Every
+// completed request is written to stdout as one JSON line the moment it
+// finishes, interleaved with cumulative interim summaries, and the run ends
+// with a final summary line. The human-readable progress moves to stderr so
+// stdout stays parseable.
+
+const (
+	ndjsonRecordResult  = "result"
+	ndjsonRecordInterim = "interim"
+	ndjsonRecordSummary = "summary"
+
+	// defaultNDJSONInterimInterval is the interim summary cadence when
+	// --soak-report-interval is not set.
+	defaultNDJSONInterimInterval = 10 * time.Second
+)
+
+// NDJSONRecord is one line of --output ndjson-stream. Type says which of
+// Result or Summary is set. Summaries leave out the per-request results,
+// which the stream has already delivered as result records.
+type NDJSONRecord struct {
+	Type      string           `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	Result    *BenchmarkResult `json:"result,omitempty"`
+	Summary   any              `json:"summary,omitempty"`
+}
+
+// ndjsonStream writes NDJSONRecords as results arrive. It is safe for
+// concurrent use by stress workers.
+type ndjsonStream struct {
+	mu          sync.Mutex
+	enc         *json.Encoder
+	interval    time.Duration
+	lastInterim time.Time
+	results     []BenchmarkResult
+
+	// summarize builds the cumulative summary for interim records; nil
+	// disables them.
+	summarize func([]BenchmarkResult) any
+}
+
+func newNDJSONStream(w io.Writer, interval time.Duration) *ndjsonStream {
+	if interval <= 0 {
+		interval = defaultNDJSONInterimInterval
+	}
+	return &ndjsonStream{enc: json.NewEncoder(w), interval: interval, lastInterim: time.Now()}
+}
+
+// ndjsonInterimInterval reuses --soak-report-interval as the interim cadence
+// so a soak run reports on the same schedule in both forms.
+func ndjsonInterimInterval(opts *benchmarkOptions) time.Duration {
+	if opts.soakReportInterval > 0 {
+		return opts.soakReportInterval
+	}
+	return defaultNDJSONInterimInterval
+}
+
+// emitResult writes a result record and, once the interval has elapsed since
+// the last one, a cumulative interim summary.
+func (s *ndjsonStream) emitResult(result BenchmarkResult) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	_ = s.enc.Encode(NDJSONRecord{Type: ndjsonRecordResult, Timestamp: now, Result: &result})
+	s.results = append(s.results, result)
+
+	if s.summarize != nil && now.Sub(s.lastInterim) >= s.interval {
+		_ = s.enc.Encode(NDJSONRecord{Type: ndjsonRecordInterim, Timestamp: now, Summary: s.summarize(s.results)})
+		s.lastInterim = now
+	}
+}
+
+// emitSummary writes the closing summary record.
+func (s *ndjsonStream) emitSummary(summary any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(NDJSONRecord{Type: ndjsonRecordSummary, Timestamp: time.Now(), Summary: summary})
+}
+
+// redirectStdoutToStderr points os.Stdout at stderr so the fmt.Printf
+// progress output does not interleave with the stream, and returns a func
+// restoring it.
+func redirectStdoutToStderr() func() {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return func() { os.Stdout = stdout }
+}
@@ -24,6 +24,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -58,9 +60,76 @@ func initK8sClient() (client.Client, error) {
 	return k8sClient, nil
 }
 
+// getEndpoint resolves the benchmark endpoint and, unless
+// --warmup-model-load-wait is 0, blocks until the server reports the model
+// loaded. The wait applies however the endpoint was obtained, so an --endpoint
+// URL for a just-deployed service is not benchmarked while still loading.
 func getEndpoint(ctx context.Context, opts *benchmarkOptions) (string, func(), error) {
+	endpoint, cleanup, err := resolveEndpoint(ctx, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	// The benchmark only drives chat and embedding requests; sending those to
+	// a reranker would measure errors, not the rerank route.
+	if opts.mode == inferencev1alpha1.ServingModeRerank {
+		if cleanup != nil {
+			cleanup()
+		}
+		return "", nil, fmt.Errorf("endpoint %s serves %s requests, which the benchmark does not support; "+
+			"benchmark a chat or embedding service instead", endpoint, inferencev1alpha1.ServingModeRerank)
+	}
+	if opts.modelLoadWait > 0 {
+		if err := waitForModelLoad(ctx, endpoint, opts.requestHeaders, opts.modelLoadWait); err != nil {
+			if cleanup != nil {
+				cleanup()
+			}
+			return "", nil, err
+		}
+	}
+	return endpoint, cleanup, nil
+}
+
+// endpointRouteModes are the inference routes a status.endpoint or --endpoint
+// URL may end in, with the serving mode each implies.
+var endpointRouteModes = []struct{ route, mode string }{
+	{"/v1/chat/completions", inferencev1alpha1.ServingModeChat},
+	{"/v1/completions", inferencev1alpha1.ServingModeChat},
+	{"/completion", inferencev1alpha1.ServingModeChat},
+	{"/v1/embeddings", inferencev1alpha1.ServingModeEmbedding},
+	{"/embeddings", inferencev1alpha1.ServingModeEmbedding},
+	{"/embedding", inferencev1alpha1.ServingModeEmbedding},
+	{"/v1/rerank", inferencev1alpha1.ServingModeRerank},
+	{"/v1/reranking", inferencev1alpha1.ServingModeRerank},
+	{"/rerank", inferencev1alpha1.ServingModeRerank},
+	{"/reranking", inferencev1alpha1.ServingModeRerank},
+}
+
+// splitEndpointRoute strips a trailing inference route from endpoint, so
+// requests and health checks are built against the server base URL, and
+// returns the serving mode the route implies ("" when it names none).
+func splitEndpointRoute(endpoint string) (base, mode string) {
+	trimmed := strings.TrimSuffix(endpoint, "/")
+	for _, r := range endpointRouteModes {
+		if strings.HasSuffix(trimmed, r.route) {
+			return strings.TrimSuffix(trimmed, r.route), r.mode
+		}
+	}
+	return endpoint, ""
+}
+
+// detectEndpointMode fills opts.mode from the endpoint route when --mode was
+// not given, and returns the endpoint's base URL.
+func detectEndpointMode(opts *benchmarkOptions, endpoint string) string {
+	base, mode := splitEndpointRoute(endpoint)
+	if opts.mode == "" {
+		opts.mode = mode
+	}
+	return base
+}
+
+func resolveEndpoint(ctx context.Context, opts *benchmarkOptions) (string, func(), error) {
 	if opts.endpoint != "" {
-		return opts.endpoint, nil, nil
+		return detectEndpointMode(opts, opts.endpoint), nil, nil
 	}
 
 	k8sClient, err := initK8sClient()
@@ -76,6 +145,9 @@ func getEndpoint(ctx context.Context, opts *benchmarkOptions) (string, func(), e
 	if isvc.Status.Phase != phaseReady {
 		return "", nil, fmt.Errorf("InferenceService '%s' is not ready (phase: %s)", opts.name, isvc.Status.Phase)
 	}
+	if opts.mode == "" {
+		opts.mode = isvc.Status.Mode
+	}
 
 	// Check if this is a Metal deployment by looking up the referenced Model's accelerator
 	if isMetalDeployment(ctx, k8sClient, isvc) {
@@ -87,7 +159,7 @@ func getEndpoint(ctx context.Context, opts *benchmarkOptions) (string, func(), e
 	}
 
 	if isvc.Status.Endpoint != "" {
-		return isvc.Status.Endpoint, nil, nil
+		return detectEndpointMode(opts, isvc.Status.Endpoint), nil, nil
 	}
 
 	return "", nil, fmt.Errorf(
@@ -214,10 +286,6 @@ func setupPortForward(opts *benchmarkOptions) (string, func(), error) {
 		return "", nil, err
 	}
 
-	if err := waitForModelLoad(endpoint, cleanup); err != nil {
-		return "", nil, err
-	}
-
 	return endpoint, cleanup, nil
 }
 
@@ -240,34 +308,51 @@ func waitForHealthCheck(endpoint string, cleanup func()) error {
 	return fmt.Errorf("cannot connect to %s after port forward: %w", endpoint, lastErr)
 }
 
-func waitForModelLoad(endpoint string, cleanup func()) error {
-	httpClient := &http.Client{Timeout: 5 * time.Second}
+// modelLoadPollInterval is how often waitForModelLoad polls /health.
+var modelLoadPollInterval = 2 * time.Second
+
+// waitForModelLoad polls /health until it returns 200. llama-server answers
+// 503 while the model is loading, and connection errors are retried too since
+// a just-deployed server may not be listening yet. An endpoint without a
+// /health route (404/405/501, e.g. a gateway or another OpenAI-compatible
+// server) is answering requests, so it counts as ready rather than being
+// polled until the timeout.
+func waitForModelLoad(ctx context.Context, endpoint string, headers http.Header, timeout time.Duration) error {
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: 5 * time.Second}
 
 	fmt.Printf("   ⏳ Waiting for model to load...\n")
-	modelLoadTimeout := 10 * time.Minute
 	startTime := time.Now()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
 	lastStatus := 0
 	for {
-		if time.Since(startTime) > modelLoadTimeout {
-			cleanup()
-			return fmt.Errorf("timeout waiting for model to load (last status: %d)", lastStatus)
-		}
-
-		resp, err := httpClient.Get(endpoint + "/health")
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/health", nil)
 		if err != nil {
-			time.Sleep(2 * time.Second)
-			continue
+			return fmt.Errorf("invalid endpoint %s: %w", endpoint, err)
 		}
-
-		lastStatus = resp.StatusCode
-		_ = resp.Body.Close()
-
-		if resp.StatusCode == http.StatusOK {
-			fmt.Printf("   ✅ Model loaded (took %s)\n\n", time.Since(startTime).Round(time.Second))
-			return nil
+		setRequestHeaders(req, headers)
+		if resp, err := httpClient.Do(req); err == nil {
+			lastStatus = resp.StatusCode
+			_ = resp.Body.Close()
+			switch resp.StatusCode {
+			case http.StatusOK:
+				fmt.Printf("   ✅ Model loaded (took %s)\n\n", time.Since(startTime).Round(time.Second))
+				return nil
+			case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+				fmt.Printf("   ℹ️  %s has no /health route (status %d); not waiting for model load\n\n",
+					endpoint, resp.StatusCode)
+				return nil
+			}
 		}
 
-		time.Sleep(2 * time.Second)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("timeout after %s waiting for model to load at %s (last status: %d); raise --warmup-model-load-wait",
+				timeout, endpoint, lastStatus)
+		case <-time.After(modelLoadPollInterval):
+		}
 	}
 }
 
@@ -323,3 +408,72 @@ func findAvailablePort() (int, error) {
 	_ = listener.Close()
 	return port, nil
 }
+
+// keepalivePinger hits /health on a fixed interval for the lifetime of a long
+// run so NAT tables and proxies in front of the endpoint don't reap idle
+// connections between sparse requests. Pings are tracked separately and never
+// feed into benchmark results.
+type keepalivePinger struct {
+	endpoint string
+	headers  http.Header
+	client   *http.Client
+	pings    int64
+	failures int64
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newKeepalivePinger(endpoint string, headers http.Header) *keepalivePinger {
+	return &keepalivePinger{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Transport: benchmarkTransport, Timeout: 5 * time.Second},
+		stopChan: make(chan struct{}),
+	}
+}
+
+func (kp *keepalivePinger) start(interval time.Duration) {
+	kp.wg.Add(1)
+	go func() {
+		defer kp.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-kp.stopChan:
+				return
+			case <-ticker.C:
+				kp.ping()
+			}
+		}
+	}()
+}
+
+// stop halts the pinger and returns the number of successful and failed pings.
+func (kp *keepalivePinger) stop() (int64, int64) {
+	close(kp.stopChan)
+	kp.wg.Wait()
+	return atomic.LoadInt64(&kp.pings), atomic.LoadInt64(&kp.failures)
+}
+
+func (kp *keepalivePinger) ping() {
+	req, err := http.NewRequest(http.MethodGet, kp.endpoint+"/health", nil)
+	if err != nil {
+		atomic.AddInt64(&kp.failures, 1)
+		return
+	}
+	setRequestHeaders(req, kp.headers)
+	resp, err := kp.client.Do(req)
+	if err != nil {
+		atomic.AddInt64(&kp.failures, 1)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		atomic.AddInt64(&kp.failures, 1)
+		return
+	}
+	atomic.AddInt64(&kp.pings, 1)
+}
@@ -62,6 +62,9 @@ func outputTable(summary BenchmarkSummary) {
 	_, _ = fmt.Fprintf(w, "Max:\t%.0f ms\t\n", summary.LatencyMax)
 	_, _ = fmt.Fprintf(w, "Mean:\t%.0f ms\t\n", summary.LatencyMean)
 	_ = w.Flush()
+	outputInterTokenLatency(summary)
+	outputLatencyBreakdown(summary)
+	outputGPUUtilization(summary)
 
 	fmt.Printf("\n═══════════════════════════════════════════════════════════════\n")
 	fmt.Printf("Duration: %s\n", summary.Duration.Round(time.Second))
@@ -69,6 +72,50 @@ func outputTable(summary BenchmarkSummary) {
 		summary.PromptTokens, summary.MaxTokens)
 }
 
+// outputInterTokenLatency prints the inter-token latency block of a --stream
+// run; it prints nothing for non-streamed runs.
+func outputInterTokenLatency(summary BenchmarkSummary) {
+	if summary.InterTokenMean == 0 {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "INTER-TOKEN LATENCY\t\n")
+	_, _ = fmt.Fprintf(w, "───────────────────\t\n")
+	_, _ = fmt.Fprintf(w, "Mean:\t%.1f ms\t\n", summary.InterTokenMean)
+	_, _ = fmt.Fprintf(w, "P95:\t%.1f ms\t\n", summary.InterTokenP95)
+	_, _ = fmt.Fprintf(w, "P99:\t%.1f ms\t\n", summary.InterTokenP99)
+	_, _ = fmt.Fprintf(w, "Max:\t%.1f ms\t\n", summary.InterTokenMax)
+	_ = w.Flush()
+}
+
+// outputGPUUtilization prints the GPU block of a --gpu-metrics-url run; it
+// prints nothing when no metrics were scraped.
+func outputGPUUtilization(summary BenchmarkSummary) {
+	if summary.GPUSamples == 0 {
+		return
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "GPU (%d samples)\t\n", summary.GPUSamples)
+	_, _ = fmt.Fprintf(w, "───────────────\t\n")
+	_, _ = fmt.Fprintf(w, "Utilization:\t%.0f%% (mean)\t%.0f%% (peak)\n", summary.GPUUtilMean, summary.GPUUtilPeak)
+	_, _ = fmt.Fprintf(w, "Memory used:\t%.0f MiB (mean)\t%.0f / %.0f MiB (peak)\n",
+		summary.GPUMemoryUsedMeanMB, summary.GPUMemoryUsedPeakMB, summary.GPUMemoryTotalMB)
+	_ = w.Flush()
+}
+
+func outputGPUUtilizationMarkdown(summary BenchmarkSummary) {
+	if summary.GPUSamples == 0 {
+		return
+	}
+	fmt.Printf("\n## GPU\n\n")
+	fmt.Printf("| Metric | Mean | Peak |\n")
+	fmt.Printf("|--------|------|------|\n")
+	fmt.Printf("| Utilization (%%) | %.0f | %.0f |\n", summary.GPUUtilMean, summary.GPUUtilPeak)
+	fmt.Printf("| Memory used (MiB) | %.0f | %.0f |\n", summary.GPUMemoryUsedMeanMB, summary.GPUMemoryUsedPeakMB)
+}
+
 func outputJSON(summary BenchmarkSummary) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -115,6 +162,18 @@ func outputMarkdown(summary BenchmarkSummary) {
 	fmt.Printf("| Max | %.0f |\n", summary.LatencyMax)
 	fmt.Printf("| Mean | %.0f |\n", summary.LatencyMean)
 
+	if summary.InterTokenMean > 0 {
+		fmt.Printf("\n## Inter-Token Latency\n\n")
+		fmt.Printf("| Metric | Value (ms) |\n")
+		fmt.Printf("|--------|------------|\n")
+		fmt.Printf("| Mean | %.1f |\n", summary.InterTokenMean)
+		fmt.Printf("| P95 | %.1f |\n", summary.InterTokenP95)
+		fmt.Printf("| P99 | %.1f |\n", summary.InterTokenP99)
+		fmt.Printf("| Max | %.1f |\n", summary.InterTokenMax)
+	}
+	outputLatencyBreakdownMarkdown(summary)
+	outputGPUUtilizationMarkdown(summary)
+
 	fmt.Printf("\n---\n")
 	fmt.Printf("*Generated by LLMKube v%s*\n", Version)
 }
@@ -128,8 +187,16 @@ func outputStressTable(summary StressTestSummary) {
 	fmt.Printf("Total Requests:  %d\n", summary.TotalRequests)
 	fmt.Printf("Success Rate:    %.1f%% (%d/%d)\n",
 		100-summary.ErrorRate, summary.SuccessfulRuns, summary.TotalRequests)
+	if summary.SchemaViolations > 0 {
+		fmt.Printf("Schema Errors:   %d/%d responses\n", summary.SchemaViolations, summary.SuccessfulRuns)
+	}
 	fmt.Printf("Duration:        %s\n", summary.Duration.Round(time.Second))
-	fmt.Printf("Concurrency:     %d\n", summary.Concurrency)
+	if summary.TargetRPS > 0 {
+		fmt.Printf("Target Rate:     %.2f req/s (open loop)\n", summary.TargetRPS)
+		fmt.Printf("Achieved Rate:   %.2f req/s\n", summary.AchievedRPS)
+	} else {
+		fmt.Printf("Concurrency:     %d\n", summary.Concurrency)
+	}
 	fmt.Printf("Requests/sec:    %.2f\n\n", summary.RequestsPerSec)
 
 	if summary.SuccessfulRuns == 0 {
@@ -162,6 +229,9 @@ func outputStressTable(summary StressTestSummary) {
 	_, _ = fmt.Fprintf(w, "Max:\t%.0f ms\t\n", summary.LatencyMax)
 	_, _ = fmt.Fprintf(w, "Mean:\t%.0f ms\t\n", summary.LatencyMean)
 	_ = w.Flush()
+	outputInterTokenLatency(summary.BenchmarkSummary)
+	outputLatencyBreakdown(summary.BenchmarkSummary)
+	outputGPUUtilization(summary.BenchmarkSummary)
 
 	fmt.Printf("\n═══════════════════════════════════════════════════════════════\n")
 	fmt.Printf("Max tokens per request: %d\n", summary.MaxTokens)
@@ -184,8 +254,16 @@ func outputStressMarkdown(summary StressTestSummary) {
 	fmt.Printf("|--------|-------|\n")
 	fmt.Printf("| Total Requests | %d |\n", summary.TotalRequests)
 	fmt.Printf("| Success Rate | %.1f%% |\n", 100-summary.ErrorRate)
+	if summary.SchemaViolations > 0 {
+		fmt.Printf("| Schema Violations | %d/%d responses |\n", summary.SchemaViolations, summary.SuccessfulRuns)
+	}
 	fmt.Printf("| Duration | %s |\n", summary.Duration.Round(time.Second))
-	fmt.Printf("| Concurrency | %d |\n", summary.Concurrency)
+	if summary.TargetRPS > 0 {
+		fmt.Printf("| Target Rate | %.2f req/s |\n", summary.TargetRPS)
+		fmt.Printf("| Achieved Rate | %.2f req/s |\n", summary.AchievedRPS)
+	} else {
+		fmt.Printf("| Concurrency | %d |\n", summary.Concurrency)
+	}
 	fmt.Printf("| Requests/sec | %.2f |\n\n", summary.RequestsPerSec)
 
 	if summary.SuccessfulRuns == 0 {
@@ -215,6 +293,19 @@ func outputStressMarkdown(summary StressTestSummary) {
 	fmt.Printf("| Max | %.0f |\n", summary.LatencyMax)
 	fmt.Printf("| Mean | %.0f |\n", summary.LatencyMean)
 
+	if summary.InterTokenMean > 0 {
+		fmt.Printf("\n## Inter-Token Latency\n\n")
+		fmt.Printf("| Metric | Value (ms) |\n")
+		fmt.Printf("|--------|------------|\n")
+		fmt.Printf("| Mean | %.1f |\n", summary.InterTokenMean)
+		fmt.Printf("| P95 | %.1f |\n", summary.InterTokenP95)
+		fmt.Printf("| P99 | %.1f |\n", summary.InterTokenP99)
+		fmt.Printf("| Max | %.1f |\n", summary.InterTokenMax)
+	}
+
+	outputLatencyBreakdownMarkdown(summary.BenchmarkSummary)
+	outputGPUUtilizationMarkdown(summary.BenchmarkSummary)
+
 	fmt.Printf("\n---\n")
 	fmt.Printf("*Generated by LLMKube v%s*\n", Version)
 }
@@ -322,6 +413,28 @@ func outputComparisonTable(report ComparisonReport) error {
 		}
 	}
 
+	hasWarnings := false
+	for _, m := range report.Models {
+		if m.PerformanceWarning != "" {
+			if !hasWarnings {
+				fmt.Printf("\n⚠️  Underperforming for their size:\n")
+				hasWarnings = true
+			}
+			fmt.Printf("   %s: %s\n", m.ModelID, m.PerformanceWarning)
+		}
+	}
+
+	hasMismatch := false
+	for _, m := range report.Models {
+		if m.TokenizationWarning != "" {
+			if !hasMismatch {
+				fmt.Printf("\n⚠️  Tokenization differs (tok/s not apples-to-apples):\n")
+				hasMismatch = true
+			}
+			fmt.Printf("   %s: %s\n", m.ModelID, m.TokenizationWarning)
+		}
+	}
+
 	fmt.Printf("\n═══════════════════════════════════════════════════════════════════════════════\n")
 	fmt.Printf("Total Duration: %s\n", report.Duration.Round(time.Second))
 
@@ -382,6 +495,28 @@ func outputComparisonMarkdown(report ComparisonReport) error {
 		}
 	}
 
+	hasWarnings := false
+	for _, m := range report.Models {
+		if m.PerformanceWarning != "" {
+			if !hasWarnings {
+				fmt.Printf("\n## Underperforming Models\n\n")
+				hasWarnings = true
+			}
+			fmt.Printf("- **%s**: %s\n", m.ModelID, m.PerformanceWarning)
+		}
+	}
+
+	hasMismatch := false
+	for _, m := range report.Models {
+		if m.TokenizationWarning != "" {
+			if !hasMismatch {
+				fmt.Printf("\n## Tokenization Mismatch\n\n")
+				hasMismatch = true
+			}
+			fmt.Printf("- **%s**: %s\n", m.ModelID, m.TokenizationWarning)
+		}
+	}
+
 	fmt.Printf("\n---\n")
 	fmt.Printf("*Total Duration: %s*  \n", report.Duration.Round(time.Second))
 	fmt.Printf("*Generated by LLMKube v%s*\n", Version)
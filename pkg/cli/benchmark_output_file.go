@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// --output-file: the final result, in the --output format, goes to a file
+// while progress keeps going to stdout, so CI can capture clean JSON or CSV
+// without shell redirection.
+
+// outputFileExtensions maps --output formats to the extension of the
+// timestamped file created when --output-file names a directory.
+var outputFileExtensions = map[string]string{
+	outputFormatTable:    "txt",
+	outputFormatJSON:     "json",
+	outputFormatMarkdown: "md",
+	outputFormatCSV:      "csv",
+}
+
+// resolveOutputFilePath returns the file --output-file should write,
+// creating parent directories as needed. A path that is an existing
+// directory or ends in a path separator gets a benchmark-YYYYMMDD-HHMMSS
+// file inside it, named like --report-dir reports.
+func resolveOutputFilePath(path, format string, now time.Time) (string, error) {
+	isDir := strings.HasSuffix(path, "/") || strings.HasSuffix(path, string(filepath.Separator))
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		isDir = true
+	}
+
+	dir := filepath.Dir(path)
+	if isDir {
+		dir = path
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if !isDir {
+		return path, nil
+	}
+
+	ext, ok := outputFileExtensions[format]
+	if !ok {
+		ext = "txt"
+	}
+	return filepath.Join(dir, fmt.Sprintf("benchmark-%s.%s", now.Format("20060102-150405"), ext)), nil
+}
+
+// writeOutput runs write, which prints the final result to stdout, with
+// stdout pointed at the --output-file when one is set. Without
+// --output-file it just runs write.
+func writeOutput(opts *benchmarkOptions, write func() error) error {
+	if opts.outputFile == "" {
+		return write()
+	}
+
+	path, err := resolveOutputFilePath(opts.outputFile, opts.output, time.Now())
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = file
+	writeErr := write()
+	os.Stdout = stdout
+
+	if err := file.Close(); err != nil && writeErr == nil {
+		writeErr = fmt.Errorf("failed to write output file: %w", err)
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	fmt.Printf("📄 Results: %s\n", path)
+	return nil
+}
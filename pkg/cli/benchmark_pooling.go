@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+// embeddingPoolingTypes are the pooling values --pool accepts, matching the
+// llama.cpp pooling types that return an embedding per input.
+var embeddingPoolingTypes = []string{"mean", "cls", "last", "none"}
+
+// PoolingResult is the embedding measurement for one pooling type.
+type PoolingResult struct {
+	Pooling          string  `json:"pooling"`
+	PromptTokens     float64 `json:"prompt_tokens_mean"`
+	PromptToksPerSec float64 `json:"prompt_toks_per_sec_mean"`
+	LatencyMeanMs    float64 `json:"latency_mean_ms"`
+	LatencyP50Ms     float64 `json:"latency_p50_ms"`
+	LatencyP99Ms     float64 `json:"latency_p99_ms"`
+	SuccessfulRuns   int     `json:"successful_runs"`
+	FailedRuns       int     `json:"failed_runs"`
+}
+
+// parsePoolingTypes splits the --pool list, rejecting unknown and repeated
+// pooling types.
+func parsePoolingTypes(s string) ([]string, error) {
+	var pools []string
+	for _, p := range strings.Split(s, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		if !slices.Contains(embeddingPoolingTypes, p) {
+			return nil, fmt.Errorf("unknown pooling type %q (valid: %s)", p, strings.Join(embeddingPoolingTypes, ", "))
+		}
+		if slices.Contains(pools, p) {
+			return nil, fmt.Errorf("pooling type %q listed twice", p)
+		}
+		pools = append(pools, p)
+	}
+	if len(pools) == 0 {
+		return nil, fmt.Errorf("--pool needs at least one pooling type")
+	}
+	return pools, nil
+}
+
+// measurePoolingTypes sends opts.iterations embedding requests with each
+// pooling type and aggregates latency and prompt throughput per type.
+func measurePoolingTypes(
+	ctx context.Context, endpoint string, opts *benchmarkOptions, pools []string,
+) []PoolingResult {
+	results := make([]PoolingResult, 0, len(pools))
+
+	for _, pool := range pools {
+		fmt.Printf("📊 Pooling %s (%d iterations)\n", pool, opts.iterations)
+
+		poolOpts := *opts
+		poolOpts.pooling = pool
+		group := PoolingResult{Pooling: pool}
+		var promptTokens, promptToks, latencies []float64
+		for i := 0; i < opts.iterations; i++ {
+			if ctx.Err() != nil {
+				break
+			}
+			result, err := sendEmbeddingRequest(ctx, endpoint, &poolOpts, i+1, opts.prompt)
+			if err != nil {
+				group.FailedRuns++
+				fmt.Printf("   [%d/%d] ❌ Error: %v\n", i+1, opts.iterations, err)
+				continue
+			}
+			group.SuccessfulRuns++
+			promptTokens = append(promptTokens, float64(result.PromptTokens))
+			latencies = append(latencies, result.TotalTimeMs)
+			if result.PromptToksPerSec > 0 {
+				promptToks = append(promptToks, result.PromptToksPerSec)
+			}
+			fmt.Printf("   [%d/%d] ✅ %.1f prompt tok/s (%.0fms)\n",
+				i+1, opts.iterations, result.PromptToksPerSec, result.TotalTimeMs)
+		}
+
+		sort.Float64s(latencies)
+		group.PromptTokens = mean(promptTokens)
+		group.PromptToksPerSec = mean(promptToks)
+		group.LatencyMeanMs = mean(latencies)
+		group.LatencyP50Ms = percentile(latencies, 50)
+		group.LatencyP99Ms = percentile(latencies, 99)
+		results = append(results, group)
+		fmt.Println()
+	}
+
+	return results
+}
+
+func outputPoolingTable(w io.Writer, results []PoolingResult) {
+	_, _ = fmt.Fprintf(w, "\n📊 Embedding Pooling Comparison\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "POOLING\tPROMPT TOK/S\tMEAN (ms)\tP50 (ms)\tP99 (ms)\tSUCCESS\tSTATUS\n")
+	_, _ = fmt.Fprintf(tw, "───────\t────────────\t─────────\t────────\t────────\t───────\t──────\n")
+	for _, r := range results {
+		total := r.SuccessfulRuns + r.FailedRuns
+		if r.SuccessfulRuns == 0 {
+			_, _ = fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t0/%d\t%s\n", r.Pooling, total, statusIconFailed)
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%.1f\t%.0f\t%.0f\t%.0f\t%d/%d\t%s\n",
+			r.Pooling, r.PromptToksPerSec, r.LatencyMeanMs, r.LatencyP50Ms, r.LatencyP99Ms,
+			r.SuccessfulRuns, total, statusIconSuccess)
+	}
+	_ = tw.Flush()
+}
+
+func runPoolingComparison(opts *benchmarkOptions) error {
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
+	startTime := time.Now()
+
+	pools, err := parsePoolingTypes(opts.pool)
+	if err != nil {
+		return err
+	}
+
+	endpoint, cleanup, err := benchmarkEndpoint(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	// The mode may only be known once the endpoint route or the service's
+	// status.mode has been read.
+	if opts.mode != inferencev1alpha1.ServingModeEmbedding {
+		return fmt.Errorf("--pool compares embedding pooling types; it requires --mode %s",
+			inferencev1alpha1.ServingModeEmbedding)
+	}
+
+	fmt.Printf("\n🔄 Embedding Pooling Comparison\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Service:     %s\n", opts.name)
+	fmt.Printf("Endpoint:    %s\n", endpoint)
+	fmt.Printf("Pooling:     %s\n", strings.Join(pools, ", "))
+	fmt.Printf("Iterations:  %d per pooling type\n", opts.iterations)
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n\n")
+
+	if opts.warmup > 0 {
+		runWarmupRequests(ctx, endpoint, opts)
+	}
+
+	results := measurePoolingTypes(ctx, endpoint, opts, pools)
+
+	if opts.output == outputFormatJSON {
+		return writeOutput(opts, func() error {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(results)
+		})
+	}
+	if err := writeOutput(opts, func() error {
+		outputPoolingTable(os.Stdout, results)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Total Duration: %s\n", time.Since(startTime).Round(time.Second))
+	return nil
+}
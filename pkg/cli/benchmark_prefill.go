@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// syntheticPromptWords are common English words that llama-family tokenizers
+// encode as a single token each, so a prompt of N words is roughly N tokens.
+var syntheticPromptWords = []string{
+	"the", "system", "will", "process", "data", "from", "each", "node", "and",
+	"report", "back", "when", "all", "work", "is", "done", "for", "this", "day",
+	"then", "start", "again", "with", "new", "input", "over", "time", "as", "we",
+	"learn", "more", "about", "how", "it", "runs",
+}
+
+// InputLengthResult is the prefill measurement for one input length.
+type InputLengthResult struct {
+	InputLength      int     `json:"input_length"`
+	PromptTokens     float64 `json:"prompt_tokens_mean"`
+	PromptToksPerSec float64 `json:"prompt_toks_per_sec_mean"`
+	TTFTMs           float64 `json:"ttft_ms_mean"`
+	SuccessfulRuns   int     `json:"successful_runs"`
+	FailedRuns       int     `json:"failed_runs"`
+}
+
+// syntheticPrompt builds a prompt of about tokens tokens. seed rotates the
+// word list and is written as the first word, so no two requests share a
+// prefix (the rotation alone repeats every len(syntheticPromptWords) seeds),
+// which would let the server's prompt cache skip prefill and hide its cost.
+func syntheticPrompt(tokens, seed int) string {
+	if tokens <= 0 {
+		return ""
+	}
+	words := make([]string, tokens)
+	words[0] = fmt.Sprintf("r%d", seed)
+	for i := 1; i < len(words); i++ {
+		words[i] = syntheticPromptWords[(i+seed)%len(syntheticPromptWords)]
+	}
+	return strings.Join(words, " ")
+}
+
+// measureInputLengths sends opts.iterations synthetic prompts at each input
+// length and aggregates prompt throughput and time to first token. TTFT is
+// the server-reported prompt processing time (llama.cpp timings); a response
+// without it counts as a failed run, since the full request latency would
+// mix decode time into the prefill measurement.
+func measureInputLengths(
+	ctx context.Context, endpoint string, opts *benchmarkOptions, lengths []int,
+) []InputLengthResult {
+	results := make([]InputLengthResult, 0, len(lengths))
+	seed := 0
+
+	for _, length := range lengths {
+		fmt.Printf("📊 Input length %d tokens (%d iterations)\n", length, opts.iterations)
+
+		group := InputLengthResult{InputLength: length}
+		var promptTokens, promptToks, ttft []float64
+		for i := 0; i < opts.iterations; i++ {
+			seed++
+			result, err := sendBenchmarkRequestWithPrompt(ctx, endpoint, opts, i+1, syntheticPrompt(length, seed))
+			if err == nil && result.PromptTimeMs == 0 {
+				err = fmt.Errorf("response has no prompt timing (timings.prompt_ms), so time to first token is unknown")
+			}
+			if err != nil {
+				group.FailedRuns++
+				fmt.Printf("   [%d/%d] ❌ Error: %v\n", i+1, opts.iterations, err)
+				continue
+			}
+			group.SuccessfulRuns++
+
+			firstToken := result.PromptTimeMs
+			promptTokens = append(promptTokens, float64(result.PromptTokens))
+			ttft = append(ttft, firstToken)
+			if result.PromptToksPerSec > 0 {
+				promptToks = append(promptToks, result.PromptToksPerSec)
+			}
+			fmt.Printf("   [%d/%d] ✅ %.1f prompt tok/s (TTFT %.0fms)\n",
+				i+1, opts.iterations, result.PromptToksPerSec, firstToken)
+		}
+
+		group.PromptTokens = mean(promptTokens)
+		group.PromptToksPerSec = mean(promptToks)
+		group.TTFTMs = mean(ttft)
+		results = append(results, group)
+		fmt.Println()
+	}
+
+	return results
+}
+
+func outputInputLengthTable(w io.Writer, results []InputLengthResult) {
+	_, _ = fmt.Fprintf(w, "\n📊 Prefill Scaling Results\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "INPUT LENGTH\tPROMPT TOKENS\tPROMPT TOK/S\tTTFT (ms)\tSTATUS\n")
+	_, _ = fmt.Fprintf(tw, "────────────\t─────────────\t────────────\t─────────\t──────\n")
+	for _, r := range results {
+		if r.SuccessfulRuns == 0 {
+			_, _ = fmt.Fprintf(tw, "%d\t-\t-\t-\t%s\n", r.InputLength, statusIconFailed)
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%.0f\t%.1f\t%.0f\t%s\n",
+			r.InputLength, r.PromptTokens, r.PromptToksPerSec, r.TTFTMs, statusIconSuccess)
+	}
+	_ = tw.Flush()
+}
+
+func runInputLengthSweep(opts *benchmarkOptions) error {
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
+	startTime := time.Now()
+
+	values, err := parseSweepValues(opts.inputLengthSweep)
+	if err != nil {
+		return fmt.Errorf("invalid input-length-sweep values: %w", err)
+	}
+
+	endpoint, cleanup, err := getEndpoint(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	reportWriter, err := newReportWriter(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🔄 Input Length Sweep\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Service:     %s\n", opts.name)
+	fmt.Printf("Values:      %v\n", values)
+	fmt.Printf("Iterations:  %d per length\n", opts.iterations)
+	fmt.Printf("Max Tokens:  %d\n", opts.maxTokens)
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n\n")
+
+	if opts.warmup > 0 {
+		runWarmupRequests(ctx, endpoint, opts)
+	}
+
+	results := measureInputLengths(ctx, endpoint, opts, values)
+	outputInputLengthTable(os.Stdout, results)
+
+	fmt.Printf("\n═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Total Duration: %s\n", time.Since(startTime).Round(time.Second))
+
+	if reportWriter != nil {
+		if err := reportWriter.writeInputLengthResults(results); err != nil {
+			return fmt.Errorf("failed to write sweep results: %w", err)
+		}
+		if err := reportWriter.close(); err != nil {
+			return fmt.Errorf("failed to close report: %w", err)
+		}
+	}
+
+	return nil
+}
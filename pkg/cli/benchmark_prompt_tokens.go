@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// promptCalibrationRounds bounds the /tokenize round trips spent converging
+// on the word count for --prompt-tokens.
+const promptCalibrationRounds = 4
+
+// promptTokenizeTimeout bounds a single /tokenize call.
+const promptTokenizeTimeout = 30 * time.Second
+
+// TokenizeRequest is a llama.cpp /tokenize request.
+type TokenizeRequest struct {
+	Content string `json:"content"`
+}
+
+// TokenizeResponse is a llama.cpp /tokenize response.
+type TokenizeResponse struct {
+	Tokens []int `json:"tokens"`
+}
+
+// countPromptTokens asks the server's /tokenize endpoint how many tokens
+// prompt encodes to.
+func countPromptTokens(ctx context.Context, endpoint string, headers http.Header, prompt string) (int, error) {
+	jsonBody, err := json.Marshal(TokenizeRequest{Content: prompt})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint+"/tokenize", bytes.NewReader(jsonBody))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setRequestHeaders(req, headers)
+
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: promptTokenizeTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokResp TokenizeResponse
+	if err := json.Unmarshal(body, &tokResp); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(tokResp.Tokens) == 0 {
+		return 0, fmt.Errorf("tokenizer returned no tokens")
+	}
+	return len(tokResp.Tokens), nil
+}
+
+// calibratePromptWords finds the number of synthetic prompt words that the
+// server's tokenizer encodes to about target tokens, rescaling the word count
+// by the measured ratio until it converges. It returns the word count and the
+// token count of the last measured prompt.
+func calibratePromptWords(
+	ctx context.Context, endpoint string, headers http.Header, target int,
+) (words, tokens int, err error) {
+	words = target
+	for range promptCalibrationRounds {
+		tokens, err = countPromptTokens(ctx, endpoint, headers, syntheticPrompt(words, 0))
+		if err != nil {
+			return 0, 0, err
+		}
+		if tokens == target {
+			break
+		}
+		next := max(words*target/tokens, 1)
+		if next == words {
+			break
+		}
+		words = next
+	}
+	return words, tokens, nil
+}
+
+// resolvePromptTokens sets opts.promptWords for --prompt-tokens. Servers
+// without a /tokenize endpoint fall back to one word per token, which is
+// approximate, with a warning.
+func resolvePromptTokens(ctx context.Context, endpoint string, opts *benchmarkOptions) {
+	if opts.promptTokens <= 0 {
+		return
+	}
+	words, tokens, err := calibratePromptWords(ctx, endpoint, opts.requestHeaders, opts.promptTokens)
+	if err != nil {
+		fmt.Printf("⚠️  Tokenize endpoint unavailable (%v); using %d filler words, so the prompt length is approximate\n\n",
+			err, opts.promptTokens)
+		opts.promptWords = opts.promptTokens
+		return
+	}
+	opts.promptWords = words
+	fmt.Printf("📏 Prompt:    %d words = %d tokens (target %d)\n\n", words, tokens, opts.promptTokens)
+}
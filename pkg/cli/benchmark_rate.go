@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// --rate: an open-loop stress test. Closed-loop workers send their next
+// request only after the previous one returns, so a slow server throttles its
+// own load and queueing never shows. With --rate, requests arrive on a
+// Poisson schedule at the target rate regardless of how many are still in
+// flight, the way independent users would, and latency includes the time a
+// request waits behind others on the server.
+
+// poissonInterval draws the wait until the next arrival of a Poisson process
+// averaging rate arrivals per second.
+func poissonInterval(rng *rand.Rand, rate float64) time.Duration {
+	return time.Duration(rng.ExpFloat64() / rate * float64(time.Second))
+}
+
+// runRateScheduler calls dispatch once per arrival of a Poisson process at
+// rate requests per second until stop returns true, stopChan closes, or ctx
+// is canceled. dispatch must not block; it is handed the 1-based arrival
+// number. Arrivals are scheduled against absolute times, so a late wakeup is
+// caught up rather than stretching the schedule. Returns the number of
+// requests dispatched.
+func runRateScheduler(
+	ctx context.Context, rate float64, rng *rand.Rand, stopChan <-chan struct{}, stop func() bool, dispatch func(i int),
+) int {
+	next := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	dispatched := 0
+	for {
+		next = next.Add(poissonInterval(rng, rate))
+		timer.Reset(time.Until(next))
+		select {
+		case <-ctx.Done():
+			return dispatched
+		case <-stopChan:
+			return dispatched
+		case <-timer.C:
+		}
+		if stop() {
+			return dispatched
+		}
+		dispatched++
+		dispatch(dispatched)
+	}
+}
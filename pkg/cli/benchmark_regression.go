@@ -0,0 +1,221 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+)
+
+// Regression gate: an explicit --baseline compares the run's generation
+// throughput and P99 latency against a saved BenchmarkSummary or
+// ComparisonReport and fails the command when either moves the wrong way by
+// more than --regression-threshold percent.
+
+const defaultRegressionThreshold = 10.0
+
+// regressionCheck is one gated metric of one service or catalog model.
+type regressionCheck struct {
+	Model     string
+	Delta     benchmarkDelta
+	Regressed bool
+}
+
+// loadRegressionBaseline reads a saved run. Exactly one of the returned
+// summary and report is set, depending on whether the file holds a
+// single-service summary or a catalog comparison report.
+func loadRegressionBaseline(path string) (*BenchmarkSummary, *ComparisonReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var probe struct {
+		Models json.RawMessage `json:"models"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if probe.Models != nil {
+		var report ComparisonReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+		}
+		return nil, &report, nil
+	}
+
+	var summary BenchmarkSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	return &summary, nil, nil
+}
+
+// gateDelta flags d when it moved the wrong way by more than threshold
+// percent. Metrics the baseline did not record are never flagged.
+func gateDelta(model string, d benchmarkDelta, threshold float64) regressionCheck {
+	check := regressionCheck{Model: model, Delta: d}
+	if d.Baseline == 0 {
+		return check
+	}
+	if d.HigherIsBetter {
+		check.Regressed = d.PercentChange < -threshold
+	} else {
+		check.Regressed = d.PercentChange > threshold
+	}
+	return check
+}
+
+func percentChange(current, baseline float64) float64 {
+	if baseline == 0 {
+		return 0
+	}
+	return (current - baseline) / baseline * 100
+}
+
+// summaryRegressionChecks gates generation tok/s and P99 latency of a
+// single-service run.
+func summaryRegressionChecks(current, baseline BenchmarkSummary, threshold float64) []regressionCheck {
+	var checks []regressionCheck
+	for _, d := range computeBenchmarkDeltas(current, baseline) {
+		if d.Metric == "Generation" || d.Metric == "P99" {
+			checks = append(checks, gateDelta(current.ServiceName, d, threshold))
+		}
+	}
+	return checks
+}
+
+// comparisonRegressionChecks gates generation tok/s and P99 latency of every
+// catalog model that succeeded in both runs. A model that succeeded in the
+// baseline but not now is always a regression; models new to this run are
+// not gated.
+func comparisonRegressionChecks(current, baseline ComparisonReport, threshold float64) []regressionCheck {
+	previous := make(map[string]ModelBenchmark, len(baseline.Models))
+	for _, m := range baseline.Models {
+		previous[m.ModelID] = m
+	}
+
+	var checks []regressionCheck
+	for _, m := range current.Models {
+		b, ok := previous[m.ModelID]
+		if !ok || b.Status != statusSuccess {
+			continue
+		}
+		if m.Status != statusSuccess {
+			checks = append(checks, regressionCheck{
+				Model:     m.ModelID,
+				Delta:     benchmarkDelta{Metric: "Status (" + m.Status + ")", Baseline: 1},
+				Regressed: true,
+			})
+			continue
+		}
+		gen := benchmarkDelta{Metric: "Generation", Unit: "tok/s", HigherIsBetter: true,
+			Current: m.GenerationToksPerSec, Baseline: b.GenerationToksPerSec,
+			PercentChange: percentChange(m.GenerationToksPerSec, b.GenerationToksPerSec)}
+		p99 := benchmarkDelta{Metric: "P99", Unit: "ms",
+			Current: m.LatencyP99Ms, Baseline: b.LatencyP99Ms,
+			PercentChange: percentChange(m.LatencyP99Ms, b.LatencyP99Ms)}
+		checks = append(checks, gateDelta(m.ModelID, gen, threshold), gateDelta(m.ModelID, p99, threshold))
+	}
+	return checks
+}
+
+func outputRegressionTable(w io.Writer, checks []regressionCheck, baselinePath string, threshold float64) {
+	_, _ = fmt.Fprintf(w, "\n🚦 Regression Check vs %s (threshold %.1f%%)\n", filepath.Base(baselinePath), threshold)
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "MODEL\tMETRIC\tCURRENT\tBASELINE\tDELTA\tRESULT\n")
+	_, _ = fmt.Fprintf(tw, "─────\t──────\t───────\t────────\t─────\t──────\n")
+	for _, c := range checks {
+		result := "✅ pass"
+		if c.Regressed {
+			result = "❌ regressed"
+		}
+		d := c.Delta
+		if d.Unit == "" {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t%s\n", c.Model, d.Metric, result)
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%.1f %s\t%.1f %s\t%s\t%s\n",
+			c.Model, d.Metric, d.Current, d.Unit, d.Baseline, d.Unit, formatDelta(d), result)
+	}
+	_ = tw.Flush()
+}
+
+// regressionError prints the checks and returns an error naming the
+// regressed metrics, or nil when none regressed.
+func regressionError(checks []regressionCheck, baselinePath string, threshold float64) error {
+	outputRegressionTable(os.Stdout, checks, baselinePath, threshold)
+
+	regressed := 0
+	for _, c := range checks {
+		if c.Regressed {
+			regressed++
+		}
+	}
+	if regressed > 0 {
+		return fmt.Errorf("%d metric(s) regressed by more than %.1f%% against baseline %s",
+			regressed, threshold, baselinePath)
+	}
+	fmt.Printf("\n✅ No regression against baseline\n")
+	return nil
+}
+
+// checkBenchmarkRegression gates a single-service benchmark or stress run
+// against an explicit --baseline.
+func checkBenchmarkRegression(summary BenchmarkSummary, opts *benchmarkOptions) error {
+	if !opts.baselineSet {
+		return nil
+	}
+	path, err := resolveBaselinePath(opts.historyDir, opts.baseline, summary.Namespace, summary.ServiceName)
+	if err != nil {
+		return err
+	}
+	baseline, report, err := loadRegressionBaseline(path)
+	if err != nil {
+		return err
+	}
+	if report != nil {
+		return fmt.Errorf("baseline %s is a catalog comparison report; compare it against a --catalog run", path)
+	}
+	return regressionError(summaryRegressionChecks(summary, *baseline, opts.regressionThreshold), path, opts.regressionThreshold)
+}
+
+// checkComparisonRegression gates a catalog comparison against an explicit
+// --baseline.
+func checkComparisonRegression(report ComparisonReport, opts *benchmarkOptions) error {
+	if !opts.baselineSet {
+		return nil
+	}
+	path, err := resolveBaselinePath(opts.historyDir, opts.baseline, opts.namespace, "")
+	if err != nil {
+		return err
+	}
+	summary, baseline, err := loadRegressionBaseline(path)
+	if err != nil {
+		return err
+	}
+	if summary != nil {
+		return fmt.Errorf("baseline %s is a single-service summary; compare it against a single-service run", path)
+	}
+	return regressionError(comparisonRegressionChecks(report, *baseline, opts.regressionThreshold), path, opts.regressionThreshold)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cli
 
 import (
+	_ "embed"
 	"fmt"
 	"os"
 	"os/exec"
@@ -25,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 )
 
@@ -42,6 +44,59 @@ func getReportPath(opts *benchmarkOptions) (string, error) {
 	return "", nil
 }
 
+//go:embed benchmark_report.md.tmpl
+var defaultReportTemplate string
+
+// ReportSection is one "## Title" block of the built-in report layout.
+type ReportSection struct {
+	Title   string
+	Content string
+}
+
+// ReportTemplateData is what a --report-template is executed with. Sections
+// holds every block the run wrote, already rendered as markdown, in order;
+// the typed fields expose the same results for templates that lay them out
+// themselves. Summary, Stress and Comparison hold the last result of their
+// kind, Sweeps every sweep of the run.
+type ReportTemplateData struct {
+	Generated    time.Time
+	Host         string
+	OS           string
+	Arch         string
+	GPU          bool
+	Accelerator  string
+	GPUCount     int32
+	Sections     []ReportSection
+	Summary      *BenchmarkSummary
+	Stress       *StressTestSummary
+	Comparison   *ComparisonReport
+	Sweeps       []*SweepReport
+	InputLengths []InputLengthResult
+	GPUMetrics   []GPUMetric
+	Duration     time.Duration
+	Version      string
+}
+
+// loadReportTemplate parses the --report-template file, or the built-in
+// markdown layout when none is given.
+func loadReportTemplate(path string) (*template.Template, error) {
+	text := defaultReportTemplate
+	name := "report"
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report template: %w", err)
+		}
+		text = string(content)
+		name = filepath.Base(path)
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse report template: %w", err)
+	}
+	return tmpl, nil
+}
+
 func newReportWriter(opts *benchmarkOptions) (*ReportWriter, error) {
 	path, err := getReportPath(opts)
 	if err != nil {
@@ -51,6 +106,13 @@ func newReportWriter(opts *benchmarkOptions) (*ReportWriter, error) {
 		return nil, nil
 	}
 
+	// Parse before creating the file, so a broken template fails the run
+	// up front instead of after the benchmark.
+	tmpl, err := loadReportTemplate(opts.reportTemplate)
+	if err != nil {
+		return nil, err
+	}
+
 	file, err := os.Create(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create report file: %w", err)
@@ -60,56 +122,35 @@ func newReportWriter(opts *benchmarkOptions) (*ReportWriter, error) {
 		file:      file,
 		startTime: time.Now(),
 		opts:      opts,
+		template:  tmpl,
 	}
-
-	if err := rw.writeHeader(); err != nil {
-		_ = file.Close()
-		return nil, err
-	}
-
-	fmt.Printf("📄 Report: %s\n", path)
-	return rw, nil
-}
-
-func (rw *ReportWriter) writeHeader() error {
-	_, err := fmt.Fprintf(rw.file, "# LLMKube Benchmark Report\n\n")
-	if err != nil {
-		return err
-	}
-
-	_, err = fmt.Fprintf(rw.file, "**Generated:** %s  \n", rw.startTime.Format("2006-01-02 15:04:05"))
-	if err != nil {
-		return err
-	}
-
-	_, err = fmt.Fprintf(rw.file, "**Host:** %s (%s/%s)  \n", getHostname(), runtime.GOOS, runtime.GOARCH)
-	if err != nil {
-		return err
+	rw.data = ReportTemplateData{
+		Generated: rw.startTime,
+		Host:      getHostname(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GPU:       opts.gpu,
+		GPUCount:  opts.gpuCount,
+		Version:   Version,
 	}
-
-	if rw.opts.gpu {
-		accelerator := rw.opts.accelerator
-		if accelerator == "" {
-			accelerator = acceleratorCUDA
+	if opts.gpu {
+		rw.data.Accelerator = opts.accelerator
+		if rw.data.Accelerator == "" {
+			rw.data.Accelerator = acceleratorCUDA
 		}
-		_, err = fmt.Fprintf(rw.file, "**Accelerator:** %s (GPU Count: %d)  \n", accelerator, rw.opts.gpuCount)
-	} else {
-		_, err = fmt.Fprintf(rw.file, "**Accelerator:** CPU  \n")
-	}
-	if err != nil {
-		return err
 	}
 
-	_, err = fmt.Fprintf(rw.file, "\n---\n\n")
-	return err
+	fmt.Printf("📄 Report: %s\n", path)
+	return rw, nil
 }
 
 func (rw *ReportWriter) writeSection(title string, content string) error {
-	_, err := fmt.Fprintf(rw.file, "## %s\n\n%s\n\n", title, content)
-	return err
+	rw.data.Sections = append(rw.data.Sections, ReportSection{Title: title, Content: content})
+	return nil
 }
 
 func (rw *ReportWriter) writeBenchmarkResult(summary *BenchmarkSummary) error {
+	rw.data.Summary = summary
 	var buf strings.Builder
 
 	buf.WriteString(fmt.Sprintf("**Service:** %s  \n", summary.ServiceName))
@@ -129,6 +170,7 @@ func (rw *ReportWriter) writeBenchmarkResult(summary *BenchmarkSummary) error {
 }
 
 func (rw *ReportWriter) writeStressResult(summary *StressTestSummary) error {
+	rw.data.Stress = summary
 	var buf strings.Builder
 
 	buf.WriteString(fmt.Sprintf("**Service:** %s  \n", summary.ServiceName))
@@ -143,6 +185,9 @@ func (rw *ReportWriter) writeStressResult(summary *StressTestSummary) error {
 	buf.WriteString(fmt.Sprintf("| Total Requests | %d |\n", summary.TotalRequests))
 	buf.WriteString(fmt.Sprintf("| Requests/sec | %.2f |\n", summary.RequestsPerSec))
 	buf.WriteString(fmt.Sprintf("| Error Rate | %.1f%% |\n", summary.ErrorRate))
+	if summary.SchemaViolations > 0 {
+		buf.WriteString(fmt.Sprintf("| Schema Violations | %d/%d responses |\n", summary.SchemaViolations, summary.SuccessfulRuns))
+	}
 	buf.WriteString(fmt.Sprintf("| Generation (tok/s) | %.1f |\n", summary.GenerationToksPerSecMean))
 	buf.WriteString(fmt.Sprintf("| Peak (tok/s) | %.1f |\n", summary.PeakToksPerSec))
 	buf.WriteString(fmt.Sprintf("| Latency P50 | %.0f ms |\n", summary.LatencyP50))
@@ -152,6 +197,7 @@ func (rw *ReportWriter) writeStressResult(summary *StressTestSummary) error {
 }
 
 func (rw *ReportWriter) writeSweepResults(sweepReport *SweepReport) error {
+	rw.data.Sweeps = append(rw.data.Sweeps, sweepReport)
 	var buf strings.Builder
 
 	buf.WriteString(fmt.Sprintf("**Sweep Type:** %s  \n", sweepReport.SweepType))
@@ -193,10 +239,30 @@ func (rw *ReportWriter) writeSweepResults(sweepReport *SweepReport) error {
 	return rw.writeSection(sweepReport.SweepType+" Sweep Results", buf.String())
 }
 
+func (rw *ReportWriter) writeInputLengthResults(results []InputLengthResult) error {
+	rw.data.InputLengths = results
+	var buf strings.Builder
+
+	buf.WriteString("| Input Length | Prompt Tokens | Prompt tok/s | TTFT (ms) | Status |\n")
+	buf.WriteString("|--------------|---------------|--------------|-----------|--------|\n")
+
+	for _, r := range results {
+		if r.SuccessfulRuns == 0 {
+			buf.WriteString(fmt.Sprintf("| %d | - | - | - | %s |\n", r.InputLength, statusIconFailed))
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("| %d | %.0f | %.1f | %.0f | %s |\n",
+			r.InputLength, r.PromptTokens, r.PromptToksPerSec, r.TTFTMs, statusIconSuccess))
+	}
+
+	return rw.writeSection("Input Length Sweep Results", buf.String())
+}
+
 func (rw *ReportWriter) writeGPUMetrics(metrics []GPUMetric) error {
 	if len(metrics) == 0 {
 		return nil
 	}
+	rw.data.GPUMetrics = metrics
 
 	var buf strings.Builder
 	buf.WriteString(fmt.Sprintf("**Samples:** %d  \n\n", len(metrics)))
@@ -235,6 +301,7 @@ func (rw *ReportWriter) writeGPUMetrics(metrics []GPUMetric) error {
 }
 
 func (rw *ReportWriter) writeComparisonReport(report ComparisonReport) error {
+	rw.data.Comparison = &report
 	var buf strings.Builder
 
 	buf.WriteString(fmt.Sprintf("**Models:** %d  \n", len(report.Models)))
@@ -305,12 +372,21 @@ func (rw *ReportWriter) writeComparisonReport(report ComparisonReport) error {
 	return rw.writeSection("Model Comparison", buf.String())
 }
 
+// close renders the collected report into the file. A second call is a
+// no-op, so a deferred close after an explicit one is harmless.
 func (rw *ReportWriter) close() error {
-	duration := time.Since(rw.startTime)
-	_, _ = fmt.Fprintf(rw.file, "\n---\n\n")
-	_, _ = fmt.Fprintf(rw.file, "*Total Duration: %s*  \n", duration.Round(time.Second))
-	_, _ = fmt.Fprintf(rw.file, "*Generated by LLMKube v%s*\n", Version)
-	return rw.file.Close()
+	if rw.file == nil {
+		return nil
+	}
+	file := rw.file
+	rw.file = nil
+
+	rw.data.Duration = time.Since(rw.startTime).Round(time.Second)
+	if err := rw.template.Execute(file, rw.data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to render report template: %w", err)
+	}
+	return file.Close()
 }
 
 func getHostname() string {
@@ -326,12 +402,16 @@ type gpuMonitor struct {
 	mu       sync.Mutex
 	stopChan chan struct{}
 	wg       sync.WaitGroup
+
+	// sample takes one reading; nil readings are skipped
+	sample func() *GPUMetric
 }
 
 func newGPUMonitor() *gpuMonitor {
 	return &gpuMonitor{
 		metrics:  make([]GPUMetric, 0),
 		stopChan: make(chan struct{}),
+		sample:   sampleNvidiaSMI,
 	}
 }
 
@@ -371,7 +451,7 @@ func (gm *gpuMonitor) stop() []GPUMetric {
 	return gm.metrics
 }
 
-func (gm *gpuMonitor) sample() *GPUMetric {
+func sampleNvidiaSMI() *GPUMetric {
 	cmd := exec.Command("nvidia-smi",
 		"--query-gpu=memory.used,memory.total,utilization.gpu,temperature.gpu,power.draw",
 		"--format=csv,noheader,nounits")
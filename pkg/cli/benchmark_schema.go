@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+const openAIChatCompletionObject = "chat.completion"
+
+// validateChatCompletionSchema checks a 200 response body against the
+// OpenAI chat-completion response shape and returns one message per
+// violation. The typed ChatCompletionResponse decode tolerates missing or
+// mistyped fields by zeroing them, so conformance is checked on the raw JSON.
+func validateChatCompletionSchema(body []byte) []string {
+	var resp map[string]any
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return []string{fmt.Sprintf("response is not a JSON object: %v", err)}
+	}
+
+	var violations []string
+	addf := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if s, ok := resp["id"].(string); !ok || s == "" {
+		addf("id: missing or not a non-empty string")
+	}
+	if obj, ok := resp["object"].(string); !ok {
+		addf("object: missing or not a string")
+	} else if obj != openAIChatCompletionObject {
+		addf("object: got %q, want %q", obj, openAIChatCompletionObject)
+	}
+	if _, ok := resp["created"].(float64); !ok {
+		addf("created: missing or not a number")
+	}
+	if _, ok := resp["model"].(string); !ok {
+		addf("model: missing or not a string")
+	}
+
+	choices, ok := resp["choices"].([]any)
+	switch {
+	case !ok:
+		addf("choices: missing or not an array")
+	case len(choices) == 0:
+		addf("choices: empty")
+	}
+	for i, c := range choices {
+		choice, ok := c.(map[string]any)
+		if !ok {
+			addf("choices[%d]: not an object", i)
+			continue
+		}
+		if _, ok := choice["index"].(float64); !ok {
+			addf("choices[%d].index: missing or not a number", i)
+		}
+		if _, ok := choice["finish_reason"]; !ok {
+			addf("choices[%d].finish_reason: missing", i)
+		}
+		msg, ok := choice["message"].(map[string]any)
+		if !ok {
+			addf("choices[%d].message: missing or not an object", i)
+			continue
+		}
+		if role, _ := msg["role"].(string); role != "assistant" {
+			addf("choices[%d].message.role: got %q, want \"assistant\"", i, role)
+		}
+		if content, ok := msg["content"]; !ok {
+			addf("choices[%d].message.content: missing", i)
+		} else if _, isString := content.(string); !isString && content != nil {
+			addf("choices[%d].message.content: not a string or null", i)
+		}
+	}
+
+	usage, ok := resp["usage"].(map[string]any)
+	if !ok {
+		addf("usage: missing or not an object")
+		return violations
+	}
+	counts := map[string]float64{}
+	for _, key := range []string{"prompt_tokens", "completion_tokens", "total_tokens"} {
+		n, ok := usage[key].(float64)
+		if !ok {
+			addf("usage.%s: missing or not a number", key)
+			continue
+		}
+		counts[key] = n
+	}
+	if len(counts) == 3 && counts["prompt_tokens"]+counts["completion_tokens"] != counts["total_tokens"] {
+		addf("usage.total_tokens: %.0f != prompt_tokens + completion_tokens (%.0f)",
+			counts["total_tokens"], counts["prompt_tokens"]+counts["completion_tokens"])
+	}
+	return violations
+}
+
+// outputSchemaConformance prints how many responses violated the OpenAI
+// schema and how often each violation occurred. It is kept separate from the
+// performance tables so a fast but non-conformant server is easy to spot.
+func outputSchemaConformance(w io.Writer, results []BenchmarkResult) {
+	checked, failing := 0, 0
+	counts := map[string]int{}
+	for _, r := range results {
+		if r.Error != "" {
+			continue
+		}
+		checked++
+		if len(r.SchemaViolations) > 0 {
+			failing++
+		}
+		for _, v := range r.SchemaViolations {
+			counts[v]++
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\n🧾 OpenAI Schema Conformance\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n")
+	if failing == 0 {
+		_, _ = fmt.Fprintf(w, "%s %d/%d responses conform\n", statusIconSuccess, checked, checked)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s %d/%d responses violate the schema\n\n", statusIconFailed, failing, checked)
+
+	violations := make([]string, 0, len(counts))
+	for v := range counts {
+		violations = append(violations, v)
+	}
+	sort.Strings(violations)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "COUNT\tVIOLATION\n")
+	_, _ = fmt.Fprintf(tw, "─────\t─────────\n")
+	for _, v := range violations {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\n", counts[v], v)
+	}
+	_ = tw.Flush()
+}
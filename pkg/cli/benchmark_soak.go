@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// SoakInterimReport is one periodic summary written during a long stress
+// run. Window covers only the requests finished since the previous report,
+// so a leak or thermal throttling shows up as a trend across reports instead
+// of being averaged away in the final summary.
+type SoakInterimReport struct {
+	Index      int               `json:"index"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Elapsed    time.Duration     `json:"elapsed"`
+	Window     SoakIntervalStats `json:"window"`
+	Cumulative SoakIntervalStats `json:"cumulative"`
+}
+
+// SoakIntervalStats is the per-request-set slice of a stress summary that is
+// worth tracking over time.
+type SoakIntervalStats struct {
+	Requests                 int64   `json:"requests"`
+	FailedRuns               int     `json:"failed_runs"`
+	ErrorRate                float64 `json:"error_rate"`
+	RequestsPerSec           float64 `json:"requests_per_sec"`
+	GenerationToksPerSecMean float64 `json:"generation_toks_per_sec_mean"`
+	LatencyP50               float64 `json:"latency_p50_ms"`
+	LatencyP95               float64 `json:"latency_p95_ms"`
+	LatencyP99               float64 `json:"latency_p99_ms"`
+}
+
+// soakReporter emits a SoakInterimReport every interval for the lifetime of a
+// stress run: a one-line summary to out and, with --soak-report-file, one
+// JSON object per line appended to the file.
+type soakReporter struct {
+	opts        *benchmarkOptions
+	endpoint    string
+	concurrency int
+	startTime   time.Time
+	snapshot    func() []BenchmarkResult
+	out         io.Writer
+	file        *os.File
+
+	index      int
+	lastCount  int
+	lastReport time.Time
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// newSoakReporter opens --soak-report-file (when set) for appending. snapshot
+// must return a copy of the results gathered so far, in completion order.
+func newSoakReporter(
+	opts *benchmarkOptions, endpoint string, concurrency int, startTime time.Time,
+	snapshot func() []BenchmarkResult, out io.Writer,
+) (*soakReporter, error) {
+	sr := &soakReporter{
+		opts:        opts,
+		endpoint:    endpoint,
+		concurrency: concurrency,
+		startTime:   startTime,
+		snapshot:    snapshot,
+		out:         out,
+		lastReport:  startTime,
+		stopChan:    make(chan struct{}),
+	}
+	if opts.soakReportFile != "" {
+		f, err := os.OpenFile(opts.soakReportFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open soak report file: %w", err)
+		}
+		sr.file = f
+	}
+	return sr, nil
+}
+
+func (sr *soakReporter) start(interval time.Duration) {
+	sr.wg.Add(1)
+	go func() {
+		defer sr.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-sr.stopChan:
+				return
+			case <-ticker.C:
+				sr.report()
+			}
+		}
+	}()
+}
+
+// stop halts the reporter and returns the number of interim reports written.
+func (sr *soakReporter) stop() int {
+	close(sr.stopChan)
+	sr.wg.Wait()
+	if sr.file != nil {
+		_ = sr.file.Close()
+	}
+	return sr.index
+}
+
+func (sr *soakReporter) report() {
+	now := time.Now()
+	results := sr.snapshot()
+	window := results[min(sr.lastCount, len(results)):]
+
+	sr.index++
+	report := SoakInterimReport{
+		Index:      sr.index,
+		Timestamp:  now,
+		Elapsed:    now.Sub(sr.startTime),
+		Window:     sr.stats(window, now.Sub(sr.lastReport)),
+		Cumulative: sr.stats(results, now.Sub(sr.startTime)),
+	}
+	sr.lastCount = len(results)
+	sr.lastReport = now
+
+	// Leading newline: the live progress line is drawn with \r.
+	_, _ = fmt.Fprintf(sr.out,
+		"\n📈 Interim #%d @ %s | last %s: %d req (%.1f/s) | %.1f tok/s | P95 %.0f ms | %.1f%% errors\n",
+		report.Index, report.Elapsed.Round(time.Second), sr.opts.soakReportInterval,
+		report.Window.Requests, report.Window.RequestsPerSec,
+		report.Window.GenerationToksPerSecMean, report.Window.LatencyP95, report.Window.ErrorRate)
+
+	if sr.file != nil {
+		data, err := json.Marshal(report)
+		if err == nil {
+			_, _ = sr.file.Write(append(data, '\n'))
+		}
+	}
+}
+
+func (sr *soakReporter) stats(results []BenchmarkResult, elapsed time.Duration) SoakIntervalStats {
+	summary := calculateStressSummary(sr.opts, sr.endpoint, results, sr.startTime, sr.concurrency)
+	stats := SoakIntervalStats{
+		Requests:                 summary.TotalRequests,
+		FailedRuns:               summary.FailedRuns,
+		ErrorRate:                summary.ErrorRate,
+		GenerationToksPerSecMean: summary.GenerationToksPerSecMean,
+		LatencyP50:               summary.LatencyP50,
+		LatencyP95:               summary.LatencyP95,
+		LatencyP99:               summary.LatencyP99,
+	}
+	if secs := elapsed.Seconds(); secs > 0 {
+		stats.RequestsPerSec = float64(len(results)) / secs
+	}
+	return stats
+}
@@ -39,6 +39,7 @@ func calculateSummary(
 	latencies := make([]float64, 0, len(results))
 	genToks := make([]float64, 0, len(results))
 	promptToks := make([]float64, 0, len(results))
+	var interTokenGaps []float64
 
 	for _, r := range results {
 		if r.Error != "" {
@@ -47,6 +48,9 @@ func calculateSummary(
 		}
 		summary.SuccessfulRuns++
 		summary.PromptTokens = r.PromptTokens // They should all be the same
+		if len(r.SchemaViolations) > 0 {
+			summary.SchemaViolations++
+		}
 
 		latencies = append(latencies, r.TotalTimeMs)
 		if r.GenerationToksPerSec > 0 {
@@ -55,6 +59,7 @@ func calculateSummary(
 		if r.PromptToksPerSec > 0 {
 			promptToks = append(promptToks, r.PromptToksPerSec)
 		}
+		interTokenGaps = append(interTokenGaps, r.InterTokenGapsMs...)
 	}
 
 	if len(latencies) == 0 {
@@ -79,6 +84,16 @@ func calculateSummary(
 	if len(promptToks) > 0 {
 		summary.PromptToksPerSecMean = mean(promptToks)
 	}
+	if len(interTokenGaps) > 0 {
+		sort.Float64s(interTokenGaps)
+		summary.InterTokenMean = mean(interTokenGaps)
+		summary.InterTokenP95 = percentile(interTokenGaps, 95)
+		summary.InterTokenP99 = percentile(interTokenGaps, 99)
+		summary.InterTokenMax = interTokenGaps[len(interTokenGaps)-1]
+	}
+	if opts.latencyBreakdown {
+		summary.LatencyBreakdown = calculateLatencyBreakdown(results)
+	}
 
 	return summary
 }
@@ -93,6 +108,7 @@ func calculateStressSummary(
 		Concurrency:      concurrency,
 		TargetDuration:   opts.duration,
 		TotalRequests:    int64(len(results)),
+		TargetRPS:        opts.rate,
 	}
 
 	elapsed := time.Since(startTime).Seconds()
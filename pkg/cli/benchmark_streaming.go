@@ -0,0 +1,199 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --stream: requests stream their completion and every chunk carrying
+// generated output is timestamped on arrival. The gaps between consecutive
+// chunks give the inter-token latency distribution, which exposes stalls
+// (KV-cache eviction, a batch admitting a new prefill) that a mean tok/s
+// averages away. llama.cpp sends one token per chunk; servers that coalesce
+// tokens report gaps per chunk instead.
+
+// StreamOptions asks an OpenAI-compatible server to append a usage chunk to
+// a streamed completion.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// interTokenGaps returns the gaps between consecutive token arrivals in
+// milliseconds.
+func interTokenGaps(arrivals []time.Time) []float64 {
+	if len(arrivals) < 2 {
+		return nil
+	}
+	gaps := make([]float64, 0, len(arrivals)-1)
+	for i := 1; i < len(arrivals); i++ {
+		gaps = append(gaps, float64(arrivals[i].Sub(arrivals[i-1]).Microseconds())/1000)
+	}
+	return gaps
+}
+
+// carriesContent reports whether the chunk holds generated text. Unlike
+// carriesToken, a bare finish_reason does not count: it arrives right after
+// the last token and would add a near-zero gap.
+func (c firstTokenStreamChunk) carriesContent() bool {
+	for _, choice := range c.Choices {
+		if choice.Delta.Content != "" || choice.Delta.ReasoningContent != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sendStreamingBenchmarkRequest streams one chat completion, recording when
+// each token arrives. Server timings and usage, when the final chunks carry
+// them, take precedence over the client-side counts.
+func sendStreamingBenchmarkRequest(
+	ctx context.Context, endpoint string, opts *benchmarkOptions, iteration int, prompt string,
+) (BenchmarkResult, error) {
+	result := BenchmarkResult{
+		Iteration: iteration,
+	}
+
+	reqBody := ChatCompletionRequest{
+		Messages: []ChatMessage{
+			{Role: "user", Content: prompt},
+		},
+		MaxTokens:     opts.maxTokens,
+		Stream:        true,
+		StreamOptions: &StreamOptions{IncludeUsage: true},
+	}
+	applySamplingParams(&reqBody, opts, iteration)
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint+"/v1/chat/completions", bytes.NewReader(jsonBody))
+	if err != nil {
+		return result, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	setRequestHeaders(req, opts.requestHeaders)
+
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: effectiveGenerationTimeout(opts)}
+	reqStartTime := time.Now()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return result, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var arrivals []time.Time
+	var final ChatCompletionResponse
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if payload == "" {
+			continue
+		}
+		if payload == "[DONE]" {
+			break
+		}
+		arrived := time.Now()
+		var chunk firstTokenStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			return result, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.carriesContent() {
+			arrivals = append(arrivals, arrived)
+		}
+		// Usage and llama.cpp timings ride on the last chunks: the one with
+		// the finish_reason and the choice-less usage chunk.
+		if len(chunk.Choices) == 0 || chunk.Choices[0].FinishReason != "" {
+			var meta ChatCompletionResponse
+			if err := json.Unmarshal([]byte(payload), &meta); err == nil {
+				if meta.Usage.TotalTokens > 0 {
+					final.Usage = meta.Usage
+				}
+				if meta.Timings.PredictedN > 0 {
+					final.Timings = meta.Timings
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read stream: %w", err)
+	}
+	totalTime := time.Since(reqStartTime)
+	if len(arrivals) == 0 {
+		return result, fmt.Errorf("stream ended before the first token")
+	}
+
+	result.PromptTokens = final.Usage.PromptTokens
+	result.PromptN = final.Timings.PromptN
+	result.CompletionTokens = final.Usage.CompletionTokens
+	if result.CompletionTokens == 0 {
+		result.CompletionTokens = len(arrivals)
+	}
+	result.TotalTokens = final.Usage.TotalTokens
+	result.TotalTimeMs = float64(totalTime.Milliseconds())
+	result.TTFTMs = float64(arrivals[0].Sub(reqStartTime).Milliseconds())
+
+	if final.Timings.PromptMs > 0 {
+		result.PromptTimeMs = final.Timings.PromptMs
+		result.GenerationTimeMs = final.Timings.PredictedMs
+		result.PromptToksPerSec = final.Timings.PromptPerSecond
+		result.GenerationToksPerSec = final.Timings.PredictedPerSecond
+		result.ServerTimings = true
+	} else {
+		// Without server timings the first token marks the end of prefill.
+		result.PromptTimeMs = float64(arrivals[0].Sub(reqStartTime).Milliseconds())
+		generation := arrivals[len(arrivals)-1].Sub(arrivals[0])
+		result.GenerationTimeMs = float64(generation.Milliseconds())
+		if len(arrivals) > 1 && generation > 0 {
+			result.GenerationToksPerSec = float64(len(arrivals)-1) / generation.Seconds()
+		}
+	}
+
+	result.InterTokenGapsMs = interTokenGaps(arrivals)
+	if len(result.InterTokenGapsMs) > 0 {
+		sorted := append([]float64(nil), result.InterTokenGapsMs...)
+		sort.Float64s(sorted)
+		result.InterTokenMeanMs = mean(sorted)
+		result.InterTokenP95Ms = percentile(sorted, 95)
+		result.InterTokenP99Ms = percentile(sorted, 99)
+	}
+
+	return result, nil
+}
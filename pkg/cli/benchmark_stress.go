@@ -22,14 +22,46 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
 
+// benchmarkConnectTimeout bounds the TCP dial of every benchmark request. It
+// stays tight regardless of --generation-timeout so an unreachable endpoint
+// fails fast instead of waiting out a generous generation deadline.
+const benchmarkConnectTimeout = 10 * time.Second
+
+// generationTimePerToken is the per-token budget behind the default
+// --generation-timeout: 4 tok/s, well under any healthy server, so only
+// stalled generations hit it.
+const generationTimePerToken = 250 * time.Millisecond
+
+// benchmarkTransport is shared by all benchmark requests so connections are
+// pooled across iterations and workers.
+var benchmarkTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = (&net.Dialer{Timeout: benchmarkConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	return t
+}()
+
+// effectiveGenerationTimeout is the whole-request deadline for one chat
+// completion: --generation-timeout when set, otherwise --timeout raised to
+// fit --max-tokens at generationTimePerToken.
+func effectiveGenerationTimeout(opts *benchmarkOptions) time.Duration {
+	if opts.generationTimeout > 0 {
+		return opts.generationTimeout
+	}
+	return max(opts.timeout, time.Duration(opts.maxTokens)*generationTimePerToken)
+}
+
 var stressTestPrompts = []string{
 	// Short prompts (fast prefill, test generation throughput)
 	"What is 2+2?",
@@ -60,16 +92,63 @@ var stressTestPrompts = []string{
 		"read-through and write-through caching patterns.",
 }
 
+// stopFilePollInterval is how often a duration-bound stress run checks
+// --stop-file while the workers are busy.
+var stopFilePollInterval = time.Second
+
+// stopFileExists reports whether the --stop-file has appeared.
+func stopFileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 func makeStopCondition(opts *benchmarkOptions, iteration *int64) func() bool {
+	var limit func() bool
 	if opts.duration > 0 {
 		deadline := time.Now().Add(opts.duration)
-		return func() bool {
+		limit = func() bool {
 			return time.Now().After(deadline)
 		}
+	} else {
+		totalIterations := int64(opts.iterations)
+		limit = func() bool {
+			return atomic.LoadInt64(iteration) >= totalIterations
+		}
 	}
-	totalIterations := int64(opts.iterations)
 	return func() bool {
-		return atomic.LoadInt64(iteration) >= totalIterations
+		return limit() || stopFileExists(opts.stopFile)
+	}
+}
+
+// waitForStressDeadline blocks until the run duration elapses, the
+// --stop-file appears, or ctx is canceled, whichever comes first.
+func waitForStressDeadline(ctx context.Context, opts *benchmarkOptions) {
+	deadline := time.NewTimer(opts.duration)
+	defer deadline.Stop()
+	if opts.stopFile == "" {
+		select {
+		case <-deadline.C:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	ticker := time.NewTicker(stopFilePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-deadline.C:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if stopFileExists(opts.stopFile) {
+				return
+			}
+		}
 	}
 }
 
@@ -117,7 +196,11 @@ func runStressTestInternal(
 	fmt.Printf("Service:     %s\n", opts.name)
 	fmt.Printf("Namespace:   %s\n", opts.namespace)
 	fmt.Printf("Endpoint:    %s\n", endpoint)
-	fmt.Printf("Concurrency: %d\n", concurrency)
+	if opts.rate > 0 {
+		fmt.Printf("Rate:        %.2f req/s (open loop)\n", opts.rate)
+	} else {
+		fmt.Printf("Concurrency: %d\n", concurrency)
+	}
 	if opts.duration > 0 {
 		fmt.Printf("Duration:    %s\n", opts.duration)
 	} else {
@@ -153,67 +236,151 @@ func runStressTestInternal(
 		printMu     sync.Mutex
 	)
 
+	var soak *soakReporter
+	if opts.soakReportInterval > 0 {
+		soak, err = newSoakReporter(opts, endpoint, concurrency, startTime, func() []BenchmarkResult {
+			resultsMu.Lock()
+			defer resultsMu.Unlock()
+			return append([]BenchmarkResult(nil), results...)
+		}, os.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		soak.start(opts.soakReportInterval)
+	}
+
+	var keepalive *keepalivePinger
+	if opts.keepaliveInterval > 0 {
+		keepalive = newKeepalivePinger(endpoint, opts.requestHeaders)
+		keepalive.start(opts.keepaliveInterval)
+	}
+
 	stopCondition := makeStopCondition(opts, &iteration)
-	if opts.duration > 0 {
+	switch {
+	case opts.rate > 0 && opts.duration > 0:
+		fmt.Printf("📊 Running stress test for %s at %.2f req/s (open loop)...\n\n", opts.duration, opts.rate)
+	case opts.rate > 0:
+		fmt.Printf("📊 Running %d iterations at %.2f req/s (open loop)...\n\n", opts.iterations, opts.rate)
+	case opts.duration > 0:
 		fmt.Printf("📊 Running stress test for %s with %d concurrent workers...\n\n", opts.duration, concurrency)
-	} else {
+	default:
 		fmt.Printf("📊 Running %d iterations with %d concurrent workers...\n\n", opts.iterations, concurrency)
 	}
 
-	for w := 0; w < concurrency; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for {
-				select {
-				case <-stopChan:
-					return
-				default:
-					if stopCondition() {
-						return
-					}
+	// runRequest sends request i and records its outcome. It returns false
+	// when the run was interrupted mid-request.
+	runRequest := func(i int) bool {
+		prompt := prompts[(i-1)%len(prompts)]
 
-					i := int(atomic.AddInt64(&iteration, 1))
-					prompt := prompts[(i-1)%len(prompts)]
+		result, err := sendBenchmarkRequestWithPrompt(ctx, endpoint, opts, i, prompt)
+		if ctx.Err() != nil {
+			// Interrupted mid-request; drop it rather than count
+			// the cancellation as a server error.
+			return false
+		}
+		if err != nil {
+			result = BenchmarkResult{
+				Iteration: i,
+				Error:     err.Error(),
+			}
+			atomic.AddInt64(&errors, 1)
+		} else {
+			atomic.AddInt64(&completed, 1)
+			atomic.AddInt64(&totalToks, int64(result.CompletionTokens))
+		}
 
-					result, err := sendBenchmarkRequestWithPrompt(ctx, endpoint, opts, i, prompt)
-					if err != nil {
-						result = BenchmarkResult{
-							Iteration: i,
-							Error:     err.Error(),
-						}
-						atomic.AddInt64(&errors, 1)
-					} else {
-						atomic.AddInt64(&completed, 1)
-						atomic.AddInt64(&totalToks, int64(result.CompletionTokens))
-					}
+		resultsMu.Lock()
+		results = append(results, result)
+		resultsMu.Unlock()
+		opts.stream.emitResult(result)
+
+		printMu.Lock()
+		if time.Since(lastPrintAt) >= 2*time.Second {
+			printStressProgress(opts, startTime,
+				atomic.LoadInt64(&completed),
+				atomic.LoadInt64(&errors),
+				atomic.LoadInt64(&totalToks))
+			lastPrintAt = time.Now()
+		}
+		printMu.Unlock()
+		return true
+	}
 
-					resultsMu.Lock()
-					results = append(results, result)
-					resultsMu.Unlock()
-
-					printMu.Lock()
-					if time.Since(lastPrintAt) >= 2*time.Second {
-						printStressProgress(opts, startTime,
-							atomic.LoadInt64(&completed),
-							atomic.LoadInt64(&errors),
-							atomic.LoadInt64(&totalToks))
-						lastPrintAt = time.Now()
+	var (
+		dispatched     int
+		dispatchWindow time.Duration
+		scheduleDone   = make(chan struct{})
+	)
+	if opts.rate > 0 {
+		go func() {
+			defer close(scheduleDone)
+			dispatchStart := time.Now()
+			dispatched = runRateScheduler(ctx, opts.rate, rand.New(rand.NewPCG(uint64(dispatchStart.UnixNano()), 0)),
+				stopChan, stopCondition, func(i int) {
+					atomic.StoreInt64(&iteration, int64(i))
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						runRequest(i)
+					}()
+				})
+			dispatchWindow = time.Since(dispatchStart)
+		}()
+	} else {
+		close(scheduleDone)
+		for w := 0; w < concurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stopChan:
+						return
+					case <-ctx.Done():
+						return
+					default:
+						if stopCondition() {
+							return
+						}
+						if !runRequest(int(atomic.AddInt64(&iteration, 1))) {
+							return
+						}
 					}
-					printMu.Unlock()
 				}
-			}
-		}()
+			}()
+		}
 	}
 
 	if opts.duration > 0 {
-		time.Sleep(opts.duration)
+		waitForStressDeadline(ctx, opts)
 		close(stopChan)
 	}
+	<-scheduleDone
 	wg.Wait()
+	var interimReports int
+	if soak != nil {
+		interimReports = soak.stop()
+	}
 	fmt.Printf("\n\n")
 
+	if ctx.Err() != nil {
+		fmt.Printf("⚠️  Interrupted; summarizing completed requests\n\n")
+	} else if stopFileExists(opts.stopFile) {
+		fmt.Printf("🛑 Stop file %s found; summarizing completed requests\n\n", opts.stopFile)
+	}
+
+	if keepalive != nil {
+		pings, failures := keepalive.stop()
+		fmt.Printf("💓 Keepalive: %d pings sent (%d failed)\n\n", pings+failures, failures)
+	}
+	if soak != nil {
+		fmt.Printf("📈 Soak: %d interim summaries written\n\n", interimReports)
+	}
+
 	summary := calculateStressSummary(opts, endpoint, results, startTime, concurrency)
+	if opts.rate > 0 && dispatchWindow > 0 {
+		summary.AchievedRPS = float64(dispatched) / dispatchWindow.Seconds()
+	}
 	return &summary, nil
 }
 
@@ -237,13 +404,23 @@ func loadPrompts(opts *benchmarkOptions) ([]string, error) {
 		return prompts, nil
 	}
 
+	// --prompt-tokens: one rotation of the calibrated synthetic prompt per
+	// filler word, so concurrent requests do not share a cached prefix
+	if opts.promptWords > 0 {
+		prompts := make([]string, len(syntheticPromptWords))
+		for i := range prompts {
+			prompts[i] = syntheticPrompt(opts.promptWords, i)
+		}
+		return prompts, nil
+	}
+
 	// Use prompt flag if specified (single prompt mode)
 	if opts.prompt != defaultBenchmarkPrompt {
 		return []string{opts.prompt}, nil
 	}
 
 	// For stress tests, use built-in varied prompts by default
-	if opts.concurrent > 1 || opts.duration > 0 {
+	if opts.concurrent > 1 || opts.duration > 0 || opts.rate > 0 {
 		return stressTestPrompts, nil
 	}
 
@@ -254,12 +431,52 @@ func loadPrompts(opts *benchmarkOptions) ([]string, error) {
 func sendBenchmarkRequest(
 	ctx context.Context, endpoint string, opts *benchmarkOptions, iteration int,
 ) (BenchmarkResult, error) {
-	return sendBenchmarkRequestWithPrompt(ctx, endpoint, opts, iteration, opts.prompt)
+	prompt := opts.prompt
+	if opts.promptWords > 0 {
+		// Rotate the words on every request, warmups included, so the
+		// server's prompt cache cannot skip the prefill being measured.
+		opts.promptSeq++
+		prompt = syntheticPrompt(opts.promptWords, opts.promptSeq)
+	}
+	return sendBenchmarkRequestWithPrompt(ctx, endpoint, opts, iteration, prompt)
+}
+
+// defaultBenchmarkTemperature is the --temperature default.
+const defaultBenchmarkTemperature = 0.7
+
+// applySamplingParams sets the --temperature, --top-p, --top-k and --seed
+// sampling parameters on req for request iteration.
+func applySamplingParams(req *ChatCompletionRequest, opts *benchmarkOptions, iteration int) {
+	req.Temperature = opts.temperature
+	req.TopP = opts.topP
+	req.TopK = opts.topK
+	req.Seed = requestSeed(opts, iteration)
+}
+
+// requestSeed returns the sampling seed for request iteration: --seed+N with
+// --seed-per-iteration, --seed alone when only that is set, and nil (the
+// server's choice) otherwise.
+func requestSeed(opts *benchmarkOptions, iteration int) *int64 {
+	if !opts.seedPerIteration && !opts.seedSet {
+		return nil
+	}
+	seed := opts.seed
+	if opts.seedPerIteration {
+		seed += int64(iteration)
+	}
+	return &seed
 }
 
 func sendBenchmarkRequestWithPrompt(
 	ctx context.Context, endpoint string, opts *benchmarkOptions, iteration int, prompt string,
 ) (BenchmarkResult, error) {
+	if opts.mode == inferencev1alpha1.ServingModeEmbedding {
+		return sendEmbeddingRequest(ctx, endpoint, opts, iteration, prompt)
+	}
+	if opts.streaming {
+		return sendStreamingBenchmarkRequest(ctx, endpoint, opts, iteration, prompt)
+	}
+
 	result := BenchmarkResult{
 		Iteration: iteration,
 	}
@@ -268,10 +485,10 @@ func sendBenchmarkRequestWithPrompt(
 		Messages: []ChatMessage{
 			{Role: "user", Content: prompt},
 		},
-		MaxTokens:   opts.maxTokens,
-		Temperature: 0.7,
-		Stream:      false,
+		MaxTokens: opts.maxTokens,
+		Stream:    false,
 	}
+	applySamplingParams(&reqBody, opts, iteration)
 
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
@@ -283,8 +500,9 @@ func sendBenchmarkRequestWithPrompt(
 		return result, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	setRequestHeaders(req, opts.requestHeaders)
 
-	httpClient := &http.Client{Timeout: opts.timeout}
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: effectiveGenerationTimeout(opts)}
 	reqStartTime := time.Now()
 
 	resp, err := httpClient.Do(req)
@@ -309,7 +527,12 @@ func sendBenchmarkRequestWithPrompt(
 		return result, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	if opts.validateSchema {
+		result.SchemaViolations = validateChatCompletionSchema(body)
+	}
+
 	result.PromptTokens = chatResp.Usage.PromptTokens
+	result.PromptN = chatResp.Timings.PromptN
 	result.CompletionTokens = chatResp.Usage.CompletionTokens
 	result.TotalTokens = chatResp.Usage.TotalTokens
 	result.TotalTimeMs = float64(totalTime.Milliseconds())
@@ -319,6 +542,7 @@ func sendBenchmarkRequestWithPrompt(
 		result.GenerationTimeMs = chatResp.Timings.PredictedMs
 		result.PromptToksPerSec = chatResp.Timings.PromptPerSecond
 		result.GenerationToksPerSec = chatResp.Timings.PredictedPerSecond
+		result.ServerTimings = true
 	} else {
 		result.GenerationTimeMs = result.TotalTimeMs
 		if result.CompletionTokens > 0 && result.TotalTimeMs > 0 {
@@ -328,3 +552,65 @@ func sendBenchmarkRequestWithPrompt(
 
 	return result, nil
 }
+
+// sendEmbeddingRequest benchmarks one /v1/embeddings call. An embedding is a
+// single forward pass over the input, so the whole request is prompt
+// processing: its latency is reported as prompt time and throughput as
+// prompt tokens per second, with no generated tokens.
+func sendEmbeddingRequest(
+	ctx context.Context, endpoint string, opts *benchmarkOptions, iteration int, prompt string,
+) (BenchmarkResult, error) {
+	result := BenchmarkResult{
+		Iteration: iteration,
+	}
+
+	jsonBody, err := json.Marshal(EmbeddingRequest{Input: prompt, Pooling: opts.pooling})
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint+"/v1/embeddings", bytes.NewReader(jsonBody))
+	if err != nil {
+		return result, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setRequestHeaders(req, opts.requestHeaders)
+
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: opts.timeout}
+	reqStartTime := time.Now()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return result, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	totalTime := time.Since(reqStartTime)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	var embResp EmbeddingResponse
+	if err := json.Unmarshal(body, &embResp); err != nil {
+		return result, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(embResp.Data) == 0 || len(embResp.Data[0].Embedding) == 0 {
+		return result, fmt.Errorf("response contained no embedding")
+	}
+
+	result.PromptTokens = embResp.Usage.PromptTokens
+	result.TotalTokens = embResp.Usage.TotalTokens
+	result.TotalTimeMs = float64(totalTime.Milliseconds())
+	result.PromptTimeMs = result.TotalTimeMs
+	if result.PromptTokens > 0 && result.TotalTimeMs > 0 {
+		result.PromptToksPerSec = float64(result.PromptTokens) / (result.TotalTimeMs / 1000.0)
+	}
+
+	return result, nil
+}
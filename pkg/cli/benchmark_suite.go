@@ -19,6 +19,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -201,7 +202,8 @@ func runSuite(opts *benchmarkOptions) error {
 		return fmt.Errorf("unknown suite '%s'. Available: %s", opts.suite, strings.Join(validSuites, ", "))
 	}
 
-	ctx := context.Background()
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
 	startTime := time.Now()
 
 	modelIDs := strings.Split(opts.catalog, ",")
@@ -226,8 +228,17 @@ func runSuite(opts *benchmarkOptions) error {
 	if err != nil {
 		return err
 	}
+	defer func() {
+		for _, modelID := range modelIDs {
+			cleanupInterruptedModel(ctx, k8sClient, modelID, opts)
+		}
+	}()
 
 	for phaseIdx, phase := range suite.Phases {
+		if ctx.Err() != nil {
+			fmt.Printf("\n⚠️  Interrupted; skipping the remaining phases\n")
+			break
+		}
 		fmt.Printf("\n")
 		fmt.Printf("╔═══════════════════════════════════════════════════════════════╗\n")
 		fmt.Printf("║ Phase %d/%d: %s\n", phaseIdx+1, len(suite.Phases), phase.Description)
@@ -253,7 +264,11 @@ func runSuite(opts *benchmarkOptions) error {
 
 	fmt.Printf("\n")
 	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-	fmt.Printf("✅ Suite '%s' completed\n", suite.Name)
+	if ctx.Err() != nil {
+		fmt.Printf("⚠️  Suite '%s' interrupted\n", suite.Name)
+	} else {
+		fmt.Printf("✅ Suite '%s' completed\n", suite.Name)
+	}
 	fmt.Printf("   Total Duration: %s\n", totalDuration.Round(time.Second))
 	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
 
@@ -356,7 +371,7 @@ func runSuiteConcurrencySweep(
 	}
 
 	sweepReport.Duration = time.Since(sweepReport.Timestamp)
-	outputSweepTable(sweepReport)
+	_ = outputSweepReport(sweepReport, testOpts)
 
 	if reportWriter != nil {
 		_ = reportWriter.writeSweepResults(&sweepReport)
@@ -400,7 +415,11 @@ func runSuiteConcurrencyPhase(
 				return fmt.Errorf("stability test failed: %w", err)
 			}
 
-			outputStressTable(*summary)
+			if runOpts.output == outputFormatCSV {
+				_ = writeResultsCSV(os.Stdout, summary.Results)
+			} else {
+				outputStressTable(*summary)
+			}
 
 			if reportWriter != nil {
 				_ = reportWriter.writeStressResult(summary)
@@ -495,7 +514,7 @@ func runSuiteTokensSweep(
 		}
 
 		sweepReport.Duration = time.Since(sweepReport.Timestamp)
-		outputSweepTable(sweepReport)
+		_ = outputSweepReport(sweepReport, opts)
 
 		if reportWriter != nil {
 			_ = reportWriter.writeSweepResults(&sweepReport)
@@ -604,7 +623,7 @@ func runSuiteContextSweep(
 		}
 
 		sweepReport.Duration = time.Since(sweepReport.Timestamp)
-		outputSweepTable(sweepReport)
+		_ = outputSweepReport(sweepReport, opts)
 
 		if reportWriter != nil {
 			_ = reportWriter.writeSweepResults(&sweepReport)
@@ -695,7 +714,7 @@ func runSuiteGPUScaling(
 		}
 
 		sweepReport.Duration = time.Since(sweepReport.Timestamp)
-		outputSweepTable(sweepReport)
+		_ = outputSweepReport(sweepReport, opts)
 
 		if reportWriter != nil {
 			_ = reportWriter.writeSweepResults(&sweepReport)
@@ -63,7 +63,8 @@ func runSweepIteration(ctx context.Context, endpoint string, opts *benchmarkOpti
 }
 
 func runConcurrencySweep(opts *benchmarkOptions) error {
-	ctx := context.Background()
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
 	startTime := time.Now()
 
 	values, err := parseSweepValues(opts.concurrencySweep)
@@ -113,6 +114,10 @@ func runConcurrencySweep(opts *benchmarkOptions) error {
 	}
 
 	for _, concurrency := range values {
+		if ctx.Err() != nil {
+			fmt.Printf("⚠️  Interrupted; skipping the remaining sweep values\n\n")
+			break
+		}
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 		fmt.Printf("📊 Testing concurrency: %d\n", concurrency)
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
@@ -133,7 +138,9 @@ func runConcurrencySweep(opts *benchmarkOptions) error {
 	}
 
 	sweepReport.Duration = time.Since(startTime)
-	outputSweepTable(sweepReport)
+	if err := outputSweepReport(sweepReport, opts); err != nil {
+		return err
+	}
 
 	if reportWriter != nil {
 		if err := reportWriter.writeSweepResults(&sweepReport); err != nil {
@@ -153,7 +160,8 @@ func runConcurrencySweep(opts *benchmarkOptions) error {
 }
 
 func runTokensSweep(opts *benchmarkOptions) error {
-	ctx := context.Background()
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
 	startTime := time.Now()
 
 	values, err := parseSweepValues(opts.tokensSweep)
@@ -199,6 +207,10 @@ func runTokensSweep(opts *benchmarkOptions) error {
 	}
 
 	for _, maxTokens := range values {
+		if ctx.Err() != nil {
+			fmt.Printf("⚠️  Interrupted; skipping the remaining sweep values\n\n")
+			break
+		}
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 		fmt.Printf("📊 Testing max-tokens: %d\n", maxTokens)
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
@@ -219,7 +231,9 @@ func runTokensSweep(opts *benchmarkOptions) error {
 	}
 
 	sweepReport.Duration = time.Since(startTime)
-	outputSweepTable(sweepReport)
+	if err := outputSweepReport(sweepReport, opts); err != nil {
+		return err
+	}
 
 	if reportWriter != nil {
 		if err := reportWriter.writeSweepResults(&sweepReport); err != nil {
@@ -249,6 +263,9 @@ func runContextSweepIteration(
 	testOpts.contextSize = int32(contextSize) //nolint:gosec // G115: sweep contextSize is user-provided positive int
 	testOpts.name = modelID
 
+	// Registered before the deploy so an interrupted level is still removed.
+	defer cleanupInterruptedModel(ctx, k8sClient, modelID, &testOpts)
+
 	fmt.Printf("🚀 Deploying with context size %d...\n", contextSize)
 	if err := deployModel(ctx, k8sClient, modelID, catalogModel, &testOpts); err != nil {
 		result.Error = fmt.Sprintf("deploy failed: %v", err)
@@ -300,7 +317,8 @@ func runContextSweep(opts *benchmarkOptions) error {
 		return fmt.Errorf("--context-sweep requires --catalog mode (deploys with different context sizes)")
 	}
 
-	ctx := context.Background()
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
 	startTime := time.Now()
 
 	values, err := parseSweepValues(opts.contextSweep)
@@ -356,6 +374,10 @@ func runContextSweep(opts *benchmarkOptions) error {
 	}
 
 	for _, contextSize := range values {
+		if ctx.Err() != nil {
+			fmt.Printf("⚠️  Interrupted; skipping the remaining sweep values\n\n")
+			break
+		}
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 		fmt.Printf("📊 Testing context size: %d\n", contextSize)
 		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
@@ -366,7 +388,9 @@ func runContextSweep(opts *benchmarkOptions) error {
 	}
 
 	sweepReport.Duration = time.Since(startTime)
-	outputSweepTable(sweepReport)
+	if err := outputSweepReport(sweepReport, opts); err != nil {
+		return err
+	}
 
 	if reportWriter != nil {
 		if err := reportWriter.writeSweepResults(&sweepReport); err != nil {
@@ -18,12 +18,22 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -409,6 +419,114 @@ func TestSendBenchmarkRequestError(t *testing.T) {
 	// (result.Error is only set when we catch the error in the benchmark loop)
 }
 
+func TestSendBenchmarkRequestGenerationTimeout(t *testing.T) {
+	// A slow-but-valid generation: longer than --timeout, well within
+	// --generation-timeout.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		resp := ChatCompletionResponse{}
+		resp.Usage.PromptTokens = 10
+		resp.Usage.CompletionTokens = 20
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{
+		prompt:            "Test prompt",
+		maxTokens:         0,
+		timeout:           50 * time.Millisecond,
+		generationTimeout: 5 * time.Second,
+	}
+	result, err := sendBenchmarkRequest(t.Context(), server.URL, opts, 1)
+	if err != nil {
+		t.Fatalf("expected the long generation to succeed, got %v", err)
+	}
+	if result.CompletionTokens != 20 {
+		t.Errorf("Expected 20 completion tokens, got %d", result.CompletionTokens)
+	}
+
+	opts.generationTimeout = 50 * time.Millisecond
+	if _, err := sendBenchmarkRequest(t.Context(), server.URL, opts, 2); err == nil {
+		t.Error("expected a timeout once the generation outlives --generation-timeout")
+	}
+}
+
+func TestSendEmbeddingRequest(t *testing.T) {
+	var gotPath string
+	var gotReq EmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		resp := EmbeddingResponse{}
+		resp.Data = append(resp.Data, struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		}{Embedding: []float64{0.1, 0.2, 0.3}})
+		resp.Usage.PromptTokens = 12
+		resp.Usage.TotalTokens = 12
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{prompt: "Embed me", timeout: 5 * time.Second, mode: "embedding"}
+	result, err := sendBenchmarkRequest(t.Context(), server.URL, opts, 1)
+	if err != nil {
+		t.Fatalf("sendBenchmarkRequest: %v", err)
+	}
+	if gotPath != "/v1/embeddings" || gotReq.Input != "Embed me" {
+		t.Errorf("expected an embeddings request for the prompt, got %s %+v", gotPath, gotReq)
+	}
+	if result.PromptTokens != 12 || result.CompletionTokens != 0 {
+		t.Errorf("expected 12 prompt and 0 completion tokens, got %d and %d", result.PromptTokens, result.CompletionTokens)
+	}
+}
+
+func TestSplitEndpointRoute(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantBase string
+		wantMode string
+	}{
+		{"http://svc.ns.svc.cluster.local:8080/v1/embeddings", "http://svc.ns.svc.cluster.local:8080", "embedding"},
+		{"http://svc.ns.svc.cluster.local:8080/v1/chat/completions", "http://svc.ns.svc.cluster.local:8080", "chat"},
+		{"https://llm.example.com/bge/embeddings/", "https://llm.example.com/bge", "embedding"},
+		{"http://svc:8080/v1/rerank", "http://svc:8080", "rerank"},
+		{"http://localhost:8080", "http://localhost:8080", ""},
+	}
+	for _, tt := range tests {
+		base, mode := splitEndpointRoute(tt.endpoint)
+		if base != tt.wantBase || mode != tt.wantMode {
+			t.Errorf("splitEndpointRoute(%q) = %q, %q; want %q, %q", tt.endpoint, base, mode, tt.wantBase, tt.wantMode)
+		}
+	}
+
+	opts := &benchmarkOptions{mode: "chat"}
+	if base := detectEndpointMode(opts, "http://svc:8080/v1/embeddings"); base != "http://svc:8080" || opts.mode != "chat" {
+		t.Errorf("expected an explicit --mode to win over the endpoint path, got %q with mode %q", base, opts.mode)
+	}
+}
+
+func TestEffectiveGenerationTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts benchmarkOptions
+		want time.Duration
+	}{
+		{"explicit flag wins", benchmarkOptions{timeout: time.Minute, maxTokens: 4096, generationTimeout: 2 * time.Minute}, 2 * time.Minute},
+		{"short runs keep --timeout", benchmarkOptions{timeout: time.Minute, maxTokens: 50}, time.Minute},
+		{"long runs scale with max-tokens", benchmarkOptions{timeout: time.Minute, maxTokens: 2048}, 512 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := effectiveGenerationTimeout(&tt.opts); got != tt.want {
+				t.Errorf("effectiveGenerationTimeout() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestOutputJSON(t *testing.T) {
 	summary := BenchmarkSummary{
 		ServiceName:              "test-service",
@@ -1258,6 +1376,7 @@ func TestOutputStressTable(t *testing.T) {
 		ErrorRate:      5.0,
 		PeakToksPerSec: 70.0,
 	}
+	summary.SchemaViolations = 3
 
 	old := os.Stdout
 	r, w, _ := os.Pipe()
@@ -1278,6 +1397,9 @@ func TestOutputStressTable(t *testing.T) {
 	if !strings.Contains(output, "100") {
 		t.Error("outputStressTable should show total requests")
 	}
+	if !strings.Contains(output, "Schema Errors:   3/95 responses") {
+		t.Errorf("outputStressTable should show schema violations next to the success rate, got:\n%s", output)
+	}
 }
 
 func TestOutputStressMarkdown(t *testing.T) {
@@ -1325,6 +1447,9 @@ func TestOutputStressMarkdown(t *testing.T) {
 	if !strings.Contains(output, "## Throughput") {
 		t.Error("outputStressMarkdown should contain throughput section")
 	}
+	if strings.Contains(output, "Schema Violations") {
+		t.Error("outputStressMarkdown should omit schema violations when there are none")
+	}
 }
 
 func TestOutputStressJSON(t *testing.T) {
@@ -1753,9 +1878,13 @@ func TestReportWriterMethods(t *testing.T) {
 		ErrorRate:        5.0,
 		PeakToksPerSec:   30.0,
 	}
+	stressSummary.SchemaViolations = 2
 	if err := rw.writeStressResult(stressSummary); err != nil {
 		t.Errorf("writeStressResult error: %v", err)
 	}
+	if section := rw.data.Sections[len(rw.data.Sections)-1]; !strings.Contains(section.Content, "| Schema Violations | 2/5 responses |") {
+		t.Errorf("stress report section should show schema violations, got:\n%s", section.Content)
+	}
 
 	sweepReport := &SweepReport{
 		SweepType: "Concurrency",
@@ -1784,3 +1913,2224 @@ func TestReportWriterMethods(t *testing.T) {
 		t.Errorf("writeComparisonReport error: %v", err)
 	}
 }
+
+func TestReportWriterDefaultTemplate(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	opts := &benchmarkOptions{report: reportPath, gpu: true, gpuCount: 2}
+
+	rw, err := newReportWriter(opts)
+	if err != nil {
+		t.Fatalf("newReportWriter error: %v", err)
+	}
+	if err := rw.writeSection("First", "one"); err != nil {
+		t.Fatalf("writeSection error: %v", err)
+	}
+	if err := rw.writeSection("Second", "two"); err != nil {
+		t.Fatalf("writeSection error: %v", err)
+	}
+	if err := rw.close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	want := fmt.Sprintf("# LLMKube Benchmark Report\n\n"+
+		"**Generated:** %s  \n"+
+		"**Host:** %s (%s/%s)  \n"+
+		"**Accelerator:** cuda (GPU Count: 2)  \n\n---\n\n"+
+		"## First\n\none\n\n## Second\n\ntwo\n\n"+
+		"\n---\n\n*Total Duration: 0s*  \n*Generated by LLMKube v%s*\n",
+		rw.startTime.Format("2006-01-02 15:04:05"), getHostname(), runtime.GOOS, runtime.GOARCH, Version)
+	if string(content) != want {
+		t.Errorf("report =\n%s\nwant\n%s", content, want)
+	}
+}
+
+func TestReportWriterCustomTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "branded.html.tmpl")
+	tmpl := `<h1>ACME inference report</h1>
+{{with .Summary}}<p>{{.ServiceName}}: {{printf "%.1f" .GenerationToksPerSecMean}} tok/s, P99 {{printf "%.0f" .LatencyP99}} ms</p>{{end}}
+{{with .Comparison}}{{range .Models}}<li>{{.ModelID}} {{.GenerationToksPerSec}}</li>{{end}}{{end}}
+<footer>v{{.Version}}, {{len .Sections}} sections</footer>
+`
+	if err := os.WriteFile(templatePath, []byte(tmpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reportPath := filepath.Join(dir, "report.html")
+	opts := &benchmarkOptions{report: reportPath, reportTemplate: templatePath}
+
+	rw, err := newReportWriter(opts)
+	if err != nil {
+		t.Fatalf("newReportWriter error: %v", err)
+	}
+	if err := rw.writeBenchmarkResult(&BenchmarkSummary{
+		ServiceName: "llama-3b", Iterations: 5, SuccessfulRuns: 5,
+		GenerationToksPerSecMean: 42.25, LatencyP99: 812.4,
+	}); err != nil {
+		t.Fatalf("writeBenchmarkResult error: %v", err)
+	}
+	if err := rw.writeComparisonReport(ComparisonReport{Models: []ModelBenchmark{
+		{ModelID: "phi-4-mini", Status: statusSuccess, GenerationToksPerSec: 61.5},
+	}}); err != nil {
+		t.Fatalf("writeComparisonReport error: %v", err)
+	}
+	if err := rw.close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	for _, want := range []string{
+		"<h1>ACME inference report</h1>",
+		"<p>llama-3b: 42.2 tok/s, P99 812 ms</p>",
+		"<li>phi-4-mini 61.5</li>",
+		fmt.Sprintf("<footer>v%s, 2 sections</footer>", Version),
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("report missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), "# LLMKube Benchmark Report") {
+		t.Error("custom template output still contains the built-in header")
+	}
+}
+
+func TestReportWriterBadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "broken.tmpl")
+	if err := os.WriteFile(templatePath, []byte("{{.Summary"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reportPath := filepath.Join(dir, "report.md")
+	opts := &benchmarkOptions{report: reportPath, reportTemplate: templatePath}
+
+	if _, err := newReportWriter(opts); err == nil {
+		t.Fatal("expected a parse error for a broken template")
+	}
+	if _, err := os.Stat(reportPath); !os.IsNotExist(err) {
+		t.Error("report file created although the template does not parse")
+	}
+}
+
+func TestKeepalivePingsNotCountedAsRequests(t *testing.T) {
+	var healthHits, completionHits int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			atomic.AddInt64(&healthHits, 1)
+			w.WriteHeader(http.StatusOK)
+		case "/v1/chat/completions":
+			atomic.AddInt64(&completionHits, 1)
+			// Slow enough that the keepalive ticker fires during the run.
+			time.Sleep(30 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{
+		name:              "test",
+		prompt:            defaultBenchmarkPrompt,
+		maxTokens:         10,
+		iterations:        6,
+		concurrent:        2,
+		timeout:           5 * time.Second,
+		keepaliveInterval: 10 * time.Millisecond,
+	}
+
+	summary, err := runStressTestInternal(t.Context(), server.URL, opts, time.Now())
+	if err != nil {
+		t.Fatalf("runStressTestInternal failed: %v", err)
+	}
+
+	if atomic.LoadInt64(&healthHits) == 0 {
+		t.Error("Expected keepalive pings to /health, got none")
+	}
+	if got := atomic.LoadInt64(&completionHits); got != 6 {
+		t.Errorf("Expected 6 completion requests, got %d", got)
+	}
+	if summary.TotalRequests != 6 {
+		t.Errorf("Expected 6 total requests in summary, got %d", summary.TotalRequests)
+	}
+	if len(summary.Results) != 6 {
+		t.Errorf("Expected 6 results, got %d", len(summary.Results))
+	}
+}
+
+func TestKeepalivePingsDuringSequentialBenchmark(t *testing.T) {
+	var healthHits, completionHits int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			atomic.AddInt64(&healthHits, 1)
+			w.WriteHeader(http.StatusOK)
+		default:
+			atomic.AddInt64(&completionHits, 1)
+			time.Sleep(30 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+		}
+	}))
+	defer server.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	opts := &benchmarkOptions{
+		name:              "test",
+		prompt:            defaultBenchmarkPrompt,
+		maxTokens:         10,
+		iterations:        4,
+		timeout:           5 * time.Second,
+		output:            outputFormatJSON,
+		outputFile:        filepath.Join(t.TempDir(), "out.json"),
+		keepaliveInterval: 10 * time.Millisecond,
+	}
+	if err := runBenchmarkContext(t.Context(), opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+	if atomic.LoadInt64(&healthHits) == 0 {
+		t.Error("Expected keepalive pings to /health during a sequential run, got none")
+	}
+	if got := atomic.LoadInt64(&completionHits); got != 4 {
+		t.Errorf("Expected 4 completion requests, got %d", got)
+	}
+}
+
+func TestStressTestStopsGracefullyOnStopFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	oldInterval := stopFilePollInterval
+	stopFilePollInterval = 10 * time.Millisecond
+	defer func() { stopFilePollInterval = oldInterval }()
+
+	stopFile := filepath.Join(t.TempDir(), "stop")
+	opts := &benchmarkOptions{
+		name:       "test",
+		prompt:     defaultBenchmarkPrompt,
+		maxTokens:  10,
+		concurrent: 2,
+		duration:   time.Hour,
+		timeout:    5 * time.Second,
+		stopFile:   stopFile,
+	}
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = os.WriteFile(stopFile, nil, 0644)
+	}()
+
+	start := time.Now()
+	summary, err := runStressTestInternal(t.Context(), server.URL, opts, start)
+	if err != nil {
+		t.Fatalf("runStressTestInternal failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("stress run did not stop on stop file (ran %s)", elapsed)
+	}
+	if summary.SuccessfulRuns == 0 {
+		t.Error("expected completed requests in the summary")
+	}
+	if summary.FailedRuns != 0 {
+		t.Errorf("graceful stop should not fail in-flight requests, got %d failures", summary.FailedRuns)
+	}
+}
+
+func TestStressTestWorkersExitOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{
+		name:       "test",
+		prompt:     defaultBenchmarkPrompt,
+		maxTokens:  10,
+		concurrent: 4,
+		duration:   time.Hour,
+		timeout:    5 * time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	summary, err := runStressTestInternal(ctx, server.URL, opts, start)
+	if err != nil {
+		t.Fatalf("runStressTestInternal failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("workers did not exit on cancel (ran %s)", elapsed)
+	}
+	if summary.SuccessfulRuns == 0 {
+		t.Error("expected the partial summary to include completed requests")
+	}
+	if summary.FailedRuns != 0 {
+		t.Errorf("canceled requests should not count as failures, got %d", summary.FailedRuns)
+	}
+}
+
+func TestStressTestWritesSoakReportsAtInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	const interval = 100 * time.Millisecond
+	reportFile := filepath.Join(t.TempDir(), "soak.jsonl")
+	opts := &benchmarkOptions{
+		name:               "test",
+		prompt:             defaultBenchmarkPrompt,
+		maxTokens:          10,
+		concurrent:         2,
+		duration:           550 * time.Millisecond,
+		timeout:            5 * time.Second,
+		soakReportInterval: interval,
+		soakReportFile:     reportFile,
+	}
+
+	summary, err := runStressTestInternal(t.Context(), server.URL, opts, time.Now())
+	if err != nil {
+		t.Fatalf("runStressTestInternal failed: %v", err)
+	}
+
+	data, err := os.ReadFile(reportFile)
+	if err != nil {
+		t.Fatalf("reading soak report file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	// 550ms at a 100ms cadence is five reports; allow one either way for
+	// scheduler jitter.
+	if len(lines) < 4 || len(lines) > 6 {
+		t.Fatalf("expected ~5 interim reports, got %d:\n%s", len(lines), data)
+	}
+
+	var windowTotal int64
+	var prev SoakInterimReport
+	for i, line := range lines {
+		var report SoakInterimReport
+		if err := json.Unmarshal([]byte(line), &report); err != nil {
+			t.Fatalf("line %d is not a JSON report: %v", i+1, err)
+		}
+		if report.Index != i+1 {
+			t.Errorf("report %d has index %d", i+1, report.Index)
+		}
+		if gap := report.Elapsed - prev.Elapsed; gap < interval/2 || gap > 3*interval {
+			t.Errorf("report %d came %s after the previous one, want ~%s", report.Index, gap, interval)
+		}
+		if report.Window.Requests == 0 {
+			t.Errorf("report %d covers no requests", report.Index)
+		}
+		if report.Cumulative.Requests < prev.Cumulative.Requests {
+			t.Errorf("cumulative requests went backwards: %d -> %d",
+				prev.Cumulative.Requests, report.Cumulative.Requests)
+		}
+		windowTotal += report.Window.Requests
+		if windowTotal != report.Cumulative.Requests {
+			t.Errorf("report %d: windows sum to %d, cumulative is %d",
+				report.Index, windowTotal, report.Cumulative.Requests)
+		}
+		prev = report
+	}
+	if windowTotal > summary.TotalRequests {
+		t.Errorf("interim windows counted %d requests, final summary has %d", windowTotal, summary.TotalRequests)
+	}
+}
+
+func TestRunBenchmarkCleansUpPortForwardOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	var cleanedUp atomic.Bool
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, func() { cleanedUp.Store(true) }, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	opts := &benchmarkOptions{
+		name:       "test",
+		prompt:     defaultBenchmarkPrompt,
+		maxTokens:  10,
+		concurrent: 2,
+		duration:   time.Hour,
+		timeout:    5 * time.Second,
+		output:     outputFormatJSON,
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	if err := runBenchmarkContext(ctx, opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+	if !cleanedUp.Load() {
+		t.Error("expected the port-forward cleanup to run after cancellation")
+	}
+}
+
+func TestValidateChatCompletionSchemaConformant(t *testing.T) {
+	body := []byte(`{
+		"id": "chatcmpl-1", "object": "chat.completion", "created": 1700000000, "model": "m",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "hi"}, "finish_reason": "stop"}],
+		"usage": {"prompt_tokens": 5, "completion_tokens": 1, "total_tokens": 6}
+	}`)
+	if v := validateChatCompletionSchema(body); len(v) != 0 {
+		t.Errorf("expected no violations, got %v", v)
+	}
+}
+
+func TestBenchmarkReportsSchemaViolations(t *testing.T) {
+	// 200 OK, but the wrong object type and no usage block.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"x","object":"text_completion","created":1,"model":"m",` +
+			`"choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{
+		name:           "test",
+		prompt:         defaultBenchmarkPrompt,
+		maxTokens:      10,
+		iterations:     2,
+		timeout:        5 * time.Second,
+		validateSchema: true,
+	}
+
+	results := runBenchmarkIterations(t.Context(), server.URL, opts)
+	summary := calculateSummary(opts, server.URL, results, time.Now())
+	if summary.FailedRuns != 0 {
+		t.Errorf("schema violations must not count as failed runs, got %d", summary.FailedRuns)
+	}
+	if summary.SchemaViolations != 2 {
+		t.Errorf("SchemaViolations = %d, want 2", summary.SchemaViolations)
+	}
+
+	want := []string{`object: got "text_completion", want "chat.completion"`, "usage: missing or not an object"}
+	for _, w := range want {
+		found := false
+		for _, v := range results[0].SchemaViolations {
+			if v == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected violation %q, got %v", w, results[0].SchemaViolations)
+		}
+	}
+
+	var buf bytes.Buffer
+	outputSchemaConformance(&buf, results)
+	if !strings.Contains(buf.String(), "2/2 responses violate the schema") {
+		t.Errorf("conformance report should flag the violations, got:\n%s", buf.String())
+	}
+}
+
+func TestKeepalivePingerCountsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	kp := newKeepalivePinger(server.URL, nil)
+	kp.ping()
+	kp.ping()
+	pings, failures := kp.stop()
+	if pings != 0 || failures != 2 {
+		t.Errorf("Expected 0 pings and 2 failures, got %d and %d", pings, failures)
+	}
+}
+
+func TestDeltaTableAgainstStoredBaseline(t *testing.T) {
+	dir := t.TempDir()
+
+	older := BenchmarkSummary{
+		ServiceName:              "my-llm",
+		Namespace:                "default",
+		GenerationToksPerSecMean: 50,
+		LatencyP50:               100,
+		Timestamp:                time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+	baseline := BenchmarkSummary{
+		ServiceName:              "my-llm",
+		Namespace:                "default",
+		GenerationToksPerSecMean: 100,
+		PromptToksPerSecMean:     400,
+		LatencyP50:               200,
+		LatencyP95:               300,
+		LatencyP99:               400,
+		LatencyMean:              250,
+		Timestamp:                time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+	}
+	// A newer run of a service whose name extends "my-llm", and badge files
+	// sharing its prefix, must not be taken for the latest my-llm run.
+	other := BenchmarkSummary{
+		ServiceName: "my-llm-v2",
+		Namespace:   "default",
+		Timestamp:   time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC),
+	}
+	for _, s := range []BenchmarkSummary{older, baseline, other} {
+		if _, err := saveBenchmarkHistory(dir, s); err != nil {
+			t.Fatalf("saveBenchmarkHistory() error = %v", err)
+		}
+	}
+	if _, err := exportBenchmarkBadges(dir, baseline); err != nil {
+		t.Fatalf("exportBenchmarkBadges() error = %v", err)
+	}
+
+	got, path, err := loadBaselineSummary(dir, baselineLatest, "default", "my-llm")
+	if err != nil {
+		t.Fatalf("loadBaselineSummary() error = %v", err)
+	}
+	if filepath.Base(path) != "default-my-llm-20250102-100000.json" {
+		t.Errorf("baseline path = %q, want the most recent my-llm run", path)
+	}
+
+	current := BenchmarkSummary{
+		ServiceName:              "my-llm",
+		Namespace:                "default",
+		GenerationToksPerSecMean: 90,
+		PromptToksPerSecMean:     400,
+		LatencyP50:               150,
+		LatencyP95:               330,
+		LatencyP99:               400,
+		LatencyMean:              250,
+	}
+	deltas := computeBenchmarkDeltas(current, *got)
+
+	want := map[string]string{
+		"Generation": "▼ 10.0% (regression)",
+		"Prompt":     "  0.0%",
+		"P50":        "▼ 25.0%",
+		"P95":        "▲ 10.0% (regression)",
+	}
+	for _, d := range deltas {
+		if w, ok := want[d.Metric]; ok && formatDelta(d) != w {
+			t.Errorf("%s delta = %q, want %q", d.Metric, formatDelta(d), w)
+		}
+	}
+
+	var buf bytes.Buffer
+	outputDeltaTable(&buf, current, *got, path)
+	if !strings.Contains(buf.String(), "default-my-llm-20250102-100000.json") {
+		t.Errorf("delta table should name the baseline run, got:\n%s", buf.String())
+	}
+}
+
+func TestLoadBaselineSummaryByName(t *testing.T) {
+	dir := t.TempDir()
+	s := BenchmarkSummary{
+		ServiceName: "my-llm",
+		Namespace:   "default",
+		Timestamp:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+	path, err := saveBenchmarkHistory(dir, s)
+	if err != nil {
+		t.Fatalf("saveBenchmarkHistory() error = %v", err)
+	}
+
+	if _, _, err := loadBaselineSummary(dir, filepath.Base(path), "default", "my-llm"); err != nil {
+		t.Errorf("loadBaselineSummary(by name) error = %v", err)
+	}
+	if _, _, err := loadBaselineSummary(dir, baselineLatest, "default", "missing"); err == nil {
+		t.Error("loadBaselineSummary() for a service with no history should fail")
+	}
+}
+
+func TestExportBenchmarkBadges(t *testing.T) {
+	dir := t.TempDir()
+	summary := BenchmarkSummary{
+		ServiceName:              "my-llm",
+		Namespace:                "default",
+		SuccessfulRuns:           10,
+		GenerationToksPerSecMean: 42.26,
+		LatencyP99:               1234.4,
+	}
+
+	paths, err := exportBenchmarkBadges(dir, summary)
+	if err != nil {
+		t.Fatalf("exportBenchmarkBadges() error = %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("exportBenchmarkBadges() wrote %d files, want 2", len(paths))
+	}
+
+	want := map[string]shieldsBadge{
+		"default-my-llm-throughput.json": {SchemaVersion: 1, Label: "throughput", Message: "42.3 tok/s", Color: badgeColorOK},
+		"default-my-llm-p99.json":        {SchemaVersion: 1, Label: "p99 latency", Message: "1234 ms", Color: badgeColorOK},
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read badge: %v", err)
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatalf("badge %s is not valid JSON: %v", path, err)
+		}
+		for _, key := range []string{"schemaVersion", "label", "message", "color"} {
+			if _, ok := fields[key]; !ok {
+				t.Errorf("badge %s is missing %q", filepath.Base(path), key)
+			}
+		}
+
+		var got shieldsBadge
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("failed to decode badge: %v", err)
+		}
+		if got != want[filepath.Base(path)] {
+			t.Errorf("badge %s = %+v, want %+v", filepath.Base(path), got, want[filepath.Base(path)])
+		}
+	}
+}
+
+func TestBenchmarkBadgesWithoutSuccessfulRuns(t *testing.T) {
+	for key, b := range benchmarkBadges(BenchmarkSummary{FailedRuns: 3}) {
+		if b.Message != "n/a" || b.Color != badgeColorUnknown {
+			t.Errorf("%s badge = %+v, want n/a/%s", key, b, badgeColorUnknown)
+		}
+	}
+}
+
+func TestMeasureInputLengthsOneGroupPerLength(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := len(strings.Fields(req.Messages[0].Content))
+		resp := ChatCompletionResponse{}
+		resp.Usage.PromptTokens = n
+		resp.Usage.CompletionTokens = 1
+		resp.Timings.PromptMs = float64(n) / 10
+		resp.Timings.PromptPerSecond = 10000
+		resp.Timings.PredictedMs = 5
+		resp.Timings.PredictedPerSecond = 200
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{iterations: 2, maxTokens: 1, timeout: 5 * time.Second}
+	lengths := []int{128, 512, 2048}
+	results := measureInputLengths(t.Context(), server.URL, opts, lengths)
+
+	if len(results) != len(lengths) {
+		t.Fatalf("Expected %d groups, got %d", len(lengths), len(results))
+	}
+	for i, r := range results {
+		if r.InputLength != lengths[i] {
+			t.Errorf("group %d input length = %d, want %d", i, r.InputLength, lengths[i])
+		}
+		if r.SuccessfulRuns != 2 || r.FailedRuns != 0 {
+			t.Errorf("group %d runs = %d ok / %d failed, want 2 / 0", i, r.SuccessfulRuns, r.FailedRuns)
+		}
+		if r.PromptTokens != float64(lengths[i]) {
+			t.Errorf("group %d prompt tokens = %.0f, want %d", i, r.PromptTokens, lengths[i])
+		}
+		if want := float64(lengths[i]) / 10; r.TTFTMs != want {
+			t.Errorf("group %d TTFT = %.1f ms, want %.1f", i, r.TTFTMs, want)
+		}
+	}
+}
+
+func TestMeasureInputLengthsFailsRunsWithoutPromptTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// An OpenAI-compatible server without llama.cpp timings.
+		resp := ChatCompletionResponse{}
+		resp.Usage.PromptTokens = 128
+		resp.Usage.CompletionTokens = 1
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{iterations: 2, maxTokens: 1, timeout: 5 * time.Second}
+	results := measureInputLengths(t.Context(), server.URL, opts, []int{128})
+	if r := results[0]; r.SuccessfulRuns != 0 || r.FailedRuns != 2 || r.TTFTMs != 0 {
+		t.Errorf("runs = %d ok / %d failed (TTFT %.1f), want every run failed without a TTFT",
+			r.SuccessfulRuns, r.FailedRuns, r.TTFTMs)
+	}
+}
+
+func TestPromptTokensUsesTokenizeEndpoint(t *testing.T) {
+	// Mock tokenizer: every word is two tokens.
+	var tokenizeCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tokenize":
+			tokenizeCalls++
+			var req TokenizeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(TokenizeResponse{Tokens: make([]int, 2*len(strings.Fields(req.Content)))})
+		case "/v1/chat/completions":
+			var req ChatCompletionRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			n := 2 * len(strings.Fields(req.Messages[0].Content))
+			resp := ChatCompletionResponse{}
+			resp.Usage.PromptTokens = n
+			resp.Usage.CompletionTokens = 1
+			resp.Timings.PromptN = n
+			resp.Timings.PromptMs = 10
+			resp.Timings.PromptPerSecond = float64(n) * 100
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{iterations: 2, maxTokens: 1, timeout: 5 * time.Second, prompt: defaultBenchmarkPrompt, promptTokens: 512}
+	resolvePromptTokens(t.Context(), server.URL, opts)
+	if opts.promptWords != 256 {
+		t.Fatalf("promptWords = %d, want 256", opts.promptWords)
+	}
+	if tokenizeCalls == 0 {
+		t.Error("Expected the prompt to be sized with /tokenize")
+	}
+
+	for _, r := range runBenchmarkIterations(t.Context(), server.URL, opts) {
+		if r.Error != "" {
+			t.Fatalf("iteration %d failed: %s", r.Iteration, r.Error)
+		}
+		if r.PromptN != 512 {
+			t.Errorf("iteration %d prompt_n = %d, want 512", r.Iteration, r.PromptN)
+		}
+	}
+}
+
+func TestPromptTokensFallsBackWithoutTokenizeEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	opts := &benchmarkOptions{promptTokens: 300}
+	resolvePromptTokens(t.Context(), server.URL, opts)
+	if opts.promptWords != 300 {
+		t.Errorf("promptWords = %d, want one word per token (300)", opts.promptWords)
+	}
+}
+
+func TestContextFillUsesIncreasingPromptLengths(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/props":
+			_, _ = w.Write([]byte(`{"default_generation_settings":{"n_ctx":4096}}`))
+		case "/v1/chat/completions":
+			var req ChatCompletionRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			n := len(strings.Fields(req.Messages[0].Content))
+			resp := ChatCompletionResponse{}
+			resp.Usage.PromptTokens = n
+			resp.Usage.CompletionTokens = 8
+			resp.Timings.PromptMs = float64(n) / 10
+			resp.Timings.PromptPerSecond = 10000
+			resp.Timings.PredictedMs = 40
+			resp.Timings.PredictedPerSecond = 200
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	contextSize, err := serverContextSize(t.Context(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("serverContextSize: %v", err)
+	}
+	if contextSize != 4096 {
+		t.Fatalf("contextSize = %d, want 4096", contextSize)
+	}
+
+	opts := &benchmarkOptions{iterations: 2, maxTokens: 8, timeout: 5 * time.Second}
+	targets := contextFillTargets(contextSize, opts.maxTokens)
+	results := measureContextFill(t.Context(), server.URL, opts, targets, 1.0)
+
+	if len(results) != len(contextFillFractions) {
+		t.Fatalf("Expected %d fill levels, got %d", len(contextFillFractions), len(results))
+	}
+	for i, r := range results {
+		if want := int(contextFillFractions[i]*4096) - 8; r.TargetPromptTokens != want {
+			t.Errorf("level %d target = %d, want %d", i, r.TargetPromptTokens, want)
+		}
+		if r.PromptTokens != float64(r.TargetPromptTokens) {
+			t.Errorf("level %d prompt tokens = %.0f, want %d", i, r.PromptTokens, r.TargetPromptTokens)
+		}
+		if r.SuccessfulRuns != 2 || r.TTFTMs <= 0 || r.GenerationToksPerSec != 200 {
+			t.Errorf("level %d metrics = %+v", i, r)
+		}
+		if i > 0 && r.PromptTokens <= results[i-1].PromptTokens {
+			t.Errorf("level %d prompt tokens %.0f not above level %d (%.0f)", i, r.PromptTokens, i-1, results[i-1].PromptTokens)
+		}
+	}
+
+	var buf bytes.Buffer
+	outputContextFillTable(&buf, contextSize, results)
+	if !strings.Contains(buf.String(), "90%") || !strings.Contains(buf.String(), "n_ctx 4096") {
+		t.Errorf("table missing fill levels:\n%s", buf.String())
+	}
+}
+
+func TestRateSchedulerEmitsTargetRate(t *testing.T) {
+	const rate = 200.0
+	window := 500 * time.Millisecond
+	deadline := time.Now().Add(window)
+
+	var calls int
+	got := runRateScheduler(t.Context(), rate, rand.New(rand.NewPCG(1, 2)), make(chan struct{}),
+		func() bool { return time.Now().After(deadline) },
+		func(int) { calls++ })
+
+	if got != calls {
+		t.Errorf("runRateScheduler returned %d, dispatch called %d times", got, calls)
+	}
+	// 100 arrivals expected; a Poisson count has a standard deviation of 10.
+	if want := int(rate * window.Seconds()); got < want-40 || got > want+40 {
+		t.Errorf("dispatched %d requests in %s at %.0f req/s, want about %d", got, window, rate, want)
+	}
+}
+
+func TestStressTestOpenLoopRate(t *testing.T) {
+	var inFlight, maxInFlight int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			m := atomic.LoadInt64(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, n) {
+				break
+			}
+		}
+		// Slower than the arrival interval, so open-loop requests overlap.
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{
+		name:       "test",
+		prompt:     defaultBenchmarkPrompt,
+		maxTokens:  10,
+		iterations: 10,
+		concurrent: 1,
+		rate:       100,
+		timeout:    5 * time.Second,
+	}
+
+	summary, err := runStressTestInternal(t.Context(), server.URL, opts, time.Now())
+	if err != nil {
+		t.Fatalf("runStressTestInternal failed: %v", err)
+	}
+	if summary.TotalRequests != 10 {
+		t.Errorf("Expected 10 total requests, got %d", summary.TotalRequests)
+	}
+	if summary.TargetRPS != 100 || summary.AchievedRPS <= 0 {
+		t.Errorf("rates = target %.1f achieved %.1f, want target 100 and a measured achieved rate",
+			summary.TargetRPS, summary.AchievedRPS)
+	}
+	if atomic.LoadInt64(&maxInFlight) < 2 {
+		t.Error("Expected open-loop requests to overlap despite --concurrent 1")
+	}
+}
+
+func TestSyntheticPromptVariesPrefix(t *testing.T) {
+	a := syntheticPrompt(64, 1)
+	b := syntheticPrompt(64, 2)
+	if len(strings.Fields(a)) != 64 {
+		t.Errorf("Expected 64 words, got %d", len(strings.Fields(a)))
+	}
+	if strings.Fields(a)[0] == strings.Fields(b)[0] {
+		t.Error("Expected consecutive synthetic prompts to start differently")
+	}
+	if wrapped := syntheticPrompt(64, 1+len(syntheticPromptWords)); strings.Fields(wrapped)[0] == strings.Fields(a)[0] {
+		t.Error("Expected prompts to stay distinct once the word rotation wraps")
+	}
+	if syntheticPrompt(0, 1) != "" {
+		t.Error("Expected empty prompt for zero length")
+	}
+}
+
+func TestParseConcurrentModels(t *testing.T) {
+	got := parseConcurrentModels(" llama-8b, qwen-7b,,llama-8b ")
+	if len(got) != 2 || got[0] != "llama-8b" || got[1] != "qwen-7b" {
+		t.Errorf("parseConcurrentModels() = %v, want [llama-8b qwen-7b]", got)
+	}
+}
+
+func TestConcurrentModelsDrivesServicesInParallel(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int64
+	newService := func(completionTokens int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				old := maxInFlight.Load()
+				if n <= old || maxInFlight.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"usage":{"prompt_tokens":5,"completion_tokens":%d,"total_tokens":%d}}`,
+				completionTokens, completionTokens+5)
+		}))
+	}
+	svcA := newService(10)
+	defer svcA.Close()
+	svcB := newService(20)
+	defer svcB.Close()
+
+	var cleanups atomic.Int64
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(_ context.Context, opts *benchmarkOptions) (string, func(), error) {
+		endpoint := svcA.URL
+		if opts.name == "svc-b" {
+			endpoint = svcB.URL
+		}
+		return endpoint, func() { cleanups.Add(1) }, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	opts := &benchmarkOptions{
+		namespace:        "default",
+		prompt:           defaultBenchmarkPrompt,
+		maxTokens:        20,
+		iterations:       3,
+		timeout:          5 * time.Second,
+		output:           outputFormatJSON,
+		concurrentModels: "svc-a,svc-b",
+	}
+
+	targets := []concurrentModelTarget{
+		{opts: &benchmarkOptions{name: "svc-a", prompt: opts.prompt, maxTokens: 20, iterations: 3, timeout: opts.timeout}, endpoint: svcA.URL},
+		{opts: &benchmarkOptions{name: "svc-b", prompt: opts.prompt, maxTokens: 20, iterations: 3, timeout: opts.timeout}, endpoint: svcB.URL},
+	}
+	report := benchmarkConcurrentModels(t.Context(), targets, opts)
+
+	if maxInFlight.Load() < 2 {
+		t.Errorf("expected both services to be loaded at once, max in flight = %d", maxInFlight.Load())
+	}
+	if len(report.Services) != 2 {
+		t.Fatalf("expected 2 per-service summaries, got %d", len(report.Services))
+	}
+	for _, s := range report.Services {
+		if s.SuccessfulRuns != 3 || s.FailedRuns != 0 {
+			t.Errorf("%s: got %d ok / %d failed, want 3 / 0", s.ServiceName, s.SuccessfulRuns, s.FailedRuns)
+		}
+	}
+	if report.Aggregate.SuccessfulRuns != 6 {
+		t.Errorf("aggregate successful runs = %d, want 6", report.Aggregate.SuccessfulRuns)
+	}
+	if report.Aggregate.Results != nil {
+		t.Error("aggregate should not duplicate per-request results")
+	}
+	if report.AggregateGenerationToksPerSec <= 0 {
+		t.Errorf("expected positive aggregate throughput, got %.1f", report.AggregateGenerationToksPerSec)
+	}
+
+	if err := runConcurrentModelsContext(t.Context(), opts); err != nil {
+		t.Fatalf("runConcurrentModelsContext failed: %v", err)
+	}
+	if cleanups.Load() != 2 {
+		t.Errorf("expected both port-forward cleanups to run, got %d", cleanups.Load())
+	}
+}
+
+func TestGetEndpointWaitsForModelLoad(t *testing.T) {
+	oldInterval := modelLoadPollInterval
+	modelLoadPollInterval = 10 * time.Millisecond
+	defer func() { modelLoadPollInterval = oldInterval }()
+
+	var healthHits int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// llama-server answers 503 while the model is still loading.
+		if atomic.AddInt64(&healthHits, 1) <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{endpoint: server.URL, modelLoadWait: 5 * time.Second}
+	endpoint, cleanup, err := getEndpoint(t.Context(), opts)
+	if err != nil {
+		t.Fatalf("getEndpoint failed: %v", err)
+	}
+	if cleanup != nil {
+		t.Error("expected no cleanup for an --endpoint URL")
+	}
+	if endpoint != server.URL {
+		t.Errorf("endpoint = %q, want %q", endpoint, server.URL)
+	}
+	if got := atomic.LoadInt64(&healthHits); got != 4 {
+		t.Errorf("expected the wait to poll /health until it returned 200 (4 hits), got %d", got)
+	}
+}
+
+func TestGetEndpointModelLoadWaitTimesOut(t *testing.T) {
+	oldInterval := modelLoadPollInterval
+	modelLoadPollInterval = 10 * time.Millisecond
+	defer func() { modelLoadPollInterval = oldInterval }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{endpoint: server.URL, modelLoadWait: 100 * time.Millisecond}
+	_, _, err := getEndpoint(t.Context(), opts)
+	if err == nil || !strings.Contains(err.Error(), "last status: 503") {
+		t.Fatalf("expected a model-load timeout reporting the last 503, got %v", err)
+	}
+}
+
+func TestGetEndpointModelLoadWaitAcceptsMissingHealthRoute(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented} {
+		var healthHits int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&healthHits, 1)
+			w.WriteHeader(status)
+		}))
+
+		opts := &benchmarkOptions{endpoint: server.URL, modelLoadWait: time.Minute}
+		if _, _, err := getEndpoint(t.Context(), opts); err != nil {
+			t.Errorf("status %d: expected an endpoint without /health to count as ready, got %v", status, err)
+		}
+		if got := atomic.LoadInt64(&healthHits); got != 1 {
+			t.Errorf("status %d: expected a single /health probe, got %d", status, got)
+		}
+		server.Close()
+	}
+}
+
+func TestGetEndpointRejectsRerankMode(t *testing.T) {
+	for _, opts := range []*benchmarkOptions{
+		{endpoint: "http://svc:8080/v1/rerank"},
+		{endpoint: "http://svc:8080", mode: "rerank"},
+	} {
+		if _, _, err := getEndpoint(t.Context(), opts); err == nil || !strings.Contains(err.Error(), "does not support") {
+			t.Errorf("expected a rerank endpoint %q to be rejected, got %v", opts.endpoint, err)
+		}
+	}
+}
+
+func TestGetEndpointSkipsModelLoadWaitWhenDisabled(t *testing.T) {
+	var healthHits int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&healthHits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{endpoint: server.URL}
+	if _, _, err := getEndpoint(t.Context(), opts); err != nil {
+		t.Fatalf("getEndpoint failed: %v", err)
+	}
+	if got := atomic.LoadInt64(&healthHits); got != 0 {
+		t.Errorf("expected no /health polling with --warmup-model-load-wait=0, got %d hits", got)
+	}
+}
+
+func TestFirstTokenProbeMeasuresOnlyFirstToken(t *testing.T) {
+	const tokenDelay = 50 * time.Millisecond
+	var requests atomic.Int32
+	var badRequest atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var req ChatCompletionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/v1/chat/completions" || req.MaxTokens != 1 || !req.Stream {
+			badRequest.Store(fmt.Sprintf("%s max_tokens=%d stream=%v", r.URL.Path, req.MaxTokens, req.Stream))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		flusher.Flush()
+		time.Sleep(tokenDelay)
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hi\"}}]}\n\n")
+		flusher.Flush()
+		// A full generation would keep going; the probe must not wait for it.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{prompt: "Hello", iterations: 3, timeout: 10 * time.Second, maxTokens: 500}
+	start := time.Now()
+	ttfts, failed := runFirstTokenProbes(t.Context(), server.URL, opts)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the probes to stop at the first token, took %s", elapsed)
+	}
+	if bad := badRequest.Load(); bad != nil {
+		t.Fatalf("expected a streamed one-token chat completion, got %v", bad)
+	}
+	if failed != 0 || len(ttfts) != 3 || requests.Load() != 3 {
+		t.Fatalf("expected 3 successful probes, got %d ok, %d failed, %d requests", len(ttfts), failed, requests.Load())
+	}
+	for _, ttft := range ttfts {
+		if ttft < float64(tokenDelay.Milliseconds()) {
+			t.Errorf("expected TTFT to include the wait for the first content token, got %.1fms", ttft)
+		}
+	}
+
+	summary := summarizeFirstTokenProbes(opts, server.URL, ttfts, failed)
+	if summary.SuccessfulRuns != 3 || summary.TTFTP50Ms < summary.TTFTMinMs || summary.TTFTMaxMs < summary.TTFTP99Ms {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe to read while a run is writing to it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestNDJSONStreamEmitsRecordsDuringRun(t *testing.T) {
+	out := &lockedBuffer{}
+	var linesBeforeRequest []int
+	var linesMu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Snapshot what the stream has written before this request is served.
+		linesMu.Lock()
+		linesBeforeRequest = append(linesBeforeRequest, strings.Count(out.String(), "\n"))
+		linesMu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	opts := &benchmarkOptions{
+		name:       "test",
+		prompt:     defaultBenchmarkPrompt,
+		maxTokens:  10,
+		iterations: 3,
+		timeout:    5 * time.Second,
+		output:     outputFormatNDJSON,
+		// A 1ns cadence makes every result followed by an interim summary.
+		stream: newNDJSONStream(out, time.Nanosecond),
+	}
+
+	if err := runBenchmarkContext(t.Context(), opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+
+	// Each request after the first must already see the earlier results
+	// (and their interim summaries) on the stream.
+	if want := []int{0, 2, 4}; fmt.Sprint(linesBeforeRequest) != fmt.Sprint(want) {
+		t.Errorf("lines written before each request = %v, want %v", linesBeforeRequest, want)
+	}
+
+	var types []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var record NDJSONRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", line, err)
+		}
+		if record.Timestamp.IsZero() {
+			t.Errorf("record %q has no timestamp", line)
+		}
+		if record.Type == ndjsonRecordResult && record.Result == nil {
+			t.Errorf("result record without a result: %q", line)
+		}
+		if record.Type != ndjsonRecordResult && record.Summary == nil {
+			t.Errorf("%s record without a summary: %q", record.Type, line)
+		}
+		types = append(types, record.Type)
+	}
+	want := []string{"result", "interim", "result", "interim", "result", "interim", "summary"}
+	if fmt.Sprint(types) != fmt.Sprint(want) {
+		t.Errorf("record types = %v, want %v", types, want)
+	}
+}
+
+func TestMeasurePoolingTypesGroupsByPooling(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EmbeddingRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		sent[req.Pooling]++
+		mu.Unlock()
+		if req.Pooling == "last" {
+			http.Error(w, "pooling type not supported", http.StatusBadRequest)
+			return
+		}
+		resp := EmbeddingResponse{}
+		resp.Data = append(resp.Data, struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		}{Embedding: []float64{0.1, 0.2}})
+		resp.Usage.PromptTokens = 8
+		resp.Usage.TotalTokens = 8
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	pools, err := parsePoolingTypes("mean, CLS,last")
+	if err != nil {
+		t.Fatalf("parsePoolingTypes: %v", err)
+	}
+	opts := &benchmarkOptions{prompt: "Embed me", iterations: 3, timeout: 5 * time.Second, mode: "embedding"}
+	results := measurePoolingTypes(t.Context(), server.URL, opts, pools)
+
+	if fmt.Sprint(sent) != "map[cls:3 last:3 mean:3]" {
+		t.Errorf("pooling sent per request = %v, want 3 each for mean, cls, last", sent)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected one result per pooling type, got %d", len(results))
+	}
+	for i, want := range []struct {
+		pool      string
+		succeeded int
+	}{{"mean", 3}, {"cls", 3}, {"last", 0}} {
+		r := results[i]
+		if r.Pooling != want.pool || r.SuccessfulRuns != want.succeeded || r.SuccessfulRuns+r.FailedRuns != 3 {
+			t.Errorf("results[%d] = %+v, want %s with %d/3 successful", i, r, want.pool, want.succeeded)
+		}
+	}
+	if results[0].PromptTokens != 8 {
+		t.Errorf("mean pooling prompt tokens = %.0f, want 8", results[0].PromptTokens)
+	}
+	if opts.pooling != "" {
+		t.Errorf("measuring must not leave a pooling type on the caller's options, got %q", opts.pooling)
+	}
+
+	for _, bad := range []string{"max", "mean,mean", " , "} {
+		if _, err := parsePoolingTypes(bad); err == nil {
+			t.Errorf("expected --pool %q to be rejected", bad)
+		}
+	}
+}
+
+func TestInterTokenGaps(t *testing.T) {
+	start := time.Now()
+	arrivals := []time.Time{start, start.Add(10 * time.Millisecond), start.Add(25 * time.Millisecond), start.Add(125 * time.Millisecond)}
+	got := interTokenGaps(arrivals)
+	want := []float64{10, 15, 100}
+	if len(got) != len(want) {
+		t.Fatalf("interTokenGaps() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("gap %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if gaps := interTokenGaps(arrivals[:1]); gaps != nil {
+		t.Errorf("interTokenGaps() of one token = %v, want none", gaps)
+	}
+}
+
+func TestStreamingBenchmarkRecordsInterTokenLatency(t *testing.T) {
+	// Eight tokens 10ms apart with a 150ms stall after the fourth, the kind
+	// of hiccup a mean tok/s hides.
+	delays := []time.Duration{0, 10, 10, 10, 150, 10, 10, 10}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Errorf("expected a streamed request with include_usage, got %+v", req)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		flusher.Flush()
+		for _, d := range delays {
+			time.Sleep(d * time.Millisecond)
+			_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"tok\"}}]}\n\n")
+			flusher.Flush()
+		}
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{},\"finish_reason\":\"length\"}]}\n\n")
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":8,\"total_tokens\":20}}\n\n")
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{maxTokens: 8, streaming: true, timeout: 10 * time.Second}
+	result, err := sendBenchmarkRequestWithPrompt(context.Background(), server.URL, opts, 1, "hi")
+	if err != nil {
+		t.Fatalf("sendBenchmarkRequestWithPrompt() error: %v", err)
+	}
+	if result.PromptTokens != 12 || result.CompletionTokens != 8 {
+		t.Errorf("usage = %d prompt / %d completion tokens, want 12 / 8", result.PromptTokens, result.CompletionTokens)
+	}
+	if len(result.InterTokenGapsMs) != 7 {
+		t.Fatalf("recorded %d gaps, want 7: %v", len(result.InterTokenGapsMs), result.InterTokenGapsMs)
+	}
+	if result.InterTokenP99Ms < 140 {
+		t.Errorf("InterTokenP99Ms = %.1f, want the 150ms stall to surface", result.InterTokenP99Ms)
+	}
+	if result.InterTokenMeanMs < 20 || result.InterTokenMeanMs > 100 {
+		t.Errorf("InterTokenMeanMs = %.1f, want about (6×10 + 150) / 7", result.InterTokenMeanMs)
+	}
+
+	summary := calculateSummary(opts, server.URL, []BenchmarkResult{result, result}, time.Now())
+	if summary.InterTokenMax < 150 || summary.InterTokenP95 < 140 || math.Abs(summary.InterTokenMean-result.InterTokenMeanMs) > 0.01 {
+		t.Errorf("summary inter-token latency = mean %.1f / P95 %.1f / max %.1f, want the per-request figures pooled",
+			summary.InterTokenMean, summary.InterTokenP95, summary.InterTokenMax)
+	}
+}
+
+// parseInfluxLine splits a line protocol point into its measurement, tags,
+// fields and timestamp, honoring backslash escapes and quoted string fields.
+func parseInfluxLine(t *testing.T, line string) (string, map[string]string, map[string]string, int64) {
+	t.Helper()
+	var sections []string
+	var cur strings.Builder
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			cur.WriteByte(c)
+			cur.WriteByte(line[i+1])
+			i++
+		case c == '"':
+			inQuotes = !inQuotes
+			cur.WriteByte(c)
+		case c == ' ' && !inQuotes:
+			sections = append(sections, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	sections = append(sections, cur.String())
+	if len(sections) != 3 {
+		t.Fatalf("line %q has %d space-separated sections, want 3", line, len(sections))
+	}
+
+	splitUnescaped := func(s string, sep byte) []string {
+		var parts []string
+		start, quoted := 0, false
+		for i := 0; i < len(s); i++ {
+			switch {
+			case s[i] == '\\':
+				i++
+			case s[i] == '"':
+				quoted = !quoted
+			case s[i] == sep && !quoted:
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+		return append(parts, s[start:])
+	}
+	unescape := strings.NewReplacer(`\,`, `,`, `\=`, `=`, `\ `, ` `)
+
+	keyValues := func(parts []string) map[string]string {
+		m := make(map[string]string, len(parts))
+		for _, part := range parts {
+			kv := splitUnescaped(part, '=')
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				t.Fatalf("line %q has malformed key=value %q", line, part)
+			}
+			m[unescape.Replace(kv[0])] = unescape.Replace(kv[1])
+		}
+		return m
+	}
+
+	head := splitUnescaped(sections[0], ',')
+	fields := keyValues(splitUnescaped(sections[1], ','))
+	for k, v := range fields {
+		if strings.HasPrefix(v, `"`) {
+			continue
+		}
+		if _, err := strconv.ParseFloat(strings.TrimSuffix(v, "i"), 64); err != nil {
+			t.Fatalf("field %s=%s is not a valid number: %v", k, v, err)
+		}
+	}
+	ts, err := strconv.ParseInt(sections[2], 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp %q is not an integer: %v", sections[2], err)
+	}
+	return head[0], keyValues(head[1:]), fields, ts
+}
+
+func TestInfluxLinesParseAsLineProtocol(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	summary := BenchmarkSummary{
+		ServiceName:              "my llm",
+		Namespace:                "team,a",
+		SuccessfulRuns:           9,
+		FailedRuns:               1,
+		LatencyP50:               120.5,
+		LatencyP95:               180,
+		LatencyP99:               210.25,
+		GenerationToksPerSecMean: 42.5,
+		InterTokenMean:           20,
+		InterTokenP99:            35,
+		Timestamp:                ts,
+	}
+
+	measurement, tags, fields, gotTS := parseInfluxLine(t, benchmarkInfluxLine(summary))
+	if measurement != influxMeasurement {
+		t.Errorf("measurement = %q, want %q", measurement, influxMeasurement)
+	}
+	if tags["service"] != "my llm" || tags["namespace"] != "team,a" || tags["run"] != "benchmark" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	for key, want := range map[string]string{
+		"gen_tok_s": "42.5", "p99_ms": "210.25", "successful_runs": "9i", "failed_runs": "1i", "inter_token_p99_ms": "35",
+	} {
+		if fields[key] != want {
+			t.Errorf("field %s = %q, want %q", key, fields[key], want)
+		}
+	}
+	if gotTS != ts.UnixNano() {
+		t.Errorf("timestamp = %d, want %d", gotTS, ts.UnixNano())
+	}
+
+	stress := StressTestSummary{BenchmarkSummary: summary, Concurrency: 4, RequestsPerSec: 3.5, ErrorRate: 10}
+	_, tags, fields, _ = parseInfluxLine(t, stressInfluxLine(stress))
+	if tags["run"] != "stress" || fields["concurrency"] != "4i" || fields["req_s"] != "3.5" {
+		t.Errorf("unexpected stress point tags %v fields %v", tags, fields)
+	}
+
+	report := ComparisonReport{
+		Timestamp: ts,
+		Models: []ModelBenchmark{
+			{ModelID: "llama-3.2-3b", Status: statusSuccess, GenerationToksPerSec: 80, LatencyP99Ms: 90},
+			{ModelID: "phi-4-mini", Status: statusFailed, Error: "deployment failed"},
+		},
+	}
+	lines := comparisonInfluxLines(report)
+	if len(lines) != 2 {
+		t.Fatalf("got %d comparison lines, want 2", len(lines))
+	}
+	_, tags, fields, _ = parseInfluxLine(t, lines[0])
+	if tags["model"] != "llama-3.2-3b" || fields["gen_tok_s"] != "80" || fields["success"] != "1i" {
+		t.Errorf("unexpected model point tags %v fields %v", tags, fields)
+	}
+	_, tags, fields, _ = parseInfluxLine(t, lines[1])
+	if tags["status"] != statusFailed || fields["success"] != "0i" {
+		t.Errorf("unexpected failed model point tags %v fields %v", tags, fields)
+	}
+	if _, ok := fields["gen_tok_s"]; ok {
+		t.Errorf("failed model point should carry no metrics, got %v", fields)
+	}
+}
+
+func TestRecordInfluxLinesAppendsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.lp")
+	var out bytes.Buffer
+	opts := &benchmarkOptions{output: outputFormatInflux, influxFile: path, influxOut: &out}
+
+	for range 2 {
+		if err := recordInfluxLines([]string{"llmkube_benchmark,run=benchmark gen_tok_s=1 1"}, opts); err != nil {
+			t.Fatalf("recordInfluxLines: %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), "\n"); got != 2 {
+		t.Errorf("influx file has %d lines, want 2 appended lines", got)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 2 {
+		t.Errorf("stdout got %d lines, want 2", got)
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	results := []BenchmarkResult{
+		{Iteration: 1, PromptTokens: 12, CompletionTokens: 50, TotalTokens: 62,
+			PromptTimeMs: 20.5, GenerationTimeMs: 1000, TotalTimeMs: 1020.5,
+			PromptToksPerSec: 585.4, GenerationToksPerSec: 50},
+		{Iteration: 2, Error: "request failed: timeout, retrying"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeResultsCSV(&buf, results); err != nil {
+		t.Fatalf("writeResultsCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header + 2 rows:\n%s", len(lines), buf.String())
+	}
+	wantHeader := "iteration,prompt_tokens,completion_tokens,total_tokens,prompt_time_ms,generation_time_ms," +
+		"total_time_ms,prompt_tokens_per_sec,generation_tokens_per_sec," +
+		"inter_token_mean_ms,inter_token_p95_ms,inter_token_p99_ms,error"
+	if lines[0] != wantHeader {
+		t.Errorf("header = %q, want %q", lines[0], wantHeader)
+	}
+	if want := "1,12,50,62,20.5,1000,1020.5,585.4,50,0,0,0,"; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+	if want := `2,0,0,0,0,0,0,0,0,0,0,0,"request failed: timeout, retrying"`; lines[2] != want {
+		t.Errorf("error row = %q, want %q", lines[2], want)
+	}
+}
+
+func TestWriteComparisonCSV(t *testing.T) {
+	report := ComparisonReport{
+		IsStressTest: true,
+		Models: []ModelBenchmark{
+			{ModelID: "llama-3.2-3b", ModelName: "Llama 3.2 3B", ModelSize: "3B", Status: statusSuccess,
+				GenerationToksPerSec: 80.5, PromptToksPerSec: 900, LatencyP50Ms: 600, LatencyP99Ms: 750,
+				VRAMEstimate: "4GB", TotalRequests: 120, RequestsPerSec: 2, ErrorRate: 0.5},
+			{ModelID: "phi-4-mini", ModelName: "Phi 4 Mini", ModelSize: "3.8B", Status: statusFailed,
+				VRAMEstimate: "5GB", Error: "deployment timeout"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeComparisonCSV(&buf, report); err != nil {
+		t.Fatalf("writeComparisonCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header + 2 rows:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "model_id,model_name,model_size,status,generation_toks_per_sec,") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if want := "llama-3.2-3b,Llama 3.2 3B,3B,success,80.5,900,600,750,4GB,120,2,0.5,"; lines[1] != want {
+		t.Errorf("row = %q, want %q", lines[1], want)
+	}
+	if want := "phi-4-mini,Phi 4 Mini,3.8B,failed,,,,,5GB,,,,deployment timeout"; lines[2] != want {
+		t.Errorf("failed row = %q, want %q", lines[2], want)
+	}
+}
+
+func TestOutputFileWritesJSONResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	// A directory that does not exist yet, with a trailing slash, gets a
+	// timestamped file created inside it.
+	dir := filepath.Join(t.TempDir(), "ci", "results") + string(filepath.Separator)
+	opts := &benchmarkOptions{
+		name:       "test",
+		prompt:     defaultBenchmarkPrompt,
+		maxTokens:  10,
+		iterations: 2,
+		timeout:    5 * time.Second,
+		output:     outputFormatJSON,
+		outputFile: dir,
+	}
+	if err := runBenchmarkContext(t.Context(), opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "benchmark-*.json"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one timestamped JSON file in %s, got %v (%v)", dir, matches, err)
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary BenchmarkSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("output file is not valid JSON: %v\n%s", err, data)
+	}
+	if summary.ServiceName != "test" || summary.SuccessfulRuns != 2 {
+		t.Errorf("unexpected summary: service=%q successful=%d", summary.ServiceName, summary.SuccessfulRuns)
+	}
+}
+
+func TestResolveOutputFilePath(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	root := t.TempDir()
+
+	path, err := resolveOutputFilePath(filepath.Join(root, "nested", "out.json"), outputFormatJSON, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "nested", "out.json"); path != want {
+		t.Errorf("file path = %q, want %q", path, want)
+	}
+	if info, err := os.Stat(filepath.Join(root, "nested")); err != nil || !info.IsDir() {
+		t.Errorf("parent directory was not created: %v", err)
+	}
+
+	path, err = resolveOutputFilePath(root, outputFormatCSV, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "benchmark-20260304-050607.csv"); path != want {
+		t.Errorf("directory path = %q, want %q", path, want)
+	}
+}
+
+func TestSeedPerIterationIsBasePlusIteration(t *testing.T) {
+	var mu sync.Mutex
+	var seeds []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Seed == nil {
+			http.Error(w, "missing seed", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		seeds = append(seeds, *req.Seed)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	run := func() []int64 {
+		mu.Lock()
+		seeds = nil
+		mu.Unlock()
+		opts := &benchmarkOptions{
+			name:             "test",
+			prompt:           defaultBenchmarkPrompt,
+			maxTokens:        10,
+			iterations:       3,
+			timeout:          5 * time.Second,
+			output:           outputFormatJSON,
+			seed:             100,
+			seedPerIteration: true,
+		}
+		if err := runBenchmarkContext(t.Context(), opts); err != nil {
+			t.Fatalf("runBenchmarkContext failed: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int64(nil), seeds...)
+	}
+
+	first := run()
+	if want := []int64{101, 102, 103}; !slices.Equal(first, want) {
+		t.Fatalf("seeds = %v, want %v", first, want)
+	}
+	if second := run(); !slices.Equal(second, first) {
+		t.Errorf("repeated run sent seeds %v, first run sent %v", second, first)
+	}
+}
+
+func TestRequestSeed(t *testing.T) {
+	if seed := requestSeed(&benchmarkOptions{}, 3); seed != nil {
+		t.Errorf("no seed flags: got %d, want nil", *seed)
+	}
+	if seed := requestSeed(&benchmarkOptions{seed: 7, seedSet: true}, 3); seed == nil || *seed != 7 {
+		t.Errorf("--seed 7: got %v, want 7", seed)
+	}
+	if seed := requestSeed(&benchmarkOptions{seed: 7, seedPerIteration: true}, 3); seed == nil || *seed != 10 {
+		t.Errorf("--seed 7 --seed-per-iteration, iteration 3: got %v, want 10", seed)
+	}
+}
+
+func writeJSONFile(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckBenchmarkRegression(t *testing.T) {
+	path := writeJSONFile(t, BenchmarkSummary{
+		ServiceName:              "my-llm",
+		Namespace:                "default",
+		GenerationToksPerSecMean: 100,
+		LatencyP99:               400,
+	})
+
+	testCases := []struct {
+		name        string
+		genToks     float64
+		p99         float64
+		wantFailure bool
+	}{
+		{name: "pass within threshold", genToks: 95, p99: 420},
+		{name: "throughput regressed", genToks: 85, p99: 400, wantFailure: true},
+		{name: "latency regressed", genToks: 100, p99: 460, wantFailure: true},
+		{name: "improved", genToks: 130, p99: 300},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &benchmarkOptions{baseline: path, baselineSet: true, regressionThreshold: defaultRegressionThreshold}
+			current := BenchmarkSummary{
+				ServiceName:              "my-llm",
+				Namespace:                "default",
+				GenerationToksPerSecMean: tc.genToks,
+				LatencyP99:               tc.p99,
+			}
+			err := checkBenchmarkRegression(current, opts)
+			if (err != nil) != tc.wantFailure {
+				t.Errorf("checkBenchmarkRegression() error = %v, want failure %v", err, tc.wantFailure)
+			}
+		})
+	}
+
+	// Without an explicit --baseline the gate is off.
+	if err := checkBenchmarkRegression(BenchmarkSummary{}, &benchmarkOptions{baseline: baselineLatest}); err != nil {
+		t.Errorf("gate without --baseline: %v", err)
+	}
+}
+
+func TestCheckComparisonRegression(t *testing.T) {
+	path := writeJSONFile(t, ComparisonReport{Models: []ModelBenchmark{
+		{ModelID: "llama-3.1-8b", Status: statusSuccess, GenerationToksPerSec: 100, LatencyP99Ms: 400},
+		{ModelID: "qwen-2.5-7b", Status: statusSuccess, GenerationToksPerSec: 80, LatencyP99Ms: 500},
+		{ModelID: "phi-3-mini", Status: "failed"},
+	}})
+	opts := &benchmarkOptions{baseline: path, baselineSet: true, regressionThreshold: defaultRegressionThreshold}
+
+	improved := ComparisonReport{Models: []ModelBenchmark{
+		{ModelID: "llama-3.1-8b", Status: statusSuccess, GenerationToksPerSec: 110, LatencyP99Ms: 380},
+		{ModelID: "qwen-2.5-7b", Status: statusSuccess, GenerationToksPerSec: 78, LatencyP99Ms: 510},
+		{ModelID: "phi-3-mini", Status: statusSuccess, GenerationToksPerSec: 5, LatencyP99Ms: 9000},
+	}}
+	if err := checkComparisonRegression(improved, opts); err != nil {
+		t.Errorf("improved comparison should pass, got %v", err)
+	}
+
+	regressed := ComparisonReport{Models: []ModelBenchmark{
+		{ModelID: "llama-3.1-8b", Status: statusSuccess, GenerationToksPerSec: 100, LatencyP99Ms: 400},
+		{ModelID: "qwen-2.5-7b", Status: statusSuccess, GenerationToksPerSec: 60, LatencyP99Ms: 500},
+	}}
+	if err := checkComparisonRegression(regressed, opts); err == nil {
+		t.Error("qwen-2.5-7b dropped 25% generation tok/s; expected a regression")
+	}
+
+	failed := ComparisonReport{Models: []ModelBenchmark{
+		{ModelID: "llama-3.1-8b", Status: "failed"},
+	}}
+	if err := checkComparisonRegression(failed, opts); err == nil {
+		t.Error("a model that succeeded in the baseline and failed now should regress")
+	}
+
+	// A single-service baseline cannot gate a catalog comparison.
+	opts.baseline = writeJSONFile(t, BenchmarkSummary{ServiceName: "my-llm"})
+	if err := checkComparisonRegression(improved, opts); err == nil {
+		t.Error("expected an error for a single-service baseline")
+	}
+}
+
+func TestRequestHeadersReachServer(t *testing.T) {
+	var mu sync.Mutex
+	var unauthorized int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" || r.Header.Get("X-Tenant") != "team-a" {
+			mu.Lock()
+			unauthorized++
+			mu.Unlock()
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	headers, err := parseRequestHeaders([]string{"X-Tenant: team-a"}, "s3cret")
+	if err != nil {
+		t.Fatalf("parseRequestHeaders() error = %v", err)
+	}
+
+	if err := waitForModelLoad(t.Context(), server.URL, headers, 5*time.Second); err != nil {
+		t.Fatalf("health check did not carry the headers: %v", err)
+	}
+
+	opts := &benchmarkOptions{
+		name:           "test",
+		prompt:         defaultBenchmarkPrompt,
+		maxTokens:      10,
+		timeout:        5 * time.Second,
+		requestHeaders: headers,
+	}
+	if _, err := sendBenchmarkRequest(t.Context(), server.URL, opts, 1); err != nil {
+		t.Errorf("sendBenchmarkRequest() error = %v", err)
+	}
+	if _, err := sendBenchmarkRequestWithPrompt(t.Context(), server.URL, opts, 2, "hello"); err != nil {
+		t.Errorf("sendBenchmarkRequestWithPrompt() error = %v", err)
+	}
+	// The mock does not speak SSE, so only whether the streamed request was
+	// authorized matters here.
+	opts.streaming = true
+	_, _ = sendBenchmarkRequestWithPrompt(t.Context(), server.URL, opts, 3, "hello")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if unauthorized != 0 {
+		t.Errorf("%d request(s) reached the server without the configured headers", unauthorized)
+	}
+}
+
+func TestParseRequestHeaders(t *testing.T) {
+	if h, err := parseRequestHeaders(nil, ""); err != nil || h != nil {
+		t.Errorf("no flags: got %v, %v; want nil, nil", h, err)
+	}
+	if _, err := parseRequestHeaders([]string{"no-colon"}, ""); err == nil {
+		t.Error("expected an error for a header without a colon")
+	}
+	if _, err := parseRequestHeaders([]string{"Authorization: Basic abc"}, "token"); err == nil {
+		t.Error("expected an error for --bearer-token with an Authorization --header")
+	}
+	h, err := parseRequestHeaders([]string{"X-Api-Key:  abc:def "}, "")
+	if err != nil || h.Get("X-Api-Key") != "abc:def" {
+		t.Errorf("got %v, %v; want X-Api-Key=abc:def", h, err)
+	}
+}
+
+func TestFlagUnderperformingModels(t *testing.T) {
+	models := []ModelBenchmark{
+		// ~6.5 GB at 60 tok/s and ~20 GB at 20 tok/s: both ~400 GB/s.
+		{ModelID: "llama-3.1-8b", ModelSize: "8B", VRAMEstimate: "5-8GB", Status: statusSuccess, GenerationToksPerSec: 60},
+		{ModelID: "qwen-2.5-32b", ModelSize: "32B", VRAMEstimate: "18-24GB", Status: statusSuccess, GenerationToksPerSec: 20},
+		// A 3B model at 8 tok/s streams ~24 GB/s: layers are on the CPU.
+		{ModelID: "llama-3.2-3b", ModelSize: "3B", VRAMEstimate: "2-4GB", Status: statusSuccess, GenerationToksPerSec: 8},
+		{ModelID: "phi-4-mini", ModelSize: "3.8B", VRAMEstimate: "2-4GB", Status: statusFailed},
+	}
+	flagUnderperformingModels(models)
+
+	for _, m := range models {
+		flagged := m.PerformanceWarning != ""
+		if want := m.ModelID == "llama-3.2-3b"; flagged != want {
+			t.Errorf("%s flagged = %v, want %v (warning %q)", m.ModelID, flagged, want, m.PerformanceWarning)
+		}
+	}
+	if w := models[2].PerformanceWarning; !strings.Contains(w, "3B") || !strings.Contains(w, "--gpu-layers") {
+		t.Errorf("warning should name the size and the likely fix, got %q", w)
+	}
+
+	single := []ModelBenchmark{{ModelID: "llama-3.2-3b", VRAMEstimate: "2-4GB", Status: statusSuccess, GenerationToksPerSec: 1}}
+	flagUnderperformingModels(single)
+	if single[0].PerformanceWarning != "" {
+		t.Errorf("a model without peers should not be flagged, got %q", single[0].PerformanceWarning)
+	}
+}
+
+func TestParseVRAMEstimateGB(t *testing.T) {
+	cases := map[string]float64{"5-8GB": 6.5, "24GB": 24, " 2-4gb ": 3}
+	for in, want := range cases {
+		if got, ok := parseVRAMEstimateGB(in); !ok || got != want {
+			t.Errorf("parseVRAMEstimateGB(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "unknown", "8-5GB"} {
+		if _, ok := parseVRAMEstimateGB(in); ok {
+			t.Errorf("parseVRAMEstimateGB(%q) should fail", in)
+		}
+	}
+}
+
+func TestWarnOnTokenMismatch(t *testing.T) {
+	models := []ModelBenchmark{
+		{ModelID: "llama-3.1-8b", Status: statusSuccess, GenerationToksPerSec: 60, PromptTokens: 40},
+		{ModelID: "qwen-2.5-7b", Status: statusSuccess, GenerationToksPerSec: 62, PromptTokens: 44},
+		{ModelID: "gemma-2-9b", Status: statusSuccess, GenerationToksPerSec: 75, PromptTokens: 58},
+		{ModelID: "phi-4-mini", Status: statusFailed},
+	}
+	flagTokenMismatch(models)
+
+	for _, m := range models {
+		flagged := m.TokenizationWarning != ""
+		if want := m.ModelID == "gemma-2-9b"; flagged != want {
+			t.Errorf("%s flagged = %v, want %v (warning %q)", m.ModelID, flagged, want, m.TokenizationWarning)
+		}
+	}
+	if w := models[2].TokenizationWarning; !strings.Contains(w, "58 tokens") || !strings.Contains(w, "llama-3.1-8b (40)") {
+		t.Errorf("warning should give both token counts, got %q", w)
+	}
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	_ = outputComparisonTable(ComparisonReport{Models: models})
+	_ = w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	if output := buf.String(); !strings.Contains(output, "Tokenization differs") ||
+		!strings.Contains(output, "gemma-2-9b: tokenizes the benchmark prompt") {
+		t.Errorf("comparison table is missing the tokenization warning:\n%s", output)
+	}
+
+	single := []ModelBenchmark{{ModelID: "gemma-2-9b", Status: statusSuccess, PromptTokens: 58}}
+	flagTokenMismatch(single)
+	if single[0].TokenizationWarning != "" {
+		t.Errorf("a model without peers should not be flagged, got %q", single[0].TokenizationWarning)
+	}
+}
+
+func TestSamplingParamsInRequestBody(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	opts := &benchmarkOptions{
+		prompt:      defaultBenchmarkPrompt,
+		maxTokens:   10,
+		timeout:     5 * time.Second,
+		temperature: 0,
+		topP:        0.9,
+		topK:        40,
+		seed:        1234,
+		seedSet:     true,
+	}
+	if _, err := sendBenchmarkRequest(t.Context(), server.URL, opts, 1); err != nil {
+		t.Fatalf("sendBenchmarkRequest() error = %v", err)
+	}
+
+	want := map[string]float64{"temperature": 0, "top_p": 0.9, "top_k": 40, "seed": 1234}
+	for key, v := range want {
+		got, ok := body[key].(float64)
+		if !ok || got != v {
+			t.Errorf("request body %s = %v, want %v", key, body[key], v)
+		}
+	}
+
+	// Unset top-p/top-k leave the server's defaults in place.
+	opts.topP, opts.topK = 0, 0
+	if _, err := sendBenchmarkRequest(t.Context(), server.URL, opts, 1); err != nil {
+		t.Fatalf("sendBenchmarkRequest() error = %v", err)
+	}
+	for _, key := range []string{"top_p", "top_k"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("request body should omit %s when unset, got %v", key, body[key])
+		}
+	}
+}
+
+func TestGPUMetricsURLReportsUtilization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	// Two GPUs on a dcgm-exporter: the busiest GPU's utilization and the
+	// summed framebuffer are reported.
+	metrics := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write([]byte(`# HELP DCGM_FI_DEV_GPU_UTIL GPU utilization (in %).
+# TYPE DCGM_FI_DEV_GPU_UTIL gauge
+DCGM_FI_DEV_GPU_UTIL{gpu="0",UUID="GPU-a",modelName="NVIDIA L4",Hostname="node 1"} 87
+DCGM_FI_DEV_GPU_UTIL{gpu="1",UUID="GPU-b",modelName="NVIDIA L4",Hostname="node 1"} 41
+# TYPE DCGM_FI_DEV_FB_USED gauge
+DCGM_FI_DEV_FB_USED{gpu="0",UUID="GPU-a"} 12000
+DCGM_FI_DEV_FB_USED{gpu="1",UUID="GPU-b"} 9000
+# TYPE DCGM_FI_DEV_FB_FREE gauge
+DCGM_FI_DEV_FB_FREE{gpu="0",UUID="GPU-a"} 10000
+DCGM_FI_DEV_FB_FREE{gpu="1",UUID="GPU-b"} 13000
+`))
+	}))
+	defer metrics.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	outputFile := filepath.Join(t.TempDir(), "result.json")
+	opts := &benchmarkOptions{
+		name:          "test",
+		prompt:        defaultBenchmarkPrompt,
+		maxTokens:     10,
+		iterations:    2,
+		timeout:       5 * time.Second,
+		output:        outputFormatJSON,
+		outputFile:    outputFile,
+		gpuMetricsURL: metrics.URL + "/metrics",
+	}
+	if err := runBenchmarkContext(t.Context(), opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+	if opts.gpuMetrics != nil {
+		t.Error("GPU metrics poller still set after the run")
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary BenchmarkSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("output file is not valid JSON: %v\n%s", err, data)
+	}
+	if summary.GPUSamples == 0 {
+		t.Fatalf("no GPU samples recorded:\n%s", data)
+	}
+	if summary.GPUUtilMean != 87 || summary.GPUUtilPeak != 87 {
+		t.Errorf("GPU utilization mean/peak = %v/%v, want 87/87", summary.GPUUtilMean, summary.GPUUtilPeak)
+	}
+	if summary.GPUMemoryUsedMeanMB != 21000 || summary.GPUMemoryUsedPeakMB != 21000 || summary.GPUMemoryTotalMB != 44000 {
+		t.Errorf("GPU memory mean/peak/total = %v/%v/%v, want 21000/21000/44000",
+			summary.GPUMemoryUsedMeanMB, summary.GPUMemoryUsedPeakMB, summary.GPUMemoryTotalMB)
+	}
+}
+
+func TestParseGPUMetricsExposition(t *testing.T) {
+	// nvidia_gpu_exporter reports a 0-1 ratio and bytes.
+	metric, err := parseGPUMetricsExposition(`# TYPE nvidia_smi_utilization_gpu_ratio gauge
+nvidia_smi_utilization_gpu_ratio{uuid="a"} 0.5
+nvidia_smi_memory_used_bytes{uuid="a"} 2.147483648e+09
+nvidia_smi_memory_total_bytes{uuid="a"} 8.589934592e+09
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metric.UtilPercent != 50 || metric.MemoryUsedMB != 2048 || metric.MemoryTotalMB != 8192 {
+		t.Errorf("got util=%d used=%d total=%d, want 50/2048/8192",
+			metric.UtilPercent, metric.MemoryUsedMB, metric.MemoryTotalMB)
+	}
+
+	// A similarly prefixed metric is not mistaken for the utilization one.
+	if _, err := parseGPUMetricsExposition("DCGM_FI_DEV_GPU_UTIL_TOTAL 10\n"); err == nil {
+		t.Error("expected an error for output without GPU utilization")
+	}
+}
+
+func TestExportCSVPerIterationWritesEveryResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		for range 3 {
+			time.Sleep(5 * time.Millisecond)
+			_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"tok\"}}]}\n\n")
+			flusher.Flush()
+		}
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{},\"finish_reason\":\"length\"}],"+
+			"\"timings\":{\"prompt_n\":5,\"prompt_ms\":4,\"prompt_per_second\":1250,"+
+			"\"predicted_n\":3,\"predicted_ms\":10,\"predicted_per_second\":300}}\n\n")
+		_, _ = fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":3,\"total_tokens\":8}}\n\n")
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	path := filepath.Join(t.TempDir(), "iterations.csv")
+	opts := &benchmarkOptions{
+		name:                  "test",
+		prompt:                defaultBenchmarkPrompt,
+		maxTokens:             3,
+		iterations:            3,
+		timeout:               5 * time.Second,
+		output:                outputFormatJSON,
+		outputFile:            filepath.Join(t.TempDir(), "summary.json"),
+		streaming:             true,
+		exportCSVPerIteration: path,
+	}
+	if err := runBenchmarkContext(t.Context(), opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("per-iteration CSV does not parse: %v", err)
+	}
+	if !slices.Equal(rows[0], perIterationCSVHeader) {
+		t.Fatalf("header = %v, want %v", rows[0], perIterationCSVHeader)
+	}
+	if len(rows)-1 != opts.iterations {
+		t.Fatalf("got %d data rows, want one per iteration (%d)", len(rows)-1, opts.iterations)
+	}
+	for i, row := range rows[1:] {
+		if row[0] != strconv.Itoa(i+1) {
+			t.Errorf("row %d: iteration = %q, want %d", i, row[0], i+1)
+		}
+		// Every column but the error ones is populated on a successful
+		// streamed request.
+		for j, col := range perIterationCSVHeader {
+			if col == "schema_violations" || col == "error" {
+				if row[j] != "" {
+					t.Errorf("row %d: %s = %q, want empty", i, col, row[j])
+				}
+				continue
+			}
+			if row[j] == "" {
+				t.Errorf("row %d: %s is empty", i, col)
+			}
+		}
+	}
+}
+
+func TestCalculateLatencyBreakdown(t *testing.T) {
+	results := []BenchmarkResult{
+		// 100 ms queued behind other requests
+		{TotalTimeMs: 400, PromptTimeMs: 100, GenerationTimeMs: 200, ServerTimings: true},
+		// Millisecond truncation leaves the total just under the server's sum.
+		{TotalTimeMs: 299, PromptTimeMs: 100.4, GenerationTimeMs: 199.2, ServerTimings: true},
+		// No server timings, failed: both ignored
+		{TotalTimeMs: 900, GenerationTimeMs: 900},
+		{TotalTimeMs: 50, PromptTimeMs: 10, GenerationTimeMs: 10, ServerTimings: true, Error: "HTTP 503"},
+	}
+
+	b := calculateLatencyBreakdown(results)
+	if b == nil {
+		t.Fatal("no breakdown computed")
+	}
+	if b.Samples != 2 {
+		t.Errorf("samples = %d, want 2", b.Samples)
+	}
+	if b.QueueMeanMs != 50 || b.QueueP95Ms != 95 {
+		t.Errorf("queue mean/P95 = %v/%v, want 50/95", b.QueueMeanMs, b.QueueP95Ms)
+	}
+	if math.Abs(b.PrefillMeanMs-100.2) > 1e-9 || math.Abs(b.DecodeMeanMs-199.6) > 1e-9 {
+		t.Errorf("prefill/decode mean = %v/%v, want 100.2/199.6", b.PrefillMeanMs, b.DecodeMeanMs)
+	}
+	if math.Abs(b.QueueShare-50/349.5) > 1e-9 {
+		t.Errorf("queue share = %v, want %v", b.QueueShare, 50/349.5)
+	}
+	if got := latencyBreakdownVerdict(b); !strings.HasPrefix(got, "compute-bound in decode") {
+		t.Errorf("verdict = %q, want compute-bound in decode", got)
+	}
+	b.QueueShare = queueBoundShare
+	if got := latencyBreakdownVerdict(b); !strings.HasPrefix(got, "saturated") {
+		t.Errorf("verdict = %q, want saturated", got)
+	}
+
+	if b := calculateLatencyBreakdown(results[2:3]); b != nil {
+		t.Errorf("breakdown without server timings = %+v, want nil", b)
+	}
+}
+
+func TestLatencyBreakdownFromServerTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server accounts for 30 ms; the rest of the sleep is queueing.
+		time.Sleep(80 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"usage":{"prompt_tokens":5,"completion_tokens":10,"total_tokens":15},` +
+			`"timings":{"prompt_n":5,"prompt_ms":10,"predicted_n":10,"predicted_ms":20}}`))
+	}))
+	defer server.Close()
+
+	oldEndpoint := benchmarkEndpoint
+	benchmarkEndpoint = func(context.Context, *benchmarkOptions) (string, func(), error) {
+		return server.URL, nil, nil
+	}
+	defer func() { benchmarkEndpoint = oldEndpoint }()
+
+	outputFile := filepath.Join(t.TempDir(), "result.json")
+	opts := &benchmarkOptions{
+		name:             "test",
+		prompt:           defaultBenchmarkPrompt,
+		maxTokens:        10,
+		iterations:       2,
+		timeout:          5 * time.Second,
+		output:           outputFormatJSON,
+		outputFile:       outputFile,
+		latencyBreakdown: true,
+	}
+	if err := runBenchmarkContext(t.Context(), opts); err != nil {
+		t.Fatalf("runBenchmarkContext failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary BenchmarkSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("output file is not valid JSON: %v\n%s", err, data)
+	}
+	b := summary.LatencyBreakdown
+	if b == nil {
+		t.Fatalf("no latency breakdown in the summary:\n%s", data)
+	}
+	if b.Samples != 2 || b.PrefillMeanMs != 10 || b.DecodeMeanMs != 20 {
+		t.Errorf("samples/prefill/decode = %d/%v/%v, want 2/10/20", b.Samples, b.PrefillMeanMs, b.DecodeMeanMs)
+	}
+	if b.QueueMeanMs < 50 {
+		t.Errorf("queue mean = %v ms, want at least the 50 ms the server did not account for", b.QueueMeanMs)
+	}
+}
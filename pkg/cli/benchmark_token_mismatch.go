@@ -0,0 +1,61 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import "fmt"
+
+// --warn-on-token-mismatch: every catalog model is sent the same prompt,
+// but each tokenizer splits it differently. A model that needs many more
+// tokens for the same text generates shorter tokens too, so its tok/s
+// overstates its speed next to the others.
+
+// tokenMismatchRatio is how many times the fewest prompt tokens in the run
+// a model may need before it is flagged.
+const tokenMismatchRatio = 1.2
+
+// flagTokenMismatch sets TokenizationWarning on every successful model whose
+// prompt token count exceeds the run's smallest by more than
+// tokenMismatchRatio. It needs at least two models that reported a count.
+func flagTokenMismatch(models []ModelBenchmark) {
+	fewest := -1
+	for i, m := range models {
+		if m.Status != statusSuccess || m.PromptTokens <= 0 {
+			continue
+		}
+		if fewest < 0 || m.PromptTokens < models[fewest].PromptTokens {
+			fewest = i
+		}
+	}
+	if fewest < 0 {
+		return
+	}
+
+	base := models[fewest]
+	for i, m := range models {
+		if i == fewest || m.Status != statusSuccess || m.PromptTokens <= 0 {
+			continue
+		}
+		ratio := float64(m.PromptTokens) / float64(base.PromptTokens)
+		if ratio <= tokenMismatchRatio {
+			continue
+		}
+		models[i].TokenizationWarning = fmt.Sprintf(
+			"tokenizes the benchmark prompt into %d tokens, %.0f%% more than %s (%d); "+
+				"its tokens are shorter, so its tok/s is not directly comparable",
+			m.PromptTokens, (ratio-1)*100, base.ModelID, base.PromptTokens)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// --probe-first-token-only: a fast latency smoke check. Each request streams
+// a chat completion capped at one token and is timed until the first token
+// arrives, so the run measures time to first token (queueing + prefill) and
+// never pays for a full generation.
+
+// firstTokenProbeMaxTokens caps each probe at a single generated token.
+const firstTokenProbeMaxTokens = 1
+
+// FirstTokenProbeSummary aggregates time to first token across the probes.
+type FirstTokenProbeSummary struct {
+	ServiceName    string    `json:"service_name"`
+	Namespace      string    `json:"namespace"`
+	Endpoint       string    `json:"endpoint"`
+	Iterations     int       `json:"iterations"`
+	SuccessfulRuns int       `json:"successful_runs"`
+	FailedRuns     int       `json:"failed_runs"`
+	TTFTMinMs      float64   `json:"ttft_min_ms"`
+	TTFTMeanMs     float64   `json:"ttft_mean_ms"`
+	TTFTP50Ms      float64   `json:"ttft_p50_ms"`
+	TTFTP90Ms      float64   `json:"ttft_p90_ms"`
+	TTFTP99Ms      float64   `json:"ttft_p99_ms"`
+	TTFTMaxMs      float64   `json:"ttft_max_ms"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// firstTokenStreamChunk is the subset of a streamed chat completion chunk
+// the probe reads to spot the first generated token.
+type firstTokenStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content          string `json:"content"`
+			ReasoningContent string `json:"reasoning_content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// carriesToken reports whether the chunk holds generated output. The opening
+// role-only delta does not count; a finish_reason does, since a one-token
+// completion whose token decodes to nothing still ends with one.
+func (c firstTokenStreamChunk) carriesToken() bool {
+	for _, choice := range c.Choices {
+		if choice.Delta.Content != "" || choice.Delta.ReasoningContent != "" || choice.FinishReason != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sendFirstTokenProbe streams a one-token chat completion and returns the
+// time from sending the request to receiving the first token.
+func sendFirstTokenProbe(ctx context.Context, endpoint string, opts *benchmarkOptions, prompt string) (time.Duration, error) {
+	reqBody := ChatCompletionRequest{
+		Messages: []ChatMessage{
+			{Role: "user", Content: prompt},
+		},
+		MaxTokens: firstTokenProbeMaxTokens,
+		Stream:    true,
+	}
+	applySamplingParams(&reqBody, opts, 0)
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint+"/v1/chat/completions", bytes.NewReader(jsonBody))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	setRequestHeaders(req, opts.requestHeaders)
+
+	httpClient := &http.Client{Transport: benchmarkTransport, Timeout: opts.timeout}
+	start := time.Now()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if payload == "" {
+			continue
+		}
+		if payload == "[DONE]" {
+			break
+		}
+		var chunk firstTokenStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			return 0, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.carriesToken() {
+			return time.Since(start), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read stream: %w", err)
+	}
+	return 0, fmt.Errorf("stream ended before the first token")
+}
+
+// runFirstTokenProbes sends opts.iterations probes and returns the TTFT of
+// each successful one, in milliseconds.
+func runFirstTokenProbes(ctx context.Context, endpoint string, opts *benchmarkOptions) (ttfts []float64, failed int) {
+	fmt.Printf("⚡ Probing time to first token (%d iterations)...\n", opts.iterations)
+	for i := 0; i < opts.iterations; i++ {
+		if ctx.Err() != nil {
+			fmt.Printf("   ⚠️  Interrupted after %d/%d iterations\n", i, opts.iterations)
+			break
+		}
+		ttft, err := sendFirstTokenProbe(ctx, endpoint, opts, opts.prompt)
+		if err != nil {
+			failed++
+			fmt.Printf("   [%d/%d] ❌ Error: %v\n", i+1, opts.iterations, err)
+			continue
+		}
+		ms := float64(ttft.Microseconds()) / 1000.0
+		ttfts = append(ttfts, ms)
+		fmt.Printf("   [%d/%d] ✅ TTFT %.0fms\n", i+1, opts.iterations, ms)
+	}
+	fmt.Println()
+	return ttfts, failed
+}
+
+func summarizeFirstTokenProbes(opts *benchmarkOptions, endpoint string, ttfts []float64, failed int) FirstTokenProbeSummary {
+	summary := FirstTokenProbeSummary{
+		ServiceName:    opts.name,
+		Namespace:      opts.namespace,
+		Endpoint:       endpoint,
+		Iterations:     len(ttfts) + failed,
+		SuccessfulRuns: len(ttfts),
+		FailedRuns:     failed,
+		Timestamp:      time.Now(),
+	}
+	if len(ttfts) == 0 {
+		return summary
+	}
+	sorted := append([]float64(nil), ttfts...)
+	sort.Float64s(sorted)
+	summary.TTFTMinMs = sorted[0]
+	summary.TTFTMaxMs = sorted[len(sorted)-1]
+	summary.TTFTMeanMs = mean(sorted)
+	summary.TTFTP50Ms = percentile(sorted, 50)
+	summary.TTFTP90Ms = percentile(sorted, 90)
+	summary.TTFTP99Ms = percentile(sorted, 99)
+	return summary
+}
+
+func outputFirstTokenProbeTable(w io.Writer, summary FirstTokenProbeSummary) {
+	_, _ = fmt.Fprintf(w, "⚡ Time to First Token\n")
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════\n\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "MIN (ms)\tMEAN (ms)\tP50 (ms)\tP90 (ms)\tP99 (ms)\tMAX (ms)\tSUCCESS\n")
+	_, _ = fmt.Fprintf(tw, "────────\t─────────\t────────\t────────\t────────\t────────\t───────\n")
+	if summary.SuccessfulRuns == 0 {
+		_, _ = fmt.Fprintf(tw, "-\t-\t-\t-\t-\t-\t0/%d\n", summary.Iterations)
+	} else {
+		_, _ = fmt.Fprintf(tw, "%.0f\t%.0f\t%.0f\t%.0f\t%.0f\t%.0f\t%d/%d\n",
+			summary.TTFTMinMs, summary.TTFTMeanMs, summary.TTFTP50Ms, summary.TTFTP90Ms,
+			summary.TTFTP99Ms, summary.TTFTMaxMs, summary.SuccessfulRuns, summary.Iterations)
+	}
+	_ = tw.Flush()
+}
+
+func runFirstTokenProbe(opts *benchmarkOptions) error {
+	ctx, stop := benchmarkSignalContext()
+	defer stop()
+
+	endpoint, cleanup, err := benchmarkEndpoint(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	fmt.Printf("\n⚡ LLMKube First-Token Probe\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	fmt.Printf("Service:     %s\n", opts.name)
+	fmt.Printf("Namespace:   %s\n", opts.namespace)
+	fmt.Printf("Endpoint:    %s\n", endpoint)
+	fmt.Printf("Iterations:  %d (+ %d warmup)\n", opts.iterations, opts.warmup)
+	fmt.Printf("Max Tokens:  %d (streamed)\n", firstTokenProbeMaxTokens)
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n\n")
+
+	if opts.warmup > 0 {
+		fmt.Printf("🔥 Running %d warmup probes...\n", opts.warmup)
+		for i := 0; i < opts.warmup; i++ {
+			if _, err := sendFirstTokenProbe(ctx, endpoint, opts, opts.prompt); err != nil {
+				fmt.Printf("   Warmup %d: failed (%v)\n", i+1, err)
+			} else {
+				fmt.Printf("   Warmup %d: ok\n", i+1)
+			}
+		}
+		fmt.Println()
+	}
+
+	ttfts, failed := runFirstTokenProbes(ctx, endpoint, opts)
+	summary := summarizeFirstTokenProbes(opts, endpoint, ttfts, failed)
+
+	return writeOutput(opts, func() error {
+		if opts.output == outputFormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(summary)
+		}
+		outputFirstTokenProbeTable(os.Stdout, summary)
+		return nil
+	})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+	"github.com/defilantech/llmkube/pkg/gguf"
+)
+
+// --assert-max-vram: estimate a model's weights + KV cache from its GGUF
+// header (fetched with Range requests, not a full download) and refuse to
+// benchmark it when the estimate exceeds the budget. Catalog runs check each
+// entry before deploying it; service runs check the deployed Model's source.
+
+// estimateSourceVRAM reads the GGUF header at source and estimates VRAM for
+// contextSize tokens (0 = the model's trained context). It is a variable so
+// tests can stub the remote read.
+var estimateSourceVRAM = func(ctx context.Context, source string, contextSize uint64) (gguf.VRAMEstimate, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return gguf.VRAMEstimate{}, fmt.Errorf("cannot estimate VRAM for %q: only http(s) sources can be read remotely", source)
+	}
+	f, err := gguf.ParseFromURL(ctx, source)
+	if err != nil {
+		return gguf.VRAMEstimate{}, fmt.Errorf("failed to read GGUF header from %s: %w", source, err)
+	}
+	return f.EstimateVRAM(contextSize), nil
+}
+
+// parseVRAMBudget parses --assert-max-vram as a Kubernetes quantity.
+func parseVRAMBudget(budget string) (resource.Quantity, error) {
+	q, err := resource.ParseQuantity(budget)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("invalid --assert-max-vram %q: %w", budget, err)
+	}
+	if q.Sign() <= 0 {
+		return resource.Quantity{}, fmt.Errorf("--assert-max-vram must be positive, got %q", budget)
+	}
+	return q, nil
+}
+
+// checkVRAMBudget fails when the estimate does not fit the budget.
+func checkVRAMBudget(estimate gguf.VRAMEstimate, budget resource.Quantity) error {
+	//nolint:gosec // G115: parseVRAMBudget rejects non-positive budgets
+	if estimate.TotalBytes <= uint64(budget.Value()) {
+		return nil
+	}
+	return fmt.Errorf("estimated VRAM %s (weights %s + KV cache %s) exceeds --assert-max-vram %s",
+		formatVRAMBytes(estimate.TotalBytes), formatVRAMBytes(estimate.WeightsBytes),
+		formatVRAMBytes(estimate.KVCacheBytes), budget.String())
+}
+
+// formatVRAMBytes is formatBytes for the unsigned sizes a GGUF estimate reports.
+func formatVRAMBytes(b uint64) string {
+	return formatBytes(int64(b)) //nolint:gosec // G115: GGUF sizes are far below math.MaxInt64
+}
+
+// assertVRAMBudget estimates source at contextSize and checks it against
+// --assert-max-vram. No-op when the flag is unset.
+func assertVRAMBudget(ctx context.Context, source string, contextSize uint64, opts *benchmarkOptions) error {
+	if opts.assertMaxVRAM == "" {
+		return nil
+	}
+	budget, err := parseVRAMBudget(opts.assertMaxVRAM)
+	if err != nil {
+		return err
+	}
+	estimate, err := estimateSourceVRAM(ctx, source, contextSize)
+	if err != nil {
+		return err
+	}
+	if err := checkVRAMBudget(estimate, budget); err != nil {
+		return err
+	}
+	fmt.Printf("   ✅ Estimated VRAM %s fits the %s budget\n", formatVRAMBytes(estimate.TotalBytes), budget.String())
+	return nil
+}
+
+// catalogContextSize is the context a catalog deployment is served at.
+func catalogContextSize(catalogModel *Model, opts *benchmarkOptions) uint64 {
+	if opts.contextSize > 0 {
+		return uint64(opts.contextSize)
+	}
+	if catalogModel.ContextSize > 0 {
+		return uint64(catalogModel.ContextSize) //nolint:gosec // G115: checked positive
+	}
+	return 0
+}
+
+// assertServiceVRAMBudget checks the Model behind a deployed InferenceService
+// against --assert-max-vram, at the service's configured context size.
+func assertServiceVRAMBudget(ctx context.Context, opts *benchmarkOptions) error {
+	if opts.assertMaxVRAM == "" {
+		return nil
+	}
+	k8sClient, err := initK8sClient()
+	if err != nil {
+		return err
+	}
+	isvc := &inferencev1alpha1.InferenceService{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: opts.name, Namespace: opts.namespace}, isvc); err != nil {
+		return fmt.Errorf("failed to get InferenceService '%s': %w", opts.name, err)
+	}
+	model := &inferencev1alpha1.Model{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: isvc.Spec.ModelRef, Namespace: opts.namespace}, model); err != nil {
+		return fmt.Errorf("failed to get Model '%s': %w", isvc.Spec.ModelRef, err)
+	}
+	var contextSize uint64
+	if isvc.Spec.ContextSize != nil && *isvc.Spec.ContextSize > 0 {
+		contextSize = uint64(*isvc.Spec.ContextSize)
+	}
+	fmt.Printf("📏 Checking %s against the VRAM budget...\n", model.Name)
+	return assertVRAMBudget(ctx, model.Spec.Source, contextSize, opts)
+}
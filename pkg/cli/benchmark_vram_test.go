@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+	"github.com/defilantech/llmkube/pkg/gguf"
+)
+
+const gib = uint64(1) << 30
+
+func TestCheckVRAMBudget(t *testing.T) {
+	budget := resource.MustParse("16Gi")
+	tests := []struct {
+		name     string
+		estimate gguf.VRAMEstimate
+		wantErr  bool
+	}{
+		{"well under budget", gguf.VRAMEstimate{WeightsBytes: 5 * gib, KVCacheBytes: 2 * gib, TotalBytes: 7 * gib}, false},
+		{"exactly at budget", gguf.VRAMEstimate{WeightsBytes: 12 * gib, KVCacheBytes: 4 * gib, TotalBytes: 16 * gib}, false},
+		{"KV cache pushes it over", gguf.VRAMEstimate{WeightsBytes: 12 * gib, KVCacheBytes: 4*gib + 1, TotalBytes: 16*gib + 1}, true},
+		{"weights alone exceed it", gguf.VRAMEstimate{WeightsBytes: 40 * gib, TotalBytes: 40 * gib}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVRAMBudget(tt.estimate, budget)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkVRAMBudget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "16Gi") {
+				t.Errorf("expected the budget in the error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestParseVRAMBudget(t *testing.T) {
+	if _, err := parseVRAMBudget("24Gi"); err != nil {
+		t.Errorf("parseVRAMBudget(24Gi): %v", err)
+	}
+	for _, bad := range []string{"lots", "0", "-8Gi"} {
+		if _, err := parseVRAMBudget(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestBenchmarkSingleCatalogModelSkipsOverBudget(t *testing.T) {
+	var gotContext uint64
+	orig := estimateSourceVRAM
+	estimateSourceVRAM = func(_ context.Context, _ string, contextSize uint64) (gguf.VRAMEstimate, error) {
+		gotContext = contextSize
+		return gguf.VRAMEstimate{WeightsBytes: 20 * gib, KVCacheBytes: 4 * gib, TotalBytes: 24 * gib}, nil
+	}
+	t.Cleanup(func() { estimateSourceVRAM = orig })
+
+	scheme := runtime.NewScheme()
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	catalogModel, err := GetModel("llama-3.1-8b")
+	if err != nil {
+		t.Fatalf("GetModel: %v", err)
+	}
+	opts := &benchmarkOptions{namespace: "default", assertMaxVRAM: "16Gi", contextSize: 8192, cleanup: true}
+
+	result := benchmarkSingleCatalogModel(t.Context(), k8sClient, "llama-3.1-8b", catalogModel, opts, false)
+	if result.Status != statusFailed || !strings.Contains(result.Error, "exceeds --assert-max-vram") {
+		t.Errorf("expected the over-budget entry to fail, got %q: %s", result.Status, result.Error)
+	}
+	if gotContext != 8192 {
+		t.Errorf("expected the estimate at the configured --context 8192, got %d", gotContext)
+	}
+	models := &inferencev1alpha1.ModelList{}
+	if err := k8sClient.List(t.Context(), models); err != nil {
+		t.Fatalf("list Models: %v", err)
+	}
+	if len(models.Items) != 0 {
+		t.Errorf("expected nothing to be deployed, got %d Models", len(models.Items))
+	}
+}
@@ -18,8 +18,11 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -37,18 +40,54 @@ import (
 const (
 	statusActive   = "active"
 	statusOrphaned = "orphaned"
+
+	// cacheUsageWarnPercent is the PVC utilization above which cache list
+	// suggests clearing orphaned entries.
+	cacheUsageWarnPercent = 90
 )
 
 // CacheEntry represents a cached model
 type CacheEntry struct {
-	CacheKey         string
-	Source           string
-	Size             int64
-	SizeHuman        string
-	ModTime          time.Time
-	ModelNames       []string // Models using this cache entry
-	Status           string   // "active" or "orphaned"
-	InferenceService string   // owning InferenceService; empty for shared cache
+	CacheKey         string    `json:"cache_key"`
+	Source           string    `json:"source,omitempty"`
+	Size             int64     `json:"size"`
+	SizeHuman        string    `json:"size_human,omitempty"`
+	ModTime          time.Time `json:"-"`
+	ModelNames       []string  `json:"model_names"`                 // Models using this cache entry
+	Status           string    `json:"status"`                      // "active" or "orphaned"
+	InferenceService string    `json:"inference_service,omitempty"` // owning InferenceService; empty for shared cache
+	PVCs             []string  `json:"pvcs,omitempty"`              // cache PVCs holding the entry's directory
+}
+
+// cacheList is the output of cache list: the entries sorted by cache key and
+// the counts of the summary line. Usage is set only when the cache PVCs were
+// inspected.
+type cacheList struct {
+	Entries  []*CacheEntry `json:"entries"`
+	Total    int           `json:"total"`
+	Active   int           `json:"active"`
+	Orphaned int           `json:"orphaned"`
+	Models   int           `json:"models"`
+	Usage    *CacheUsage   `json:"usage,omitempty"`
+}
+
+func newCacheList(cacheEntries map[string]*CacheEntry, modelCount int, usage *CacheUsage) cacheList {
+	list := cacheList{
+		Entries: make([]*CacheEntry, 0, len(cacheEntries)),
+		Total:   len(cacheEntries),
+		Models:  modelCount,
+		Usage:   usage,
+	}
+	for _, entry := range cacheEntries {
+		if entry.Status == statusOrphaned {
+			list.Orphaned++
+		} else {
+			list.Active++
+		}
+		list.Entries = append(list.Entries, entry)
+	}
+	sort.Slice(list.Entries, func(i, j int) bool { return list.Entries[i].CacheKey < list.Entries[j].CacheKey })
+	return list
 }
 
 // NewCacheCommand creates the cache command
@@ -69,7 +108,10 @@ Examples:
   llmkube cache clear
 
   # Clear a specific cached model by name
-  llmkube cache clear --model llama-3.1-8b
+  llmkube cache clear --model llama-3.1-8b --force
+
+  # Clear cache entries no Model uses
+  llmkube cache clear --orphaned
 
   # Pre-download a catalog model to the cache
   llmkube cache preload llama-3.1-8b
@@ -88,6 +130,7 @@ func newCacheListCommand() *cobra.Command {
 	var namespace string
 	var allNamespaces bool
 	var orphanedOnly bool
+	var output string
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -96,42 +139,24 @@ func newCacheListCommand() *cobra.Command {
 
 Shows cache entries with their size, status, and which Model resources
 are using each cache entry. Inspects the actual PVC contents to detect
-orphaned cache entries that have no corresponding Model resource.`,
+orphaned cache entries that have no corresponding Model resource.
+
+With --output json, prints the entries and the summary counts as one JSON
+document, e.g. to feed an orphan cleanup script:
+
+  llmkube cache list --orphaned -o json | jq -r '.entries[].cache_key'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCacheList(namespace, allNamespaces, orphanedOnly)
+			if output != "table" && output != "json" {
+				return fmt.Errorf("--output must be table or json, got %q", output)
+			}
+			return runCacheList(namespace, allNamespaces, orphanedOnly, output)
 		},
 	}
 
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
 	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List models from all namespaces")
 	cmd.Flags().BoolVar(&orphanedOnly, "orphaned", false, "Show only orphaned cache entries (no matching Model)")
-
-	return cmd
-}
-
-func newCacheClearCommand() *cobra.Command {
-	var modelName string
-	var namespace string
-	var force bool
-
-	cmd := &cobra.Command{
-		Use:   "clear",
-		Short: "Clear cached models",
-		Long: `Clear models from the persistent cache.
-
-By default, clears all cached models. Use --model to clear a specific
-model's cache entry.
-
-WARNING: Clearing the cache will cause models to be re-downloaded
-when InferenceServices restart or new pods are created.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCacheClear(modelName, namespace, force)
-		},
-	}
-
-	cmd.Flags().StringVar(&modelName, "model", "", "Clear cache for a specific model")
-	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace (used with --model)")
-	cmd.Flags().BoolVar(&force, "force", false, "Force clear without confirmation")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json")
 
 	return cmd
 }
@@ -167,7 +192,7 @@ Examples:
 	return cmd
 }
 
-func runCacheList(namespace string, allNamespaces bool, orphanedOnly bool) error {
+func runCacheList(namespace string, allNamespaces bool, orphanedOnly bool, output string) error {
 	ctx := context.Background()
 
 	cfg, err := config.GetConfig()
@@ -194,61 +219,19 @@ func runCacheList(namespace string, allNamespaces bool, orphanedOnly bool) error
 		return fmt.Errorf("failed to list models: %w", err)
 	}
 
-	cacheEntries := make(map[string]*CacheEntry)
-	for _, model := range modelList.Items {
-		cacheKey := cachekey.EffectiveKey(&model)
-		if cacheKey == "" {
-			// metal / single-file runtime-resolved models are never cached
-			// under a derived key; skip them so they do not show up as
-			// phantom entries.
-			continue
-		}
-
-		entry, exists := cacheEntries[cacheKey]
-		if !exists {
-			entry = &CacheEntry{
-				CacheKey:   cacheKey,
-				Source:     model.Spec.Source,
-				ModelNames: []string{},
-				Status:     statusActive,
-			}
-			cacheEntries[cacheKey] = entry
-		}
-
-		modelName := model.Name
-		if allNamespaces {
-			modelName = fmt.Sprintf("%s/%s", model.Namespace, model.Name)
-		}
-		entry.ModelNames = append(entry.ModelNames, modelName)
-
-		if model.Status.Size != "" {
-			entry.SizeHuman = model.Status.Size
-		}
-	}
+	cacheEntries := modelCacheEntries(modelList.Items, allNamespaces)
 
 	// Inspect actual PVC contents (only for single-namespace mode)
-	var pvcInspected bool
+	var usage *CacheUsage
 	if !allNamespaces {
-		pvcEntries, err := inspectPVCCache(ctx, cfg, k8sClient, namespace)
+		pvcEntries, capacityBytes, err := inspectPVCCache(ctx, cfg, k8sClient, namespace)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not inspect PVC contents: %v\n", err)
 		} else if pvcEntries != nil {
-			pvcInspected = true
-			for _, pe := range pvcEntries {
-				if entry, exists := cacheEntries[pe.CacheKey]; exists {
-					entry.Size = pe.SizeBytes
-					entry.SizeHuman = formatBytes(pe.SizeBytes)
-					entry.InferenceService = pe.InferenceService
-				} else {
-					cacheEntries[pe.CacheKey] = &CacheEntry{
-						CacheKey:         pe.CacheKey,
-						Size:             pe.SizeBytes,
-						SizeHuman:        formatBytes(pe.SizeBytes),
-						Status:           statusOrphaned,
-						InferenceService: pe.InferenceService,
-					}
-				}
-			}
+			mergePVCEntries(cacheEntries, pvcEntries)
+			// Before the --orphaned filter: utilization is about the whole PVC.
+			summary := cacheUsageSummary(cacheEntries, capacityBytes)
+			usage = &summary
 		}
 	}
 
@@ -260,28 +243,40 @@ func runCacheList(namespace string, allNamespaces bool, orphanedOnly bool) error
 		}
 	}
 
-	if len(cacheEntries) == 0 {
+	return writeCacheList(os.Stdout, newCacheList(cacheEntries, len(modelList.Items), usage), orphanedOnly, output)
+}
+
+// writeCacheList prints list as the cache list table, or as JSON when output
+// is "json".
+func writeCacheList(out io.Writer, list cacheList, orphanedOnly bool, output string) error {
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(list)
+	}
+
+	if list.Total == 0 {
 		if orphanedOnly {
-			fmt.Println("No orphaned cache entries found.")
+			_, _ = fmt.Fprintln(out, "No orphaned cache entries found.")
 		} else {
-			fmt.Println("No cache entries found.")
+			_, _ = fmt.Fprintln(out, "No cache entries found.")
 		}
 		return nil
 	}
 
-	fmt.Printf("\nModel Cache Entries\n")
-	fmt.Printf("═══════════════════════════════════════════════════════════════════════════════\n")
+	pvcInspected := list.Usage != nil
+
+	_, _ = fmt.Fprintf(out, "\nModel Cache Entries\n")
+	_, _ = fmt.Fprintf(out, "═══════════════════════════════════════════════════════════════════════════════\n")
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	if pvcInspected {
 		_, _ = fmt.Fprintln(w, "CACHE KEY\tSTATUS\tSIZE\tISVC\tMODELS\tSOURCE")
 	} else {
 		_, _ = fmt.Fprintln(w, "CACHE KEY\tSIZE\tMODELS\tSOURCE")
 	}
 
-	var activeCount, orphanedCount int
-	var totalBytes int64
-	for _, entry := range cacheEntries {
+	for _, entry := range list.Entries {
 		models := strings.Join(entry.ModelNames, ", ")
 		if len(models) > 40 {
 			models = models[:37] + "..."
@@ -302,13 +297,6 @@ func runCacheList(namespace string, allNamespaces bool, orphanedOnly bool) error
 			isvc = "shared"
 		}
 
-		if entry.Status == statusOrphaned {
-			orphanedCount++
-		} else {
-			activeCount++
-		}
-		totalBytes += entry.Size
-
 		if pvcInspected {
 			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.CacheKey, entry.Status, size, isvc, models, source)
 		} else {
@@ -318,85 +306,15 @@ func runCacheList(namespace string, allNamespaces bool, orphanedOnly bool) error
 	_ = w.Flush()
 
 	if pvcInspected {
-		fmt.Printf("\nTotal: %d cache entries (%d active, %d orphaned), %s used\n",
-			len(cacheEntries), activeCount, orphanedCount, formatBytes(totalBytes))
-	} else {
-		fmt.Printf("\nTotal: %d cache entries, %d models\n", len(cacheEntries), len(modelList.Items))
-	}
-
-	return nil
-}
-
-func runCacheClear(modelName, namespace string, force bool) error {
-	ctx := context.Background()
-
-	// Get Kubernetes client
-	cfg, err := config.GetConfig()
-	if err != nil {
-		return fmt.Errorf("failed to get kubeconfig: %w", err)
-	}
-
-	if err := inferencev1alpha1.AddToScheme(scheme.Scheme); err != nil {
-		return fmt.Errorf("failed to add scheme: %w", err)
-	}
-
-	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
-		return fmt.Errorf("failed to create client: %w", err)
-	}
-
-	if modelName != "" {
-		// Clear specific model's cache
-		model := &inferencev1alpha1.Model{}
-		if err := k8sClient.Get(ctx, client.ObjectKey{Name: modelName, Namespace: namespace}, model); err != nil {
-			return fmt.Errorf("failed to get model '%s': %w", modelName, err)
-		}
-
-		if model.Status.CacheKey == "" {
-			return fmt.Errorf("model '%s' does not have a cache key (may not be cached)", modelName)
-		}
-
-		if !force {
-			fmt.Printf("This will clear the cache entry for model '%s' (cache key: %s)\n", modelName, model.Status.CacheKey)
-			fmt.Printf("The model will be re-downloaded when the InferenceService restarts.\n")
-			fmt.Printf("Continue? [y/N] ")
-
-			var response string
-			_, _ = fmt.Scanln(&response)
-			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-				fmt.Println("Cancelled.")
-				return nil
-			}
-		}
-
-		// Clear cache by deleting the model's cache key directory
-		// Note: This requires exec into the controller pod or direct PVC access
-		fmt.Printf("To clear the cache, delete the directory on the model-cache PVC:\n")
-		fmt.Printf("  kubectl exec -n llmkube-system deploy/llmkube-controller-manager -- \\\n")
-		fmt.Printf("    rm -rf /models/%s\n", model.Status.CacheKey)
-		fmt.Printf("\nAlternatively, delete and recreate the Model resource to trigger a re-download.\n")
-
-		return nil
-	}
-
-	// Clear all cache
-	if !force {
-		fmt.Printf("This will clear ALL cached models.\n")
-		fmt.Printf("All models will be re-downloaded when InferenceServices restart.\n")
-		fmt.Printf("Continue? [y/N] ")
-
-		var response string
-		_, _ = fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-			fmt.Println("Cancelled.")
-			return nil
+		_, _ = fmt.Fprintf(out, "\nTotal: %d cache entries (%d active, %d orphaned), %s\n",
+			list.Total, list.Active, list.Orphaned, list.Usage)
+		if list.Usage.UtilizationPercent > cacheUsageWarnPercent {
+			_, _ = fmt.Fprintf(out, "⚠️  Cache PVC is %.0f%% full; free space with 'llmkube cache clear --orphaned'\n",
+				list.Usage.UtilizationPercent)
 		}
+	} else {
+		_, _ = fmt.Fprintf(out, "\nTotal: %d cache entries, %d models\n", list.Total, list.Models)
 	}
-
-	fmt.Printf("To clear all cache, run:\n")
-	fmt.Printf("  kubectl exec -n llmkube-system deploy/llmkube-controller-manager -- rm -rf /models/*\n")
-	fmt.Printf("\nNote: Do not delete the /models directory itself, only its contents.\n")
-
 	return nil
 }
 
@@ -504,3 +422,97 @@ func runCachePreload(modelID, namespace string) error {
 		}
 	}
 }
+
+// modelCacheEntries groups Models by the cache key they resolve to. Every
+// entry starts out active; the PVC inspection adds sizes and orphans.
+func modelCacheEntries(models []inferencev1alpha1.Model, allNamespaces bool) map[string]*CacheEntry {
+	cacheEntries := make(map[string]*CacheEntry)
+	for i := range models {
+		model := &models[i]
+		cacheKey := cachekey.EffectiveKey(model)
+		if cacheKey == "" {
+			// metal / single-file runtime-resolved models are never cached
+			// under a derived key; skip them so they do not show up as
+			// phantom entries.
+			continue
+		}
+
+		entry, exists := cacheEntries[cacheKey]
+		if !exists {
+			entry = &CacheEntry{
+				CacheKey:   cacheKey,
+				Source:     model.Spec.Source,
+				ModelNames: []string{},
+				Status:     statusActive,
+			}
+			cacheEntries[cacheKey] = entry
+		}
+
+		modelName := model.Name
+		if allNamespaces {
+			modelName = fmt.Sprintf("%s/%s", model.Namespace, model.Name)
+		}
+		entry.ModelNames = append(entry.ModelNames, modelName)
+
+		if model.Status.Size != "" {
+			entry.SizeHuman = model.Status.Size
+		}
+	}
+	return cacheEntries
+}
+
+// mergePVCEntries folds the directories found on the cache PVCs into the
+// Model-derived entries. A directory no Model resolves to is orphaned.
+func mergePVCEntries(cacheEntries map[string]*CacheEntry, pvcEntries []PVCCacheEntry) {
+	for _, pe := range pvcEntries {
+		// A key on several PVCs (shared and per-service caches) takes up
+		// space on each, so its size is the sum.
+		entry, exists := cacheEntries[pe.CacheKey]
+		if exists {
+			entry.Size += pe.SizeBytes
+			entry.SizeHuman = formatBytes(entry.Size)
+			entry.InferenceService = pe.InferenceService
+		} else {
+			entry = &CacheEntry{
+				CacheKey:         pe.CacheKey,
+				Size:             pe.SizeBytes,
+				SizeHuman:        formatBytes(pe.SizeBytes),
+				ModelNames:       []string{},
+				Status:           statusOrphaned,
+				InferenceService: pe.InferenceService,
+			}
+			cacheEntries[pe.CacheKey] = entry
+		}
+		if pe.PVC != "" {
+			entry.PVCs = append(entry.PVCs, pe.PVC)
+		}
+	}
+}
+
+// CacheUsage rolls a cache listing up against the requested capacity of the
+// inspected cache PVCs. CapacityBytes is 0 when no PVC requests storage.
+type CacheUsage struct {
+	UsedBytes          int64   `json:"used_bytes"`
+	CapacityBytes      int64   `json:"capacity_bytes,omitempty"`
+	UtilizationPercent float64 `json:"utilization_percent,omitempty"`
+}
+
+func cacheUsageSummary(cacheEntries map[string]*CacheEntry, capacityBytes int64) CacheUsage {
+	usage := CacheUsage{CapacityBytes: capacityBytes}
+	for _, entry := range cacheEntries {
+		usage.UsedBytes += entry.Size
+	}
+	if capacityBytes > 0 {
+		usage.UtilizationPercent = float64(usage.UsedBytes) / float64(capacityBytes) * 100
+	}
+	return usage
+}
+
+// String renders the usage for the cache list summary line.
+func (u CacheUsage) String() string {
+	if u.CapacityBytes == 0 {
+		return formatBytes(u.UsedBytes) + " used"
+	}
+	return fmt.Sprintf("%s used of %s (%.0f%%)",
+		formatBytes(u.UsedBytes), formatBytes(u.CapacityBytes), u.UtilizationPercent)
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+	"github.com/defilantech/llmkube/pkg/cachekey"
+)
+
+// cacheKeyPattern matches the directory names the controller creates on the
+// cache PVC (cachekey.Compute output). Anything else, such as an ext4
+// lost+found, is never selected for deletion.
+var cacheKeyPattern = regexp.MustCompile(`^[0-9a-f]+$`)
+
+type cacheClearOptions struct {
+	modelName string
+	key       string
+	namespace string
+	orphaned  bool
+	dryRun    bool
+	force     bool
+}
+
+func newCacheClearCommand() *cobra.Command {
+	opts := &cacheClearOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Clear cached models",
+		Long: `Clear models from the persistent cache.
+
+With --key, --model, or --orphaned, the selected /models/<key> directories
+are removed from the namespace's cache PVCs through a short-lived pod that
+mounts them. Orphaned entries are directories no Model resolves to, as shown
+by 'llmkube cache list --orphaned'. A key still used by a Model is refused
+unless --force is given. --dry-run prints what would be deleted.
+
+Without a selector, prints how to clear the whole cache.
+
+WARNING: Clearing the cache will cause models to be re-downloaded
+when InferenceServices restart or new pods are created.
+
+Examples:
+  # Show which orphaned entries would be removed
+  llmkube cache clear --orphaned --dry-run
+
+  # Remove every orphaned entry
+  llmkube cache clear --orphaned
+
+  # Remove one entry, even though a Model still uses it
+  llmkube cache clear --key f1c314277254a2fd --force
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selectors := 0
+			for _, set := range []bool{opts.modelName != "", opts.key != "", opts.orphaned} {
+				if set {
+					selectors++
+				}
+			}
+			if selectors > 1 {
+				return fmt.Errorf("--model, --key, and --orphaned are mutually exclusive")
+			}
+			if opts.dryRun && selectors == 0 {
+				return fmt.Errorf("--dry-run requires --model, --key, or --orphaned")
+			}
+			if opts.key != "" && !cacheKeyPattern.MatchString(opts.key) {
+				return fmt.Errorf("--key must be a lowercase hex cache key, got %q", opts.key)
+			}
+			return runCacheClear(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.modelName, "model", "", "Clear the cache entry of a specific model")
+	cmd.Flags().StringVar(&opts.key, "key", "", "Clear a specific cache key (see 'llmkube cache list')")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "Kubernetes namespace")
+	cmd.Flags().BoolVar(&opts.orphaned, "orphaned", false, "Clear every orphaned cache entry (no matching Model)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print what would be deleted without deleting it")
+	cmd.Flags().BoolVar(&opts.force, "force", false,
+		"Skip confirmation, and allow clearing a key still used by a Model")
+
+	return cmd
+}
+
+func runCacheClear(opts *cacheClearOptions) error {
+	ctx := context.Background()
+
+	if opts.modelName == "" && opts.key == "" && !opts.orphaned {
+		return printClearAllInstructions(opts.force)
+	}
+
+	// Get Kubernetes client
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	if err := inferencev1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		return fmt.Errorf("failed to add scheme: %w", err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	key := opts.key
+	if opts.modelName != "" {
+		model := &inferencev1alpha1.Model{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: opts.modelName, Namespace: opts.namespace}, model); err != nil {
+			return fmt.Errorf("failed to get model '%s': %w", opts.modelName, err)
+		}
+		key = cachekey.EffectiveKey(model)
+		if key == "" {
+			return fmt.Errorf("model '%s' does not have a cache key (may not be cached)", opts.modelName)
+		}
+	}
+
+	modelList := &inferencev1alpha1.ModelList{}
+	if err := k8sClient.List(ctx, modelList, client.InNamespace(opts.namespace)); err != nil {
+		return fmt.Errorf("failed to list models: %w", err)
+	}
+	pvcEntries, _, err := inspectPVCCache(ctx, cfg, k8sClient, opts.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to inspect cache PVCs: %w", err)
+	}
+	cacheEntries := modelCacheEntries(modelList.Items, false)
+	mergePVCEntries(cacheEntries, pvcEntries)
+
+	targets, err := selectCacheClearTargets(cacheEntries, key, opts.orphaned, opts.force)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println("No orphaned cache entries found.")
+		return nil
+	}
+
+	printCacheClearTargets(targets)
+	if opts.dryRun {
+		fmt.Println("\nDry run: nothing deleted.")
+		return nil
+	}
+
+	if !opts.force {
+		fmt.Printf("\nThe deleted models will be re-downloaded when they are next served.\n")
+		if !confirmCacheClear() {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %w", err)
+	}
+	for _, pvc := range cacheClearKeysByPVC(targets) {
+		if err := clearPVCKeys(ctx, cfg, clientset, opts.namespace, pvc.name, pvc.keys); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Cleared %s from PVC %s\n", strings.Join(pvc.keys, ", "), pvc.name)
+	}
+	return nil
+}
+
+// selectCacheClearTargets picks the entries cache clear removes from the
+// merged Model/PVC listing: the single entry named by key, or every orphaned
+// entry. A key that is not on any cache PVC, or that a Model still resolves
+// to without force, is an error. The result is sorted by cache key.
+func selectCacheClearTargets(
+	cacheEntries map[string]*CacheEntry, key string, orphaned, force bool,
+) ([]*CacheEntry, error) {
+	if key != "" {
+		entry, ok := cacheEntries[key]
+		if !ok || len(entry.PVCs) == 0 {
+			return nil, fmt.Errorf("cache key %s is not on any cache PVC", key)
+		}
+		if entry.Status == statusActive && !force {
+			return nil, fmt.Errorf("cache key %s is used by Model(s) %s; pass --force to clear it anyway",
+				key, strings.Join(entry.ModelNames, ", "))
+		}
+		return []*CacheEntry{entry}, nil
+	}
+
+	var targets []*CacheEntry
+	if orphaned {
+		for k, entry := range cacheEntries {
+			if entry.Status == statusOrphaned && len(entry.PVCs) > 0 && cacheKeyPattern.MatchString(k) {
+				targets = append(targets, entry)
+			}
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].CacheKey < targets[j].CacheKey })
+	return targets, nil
+}
+
+type pvcClearKeys struct {
+	name string
+	keys []string
+}
+
+// cacheClearKeysByPVC groups the targets' keys by the PVC holding them, so
+// each PVC is mounted once. PVCs come out sorted by name.
+func cacheClearKeysByPVC(targets []*CacheEntry) []pvcClearKeys {
+	byPVC := map[string][]string{}
+	for _, entry := range targets {
+		for _, pvc := range entry.PVCs {
+			byPVC[pvc] = append(byPVC[pvc], entry.CacheKey)
+		}
+	}
+	groups := make([]pvcClearKeys, 0, len(byPVC))
+	for name, keys := range byPVC {
+		groups = append(groups, pvcClearKeys{name: name, keys: keys})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+	return groups
+}
+
+func printCacheClearTargets(targets []*CacheEntry) {
+	var totalBytes int64
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CACHE KEY\tSTATUS\tSIZE\tPVC\tMODELS")
+	for _, entry := range targets {
+		models := strings.Join(entry.ModelNames, ", ")
+		if models == "" {
+			models = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			entry.CacheKey, entry.Status, entry.SizeHuman, strings.Join(entry.PVCs, ", "), models)
+		totalBytes += entry.Size
+	}
+	_ = w.Flush()
+	fmt.Printf("\nTotal: %d cache entries to delete, %s\n", len(targets), formatBytes(totalBytes))
+}
+
+func confirmCacheClear() bool {
+	fmt.Printf("Continue? [y/N] ")
+	var response string
+	_, _ = fmt.Scanln(&response)
+	response = strings.ToLower(response)
+	return response == "y" || response == "yes"
+}
+
+// clearPVCKeys removes /models/<key> for each key through a cleaner pod that
+// mounts the PVC read-write.
+func clearPVCKeys(
+	ctx context.Context, cfg *rest.Config, clientset kubernetes.Interface, namespace, pvcName string, keys []string,
+) error {
+	podName, err := createCleanerPodForPVC(ctx, clientset, namespace, pvcName)
+	if err != nil {
+		return fmt.Errorf("failed to create cleaner pod for PVC %s: %w", pvcName, err)
+	}
+	defer deleteInspectorPod(context.Background(), clientset, namespace, podName)
+
+	if err := waitForPodRunning(ctx, clientset, namespace, podName, 120*time.Second); err != nil {
+		return fmt.Errorf("cleaner pod failed to start: %w", err)
+	}
+
+	command := []string{"rm", "-rf", "--"}
+	for _, key := range keys {
+		command = append(command, path.Join(defaultModelMountPath, key))
+	}
+	if _, err := execInPod(ctx, cfg, clientset, namespace, podName, "inspector", command); err != nil {
+		return fmt.Errorf("failed to clear cache entries on PVC %s: %w", pvcName, err)
+	}
+	return nil
+}
+
+func printClearAllInstructions(force bool) error {
+	if !force {
+		fmt.Printf("This will clear ALL cached models.\n")
+		fmt.Printf("All models will be re-downloaded when InferenceServices restart.\n")
+		if !confirmCacheClear() {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	fmt.Printf("To clear all cache, run:\n")
+	fmt.Printf("  kubectl exec -n llmkube-system deploy/llmkube-controller-manager -- rm -rf /models/*\n")
+	fmt.Printf("\nNote: Do not delete the /models directory itself, only its contents.\n")
+	fmt.Printf("To clear only unused entries, use --orphaned.\n")
+
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
+)
+
+func cacheClearTestEntries() map[string]*CacheEntry {
+	models := []inferencev1alpha1.Model{{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/llama.gguf"},
+		Status:     inferencev1alpha1.ModelStatus{CacheKey: "aaaa000000000001"},
+	}}
+	entries := modelCacheEntries(models, false)
+	mergePVCEntries(entries, []PVCCacheEntry{
+		{CacheKey: "aaaa000000000001", SizeBytes: 4096, PVC: modelCachePVCName},
+		{CacheKey: "ffff000000000002", SizeBytes: 2048, PVC: modelCachePVCName},
+		{CacheKey: "cccc000000000003", SizeBytes: 1024, PVC: "llama-model-cache", InferenceService: "llama"},
+		{CacheKey: "cccc000000000003", SizeBytes: 1024, PVC: modelCachePVCName},
+		{CacheKey: "lost+found", SizeBytes: 16384, PVC: modelCachePVCName},
+	})
+	return entries
+}
+
+func cacheClearKeys(entries []*CacheEntry) []string {
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = e.CacheKey
+	}
+	return keys
+}
+
+func TestSelectCacheClearTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		orphaned bool
+		force    bool
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:     "orphaned selects every orphaned cache key, sorted",
+			orphaned: true,
+			want:     []string{"cccc000000000003", "ffff000000000002"},
+		},
+		{
+			name: "orphaned key",
+			key:  "ffff000000000002",
+			want: []string{"ffff000000000002"},
+		},
+		{
+			name:    "active key is refused",
+			key:     "aaaa000000000001",
+			wantErr: "used by Model(s) llama",
+		},
+		{
+			name:  "active key with force",
+			key:   "aaaa000000000001",
+			force: true,
+			want:  []string{"aaaa000000000001"},
+		},
+		{
+			name:    "key not on any PVC",
+			key:     "0123456789abcdef",
+			wantErr: "not on any cache PVC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := selectCacheClearTargets(cacheClearTestEntries(), tt.key, tt.orphaned, tt.force)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := cacheClearKeys(targets); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("targets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectCacheClearTargets_ActiveModelWithoutPVCEntry(t *testing.T) {
+	models := []inferencev1alpha1.Model{{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+		Status:     inferencev1alpha1.ModelStatus{CacheKey: "bbbb000000000001"},
+	}}
+	entries := modelCacheEntries(models, false)
+
+	if _, err := selectCacheClearTargets(entries, "bbbb000000000001", false, true); err == nil {
+		t.Error("expected an error for a key with no directory on the cache PVCs")
+	}
+	if targets, err := selectCacheClearTargets(entries, "", true, false); err != nil || len(targets) != 0 {
+		t.Errorf("orphaned targets = %v, %v; want none", cacheClearKeys(targets), err)
+	}
+}
+
+func TestCacheClearKeysByPVC(t *testing.T) {
+	targets, err := selectCacheClearTargets(cacheClearTestEntries(), "", true, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []pvcClearKeys{
+		{name: "llama-model-cache", keys: []string{"cccc000000000003"}},
+		{name: modelCachePVCName, keys: []string{"cccc000000000003", "ffff000000000002"}},
+	}
+	if got := cacheClearKeysByPVC(targets); !reflect.DeepEqual(got, want) {
+		t.Errorf("cacheClearKeysByPVC = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewCacheClearCommandValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "key and orphaned together",
+			args:    []string{"--key", "abc123", "--orphaned"},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "model and key together",
+			args:    []string{"--model", "llama", "--key", "abc123"},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "dry run without a selector",
+			args:    []string{"--dry-run"},
+			wantErr: "--dry-run requires",
+		},
+		{
+			name:    "key that is not hex",
+			args:    []string{"--key", "../etc"},
+			wantErr: "lowercase hex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCacheClearCommand()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateCleanerPodMountsReadWrite(t *testing.T) {
+	clientset := fakeclientset.NewClientset()
+	ctx := context.Background()
+
+	podName, err := createCleanerPodForPVC(ctx, clientset, "default", modelCachePVCName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if podName == inspectorPodName(modelCachePVCName) {
+		t.Errorf("cleaner pod name %q collides with the inspector's", podName)
+	}
+
+	pod, err := clientset.CoreV1().Pods("default").Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get created pod: %v", err)
+	}
+	if pod.Labels["app.kubernetes.io/component"] != "cache-cleaner" {
+		t.Errorf("component label = %q, want %q", pod.Labels["app.kubernetes.io/component"], "cache-cleaner")
+	}
+	if pod.Spec.Containers[0].VolumeMounts[0].ReadOnly {
+		t.Error("cleaner volume mount should be read-write")
+	}
+	if pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly {
+		t.Error("cleaner PVC volume source should be read-write")
+	}
+}
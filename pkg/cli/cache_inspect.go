@@ -49,12 +49,14 @@ const (
 type PVCInfo struct {
 	Name             string
 	InferenceService string // empty for the shared cache
+	CapacityBytes    int64  // requested storage; 0 when unset
 }
 
 type PVCCacheEntry struct {
 	CacheKey         string
 	SizeBytes        int64
 	InferenceService string // empty for the shared cache
+	PVC              string
 }
 
 // discoverCachePVCs lists all model cache PVCs in the given namespace by
@@ -93,6 +95,7 @@ func discoverCachePVCs(ctx context.Context, k8sClient client.Client, namespace s
 		infos = append(infos, PVCInfo{
 			Name:             pvc.Name,
 			InferenceService: isvcName,
+			CapacityBytes:    pvcRequestedBytes(pvc),
 		})
 		seen[pvc.Name] = true
 	}
@@ -111,7 +114,11 @@ func discoverCachePVCs(ctx context.Context, k8sClient client.Client, namespace s
 			// Included regardless of phase; a Pending WaitForFirstConsumer
 			// shared cache binds when the inspector pod mounts it (see the
 			// loop comment above).
-			infos = append(infos, PVCInfo{Name: modelCachePVCName, InferenceService: ""})
+			infos = append(infos, PVCInfo{
+				Name:             modelCachePVCName,
+				InferenceService: "",
+				CapacityBytes:    pvcRequestedBytes(shared),
+			})
 		case !apierrors.IsNotFound(err):
 			return nil, fmt.Errorf("failed to get shared cache PVC: %w", err)
 		}
@@ -120,20 +127,31 @@ func discoverCachePVCs(ctx context.Context, k8sClient client.Client, namespace s
 	return infos, nil
 }
 
+// pvcRequestedBytes is the storage a PVC requested, the size `cache list`
+// measures utilization against.
+func pvcRequestedBytes(pvc *corev1.PersistentVolumeClaim) int64 {
+	if storage, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		return storage.Value()
+	}
+	return 0
+}
+
+// inspectPVCCache returns the cache entries found on the namespace's cache
+// PVCs, and the summed requested capacity of the PVCs it could inspect.
 func inspectPVCCache(
 	ctx context.Context, cfg *rest.Config, k8sClient client.Client, namespace string,
-) ([]PVCCacheEntry, error) {
+) ([]PVCCacheEntry, int64, error) {
 	pvcInfos, err := discoverCachePVCs(ctx, k8sClient, namespace)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if len(pvcInfos) == 0 {
-		return nil, nil
+		return nil, 0, nil
 	}
 
 	clientset, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create clientset: %w", err)
+		return nil, 0, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
 	// Non-nil even when no per-PVC entries are found: a successful inspection
@@ -142,6 +160,7 @@ func inspectPVCCache(
 	// would suppress STATUS and regress the listing to the pre-inspection
 	// format (#767).
 	allEntries := []PVCCacheEntry{}
+	var capacityBytes int64
 	for _, pvcInfo := range pvcInfos {
 		entries, err := inspectSinglePVC(ctx, cfg, k8sClient, clientset, namespace, pvcInfo)
 		if err != nil {
@@ -152,8 +171,9 @@ func inspectPVCCache(
 			continue
 		}
 		allEntries = append(allEntries, entries...)
+		capacityBytes += pvcInfo.CapacityBytes
 	}
-	return allEntries, nil
+	return allEntries, capacityBytes, nil
 }
 
 // inspectSinglePVC inspects the contents of one model cache PVC.
@@ -199,7 +219,11 @@ func inspectSinglePVC(
 		return nil, fmt.Errorf("failed to exec in pod: %w", err)
 	}
 
-	return parseDuOutput(output, pvcInfo.InferenceService), nil
+	entries := parseDuOutput(output, pvcInfo.InferenceService)
+	for i := range entries {
+		entries[i].PVC = pvcInfo.Name
+	}
+	return entries, nil
 }
 
 func findPodWithPVC(
@@ -273,17 +297,37 @@ func inspectorPodName(pvcName string) string {
 	return "llmkube-cache-inspector-" + cachekey.Compute(pvcName)
 }
 
+// cleanerPodName returns the per-PVC pod cache clear deletes through. It
+// differs from the inspector's name, so a cleaner started right after a
+// listing never collides with an inspector that is still terminating.
+func cleanerPodName(pvcName string) string {
+	return "llmkube-cache-cleaner-" + cachekey.Compute(pvcName)
+}
+
 func createInspectorPodForPVC(
 	ctx context.Context, clientset kubernetes.Interface, namespace, pvcName string,
 ) (string, error) {
-	podName := inspectorPodName(pvcName)
+	return createCachePodForPVC(ctx, clientset, namespace, inspectorPodName(pvcName), "cache-inspector", pvcName, true)
+}
+
+// createCleanerPodForPVC starts a pod like the inspector but with the cache
+// PVC mounted read-write, for cache clear to remove directories through.
+func createCleanerPodForPVC(
+	ctx context.Context, clientset kubernetes.Interface, namespace, pvcName string,
+) (string, error) {
+	return createCachePodForPVC(ctx, clientset, namespace, cleanerPodName(pvcName), "cache-cleaner", pvcName, false)
+}
+
+func createCachePodForPVC(
+	ctx context.Context, clientset kubernetes.Interface, namespace, podName, component, pvcName string, readOnly bool,
+) (string, error) {
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      podName,
 			Namespace: namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/managed-by": "llmkube-cli",
-				"app.kubernetes.io/component":  "cache-inspector",
+				"app.kubernetes.io/component":  component,
 			},
 		},
 		Spec: corev1.PodSpec{
@@ -297,7 +341,7 @@ func createInspectorPodForPVC(
 						{
 							Name:      "model-cache",
 							MountPath: defaultModelMountPath,
-							ReadOnly:  true,
+							ReadOnly:  readOnly,
 						},
 					},
 				},
@@ -308,7 +352,7 @@ func createInspectorPodForPVC(
 					VolumeSource: corev1.VolumeSource{
 						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 							ClaimName: pvcName,
-							ReadOnly:  true,
+							ReadOnly:  readOnly,
 						},
 					},
 				},
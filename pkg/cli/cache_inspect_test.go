@@ -23,6 +23,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
@@ -924,7 +925,7 @@ func TestInspectPVCCache_NoPVC(t *testing.T) {
 		WithScheme(newCoreScheme()).
 		Build()
 
-	entries, err := inspectPVCCache(context.Background(), nil, k8sClient, "default")
+	entries, _, err := inspectPVCCache(context.Background(), nil, k8sClient, "default")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1009,20 +1010,7 @@ func TestMergePVCEntriesWithModels(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Apply the same merge logic that runCacheList uses
-			for _, pe := range tt.pvcEntries {
-				if entry, exists := tt.cacheEntries[pe.CacheKey]; exists {
-					entry.Size = pe.SizeBytes
-					entry.SizeHuman = formatBytes(pe.SizeBytes)
-				} else {
-					tt.cacheEntries[pe.CacheKey] = &CacheEntry{
-						CacheKey:  pe.CacheKey,
-						Size:      pe.SizeBytes,
-						SizeHuman: formatBytes(pe.SizeBytes),
-						Status:    statusOrphaned,
-					}
-				}
-			}
+			mergePVCEntries(tt.cacheEntries, tt.pvcEntries)
 
 			var activeCount, orphanedCount int
 			for _, entry := range tt.cacheEntries {
@@ -1103,18 +1091,7 @@ func TestMergeOrphanedEntryHasNoModelNames(t *testing.T) {
 		{CacheKey: "orphan-key", SizeBytes: 9999},
 	}
 
-	for _, pe := range pvcEntries {
-		if entry, exists := cacheEntries[pe.CacheKey]; exists {
-			entry.Size = pe.SizeBytes
-		} else {
-			cacheEntries[pe.CacheKey] = &CacheEntry{
-				CacheKey:  pe.CacheKey,
-				Size:      pe.SizeBytes,
-				SizeHuman: formatBytes(pe.SizeBytes),
-				Status:    statusOrphaned,
-			}
-		}
-	}
+	mergePVCEntries(cacheEntries, pvcEntries)
 
 	entry := cacheEntries["orphan-key"]
 	if entry == nil {
@@ -1619,3 +1596,63 @@ func TestCreateInspectorPodForPVC_SpecificPVCName(t *testing.T) {
 		t.Errorf("PVC claim = %q, want %q", vol.PersistentVolumeClaim.ClaimName, pvcName)
 	}
 }
+
+func TestCacheUsageSummary(t *testing.T) {
+	const gib = int64(1) << 30
+	cacheEntries := map[string]*CacheEntry{
+		"active1": {CacheKey: "active1", ModelNames: []string{"llama"}, Status: statusActive},
+		"active2": {CacheKey: "active2", ModelNames: []string{"qwen"}, Status: statusActive},
+	}
+	mergePVCEntries(cacheEntries, []PVCCacheEntry{
+		{CacheKey: "active1", SizeBytes: 40 * gib, PVC: modelCachePVCName},
+		{CacheKey: "orphan1", SizeBytes: 15 * gib, PVC: modelCachePVCName},
+		// The same key on a per-service cache counts against that PVC too.
+		{CacheKey: "active1", SizeBytes: 40 * gib, PVC: "llama-model-cache"},
+	})
+
+	usage := cacheUsageSummary(cacheEntries, 100*gib)
+	if usage.UsedBytes != 95*gib {
+		t.Errorf("UsedBytes = %d, want %d", usage.UsedBytes, 95*gib)
+	}
+	if usage.UtilizationPercent != 95 {
+		t.Errorf("UtilizationPercent = %v, want 95", usage.UtilizationPercent)
+	}
+	if got, want := usage.String(), "95.0 GiB used of 100.0 GiB (95%)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if cacheEntries["active1"].Size != 80*gib {
+		t.Errorf("active1 size = %d, want the sum over both PVCs (%d)", cacheEntries["active1"].Size, 80*gib)
+	}
+
+	unknown := cacheUsageSummary(cacheEntries, 0)
+	if unknown.UtilizationPercent != 0 {
+		t.Errorf("UtilizationPercent without capacity = %v, want 0", unknown.UtilizationPercent)
+	}
+	if got, want := unknown.String(), "95.0 GiB used"; got != want {
+		t.Errorf("String() without capacity = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverCachePVCs_RequestedCapacity(t *testing.T) {
+	sharedPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: modelCachePVCName, Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newCoreScheme()).
+		WithObjects(sharedPVC).
+		Build()
+
+	infos, err := discoverCachePVCs(context.Background(), k8sClient, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(infos) != 1 || infos[0].CapacityBytes != 100<<30 {
+		t.Errorf("infos = %+v, want one PVC with 100Gi capacity", infos)
+	}
+}
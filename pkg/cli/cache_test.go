@@ -17,8 +17,15 @@ limitations under the License.
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 	"github.com/defilantech/llmkube/pkg/cachekey"
 )
 
@@ -134,6 +141,85 @@ func TestNewCacheListCommand(t *testing.T) {
 	}
 }
 
+func TestWriteCacheListJSON(t *testing.T) {
+	models := []inferencev1alpha1.Model{{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       inferencev1alpha1.ModelSpec{Source: "https://example.com/llama.gguf"},
+		Status:     inferencev1alpha1.ModelStatus{CacheKey: "aaaa000000000001"},
+	}}
+	cacheEntries := modelCacheEntries(models, false)
+	mergePVCEntries(cacheEntries, []PVCCacheEntry{
+		{CacheKey: "aaaa000000000001", SizeBytes: 3072, PVC: modelCachePVCName},
+		{CacheKey: "ffff000000000002", SizeBytes: 1024, PVC: modelCachePVCName},
+	})
+	usage := cacheUsageSummary(cacheEntries, 8192)
+
+	var buf bytes.Buffer
+	if err := writeCacheList(&buf, newCacheList(cacheEntries, len(models), &usage), false, "json"); err != nil {
+		t.Fatalf("writeCacheList: %v", err)
+	}
+
+	var got struct {
+		Entries []struct {
+			CacheKey   string   `json:"cache_key"`
+			Source     string   `json:"source"`
+			ModelNames []string `json:"model_names"`
+			Status     string   `json:"status"`
+			Size       int64    `json:"size"`
+			SizeHuman  string   `json:"size_human"`
+		} `json:"entries"`
+		Total    int        `json:"total"`
+		Active   int        `json:"active"`
+		Orphaned int        `json:"orphaned"`
+		Models   int        `json:"models"`
+		Usage    CacheUsage `json:"usage"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if got.Total != 2 || got.Active != 1 || got.Orphaned != 1 || got.Models != 1 {
+		t.Errorf("counts = total %d, active %d, orphaned %d, models %d; want 2, 1, 1, 1",
+			got.Total, got.Active, got.Orphaned, got.Models)
+	}
+	if got.Usage.UsedBytes != 4096 || got.Usage.CapacityBytes != 8192 || got.Usage.UtilizationPercent != 50 {
+		t.Errorf("usage = %+v, want 4096 of 8192 bytes (50%%)", got.Usage)
+	}
+	if len(got.Entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(got.Entries))
+	}
+	active, orphaned := got.Entries[0], got.Entries[1]
+	if active.CacheKey != "aaaa000000000001" || active.Status != statusActive ||
+		active.Source != "https://example.com/llama.gguf" || !reflect.DeepEqual(active.ModelNames, []string{"llama"}) ||
+		active.Size != 3072 || active.SizeHuman != "3.0 KiB" {
+		t.Errorf("active entry = %+v", active)
+	}
+	if orphaned.CacheKey != "ffff000000000002" || orphaned.Status != statusOrphaned ||
+		orphaned.ModelNames == nil || len(orphaned.ModelNames) != 0 {
+		t.Errorf("orphaned entry = %+v, want an orphan with an empty model_names list", orphaned)
+	}
+	if !strings.Contains(buf.String(), `"model_names": []`) {
+		t.Errorf("expected orphaned model_names to encode as [], got\n%s", buf.String())
+	}
+}
+
+func TestWriteCacheListJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeCacheList(&buf, newCacheList(map[string]*CacheEntry{}, 0, nil), true, "json"); err != nil {
+		t.Fatalf("writeCacheList: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if entries, ok := got["entries"].([]any); !ok || len(entries) != 0 {
+		t.Errorf("entries = %v, want an empty list", got["entries"])
+	}
+	if _, ok := got["usage"]; ok {
+		t.Error("expected no usage when the PVCs were not inspected")
+	}
+}
+
 func TestNewCacheClearCommand(t *testing.T) {
 	cmd := newCacheClearCommand()
 
@@ -141,7 +227,7 @@ func TestNewCacheClearCommand(t *testing.T) {
 		t.Errorf("Use = %q, want %q", cmd.Use, "clear")
 	}
 
-	expectedFlags := []string{"model", "namespace", "force"}
+	expectedFlags := []string{"model", "key", "namespace", "orphaned", "dry-run", "force"}
 	for _, name := range expectedFlags {
 		if cmd.Flags().Lookup(name) == nil {
 			t.Errorf("Missing flag %q", name)
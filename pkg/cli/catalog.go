@@ -18,7 +18,9 @@ package cli
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -64,12 +66,17 @@ func LoadCatalog() (*Catalog, error) {
 		return catalogInstance, nil
 	}
 
-	var catalog Catalog
-	if err := yaml.Unmarshal(modelcatalog.CatalogYAML, &catalog); err != nil {
+	var builtin Catalog
+	if err := yaml.Unmarshal(modelcatalog.CatalogYAML, &builtin); err != nil {
 		return nil, fmt.Errorf("failed to parse catalog: %w", err)
 	}
 
-	catalogInstance = &catalog
+	catalog, err := applyCatalogFile(&builtin)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogInstance = catalog
 	return catalogInstance, nil
 }
 
@@ -89,8 +96,9 @@ func GetModel(modelID string) (*Model, error) {
 
 func NewCatalogCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "catalog",
-		Short: "Manage and browse the model catalog",
+		Use:     "catalog",
+		Aliases: []string{"models"},
+		Short:   "Manage and browse the model catalog",
 		Long: `Browse pre-configured LLM models in the catalog.
 
 The catalog contains battle-tested models with optimized settings for
@@ -105,22 +113,49 @@ Examples:
 
   # Filter by tags
   llmkube catalog list --tag code
+
+  # Find a model when you only half-remember its ID
+  llmkube catalog search qwen coder
+
+  # Add your own models (same format as the built-in catalog); also read
+  # from $LLMKUBE_CATALOG, and honored by deploy and benchmark --catalog
+  llmkube catalog list --catalog-file ./team-catalog.yaml
 `,
 	}
 
 	cmd.AddCommand(NewCatalogListCommand())
 	cmd.AddCommand(NewCatalogInfoCommand())
+	cmd.AddCommand(NewCatalogSearchCommand())
 
 	return cmd
 }
 
+// catalogListOptions selects and formats the models printed by catalog list.
+type catalogListOptions struct {
+	tag    string
+	filter string
+	output string
+}
+
+// catalogListEntry is one model of catalog list --output json.
+type catalogListEntry struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Size         string   `json:"size"`
+	Quantization string   `json:"quantization"`
+	VRAMEstimate string   `json:"vram_estimate"`
+	ContextSize  int      `json:"context_size"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
 func NewCatalogListCommand() *cobra.Command {
-	var tagFilter string
+	opts := catalogListOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all models in the catalog",
 		Long: `List all pre-configured models in the catalog with their key specifications.
+The IDs listed are the ones deploy and benchmark --catalog take.
 
 Examples:
   # List all models
@@ -129,13 +164,26 @@ Examples:
   # Filter by tag
   llmkube catalog list --tag code
   llmkube catalog list --tag recommended
+
+  # Filter by name or size
+  llmkube catalog list --filter qwen
+  llmkube catalog list --filter 7B
+
+  # Machine-readable, e.g. to build a --catalog list
+  llmkube catalog list --filter 8B -o json | jq -r '.[].id' | paste -sd, -
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCatalogList(tagFilter)
+			if opts.output != "table" && opts.output != "json" {
+				return fmt.Errorf("--output must be table or json, got %q", opts.output)
+			}
+			return runCatalogList(os.Stdout, opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&tagFilter, "tag", "", "Filter models by tag (e.g., code, small, recommended)")
+	cmd.Flags().StringVar(&opts.tag, "tag", "", "Filter models by tag (e.g., code, small, recommended)")
+	cmd.Flags().StringVar(&opts.filter, "filter", "",
+		"Only list models whose name or size contains this text (case-insensitive)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "table", "Output format: table or json")
 
 	return cmd
 }
@@ -159,42 +207,75 @@ Examples:
 	return cmd
 }
 
-func runCatalogList(tagFilter string) error {
+// matchesCatalogFilter reports whether model's name or size contains filter,
+// ignoring case.
+func matchesCatalogFilter(model Model, filter string) bool {
+	filter = strings.ToLower(filter)
+	return strings.Contains(strings.ToLower(model.Name), filter) ||
+		strings.Contains(strings.ToLower(model.Size), filter)
+}
+
+func runCatalogList(w io.Writer, opts catalogListOptions) error {
 	catalog, err := LoadCatalog()
 	if err != nil {
 		return err
 	}
 
 	modelIDs := make([]string, 0, len(catalog.Models))
-	for id := range catalog.Models {
+	for id, model := range catalog.Models {
+		if opts.tag != "" && !containsTag(model.Tags, opts.tag) {
+			continue
+		}
+		if opts.filter != "" && !matchesCatalogFilter(model, opts.filter) {
+			continue
+		}
 		modelIDs = append(modelIDs, id)
 	}
 	sort.Strings(modelIDs)
 
-	filteredIDs := []string{}
-	if tagFilter != "" {
+	if opts.output == "json" {
+		entries := make([]catalogListEntry, 0, len(modelIDs))
 		for _, id := range modelIDs {
 			model := catalog.Models[id]
-			if containsTag(model.Tags, tagFilter) {
-				filteredIDs = append(filteredIDs, id)
-			}
+			entries = append(entries, catalogListEntry{
+				ID:           id,
+				Name:         model.Name,
+				Size:         model.Size,
+				Quantization: model.Quantization,
+				VRAMEstimate: model.VRAMEstimate,
+				ContextSize:  model.ContextSize,
+				Tags:         model.Tags,
+			})
 		}
-		if len(filteredIDs) == 0 {
-			fmt.Printf("No models found with tag '%s'\n", tagFilter)
-			return nil
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(modelIDs) == 0 {
+		switch {
+		case opts.tag != "" && opts.filter != "":
+			_, _ = fmt.Fprintf(w, "No models found with tag '%s' matching '%s'\n", opts.tag, opts.filter)
+		case opts.tag != "":
+			_, _ = fmt.Fprintf(w, "No models found with tag '%s'\n", opts.tag)
+		default:
+			_, _ = fmt.Fprintf(w, "No models found matching '%s'\n", opts.filter)
 		}
-		modelIDs = filteredIDs
+		return nil
 	}
 
-	fmt.Printf("\n📚 LLMKube Model Catalog (v%s)\n", catalog.Version)
-	if tagFilter != "" {
-		fmt.Printf("Filter: tag=%s\n", tagFilter)
+	_, _ = fmt.Fprintf(w, "\n📚 LLMKube Model Catalog (v%s)\n", catalog.Version)
+	if opts.tag != "" {
+		_, _ = fmt.Fprintf(w, "Filter: tag=%s\n", opts.tag)
 	}
-	fmt.Printf("═══════════════════════════════════════════════════════════════════════\n\n")
+	if opts.filter != "" {
+		_, _ = fmt.Fprintf(w, "Filter: name/size contains %q\n", opts.filter)
+	}
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════════════\n\n")
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(w, "ID\tNAME\tSIZE\tQUANT\tUSE CASE\tVRAM")
-	_, _ = fmt.Fprintln(w, "──\t────\t────\t─────\t────────\t────")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNAME\tSIZE\tQUANT\tUSE CASE\tVRAM\tCONTEXT")
+	_, _ = fmt.Fprintln(tw, "──\t────\t────\t─────\t────────\t────\t───────")
 
 	for _, id := range modelIDs {
 		model := catalog.Models[id]
@@ -203,20 +284,22 @@ func runCatalogList(tagFilter string) error {
 			useCase = formatUseCase(model.UseCases[0])
 		}
 
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			id,
 			truncate(model.Name, 30),
 			model.Size,
 			model.Quantization,
 			truncate(useCase, 20),
 			model.VRAMEstimate,
+			formatNumber(model.ContextSize),
 		)
 	}
 
-	_ = w.Flush()
+	_ = tw.Flush()
 
-	fmt.Printf("\n💡 To deploy: llmkube deploy <MODEL_ID> --gpu\n")
-	fmt.Printf("💡 For details: llmkube catalog info <MODEL_ID>\n\n")
+	_, _ = fmt.Fprintf(w, "\n💡 To deploy: llmkube deploy <MODEL_ID> --gpu\n")
+	_, _ = fmt.Fprintf(w, "💡 For details: llmkube catalog info <MODEL_ID>\n")
+	_, _ = fmt.Fprintf(w, "💡 To benchmark: llmkube benchmark --catalog <MODEL_ID>[,<MODEL_ID>...] --gpu\n\n")
 
 	return nil
 }
@@ -0,0 +1,193 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// User-supplied catalogs: --catalog-file (or LLMKUBE_CATALOG) names a YAML
+// or JSON file, or an http(s) URL, in the same format as the built-in
+// catalog. Its models are added to the built-in ones, overriding entries
+// with the same ID, unless it sets "replace: true" to stand alone.
+
+const (
+	catalogFileEnv      = "LLMKUBE_CATALOG"
+	catalogFetchTimeout = 30 * time.Second
+)
+
+// catalogFilePath is set by the root command's --catalog-file flag.
+var catalogFilePath string
+
+// userCatalog is the file format of a user-supplied catalog.
+type userCatalog struct {
+	Version string           `yaml:"version"`
+	Replace bool             `yaml:"replace"`
+	Models  map[string]Model `yaml:"models"`
+}
+
+// catalogFileSource returns the user catalog to load, the flag taking
+// precedence over the environment, or "" for the built-in catalog alone.
+func catalogFileSource() string {
+	if catalogFilePath != "" {
+		return catalogFilePath
+	}
+	return os.Getenv(catalogFileEnv)
+}
+
+func readCatalogSource(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog file: %w", err)
+		}
+		return data, nil
+	}
+
+	client := &http.Client{Timeout: catalogFetchTimeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch catalog %s: %w", source, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch catalog %s: HTTP %d", source, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch catalog %s: %w", source, err)
+	}
+	return data, nil
+}
+
+// parseUserCatalog decodes a YAML or JSON catalog, rejecting unknown keys
+// so a misspelled field fails loudly instead of deploying with a default.
+func parseUserCatalog(data []byte, source string) (*userCatalog, error) {
+	var catalog userCatalog
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&catalog); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("catalog %s is empty", source)
+		}
+		return nil, fmt.Errorf("failed to parse catalog %s: %w", source, err)
+	}
+	if len(catalog.Models) == 0 {
+		return nil, fmt.Errorf("catalog %s defines no models", source)
+	}
+
+	ids := make([]string, 0, len(catalog.Models))
+	for id := range catalog.Models {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var problems []error
+	for _, id := range ids {
+		if err := validateCatalogModel(catalog.Models[id]); err != nil {
+			problems = append(problems, fmt.Errorf("model %q: %w", id, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid catalog %s: %w", source, errors.Join(problems...))
+	}
+	return &catalog, nil
+}
+
+// validateCatalogModel checks the fields deploy and benchmark --catalog
+// cannot work without.
+func validateCatalogModel(model Model) error {
+	var missing []string
+	for _, field := range []struct{ name, value string }{
+		{"name", model.Name},
+		{"source", model.Source},
+		{"size", model.Size},
+		{"quantization", model.Quantization},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required field(s): %s", strings.Join(missing, ", "))
+	}
+	if model.ContextSize <= 0 {
+		return fmt.Errorf("context_size must be positive, got %d", model.ContextSize)
+	}
+	if model.GPULayers < 0 {
+		return fmt.Errorf("gpu_layers must not be negative, got %d", model.GPULayers)
+	}
+	return nil
+}
+
+// mergeCatalog combines the built-in catalog with a user catalog and
+// returns the built-in IDs the user catalog overrides. A replacing user
+// catalog overrides nothing; it is the whole catalog.
+func mergeCatalog(builtin *Catalog, user *userCatalog) (*Catalog, []string) {
+	if user.Replace {
+		version := user.Version
+		if version == "" {
+			version = "custom"
+		}
+		return &Catalog{Version: version, Models: user.Models}, nil
+	}
+
+	merged := &Catalog{Version: builtin.Version, Models: make(map[string]Model, len(builtin.Models)+len(user.Models))}
+	for id, model := range builtin.Models {
+		merged.Models[id] = model
+	}
+	var overridden []string
+	for id, model := range user.Models {
+		if _, exists := merged.Models[id]; exists {
+			overridden = append(overridden, id)
+		}
+		merged.Models[id] = model
+	}
+	sort.Strings(overridden)
+	return merged, overridden
+}
+
+// applyCatalogFile loads the user catalog, if one is configured, on top of
+// builtin and warns on stderr about the built-in entries it overrides.
+func applyCatalogFile(builtin *Catalog) (*Catalog, error) {
+	source := catalogFileSource()
+	if source == "" {
+		return builtin, nil
+	}
+	data, err := readCatalogSource(source)
+	if err != nil {
+		return nil, err
+	}
+	user, err := parseUserCatalog(data, source)
+	if err != nil {
+		return nil, err
+	}
+	merged, overridden := mergeCatalog(builtin, user)
+	if len(overridden) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "⚠️  Catalog %s overrides built-in model(s): %s\n", source, strings.Join(overridden, ", "))
+	}
+	return merged, nil
+}
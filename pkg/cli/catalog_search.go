@@ -0,0 +1,220 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// catalogMatch is one ranked result of a catalog search.
+type catalogMatch struct {
+	ID    string
+	Model Model
+	Score float64
+}
+
+// Field weights: an ID hit is the strongest signal since the ID is what
+// --catalog and deploy take; tags are the loosest.
+const (
+	catalogSearchIDWeight   = 1.0
+	catalogSearchNameWeight = 0.9
+	catalogSearchTagWeight  = 0.7
+)
+
+func NewCatalogSearchCommand() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search QUERY",
+		Short: "Fuzzy-search catalog models by ID, name, and tags",
+		Long: `Search the catalog when you don't remember a model's exact ID.
+
+Every word of the query must match the model's ID, name, or a tag, either
+exactly, as a substring, as an abbreviation (letters in order), or with a
+small typo. Results are ranked best match first.
+
+Examples:
+  llmkube catalog search qwen coder
+  llmkube catalog search lama 8b
+  llmkube models search deepsek
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCatalogSearch(os.Stdout, strings.Join(args, " "), limit)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of matches to show (0 for all)")
+
+	return cmd
+}
+
+func runCatalogSearch(w io.Writer, query string, limit int) error {
+	catalog, err := LoadCatalog()
+	if err != nil {
+		return err
+	}
+
+	matches := searchCatalog(catalog, query)
+	if len(matches) == 0 {
+		_, _ = fmt.Fprintf(w, "No catalog models match %q\n", query)
+		_, _ = fmt.Fprintf(w, "💡 Browse everything: llmkube catalog list\n")
+		return nil
+	}
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	_, _ = fmt.Fprintf(w, "\n🔎 Catalog matches for %q\n", query)
+	_, _ = fmt.Fprintf(w, "═══════════════════════════════════════════════════════════════════════\n\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNAME\tSIZE\tTAGS")
+	_, _ = fmt.Fprintln(tw, "──\t────\t────\t────")
+	for _, m := range matches {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			m.ID,
+			truncate(m.Model.Name, 30),
+			m.Model.Size,
+			truncate(strings.Join(m.Model.Tags, ","), 30),
+		)
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "\n💡 For details: llmkube catalog info %s\n\n", matches[0].ID)
+	return nil
+}
+
+// searchCatalog ranks catalog models against query. Each whitespace-separated
+// term is scored against the ID, name, and tags and the best field wins; a
+// model matches only when every term does, and its score is the sum. Ties
+// sort by ID so output is stable.
+func searchCatalog(catalog *Catalog, query string) []catalogMatch {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var matches []catalogMatch
+	for id, model := range catalog.Models {
+		total := 0.0
+		for _, term := range terms {
+			best := fuzzyFieldScore(term, id) * catalogSearchIDWeight
+			best = max(best, fuzzyFieldScore(term, model.Name)*catalogSearchNameWeight)
+			for _, tag := range model.Tags {
+				best = max(best, fuzzyFieldScore(term, tag)*catalogSearchTagWeight)
+			}
+			if best == 0 {
+				total = 0
+				break
+			}
+			total += best
+		}
+		if total > 0 {
+			matches = append(matches, catalogMatch{ID: id, Model: model, Score: total})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	return matches
+}
+
+// fuzzyFieldScore scores one lowercase term against a field, from 100 for an
+// exact match down to 20 for an in-order abbreviation; 0 means no match.
+func fuzzyFieldScore(term, field string) float64 {
+	field = strings.ToLower(field)
+	switch {
+	case field == term:
+		return 100
+	case strings.HasPrefix(field, term):
+		return 80
+	}
+
+	tokens := strings.FieldsFunc(field, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' ' || r == '.' || r == '/'
+	})
+	for _, tok := range tokens {
+		if tok == term {
+			return 70
+		}
+	}
+	if strings.Contains(field, term) {
+		return 60
+	}
+
+	// Typo tolerance: one edit for short terms, two for longer ones.
+	maxEdits := 1
+	if len(term) > 5 {
+		maxEdits = 2
+	}
+	best := maxEdits + 1
+	for _, tok := range tokens {
+		best = min(best, editDistance(term, tok))
+	}
+	if best <= maxEdits {
+		return float64(40 - 10*best)
+	}
+
+	if isSubsequence(term, field) {
+		return 20
+	}
+	return 0
+}
+
+// isSubsequence reports whether every byte of sub appears in s in order.
+func isSubsequence(sub, s string) bool {
+	i := 0
+	for j := 0; i < len(sub) && j < len(s); j++ {
+		if sub[i] == s[j] {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
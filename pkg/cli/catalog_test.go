@@ -18,7 +18,12 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -424,21 +429,12 @@ func TestRecommendedModelsExist(t *testing.T) {
 }
 
 func TestRunCatalogList(t *testing.T) {
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	err := runCatalogList("")
-
-	_ = w.Close()
-	os.Stdout = old
-
+	var buf bytes.Buffer
+	err := runCatalogList(&buf, catalogListOptions{})
 	if err != nil {
 		t.Fatalf("runCatalogList error: %v", err)
 	}
 
-	var buf bytes.Buffer
-	_, _ = buf.ReadFrom(r)
 	output := buf.String()
 
 	if !strings.Contains(output, "Model Catalog") {
@@ -453,21 +449,12 @@ func TestRunCatalogList(t *testing.T) {
 }
 
 func TestRunCatalogListWithTag(t *testing.T) {
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	err := runCatalogList("code")
-
-	_ = w.Close()
-	os.Stdout = old
-
+	var buf bytes.Buffer
+	err := runCatalogList(&buf, catalogListOptions{tag: "code"})
 	if err != nil {
 		t.Fatalf("runCatalogList(code) error: %v", err)
 	}
 
-	var buf bytes.Buffer
-	_, _ = buf.ReadFrom(r)
 	output := buf.String()
 
 	if !strings.Contains(output, "tag=code") {
@@ -476,21 +463,12 @@ func TestRunCatalogListWithTag(t *testing.T) {
 }
 
 func TestRunCatalogListWithNonexistentTag(t *testing.T) {
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	err := runCatalogList("nonexistent-tag-xyz")
-
-	_ = w.Close()
-	os.Stdout = old
-
+	var buf bytes.Buffer
+	err := runCatalogList(&buf, catalogListOptions{tag: "nonexistent-tag-xyz"})
 	if err != nil {
 		t.Fatalf("runCatalogList(nonexistent) error: %v", err)
 	}
 
-	var buf bytes.Buffer
-	_, _ = buf.ReadFrom(r)
 	output := buf.String()
 
 	if !strings.Contains(output, "No models found") {
@@ -498,6 +476,58 @@ func TestRunCatalogListWithNonexistentTag(t *testing.T) {
 	}
 }
 
+func TestRunCatalogListJSONIncludesKnownIDs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runCatalogList(&buf, catalogListOptions{output: "json"}); err != nil {
+		t.Fatalf("runCatalogList error: %v", err)
+	}
+	var entries []catalogListEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("output is not a JSON list: %v\n%s", err, buf.String())
+	}
+	byID := make(map[string]catalogListEntry, len(entries))
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+	for _, id := range []string{"llama-3.1-8b", "qwen-2.5-coder-7b", "mistral-7b", "deepseek-r1-32b"} {
+		e, ok := byID[id]
+		if !ok {
+			t.Errorf("catalog list is missing %s", id)
+			continue
+		}
+		if e.Name == "" || e.Size == "" || e.Quantization == "" || e.VRAMEstimate == "" || e.ContextSize == 0 {
+			t.Errorf("%s has empty fields: %+v", id, e)
+		}
+	}
+}
+
+func TestRunCatalogListFilter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runCatalogList(&buf, catalogListOptions{filter: "QWEN", output: "json"}); err != nil {
+		t.Fatalf("runCatalogList error: %v", err)
+	}
+	var entries []catalogListEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("output is not a JSON list: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("--filter QWEN matched nothing")
+	}
+	for _, e := range entries {
+		if !strings.Contains(strings.ToLower(e.Name+e.Size), "qwen") {
+			t.Errorf("%s (%s, %s) does not match --filter QWEN", e.ID, e.Name, e.Size)
+		}
+	}
+
+	buf.Reset()
+	if err := runCatalogList(&buf, catalogListOptions{filter: "zzzzzz"}); err != nil {
+		t.Fatalf("runCatalogList error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No models found matching 'zzzzzz'") {
+		t.Errorf("expected no-match message, got:\n%s", buf.String())
+	}
+}
+
 func TestRunCatalogInfo(t *testing.T) {
 	old := os.Stdout
 	r, w, _ := os.Pipe()
@@ -552,6 +582,12 @@ func TestNewCatalogCommand(t *testing.T) {
 	if !subcommands["info"] {
 		t.Error("Missing 'info' subcommand")
 	}
+	if !subcommands["search"] {
+		t.Error("Missing 'search' subcommand")
+	}
+	if len(cmd.Aliases) != 1 || cmd.Aliases[0] != "models" {
+		t.Errorf("Aliases = %v, want [models]", cmd.Aliases)
+	}
 }
 
 func TestNewCatalogListCommand(t *testing.T) {
@@ -560,8 +596,10 @@ func TestNewCatalogListCommand(t *testing.T) {
 	if cmd.Use != "list" {
 		t.Errorf("Use = %q, want %q", cmd.Use, "list")
 	}
-	if cmd.Flags().Lookup("tag") == nil {
-		t.Error("Missing --tag flag")
+	for _, flag := range []string{"tag", "filter", "output"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("Missing --%s flag", flag)
+		}
 	}
 }
 
@@ -572,3 +610,194 @@ func TestNewCatalogInfoCommand(t *testing.T) {
 		t.Errorf("Use = %q, want %q", cmd.Use, "info MODEL_ID")
 	}
 }
+
+func TestSearchCatalogRanksFuzzyMatches(t *testing.T) {
+	catalog, err := LoadCatalog()
+	if err != nil {
+		t.Fatalf("Failed to load catalog: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"llama-3.1-8b", "llama-3.1-8b"},
+		{"lama 8b", "llama-3.1-8b"},
+		{"deepsek r1 32", "deepseek-r1-32b"},
+		{"mixtrl", "mixtral-8x7b"},
+		{"qwen coder 7b", "qwen-2.5-coder-7b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches := searchCatalog(catalog, tt.query)
+			if len(matches) == 0 {
+				t.Fatalf("searchCatalog(%q) returned no matches", tt.query)
+			}
+			if matches[0].ID != tt.want {
+				t.Errorf("top match for %q = %q, want %q", tt.query, matches[0].ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchCatalogRequiresEveryTerm(t *testing.T) {
+	catalog, err := LoadCatalog()
+	if err != nil {
+		t.Fatalf("Failed to load catalog: %v", err)
+	}
+
+	if matches := searchCatalog(catalog, "qwen zzzzzz"); len(matches) != 0 {
+		t.Errorf("expected no matches when one term matches nothing, got %d", len(matches))
+	}
+	if matches := searchCatalog(catalog, "   "); matches != nil {
+		t.Errorf("expected nil for an empty query, got %d matches", len(matches))
+	}
+}
+
+func TestRunCatalogSearchOutput(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runCatalogSearch(&buf, "qwen coder", 1); err != nil {
+		t.Fatalf("runCatalogSearch returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "qwen-2.5-coder-") {
+		t.Errorf("output missing coder match:\n%s", out)
+	}
+	if strings.Count(out, "qwen-2.5-coder-") != 2 {
+		t.Errorf("--limit 1 should list one row plus the info hint:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := runCatalogSearch(&buf, "zzzzzz", 10); err != nil {
+		t.Fatalf("runCatalogSearch returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No catalog models match") {
+		t.Errorf("expected no-match message, got:\n%s", buf.String())
+	}
+}
+
+// useCatalogFile points LoadCatalog at source for the rest of the test.
+func useCatalogFile(t *testing.T, source string) {
+	t.Helper()
+	catalogFilePath = source
+	catalogInstance = nil
+	t.Cleanup(func() {
+		catalogFilePath = ""
+		catalogInstance = nil
+	})
+}
+
+const userCatalogYAML = `version: "team-1"
+models:
+  team-coder-14b:
+    name: "Team Coder 14B"
+    size: "14B"
+    quantization: "Q4_K_M"
+    source: "https://models.internal.example/team-coder-14b.gguf"
+    context_size: 16384
+    gpu_layers: 49
+    vram_estimate: "10-12GB"
+  llama-3.1-8b:
+    name: "Llama 3.1 8B (mirrored)"
+    size: "8B"
+    quantization: "Q4_K_M"
+    source: "https://models.internal.example/llama-3.1-8b.gguf"
+    context_size: 8192
+`
+
+func TestLoadCatalogMergesUserFile(t *testing.T) {
+	builtin, err := LoadCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	builtinCount := len(builtin.Models)
+
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(path, []byte(userCatalogYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The environment variable is honored when the flag is not set.
+	useCatalogFile(t, "")
+	t.Setenv(catalogFileEnv, path)
+
+	catalog, err := LoadCatalog()
+	if err != nil {
+		t.Fatalf("LoadCatalog() with a user file: %v", err)
+	}
+	if len(catalog.Models) != builtinCount+1 {
+		t.Errorf("merged catalog has %d models, want %d built-in + 1 new", len(catalog.Models), builtinCount)
+	}
+	if _, err := GetModel("team-coder-14b"); err != nil {
+		t.Errorf("user model not found: %v", err)
+	}
+	override, err := GetModel("llama-3.1-8b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if override.Source != "https://models.internal.example/llama-3.1-8b.gguf" {
+		t.Errorf("user entry did not override the built-in one: source = %s", override.Source)
+	}
+	if _, err := GetModel("mistral-7b"); err != nil {
+		t.Errorf("built-in model lost in merge: %v", err)
+	}
+
+	user, err := parseUserCatalog([]byte(userCatalogYAML), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, overridden := mergeCatalog(builtin, user); !slices.Equal(overridden, []string{"llama-3.1-8b"}) {
+		t.Errorf("overridden = %v, want the conflicting built-in ID reported", overridden)
+	}
+}
+
+func TestLoadCatalogReplacesFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "airgap", "replace": true, "models": {"only-model": {
+			"name": "Only Model", "size": "7B", "quantization": "Q8_0",
+			"source": "/mnt/models/only.gguf", "context_size": 4096}}}`))
+	}))
+	defer server.Close()
+	useCatalogFile(t, server.URL+"/catalog.json")
+
+	catalog, err := LoadCatalog()
+	if err != nil {
+		t.Fatalf("LoadCatalog() from URL: %v", err)
+	}
+	if catalog.Version != "airgap" || len(catalog.Models) != 1 {
+		t.Errorf("replacing catalog = version %q with %d models, want airgap with 1", catalog.Version, len(catalog.Models))
+	}
+	if _, err := GetModel("llama-3.1-8b"); err == nil {
+		t.Error("built-in model still present after replace: true")
+	}
+}
+
+func TestParseUserCatalogRejectsMalformedFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"empty file", "", "is empty"},
+		{"not YAML", "models: [unclosed", "failed to parse"},
+		{"no models", `version: "1"`, "defines no models"},
+		{"misspelled field", "models:\n  m:\n    name: M\n    sizee: 7B\n", "sizee"},
+		{"missing fields", "models:\n  m:\n    name: M\n    context_size: 4096\n",
+			`model "m": missing required field(s): source, size, quantization`},
+		{"bad context size", "models:\n  m: {name: M, source: s, size: 7B, quantization: Q4_K_M}\n",
+			"context_size must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseUserCatalog([]byte(tt.data), "test.yaml")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseUserCatalog() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	useCatalogFile(t, filepath.Join(t.TempDir(), "missing.yaml"))
+	if _, err := LoadCatalog(); err == nil {
+		t.Error("LoadCatalog() with a missing catalog file should fail")
+	}
+}
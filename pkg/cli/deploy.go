@@ -231,6 +231,43 @@ Examples:
 func runDeploy(opts *deployOptions) error {
 	ctx := context.Background()
 
+	if err := resolveDeployOptions(opts); err != nil {
+		return err
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	if err := inferencev1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		return fmt.Errorf("failed to add scheme: %w", err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	printDeploySummary(opts)
+
+	if err := createDeployment(ctx, k8sClient, opts); err != nil {
+		return err
+	}
+
+	if opts.wait {
+		fmt.Printf("\nWaiting for deployment to be ready (timeout: %s)...\n", opts.timeout)
+		if err := waitForReady(ctx, k8sClient, opts.name, opts.namespace, opts.timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveDeployOptions fills opts in from the catalog when no --source is
+// given, validates local sources and picks the accelerator and image.
+func resolveDeployOptions(opts *deployOptions) error {
 	if opts.metalMemoryFraction != 0 && (opts.metalMemoryFraction < 0 || opts.metalMemoryFraction > 1.0) {
 		return fmt.Errorf("--memory-fraction must be between 0.0 and 1.0, got %f", opts.metalMemoryFraction)
 	}
@@ -276,24 +313,31 @@ func runDeploy(opts *deployOptions) error {
 		cacheKey := cachekey.Compute(opts.modelSource)
 		fmt.Printf("📦 Deploying from model cache (cache key: %s)\n", cacheKey)
 	}
+	return nil
+}
 
-	cfg, err := config.GetConfig()
-	if err != nil {
-		return fmt.Errorf("failed to get kubeconfig: %w", err)
-	}
-
-	if err := inferencev1alpha1.AddToScheme(scheme.Scheme); err != nil {
-		return fmt.Errorf("failed to add scheme: %w", err)
+// createDeployment creates the Model and InferenceService for opts. The
+// Model is deleted again when the InferenceService cannot be created, so a
+// failed deploy can simply be retried.
+func createDeployment(ctx context.Context, k8sClient client.Client, opts *deployOptions) error {
+	fmt.Printf("📦 Creating Model '%s'...\n", opts.name)
+	model := buildModel(opts)
+	if err := k8sClient.Create(ctx, model); err != nil {
+		return fmt.Errorf("failed to create Model: %w", err)
 	}
+	fmt.Printf("   ✅ Model created\n\n")
 
-	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
-		return fmt.Errorf("failed to create client: %w", err)
+	fmt.Printf("⚙️  Creating InferenceService '%s'...\n", opts.name)
+	inferenceService := buildInferenceService(opts)
+	if err := k8sClient.Create(ctx, inferenceService); err != nil {
+		_ = k8sClient.Delete(ctx, model)
+		return fmt.Errorf("failed to create InferenceService: %w", err)
 	}
+	fmt.Printf("   ✅ InferenceService created\n")
+	return nil
+}
 
-	printDeploySummary(opts)
-
-	fmt.Printf("📦 Creating Model '%s'...\n", opts.name)
+func buildModel(opts *deployOptions) *inferencev1alpha1.Model {
 	model := &inferencev1alpha1.Model{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      opts.name,
@@ -337,27 +381,7 @@ func runDeploy(opts *deployOptions) error {
 		model.Spec.Hardware.MemoryFraction = &opts.metalMemoryFraction
 	}
 
-	if err := k8sClient.Create(ctx, model); err != nil {
-		return fmt.Errorf("failed to create Model: %w", err)
-	}
-	fmt.Printf("   ✅ Model created\n\n")
-
-	fmt.Printf("⚙️  Creating InferenceService '%s'...\n", opts.name)
-	inferenceService := buildInferenceService(opts)
-
-	if err := k8sClient.Create(ctx, inferenceService); err != nil {
-		return fmt.Errorf("failed to create InferenceService: %w", err)
-	}
-	fmt.Printf("   ✅ InferenceService created\n")
-
-	if opts.wait {
-		fmt.Printf("\nWaiting for deployment to be ready (timeout: %s)...\n", opts.timeout)
-		if err := waitForReady(ctx, k8sClient, opts.name, opts.namespace, opts.timeout); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return model
 }
 
 func buildInferenceService(opts *deployOptions) *inferencev1alpha1.InferenceService {
@@ -17,11 +17,19 @@ limitations under the License.
 package cli
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 	"github.com/defilantech/llmkube/pkg/cachekey"
 )
@@ -452,8 +460,7 @@ func TestDeployOptions_MemoryFractionSetsHardwareSpec(t *testing.T) {
 		metalMemoryFraction: 0.8,
 	}
 
-	// Build the model the same way runDeploy does
-	model := buildTestModel(opts)
+	model := buildModel(opts)
 
 	if model.Spec.Hardware == nil {
 		t.Fatal("Hardware is nil")
@@ -478,7 +485,7 @@ func TestDeployOptions_MemoryBudgetSetsHardwareSpec(t *testing.T) {
 		metalMemoryBudget: "24Gi",
 	}
 
-	model := buildTestModel(opts)
+	model := buildModel(opts)
 
 	if model.Spec.Hardware == nil {
 		t.Fatal("Hardware is nil")
@@ -500,51 +507,13 @@ func TestDeployOptions_ZeroMemoryFractionOmitted(t *testing.T) {
 		metalMemoryFraction: 0,
 	}
 
-	model := buildTestModel(opts)
+	model := buildModel(opts)
 
 	if model.Spec.Hardware.MemoryFraction != nil {
 		t.Errorf("MemoryFraction should be nil when 0, got %f", *model.Spec.Hardware.MemoryFraction)
 	}
 }
 
-// buildTestModel mirrors the model construction in runDeploy for testing.
-func buildTestModel(opts *deployOptions) *inferencev1alpha1.Model {
-	model := &inferencev1alpha1.Model{
-		Spec: inferencev1alpha1.ModelSpec{
-			Source:       opts.modelSource,
-			Format:       opts.modelFormat,
-			Quantization: opts.quantization,
-			Hardware: &inferencev1alpha1.HardwareSpec{
-				Accelerator: opts.accelerator,
-			},
-			Resources: &inferencev1alpha1.ResourceRequirements{
-				CPU:    opts.cpu,
-				Memory: opts.memory,
-			},
-		},
-	}
-	if opts.gpu {
-		model.Spec.Hardware.GPU = &inferencev1alpha1.GPUSpec{
-			Enabled: true,
-			Count:   opts.gpuCount,
-			Vendor:  opts.gpuVendor,
-		}
-		if opts.gpuLayers != 0 {
-			model.Spec.Hardware.GPU.Layers = opts.gpuLayers
-		}
-		if opts.gpuMemory != "" {
-			model.Spec.Hardware.GPU.Memory = opts.gpuMemory
-		}
-	}
-	if opts.metalMemoryBudget != "" {
-		model.Spec.Hardware.MemoryBudget = opts.metalMemoryBudget
-	}
-	if opts.metalMemoryFraction > 0 {
-		model.Spec.Hardware.MemoryFraction = &opts.metalMemoryFraction
-	}
-	return model
-}
-
 func TestNewDeployCommand(t *testing.T) {
 	cmd := NewDeployCommand()
 
@@ -896,3 +865,108 @@ func TestComputeCacheKeyUsedByDeploy(t *testing.T) {
 		}
 	}
 }
+
+// catalogDeployOptions mirrors `llmkube deploy llama-3.1-8b -n ml --gpu
+// --accelerator cuda --gpu-count 2 --context 16384 --replicas 3`, flag
+// defaults included.
+func catalogDeployOptions() *deployOptions {
+	return &deployOptions{
+		name:        "llama-3.1-8b",
+		namespace:   "ml",
+		modelFormat: "gguf",
+		replicas:    3,
+		gpu:         true,
+		accelerator: acceleratorCUDA,
+		gpuCount:    2,
+		gpuLayers:   -1,
+		gpuVendor:   defaultGPUVendor,
+		contextSize: 16384,
+		cpu:         "2",
+		memory:      "4Gi",
+		runtime:     "llamacpp",
+	}
+}
+
+func TestCreateDeploymentFromCatalogID(t *testing.T) {
+	catalogModel, err := GetModel("llama-3.1-8b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheme := runtime.NewScheme()
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	opts := catalogDeployOptions()
+	if err := resolveDeployOptions(opts); err != nil {
+		t.Fatalf("resolveDeployOptions failed: %v", err)
+	}
+	if err := createDeployment(t.Context(), k8sClient, opts); err != nil {
+		t.Fatalf("createDeployment failed: %v", err)
+	}
+
+	key := types.NamespacedName{Name: "llama-3.1-8b", Namespace: "ml"}
+	model := &inferencev1alpha1.Model{}
+	if err := k8sClient.Get(t.Context(), key, model); err != nil {
+		t.Fatalf("Model not created: %v", err)
+	}
+	if model.Spec.Source != catalogModel.Source || model.Spec.Quantization != catalogModel.Quantization {
+		t.Errorf("Model source/quantization = %q/%q, want the catalog's %q/%q",
+			model.Spec.Source, model.Spec.Quantization, catalogModel.Source, catalogModel.Quantization)
+	}
+	if model.Spec.Hardware == nil || model.Spec.Hardware.Accelerator != acceleratorCUDA {
+		t.Fatalf("Model hardware = %+v, want accelerator %s", model.Spec.Hardware, acceleratorCUDA)
+	}
+	gpu := model.Spec.Hardware.GPU
+	if gpu == nil || !gpu.Enabled || gpu.Count != 2 || gpu.Layers != catalogModel.GPULayers {
+		t.Errorf("Model GPU = %+v, want 2 GPUs with the catalog's %d layers", gpu, catalogModel.GPULayers)
+	}
+
+	isvc := &inferencev1alpha1.InferenceService{}
+	if err := k8sClient.Get(t.Context(), key, isvc); err != nil {
+		t.Fatalf("InferenceService not created: %v", err)
+	}
+	if isvc.Spec.ModelRef != "llama-3.1-8b" {
+		t.Errorf("modelRef = %q, want llama-3.1-8b", isvc.Spec.ModelRef)
+	}
+	if isvc.Spec.Replicas == nil || *isvc.Spec.Replicas != 3 {
+		t.Errorf("replicas = %v, want 3", isvc.Spec.Replicas)
+	}
+	if isvc.Spec.ContextSize == nil || *isvc.Spec.ContextSize != 16384 {
+		t.Errorf("contextSize = %v, want the --context override 16384", isvc.Spec.ContextSize)
+	}
+	if isvc.Spec.Resources == nil || isvc.Spec.Resources.GPU != 2 {
+		t.Errorf("resources = %+v, want 2 GPUs", isvc.Spec.Resources)
+	}
+	if isvc.Spec.Image != imageLlamaCppServerCUDA {
+		t.Errorf("image = %q, want %q", isvc.Spec.Image, imageLlamaCppServerCUDA)
+	}
+}
+
+func TestCreateDeploymentRemovesModelWhenServiceFails(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = inferencev1alpha1.AddToScheme(scheme)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*inferencev1alpha1.InferenceService); ok {
+				return errors.New("admission webhook denied the request")
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	opts := catalogDeployOptions()
+	if err := resolveDeployOptions(opts); err != nil {
+		t.Fatalf("resolveDeployOptions failed: %v", err)
+	}
+	if err := createDeployment(t.Context(), k8sClient, opts); err == nil {
+		t.Fatal("expected the InferenceService failure to be returned")
+	}
+
+	var models inferencev1alpha1.ModelList
+	if err := k8sClient.List(t.Context(), &models); err != nil {
+		t.Fatal(err)
+	}
+	if len(models.Items) != 0 {
+		t.Errorf("Model %s left behind after the InferenceService failed", models.Items[0].Name)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// imageCapabilities is what a llama.cpp server image reports about its build
+// via `--version` (loaded backends, version line) and `--help` (flags).
+type imageCapabilities struct {
+	Version        string
+	Commit         string
+	BuildInfo      string
+	CUDA           bool
+	ROCm           bool
+	Metal          bool
+	Vulkan         bool
+	SYCL           bool
+	FlashAttention bool
+}
+
+// hasGPUBackend reports whether the image was built with any GPU backend.
+func (c imageCapabilities) hasGPUBackend() bool {
+	return c.CUDA || c.ROCm || c.Metal || c.Vulkan || c.SYCL
+}
+
+// NewDoctorCommand creates the doctor command for pre-deployment checks.
+func NewDoctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems before deploying",
+		Long:  `Run pre-deployment checks against images and configuration.`,
+	}
+
+	cmd.AddCommand(newDoctorCheckImageCommand())
+
+	return cmd
+}
+
+func newDoctorCheckImageCommand() *cobra.Command {
+	var (
+		runtime     string
+		accelerator string
+		timeout     time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check-image IMAGE",
+		Short: "Report the build features of a llama.cpp server image",
+		Long: `Run a llama.cpp server image locally with --version and --help and report
+the backends it was built with (CUDA, ROCm, Metal, Vulkan, SYCL) and whether it
+supports flash attention.
+
+Use --accelerator to fail when the image cannot serve the intended hardware,
+for example to catch a CPU-only image before deploying it for GPU work. For
+cuda, rocm and intel the host GPU is passed into the container (--gpus all on
+docker, CDI or device nodes otherwise): the upstream images load their GPU
+backend dynamically and only report it when a device is visible. Without a
+GPU on the host, only statically built images report their backends reliably.
+
+Examples:
+  # Inspect the CUDA server image
+  llmkube doctor check-image ghcr.io/ggml-org/llama.cpp:server-cuda13
+
+  # Fail if the image has no CUDA backend
+  llmkube doctor check-image ghcr.io/ggml-org/llama.cpp:server --accelerator cuda
+
+  # Use podman instead of docker
+  llmkube doctor check-image ghcr.io/ggml-org/llama.cpp:server --runtime podman
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			return runDoctorCheckImage(ctx, runtime, args[0], accelerator)
+		},
+	}
+
+	cmd.Flags().StringVar(&runtime, "runtime", "docker", "Container runtime used to run the image: docker, podman")
+	cmd.Flags().StringVar(&accelerator, "accelerator", "",
+		"Fail unless the image supports this accelerator: cuda, rocm, metal, vulkan, intel, cpu")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Timeout for pulling and running the image")
+
+	return cmd
+}
+
+func runDoctorCheckImage(ctx context.Context, runtime, image, accelerator string) error {
+	if _, err := exec.LookPath(runtime); err != nil {
+		return fmt.Errorf("container runtime %q not found in PATH: %w", runtime, err)
+	}
+
+	fmt.Printf("🔍 Checking image %s...\n", image)
+
+	gpuArgs := imageGPUArgs(runtime, accelerator)
+	versionOut, err := runImage(ctx, runtime, image, gpuArgs, "--version")
+	if err != nil && len(gpuArgs) > 0 {
+		// No usable GPU (or container toolkit) on this host; fall back to a
+		// plain run, which still reports statically built backends.
+		fmt.Printf("⚠️  Could not run the image with GPU access (%s); retrying without it.\n"+
+			"   Dynamically loaded GPU backends will not be reported.\n", strings.Join(gpuArgs, " "))
+		gpuArgs = nil
+		versionOut, err = runImage(ctx, runtime, image, nil, "--version")
+	}
+	if err != nil {
+		return err
+	}
+	caps := parseImageVersionOutput(versionOut)
+
+	// --help is only needed for flag support; a failure here still leaves
+	// a useful backend report.
+	if helpOut, err := runImage(ctx, runtime, image, gpuArgs, "--help"); err == nil {
+		caps.FlashAttention = parseImageHelpFlashAttention(helpOut)
+	}
+
+	printImageCapabilities(caps)
+
+	if accelerator != "" {
+		if err := checkImageAccelerator(caps, accelerator); err != nil {
+			return err
+		}
+		fmt.Printf("\n✅ Image supports accelerator %q\n", accelerator)
+	}
+	return nil
+}
+
+// imageGPUArgs returns the container runtime flags that expose the host GPU
+// for the given accelerator. Metal cannot be passed into a Linux container,
+// and Vulkan may be backed by any vendor, so neither gets passthrough.
+func imageGPUArgs(runtime, accelerator string) []string {
+	switch strings.ToLower(accelerator) {
+	case acceleratorCUDA:
+		if runtime == "podman" {
+			return []string{"--device", "nvidia.com/gpu=all"}
+		}
+		return []string{"--gpus", "all"}
+	case acceleratorROCm:
+		return []string{"--device", "/dev/kfd", "--device", "/dev/dri"}
+	case acceleratorIntel:
+		return []string{"--device", "/dev/dri"}
+	}
+	return nil
+}
+
+// runImage runs the image's default entrypoint (llama-server in the upstream
+// images) with a single argument and returns combined stdout/stderr, since
+// llama.cpp logs backend loading to stderr.
+func runImage(ctx context.Context, runtime, image string, gpuArgs []string, arg string) (string, error) {
+	args := append([]string{"run", "--rm"}, gpuArgs...)
+	cmd := exec.CommandContext(ctx, runtime, append(args, image, arg)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s %s: %w\n%s", image, arg, err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+var imageVersionRe = regexp.MustCompile(`(?m)^version:\s*(\S+)(?:\s+\(([0-9a-f]+)\))?`)
+
+// parseImageVersionOutput extracts build features from `llama-server --version`.
+// Backends show up either as "load_backend: loaded X backend" lines (dynamic
+// backend builds) or as per-backend init log prefixes (static builds). A
+// statically built backend that finds no device still logs its init line, so
+// static builds are detected on hosts without the matching hardware; dynamic
+// builds need the GPU passed through (see imageGPUArgs).
+func parseImageVersionOutput(out string) imageCapabilities {
+	caps := imageCapabilities{}
+	if m := imageVersionRe.FindStringSubmatch(out); m != nil {
+		caps.Version = m[1]
+		caps.Commit = m[2]
+	}
+
+	// HIP builds reuse the ggml_cuda_init log prefix, so a bare init line
+	// only counts as CUDA when nothing identifies the build as ROCm.
+	var cudaInit bool
+	for _, line := range strings.Split(out, "\n") {
+		trimmed := strings.TrimSpace(line)
+		lower := strings.ToLower(trimmed)
+		if strings.HasPrefix(lower, "built with") {
+			caps.BuildInfo = trimmed
+		}
+		if strings.Contains(lower, "rocm") || strings.Contains(lower, "libggml-hip") {
+			caps.ROCm = true
+		}
+		if strings.Contains(lower, "loaded cuda backend") {
+			caps.CUDA = true
+		}
+		if strings.HasPrefix(lower, "ggml_cuda_init") {
+			cudaInit = true
+		}
+		if strings.Contains(lower, "loaded metal backend") || strings.HasPrefix(lower, "ggml_metal_init") {
+			caps.Metal = true
+		}
+		if strings.Contains(lower, "loaded vulkan backend") || strings.HasPrefix(lower, "ggml_vulkan") {
+			caps.Vulkan = true
+		}
+		if strings.Contains(lower, "loaded sycl backend") || strings.HasPrefix(lower, "ggml_sycl") {
+			caps.SYCL = true
+		}
+	}
+	if cudaInit && !caps.ROCm {
+		caps.CUDA = true
+	}
+	return caps
+}
+
+// parseImageHelpFlashAttention reports whether `llama-server --help` lists the
+// flash attention flag.
+func parseImageHelpFlashAttention(help string) bool {
+	return strings.Contains(help, "--flash-attn")
+}
+
+func checkImageAccelerator(caps imageCapabilities, accelerator string) error {
+	var ok bool
+	switch strings.ToLower(accelerator) {
+	case acceleratorCUDA:
+		ok = caps.CUDA
+	case acceleratorROCm:
+		ok = caps.ROCm
+	case acceleratorMetal:
+		ok = caps.Metal
+	case "vulkan":
+		ok = caps.Vulkan
+	case acceleratorIntel:
+		ok = caps.SYCL
+	case acceleratorCPU:
+		ok = true
+	default:
+		return fmt.Errorf("unknown accelerator %q (valid: cuda, rocm, metal, vulkan, intel, cpu)", accelerator)
+	}
+	if !ok {
+		if !caps.hasGPUBackend() {
+			return fmt.Errorf("image has no GPU backend (CPU-only build); it cannot serve accelerator %q", accelerator)
+		}
+		return fmt.Errorf("image was not built with a backend for accelerator %q", accelerator)
+	}
+	return nil
+}
+
+func printImageCapabilities(caps imageCapabilities) {
+	yesNo := func(b bool) string {
+		if b {
+			return statusIconSuccess
+		}
+		return "—"
+	}
+
+	fmt.Printf("\nIMAGE CAPABILITIES\n")
+	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	if caps.Version != "" {
+		version := caps.Version
+		if caps.Commit != "" {
+			version += " (" + caps.Commit + ")"
+		}
+		fmt.Printf("Version:         %s\n", version)
+	}
+	if caps.BuildInfo != "" {
+		fmt.Printf("Build:           %s\n", caps.BuildInfo)
+	}
+	fmt.Printf("CUDA:            %s\n", yesNo(caps.CUDA))
+	fmt.Printf("ROCm:            %s\n", yesNo(caps.ROCm))
+	fmt.Printf("Metal:           %s\n", yesNo(caps.Metal))
+	fmt.Printf("Vulkan:          %s\n", yesNo(caps.Vulkan))
+	fmt.Printf("SYCL:            %s\n", yesNo(caps.SYCL))
+	fmt.Printf("Flash Attention: %s\n", yesNo(caps.FlashAttention))
+	if !caps.hasGPUBackend() {
+		fmt.Printf("\n⚠️  No GPU backend detected: this is a CPU-only image.\n")
+	}
+}
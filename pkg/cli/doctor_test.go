@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleCUDAVersionOutput = `ggml_cuda_init: GGML_CUDA_FORCE_MMQ:    no
+ggml_cuda_init: GGML_CUDA_FORCE_CUBLAS: no
+ggml_cuda_init: found 1 CUDA devices:
+  Device 0: NVIDIA GeForce RTX 4090, compute capability 8.9, VMM: yes
+load_backend: loaded CUDA backend from /app/libggml-cuda.so
+load_backend: loaded CPU backend from /app/libggml-cpu-alderlake.so
+version: 6123 (a3f1c2d4)
+built with cc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0 for x86_64-linux-gnu
+`
+
+const sampleCPUVersionOutput = `load_backend: loaded CPU backend from /app/libggml-cpu-haswell.so
+version: 6123 (a3f1c2d4)
+built with cc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0 for x86_64-linux-gnu
+`
+
+func TestParseImageVersionOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   imageCapabilities
+	}{
+		{
+			name:   "cuda build",
+			output: sampleCUDAVersionOutput,
+			want: imageCapabilities{
+				Version: "6123", Commit: "a3f1c2d4", CUDA: true,
+				BuildInfo: "built with cc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0 for x86_64-linux-gnu",
+			},
+		},
+		{
+			name: "cuda build without a device",
+			output: "ggml_cuda_init: failed to initialize CUDA: no CUDA-capable device is detected\n" +
+				"version: 6123 (a3f1c2d4)\n",
+			want: imageCapabilities{Version: "6123", Commit: "a3f1c2d4", CUDA: true},
+		},
+		{
+			name:   "cpu build",
+			output: sampleCPUVersionOutput,
+			want: imageCapabilities{
+				Version: "6123", Commit: "a3f1c2d4",
+				BuildInfo: "built with cc (Ubuntu 11.4.0-1ubuntu1~22.04) 11.4.0 for x86_64-linux-gnu",
+			},
+		},
+		{
+			name:   "rocm build",
+			output: "load_backend: loaded ROCm backend from /app/libggml-hip.so\nversion: 6000 (deadbeef)\n",
+			want:   imageCapabilities{Version: "6000", Commit: "deadbeef", ROCm: true},
+		},
+		{
+			name: "static rocm build reuses cuda init prefix",
+			output: "ggml_cuda_init: GGML_CUDA_FORCE_MMQ:    no\n" +
+				"ggml_cuda_init: found 1 ROCm devices:\n" +
+				"version: 6000 (deadbeef)\n",
+			want: imageCapabilities{Version: "6000", Commit: "deadbeef", ROCm: true},
+		},
+		{
+			name:   "metal build",
+			output: "ggml_metal_init: found device: Apple M3 Max\nversion: 6000 (deadbeef)\n",
+			want:   imageCapabilities{Version: "6000", Commit: "deadbeef", Metal: true},
+		},
+		{
+			name:   "vulkan build",
+			output: "load_backend: loaded Vulkan backend from /app/libggml-vulkan.so\nversion: 6000 (deadbeef)\n",
+			want:   imageCapabilities{Version: "6000", Commit: "deadbeef", Vulkan: true},
+		},
+		{
+			name:   "sycl build",
+			output: "load_backend: loaded SYCL backend from /app/libggml-sycl.so\nversion: 6000\n",
+			want:   imageCapabilities{Version: "6000", SYCL: true},
+		},
+		{
+			name:   "unrecognized output",
+			output: "exec format error",
+			want:   imageCapabilities{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseImageVersionOutput(tt.output)
+			if got != tt.want {
+				t.Errorf("parseImageVersionOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImageHelpFlashAttention(t *testing.T) {
+	help := "-fa,   --flash-attn [on|off|auto]     set Flash Attention use ('on', 'off', or 'auto', default: 'auto')"
+	if !parseImageHelpFlashAttention(help) {
+		t.Error("expected flash attention to be detected")
+	}
+	if parseImageHelpFlashAttention("-c, --ctx-size N   size of the prompt context") {
+		t.Error("expected no flash attention without the flag")
+	}
+}
+
+func TestCheckImageAccelerator(t *testing.T) {
+	cuda := parseImageVersionOutput(sampleCUDAVersionOutput)
+	cpu := parseImageVersionOutput(sampleCPUVersionOutput)
+
+	tests := []struct {
+		name        string
+		caps        imageCapabilities
+		accelerator string
+		wantErr     string
+	}{
+		{"cuda image for cuda", cuda, "cuda", ""},
+		{"cuda image for cpu", cuda, "cpu", ""},
+		{"cpu image for cuda", cpu, "cuda", "CPU-only build"},
+		{"cuda image for rocm", cuda, "rocm", "not built with a backend"},
+		{"unknown accelerator", cuda, "tpu", "unknown accelerator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkImageAccelerator(tt.caps, tt.accelerator)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageGPUArgs(t *testing.T) {
+	tests := []struct {
+		runtime     string
+		accelerator string
+		want        string
+	}{
+		{"docker", "cuda", "--gpus all"},
+		{"podman", "cuda", "--device nvidia.com/gpu=all"},
+		{"docker", "ROCm", "--device /dev/kfd --device /dev/dri"},
+		{"podman", "intel", "--device /dev/dri"},
+		{"docker", "metal", ""},
+		{"docker", "cpu", ""},
+		{"docker", "", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(imageGPUArgs(tt.runtime, tt.accelerator), " "); got != tt.want {
+			t.Errorf("imageGPUArgs(%q, %q) = %q, want %q", tt.runtime, tt.accelerator, got, tt.want)
+		}
+	}
+}
+
+func TestNewDoctorCommand(t *testing.T) {
+	cmd := NewDoctorCommand()
+	if cmd.Use != "doctor" {
+		t.Errorf("Use = %q, want %q", cmd.Use, "doctor")
+	}
+
+	var checkImage bool
+	for _, sub := range cmd.Commands() {
+		if sub.Name() == "check-image" {
+			checkImage = true
+			for _, flag := range []string{"runtime", "accelerator", "timeout"} {
+				if sub.Flags().Lookup(flag) == nil {
+					t.Errorf("check-image missing --%s flag", flag)
+				}
+			}
+		}
+	}
+	if !checkImage {
+		t.Error("Missing 'check-image' subcommand")
+	}
+}
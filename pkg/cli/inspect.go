@@ -96,6 +96,12 @@ func runInspect(path string, showMetadata, showTensors bool) error {
 	}
 	if hc := parsed.HeadCount(); hc > 0 {
 		fmt.Printf("Attn Heads:     %d\n", hc)
+		if kv := parsed.HeadCountKV(); kv != hc {
+			fmt.Printf("KV Heads:       %d\n", kv)
+		}
+	}
+	if ff := parsed.FeedForwardLength(); ff > 0 {
+		fmt.Printf("FFN Dim:        %d\n", ff)
 	}
 	if lic := parsed.License(); lic != "" {
 		fmt.Printf("License:        %s\n", lic)
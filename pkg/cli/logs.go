@@ -0,0 +1,204 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const (
+	llamaServerContainer     = "llama-server"
+	modelDownloaderContainer = "model-downloader"
+)
+
+type logsOptions struct {
+	name      string
+	namespace string
+	follow    bool
+	tail      int64
+	init      bool
+}
+
+func NewLogsCommand() *cobra.Command {
+	opts := &logsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "logs [NAME]",
+		Short: "Show the inference server logs of a deployment",
+		Long: `Print the logs of the llama-server container behind an InferenceService.
+
+The pod is found through the service's selector, preferring a Ready pod, so
+a replica that is still loading (or stuck loading) the model is shown when
+no other is serving. With --init, the logs of the model-downloader init
+container are shown instead, to debug a download that does not finish.
+
+Examples:
+  # Last 100 lines of the server log
+  llmkube logs llama-3.1-8b --tail 100
+
+  # Follow a model load
+  llmkube logs llama-3.1-8b -n ml -f
+
+  # Why is the download stuck?
+  llmkube logs llama-3.1-8b --init -f
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			return runLogs(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "Kubernetes namespace")
+	cmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "Stream new log lines until interrupted")
+	cmd.Flags().Int64Var(&opts.tail, "tail", -1, "Number of recent lines to show (-1 = all)")
+	cmd.Flags().BoolVar(&opts.init, "init", false,
+		"Show the model-downloader init container instead of the inference server")
+
+	return cmd
+}
+
+func runLogs(opts *logsOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	pod, err := findLogsPodForService(ctx, clientset, opts.namespace, sanitizeServiceName(opts.name))
+	if err != nil {
+		return err
+	}
+	container, err := logsContainer(pod, opts.init)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "📜 %s/%s (container %s)\n", pod.Namespace, pod.Name, container)
+
+	return streamPodLogs(ctx, clientset, pod, container, opts, os.Stdout)
+}
+
+// findLogsPodForService resolves a Service's pods through its selector, as
+// findReadyPodForService does, but falls back to the newest pod, the one
+// still loading or crashlooping, when none is Ready. Terminating pods are
+// skipped.
+func findLogsPodForService(
+	ctx context.Context, clientset kubernetes.Interface, namespace, serviceName string,
+) (*corev1.Pod, error) {
+	svc, err := clientset.CoreV1().Services(namespace).Get(ctx, serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s: %w", serviceName, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("service %s has no pod selector", serviceName)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	candidates := make([]*corev1.Pod, 0, len(pods.Items))
+	for i := range pods.Items {
+		if pods.Items[i].DeletionTimestamp == nil {
+			candidates = append(candidates, &pods.Items[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no pods found for service %s", serviceName)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := isPodReady(candidates[i]), isPodReady(candidates[j])
+		if ri != rj {
+			return ri
+		}
+		ti, tj := candidates[i].CreationTimestamp, candidates[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0], nil
+}
+
+// logsContainer names the container to read: model-downloader with --init,
+// otherwise llama-server, or the only serving container of a non-llama.cpp
+// runtime.
+func logsContainer(pod *corev1.Pod, init bool) (string, error) {
+	if init {
+		for _, c := range pod.Spec.InitContainers {
+			if c.Name == modelDownloaderContainer {
+				return c.Name, nil
+			}
+		}
+		return "", fmt.Errorf("pod %s has no %s init container (the model may be served from cache or baked into the image)",
+			pod.Name, modelDownloaderContainer)
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name == llamaServerContainer {
+			return c.Name, nil
+		}
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return "", fmt.Errorf("pod %s has no containers", pod.Name)
+	}
+	return pod.Spec.Containers[0].Name, nil
+}
+
+func streamPodLogs(
+	ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod, container string,
+	opts *logsOptions, w io.Writer,
+) error {
+	logOpts := &corev1.PodLogOptions{Container: container, Follow: opts.follow}
+	if opts.tail >= 0 {
+		logOpts.TailLines = &opts.tail
+	}
+
+	stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOpts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get logs of %s/%s: %w", pod.Name, container, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	if _, err := io.Copy(w, stream); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs of %s/%s: %w", pod.Name, container, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+)
+
+func logsTestPod(name string, created time.Time, podLabels map[string]string, ready bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "ml", Labels: podLabels, CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: modelDownloaderContainer}},
+			Containers:     []corev1.Container{{Name: llamaServerContainer}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	if ready {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return pod
+}
+
+func TestFindLogsPodForService(t *testing.T) {
+	selector := map[string]string{"app": "llama-3-1-8b", "inference.llmkube.dev/service": "llama-3.1-8b"}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-3-1-8b", Namespace: "ml"},
+		Spec:       corev1.ServiceSpec{Selector: selector},
+	}
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	otherService := map[string]string{"app": "qwen", "inference.llmkube.dev/service": "qwen"}
+
+	tests := []struct {
+		name    string
+		pods    []runtime.Object
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "ready pod wins over a newer loading one",
+			pods: []runtime.Object{
+				logsTestPod("llama-old", base, selector, true),
+				logsTestPod("llama-new", base.Add(time.Minute), selector, false),
+			},
+			want: "llama-old",
+		},
+		{
+			name: "newest pod when none is ready",
+			pods: []runtime.Object{
+				logsTestPod("llama-a", base, selector, false),
+				logsTestPod("llama-b", base.Add(time.Minute), selector, false),
+			},
+			want: "llama-b",
+		},
+		{
+			name: "pods of other services are ignored",
+			pods: []runtime.Object{
+				logsTestPod("qwen-ready", base.Add(time.Hour), otherService, true),
+				logsTestPod("llama-loading", base, selector, false),
+			},
+			want: "llama-loading",
+		},
+		{
+			name:    "no pods behind the selector",
+			pods:    []runtime.Object{logsTestPod("qwen-ready", base, otherService, true)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fakeclientset.NewClientset(append(tt.pods, service)...)
+			pod, err := findLogsPodForService(t.Context(), clientset, "ml", "llama-3-1-8b")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got pod %s", pod.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findLogsPodForService failed: %v", err)
+			}
+			if pod.Name != tt.want {
+				t.Errorf("pod = %s, want %s", pod.Name, tt.want)
+			}
+		})
+	}
+
+	if _, err := findLogsPodForService(t.Context(), fakeclientset.NewClientset(), "ml", "missing"); err == nil {
+		t.Error("expected an error for a missing service")
+	}
+}
+
+func TestLogsContainer(t *testing.T) {
+	pod := logsTestPod("llama", time.Now(), nil, true)
+	if got, err := logsContainer(pod, false); err != nil || got != llamaServerContainer {
+		t.Errorf("logsContainer(init=false) = %q, %v; want %s", got, err, llamaServerContainer)
+	}
+	if got, err := logsContainer(pod, true); err != nil || got != modelDownloaderContainer {
+		t.Errorf("logsContainer(init=true) = %q, %v; want %s", got, err, modelDownloaderContainer)
+	}
+
+	// A vLLM pod served from cache: its one container, and no downloader.
+	vllm := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "vllm"}}}}
+	if got, err := logsContainer(vllm, false); err != nil || got != "vllm" {
+		t.Errorf("logsContainer(vllm) = %q, %v; want vllm", got, err)
+	}
+	if _, err := logsContainer(vllm, true); err == nil {
+		t.Error("expected an error for --init without a model-downloader init container")
+	}
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	pod := logsTestPod("llama", time.Now(), nil, true)
+	clientset := fakeclientset.NewClientset(pod)
+
+	var out bytes.Buffer
+	opts := &logsOptions{tail: 10}
+	if err := streamPodLogs(t.Context(), clientset, pod, llamaServerContainer, opts, &out); err != nil {
+		t.Fatalf("streamPodLogs failed: %v", err)
+	}
+	if out.Len() == 0 {
+		t.Error("no log output copied")
+	}
+}
@@ -37,18 +37,24 @@ built-in observability, SLO enforcement, and edge-native capabilities.`,
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&catalogFilePath, "catalog-file", "",
+		"YAML/JSON model catalog file or http(s) URL merged into the built-in catalog "+
+			"(\"replace: true\" in the file replaces it); defaults to $"+catalogFileEnv)
+
 	// Add subcommands
 	cmd.AddCommand(NewDeployCommand())
 	cmd.AddCommand(NewListCommand())
 	cmd.AddCommand(NewDeleteCommand())
 	cmd.AddCommand(NewScaleCommand())
 	cmd.AddCommand(NewStatusCommand())
+	cmd.AddCommand(NewLogsCommand())
 	cmd.AddCommand(NewQueueCommand())
 	cmd.AddCommand(NewVersionCommand())
 	cmd.AddCommand(NewCatalogCommand())
 	cmd.AddCommand(NewBenchmarkCommand())
 	cmd.AddCommand(NewCacheCommand())
 	cmd.AddCommand(NewInspectCommand())
+	cmd.AddCommand(NewDoctorCommand())
 	cmd.AddCommand(NewLicenseCommand())
 	cmd.AddCommand(NewAuditCommand())
 	cmd.AddCommand(NewForemanCommand())
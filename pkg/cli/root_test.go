@@ -38,12 +38,14 @@ func TestNewRootCommand(t *testing.T) {
 		"delete":    false,
 		"scale":     false,
 		"status":    false,
+		"logs":      false,
 		"queue":     false,
 		"version":   false,
 		"catalog":   false,
 		"benchmark": false,
 		"cache":     false,
 		"inspect":   false,
+		"doctor":    false,
 		"license":   false,
 	}
 
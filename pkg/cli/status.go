@@ -18,13 +18,19 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
@@ -32,6 +38,29 @@ import (
 type statusOptions struct {
 	name      string
 	namespace string
+	output    string
+}
+
+// serviceStatusEntry is the -o json form of an InferenceService's status.
+// Conditions and the Model phase are only filled in for a named service.
+type serviceStatusEntry struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Model           string            `json:"model"`
+	Phase           string            `json:"phase"`
+	ReadyReplicas   int32             `json:"ready_replicas"`
+	DesiredReplicas int32             `json:"desired_replicas"`
+	Endpoint        string            `json:"endpoint,omitempty"`
+	ModelPhase      string            `json:"model_phase,omitempty"`
+	Conditions      []statusCondition `json:"conditions,omitempty"`
+}
+
+type statusCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time"`
 }
 
 func NewStatusCommand() *cobra.Command {
@@ -39,116 +68,229 @@ func NewStatusCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "status [NAME]",
-		Short: "Show status of an LLM deployment",
-		Long:  `Display detailed status information about a Model and InferenceService.`,
-		Args:  cobra.ExactArgs(1),
+		Short: "Show status of LLM deployments",
+		Long: `Display the health of the InferenceServices in a namespace: phase,
+ready/desired replicas, endpoint and referenced model.
+
+With a NAME, display detailed status information about that Model and
+InferenceService, including its conditions, most recent first.
+
+Examples:
+  # Every service in the namespace
+  llmkube status -n ml
+
+  # One service in detail
+  llmkube status llama-3.1-8b -n ml
+
+  # Machine-readable
+  llmkube status -n ml -o json
+`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.name = args[0]
+			if len(args) > 0 {
+				opts.name = args[0]
+			}
+			if opts.output != "table" && opts.output != "json" {
+				return fmt.Errorf("--output must be table or json, got %q", opts.output)
+			}
 			return runStatus(opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "Kubernetes namespace")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "table", "Output format: table or json")
 
 	return cmd
 }
 
 func runStatus(opts *statusOptions) error {
-	ctx := context.Background()
-
-	cfg, err := config.GetConfig()
+	k8sClient, err := initK8sClient()
 	if err != nil {
-		return fmt.Errorf("failed to get kubeconfig: %w", err)
+		return err
+	}
+	if opts.name == "" {
+		return listServiceStatus(context.Background(), os.Stdout, k8sClient, opts)
 	}
+	return showServiceStatus(context.Background(), os.Stdout, k8sClient, opts)
+}
 
-	if err := inferencev1alpha1.AddToScheme(scheme.Scheme); err != nil {
-		return fmt.Errorf("failed to add scheme: %w", err)
+func newServiceStatusEntry(isvc *inferencev1alpha1.InferenceService) serviceStatusEntry {
+	return serviceStatusEntry{
+		Name:            isvc.Name,
+		Namespace:       isvc.Namespace,
+		Model:           isvc.Spec.ModelRef,
+		Phase:           isvc.Status.Phase,
+		ReadyReplicas:   isvc.Status.ReadyReplicas,
+		DesiredReplicas: isvc.Status.DesiredReplicas,
+		Endpoint:        isvc.Status.Endpoint,
 	}
+}
 
-	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
-	if err != nil {
-		return fmt.Errorf("failed to create client: %w", err)
+// newestConditionsFirst returns a copy of conditions ordered by last
+// transition, most recent first, so the latest reason leads.
+func newestConditionsFirst(conditions []metav1.Condition) []metav1.Condition {
+	sorted := slices.Clone(conditions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[j].LastTransitionTime.Before(&sorted[i].LastTransitionTime)
+	})
+	return sorted
+}
+
+func writeStatusJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// listServiceStatus prints one line per InferenceService in the namespace.
+func listServiceStatus(ctx context.Context, w io.Writer, k8sClient client.Client, opts *statusOptions) error {
+	serviceList := &inferencev1alpha1.InferenceServiceList{}
+	if err := k8sClient.List(ctx, serviceList, client.InNamespace(opts.namespace)); err != nil {
+		return fmt.Errorf("failed to list inference services: %w", err)
 	}
+	sort.Slice(serviceList.Items, func(i, j int) bool {
+		return serviceList.Items[i].Name < serviceList.Items[j].Name
+	})
 
-	model := &inferencev1alpha1.Model{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: opts.name, Namespace: opts.namespace}, model); err != nil {
-		return fmt.Errorf("failed to get Model: %w", err)
+	if opts.output == "json" {
+		entries := make([]serviceStatusEntry, 0, len(serviceList.Items))
+		for i := range serviceList.Items {
+			entries = append(entries, newServiceStatusEntry(&serviceList.Items[i]))
+		}
+		return writeStatusJSON(w, entries)
+	}
+
+	if len(serviceList.Items) == 0 {
+		_, _ = fmt.Fprintf(w, "No inference services found in namespace '%s'\n", opts.namespace)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tPHASE\tREADY\tENDPOINT\tMODEL")
+	for _, isvc := range serviceList.Items {
+		phase := isvc.Status.Phase
+		if phase == "" {
+			phase = "Pending"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d/%d\t%s\t%s\n",
+			isvc.Name,
+			phase,
+			isvc.Status.ReadyReplicas,
+			isvc.Status.DesiredReplicas,
+			isvc.Status.Endpoint,
+			isvc.Spec.ModelRef,
+		)
 	}
+	return tw.Flush()
+}
+
+// showServiceStatus prints the detailed status of one Model and
+// InferenceService pair.
+func showServiceStatus(ctx context.Context, w io.Writer, k8sClient client.Client, opts *statusOptions) error {
+	name, namespace := opts.name, opts.namespace
 
 	isvc := &inferencev1alpha1.InferenceService{}
-	if err := k8sClient.Get(ctx, types.NamespacedName{Name: opts.name, Namespace: opts.namespace}, isvc); err != nil {
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, isvc); err != nil {
 		return fmt.Errorf("failed to get InferenceService: %w", err)
 	}
 
-	fmt.Printf("Deployment: %s\n", opts.name)
-	fmt.Printf("Namespace:  %s\n\n", opts.namespace)
+	model := &inferencev1alpha1.Model{}
+	modelName := isvc.Spec.ModelRef
+	if modelName == "" {
+		modelName = name
+	}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: modelName, Namespace: namespace}, model); err != nil {
+		return fmt.Errorf("failed to get Model: %w", err)
+	}
+
+	if opts.output == "json" {
+		entry := newServiceStatusEntry(isvc)
+		entry.ModelPhase = model.Status.Phase
+		for _, cond := range newestConditionsFirst(isvc.Status.Conditions) {
+			entry.Conditions = append(entry.Conditions, statusCondition{
+				Type:               cond.Type,
+				Status:             string(cond.Status),
+				Reason:             cond.Reason,
+				Message:            cond.Message,
+				LastTransitionTime: cond.LastTransitionTime.Time,
+			})
+		}
+		return writeStatusJSON(w, entry)
+	}
 
-	fmt.Printf("MODEL STATUS:\n")
-	fmt.Printf("  Phase:       %s\n", model.Status.Phase)
-	fmt.Printf("  Source:      %s\n", model.Spec.Source)
-	fmt.Printf("  Format:      %s\n", model.Spec.Format)
-	fmt.Printf("  Size:        %s\n", model.Status.Size)
-	fmt.Printf("  Path:        %s\n", model.Status.Path)
+	_, _ = fmt.Fprintf(w, "Deployment: %s\n", name)
+	_, _ = fmt.Fprintf(w, "Namespace:  %s\n\n", namespace)
+
+	_, _ = fmt.Fprintf(w, "MODEL STATUS:\n")
+	_, _ = fmt.Fprintf(w, "  Phase:       %s\n", model.Status.Phase)
+	_, _ = fmt.Fprintf(w, "  Source:      %s\n", model.Spec.Source)
+	_, _ = fmt.Fprintf(w, "  Format:      %s\n", model.Spec.Format)
+	_, _ = fmt.Fprintf(w, "  Size:        %s\n", model.Status.Size)
+	_, _ = fmt.Fprintf(w, "  Path:        %s\n", model.Status.Path)
 	if model.Spec.Hardware != nil {
-		fmt.Printf("  Accelerator: %s\n", model.Spec.Hardware.Accelerator)
+		_, _ = fmt.Fprintf(w, "  Accelerator: %s\n", model.Spec.Hardware.Accelerator)
 	}
 	if model.Status.LastUpdated != nil {
-		fmt.Printf("  Updated:     %s\n", model.Status.LastUpdated.Format("2006-01-02 15:04:05"))
+		_, _ = fmt.Fprintf(w, "  Updated:     %s\n", model.Status.LastUpdated.Format("2006-01-02 15:04:05"))
 	}
 
 	if model.Status.GGUF != nil {
-		fmt.Printf("\nGGUF METADATA:\n")
-		fmt.Printf("  Architecture:   %s\n", model.Status.GGUF.Architecture)
-		fmt.Printf("  Model Name:     %s\n", model.Status.GGUF.ModelName)
-		fmt.Printf("  Quantization:   %s\n", model.Status.GGUF.Quantization)
-		fmt.Printf("  Context Length: %d\n", model.Status.GGUF.ContextLength)
-		fmt.Printf("  Embedding Dim:  %d\n", model.Status.GGUF.EmbeddingSize)
-		fmt.Printf("  Layers:         %d\n", model.Status.GGUF.LayerCount)
-		fmt.Printf("  Attn Heads:     %d\n", model.Status.GGUF.HeadCount)
-		fmt.Printf("  Tensors:        %d\n", model.Status.GGUF.TensorCount)
-	}
-
-	fmt.Printf("\nINFERENCE SERVICE STATUS:\n")
-	fmt.Printf("  Phase:           %s\n", isvc.Status.Phase)
-	fmt.Printf("  Model Reference: %s\n", isvc.Spec.ModelRef)
-	fmt.Printf("  Replicas:        %d/%d ready\n", isvc.Status.ReadyReplicas, isvc.Status.DesiredReplicas)
-	fmt.Printf("  Endpoint:        %s\n", isvc.Status.Endpoint)
+		_, _ = fmt.Fprintf(w, "\nGGUF METADATA:\n")
+		_, _ = fmt.Fprintf(w, "  Architecture:   %s\n", model.Status.GGUF.Architecture)
+		_, _ = fmt.Fprintf(w, "  Model Name:     %s\n", model.Status.GGUF.ModelName)
+		_, _ = fmt.Fprintf(w, "  Quantization:   %s\n", model.Status.GGUF.Quantization)
+		_, _ = fmt.Fprintf(w, "  Context Length: %d\n", model.Status.GGUF.ContextLength)
+		_, _ = fmt.Fprintf(w, "  Embedding Dim:  %d\n", model.Status.GGUF.EmbeddingSize)
+		_, _ = fmt.Fprintf(w, "  Layers:         %d\n", model.Status.GGUF.LayerCount)
+		_, _ = fmt.Fprintf(w, "  Attn Heads:     %d\n", model.Status.GGUF.HeadCount)
+		_, _ = fmt.Fprintf(w, "  Tensors:        %d\n", model.Status.GGUF.TensorCount)
+		if model.Status.GGUF.ParameterCount > 0 {
+			_, _ = fmt.Fprintf(w, "  Parameters:     %d\n", model.Status.GGUF.ParameterCount)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\nINFERENCE SERVICE STATUS:\n")
+	_, _ = fmt.Fprintf(w, "  Phase:           %s\n", isvc.Status.Phase)
+	_, _ = fmt.Fprintf(w, "  Model Reference: %s\n", isvc.Spec.ModelRef)
+	_, _ = fmt.Fprintf(w, "  Replicas:        %d/%d ready\n", isvc.Status.ReadyReplicas, isvc.Status.DesiredReplicas)
+	_, _ = fmt.Fprintf(w, "  Endpoint:        %s\n", isvc.Status.Endpoint)
 
 	priority := isvc.Spec.Priority
 	if priority == "" {
 		priority = "normal"
 	}
-	fmt.Printf("  Priority:        %s\n", priority)
+	_, _ = fmt.Fprintf(w, "  Priority:        %s\n", priority)
 
 	if isvc.Status.Phase == "WaitingForGPU" {
-		fmt.Printf("\nGPU SCHEDULING:\n")
-		fmt.Printf("  Status:          %s\n", isvc.Status.SchedulingStatus)
+		_, _ = fmt.Fprintf(w, "\nGPU SCHEDULING:\n")
+		_, _ = fmt.Fprintf(w, "  Status:          %s\n", isvc.Status.SchedulingStatus)
 		if isvc.Status.WaitingFor != "" {
-			fmt.Printf("  Waiting For:     %s\n", isvc.Status.WaitingFor)
+			_, _ = fmt.Fprintf(w, "  Waiting For:     %s\n", isvc.Status.WaitingFor)
 		}
 		if isvc.Status.QueuePosition > 0 {
-			fmt.Printf("  Queue Position:  %d\n", isvc.Status.QueuePosition)
+			_, _ = fmt.Fprintf(w, "  Queue Position:  %d\n", isvc.Status.QueuePosition)
 		}
 		if isvc.Status.SchedulingMessage != "" {
-			fmt.Printf("  Message:         %s\n", isvc.Status.SchedulingMessage)
+			_, _ = fmt.Fprintf(w, "  Message:         %s\n", isvc.Status.SchedulingMessage)
 		}
 	}
 
 	if isvc.Status.LastUpdated != nil {
-		fmt.Printf("  Updated:         %s\n", isvc.Status.LastUpdated.Format("2006-01-02 15:04:05"))
+		_, _ = fmt.Fprintf(w, "  Updated:         %s\n", isvc.Status.LastUpdated.Format("2006-01-02 15:04:05"))
 	}
 
 	if len(model.Status.Conditions) > 0 {
-		fmt.Printf("\nMODEL CONDITIONS:\n")
+		_, _ = fmt.Fprintf(w, "\nMODEL CONDITIONS:\n")
 		for _, cond := range model.Status.Conditions {
-			fmt.Printf("  %s: %s (%s) - %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+			_, _ = fmt.Fprintf(w, "  %s: %s (%s) - %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
 		}
 	}
 
 	if len(isvc.Status.Conditions) > 0 {
-		fmt.Printf("\nSERVICE CONDITIONS:\n")
-		for _, cond := range isvc.Status.Conditions {
-			fmt.Printf("  %s: %s (%s) - %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+		_, _ = fmt.Fprintf(w, "\nSERVICE CONDITIONS (most recent first):\n")
+		for _, cond := range newestConditionsFirst(isvc.Status.Conditions) {
+			_, _ = fmt.Fprintf(w, "  %s: %s (%s, %s ago) - %s\n",
+				cond.Type, cond.Status, cond.Reason, formatAge(cond.LastTransitionTime.Time), cond.Message)
 		}
 	}
 
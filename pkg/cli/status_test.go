@@ -17,7 +17,18 @@ limitations under the License.
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	inferencev1alpha1 "github.com/defilantech/llmkube/api/v1alpha1"
 )
 
 func TestNewStatusCommand(t *testing.T) {
@@ -39,11 +50,131 @@ func TestNewStatusCommand(t *testing.T) {
 	}
 }
 
-func TestStatusCommandRequiresArg(t *testing.T) {
+func TestStatusCommandAcceptsAtMostOneName(t *testing.T) {
 	cmd := NewStatusCommand()
-	cmd.SetArgs([]string{})
-	err := cmd.Execute()
-	if err == nil {
-		t.Error("Expected error when no argument provided")
+	if err := cmd.Args(cmd, nil); err != nil {
+		t.Errorf("status without a name rejected: %v", err)
+	}
+	if err := cmd.Args(cmd, []string{"a", "b"}); err == nil {
+		t.Error("Expected error when two names are provided")
+	}
+
+	cmd.SetArgs([]string{"-o", "yaml"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--output") {
+		t.Errorf("Execute() error = %v, want an --output error", err)
+	}
+}
+
+func statusTestClient(t *testing.T) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = inferencev1alpha1.AddToScheme(scheme)
+
+	isvc := func(name, phase string, ready, desired int32, endpoint string) *inferencev1alpha1.InferenceService {
+		return &inferencev1alpha1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ml"},
+			Spec:       inferencev1alpha1.InferenceServiceSpec{ModelRef: name + "-model"},
+			Status: inferencev1alpha1.InferenceServiceStatus{
+				Phase: phase, ReadyReplicas: ready, DesiredReplicas: desired, Endpoint: endpoint,
+			},
+		}
+	}
+	failed := isvc("qwen", "Failed", 0, 1, "")
+	elsewhere := isvc("elsewhere", "Ready", 1, 1, "")
+	elsewhere.Namespace = "default"
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour))
+	later := metav1.NewTime(time.Now().Add(-time.Minute))
+	failed.Status.Conditions = []metav1.Condition{
+		{Type: "Progressing", Status: metav1.ConditionFalse, Reason: "Deployed", LastTransitionTime: earlier},
+		{Type: "Degraded", Status: metav1.ConditionTrue, Reason: "CrashLoopBackOff",
+			Message: "llama-server exited", LastTransitionTime: later},
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		isvc("llama", "Ready", 2, 2, "http://llama.ml.svc.cluster.local:8080/v1/chat/completions"),
+		isvc("mistral", "Creating", 0, 1, ""),
+		failed,
+		elsewhere,
+		&inferencev1alpha1.Model{
+			ObjectMeta: metav1.ObjectMeta{Name: "qwen-model", Namespace: "ml"},
+			Status:     inferencev1alpha1.ModelStatus{Phase: "Ready"},
+		},
+	).Build()
+}
+
+func TestListServiceStatus(t *testing.T) {
+	k8sClient := statusTestClient(t)
+
+	var table bytes.Buffer
+	if err := listServiceStatus(t.Context(), &table, k8sClient, &statusOptions{namespace: "ml", output: "table"}); err != nil {
+		t.Fatalf("listServiceStatus failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want a header and 3 services:\n%s", len(lines), table.String())
+	}
+	for i, want := range [][]string{
+		{"NAME", "PHASE", "READY", "ENDPOINT", "MODEL"},
+		{"llama", "Ready", "2/2", "http://llama.ml.svc.cluster.local:8080/v1/chat/completions", "llama-model"},
+		{"mistral", "Creating", "0/1", "mistral-model"},
+		{"qwen", "Failed", "0/1", "qwen-model"},
+	} {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("line %d = %q, want fields %q", i, lines[i], want)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := listServiceStatus(t.Context(), &out, k8sClient, &statusOptions{namespace: "ml", output: "json"}); err != nil {
+		t.Fatalf("listServiceStatus failed: %v", err)
+	}
+	var entries []serviceStatusEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if len(entries) != 3 || entries[2].Name != "qwen" || entries[2].Phase != "Failed" || entries[0].ReadyReplicas != 2 {
+		t.Errorf("entries = %+v", entries)
+	}
+
+	var empty bytes.Buffer
+	if err := listServiceStatus(t.Context(), &empty, k8sClient, &statusOptions{namespace: "none", output: "json"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(empty.String()) != "[]" {
+		t.Errorf("empty namespace JSON = %q, want []", empty.String())
+	}
+}
+
+func TestShowServiceStatusConditionsNewestFirst(t *testing.T) {
+	k8sClient := statusTestClient(t)
+
+	var table bytes.Buffer
+	opts := &statusOptions{name: "qwen", namespace: "ml", output: "table"}
+	if err := showServiceStatus(t.Context(), &table, k8sClient, opts); err != nil {
+		t.Fatalf("showServiceStatus failed: %v", err)
+	}
+	text := table.String()
+	degraded := strings.Index(text, "Degraded: True (CrashLoopBackOff")
+	progressing := strings.Index(text, "Progressing: False (Deployed")
+	if degraded < 0 || progressing < 0 || degraded > progressing {
+		t.Errorf("want the Degraded condition listed before the older Progressing one:\n%s", text)
+	}
+
+	opts.output = "json"
+	var out bytes.Buffer
+	if err := showServiceStatus(t.Context(), &out, k8sClient, opts); err != nil {
+		t.Fatalf("showServiceStatus failed: %v", err)
+	}
+	var entry serviceStatusEntry
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if entry.ModelPhase != "Ready" || len(entry.Conditions) != 2 || entry.Conditions[0].Reason != "CrashLoopBackOff" {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	opts.name = "llama" // its Model does not exist
+	if err := showServiceStatus(t.Context(), &out, k8sClient, opts); err == nil {
+		t.Error("expected an error for a service whose Model is missing")
 	}
 }
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gguf
+
+// VRAMEstimate is the approximate accelerator memory needed to serve a model
+// at a given context length. It covers weights and KV cache only; compute
+// buffers and runtime overhead are backend-specific and not included.
+type VRAMEstimate struct {
+	WeightsBytes uint64
+	KVCacheBytes uint64
+	TotalBytes   uint64
+}
+
+// EstimateVRAM estimates memory for serving the model at contextSize tokens
+// with llama.cpp's default F16 KV cache. A contextSize of 0 uses the model's
+// trained context length.
+func (f *GGUFFile) EstimateVRAM(contextSize uint64) VRAMEstimate {
+	return f.EstimateVRAMWithCacheType(contextSize, GGMLTypeF16)
+}
+
+// EstimateVRAMWithCacheType is EstimateVRAM with an explicit KV cache element
+// type (e.g. GGMLTypeQ8_0 for --cache-type-k/v q8_0).
+func (f *GGUFFile) EstimateVRAMWithCacheType(contextSize uint64, cacheType GGMLType) VRAMEstimate {
+	if contextSize == 0 {
+		contextSize = f.ContextLength()
+	}
+	weights := f.TensorDataSize()
+	kv := f.kvCacheBytes(contextSize, cacheType)
+	return VRAMEstimate{
+		WeightsBytes: weights,
+		KVCacheBytes: kv,
+		TotalBytes:   weights + kv,
+	}
+}
+
+// kvCacheBytes sizes the K and V caches: per layer, each holds contextSize
+// rows of head_dim * head_count_kv elements, where head_dim is
+// embedding_length / head_count. Returns 0 when the attention shape is not
+// present in the metadata.
+func (f *GGUFFile) kvCacheBytes(contextSize uint64, cacheType GGMLType) uint64 {
+	blocks := f.BlockCount()
+	embd := f.EmbeddingLength()
+	heads := f.HeadCount()
+	if blocks == 0 || embd == 0 || heads == 0 || contextSize == 0 {
+		return 0
+	}
+	kvHeads := f.HeadCountKV()
+	headDim := embd / heads
+
+	elems := 2 * blocks * contextSize * headDim * kvHeads
+	bs, ok := ggmlTypeBlockSize[cacheType]
+	if !ok {
+		bs = ggmlTypeBlockSize[GGMLTypeF16]
+	}
+	return (elems + bs.BlockSize - 1) / bs.BlockSize * bs.TypeSize
+}
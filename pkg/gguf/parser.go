@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/bits"
 )
 
 // Magic number: bytes [G, G, U, F] = [0x47, 0x47, 0x55, 0x46] read as little-endian u32.
@@ -207,6 +208,26 @@ func GGMLTypeFromID(id uint32) GGMLType {
 	return GGMLType(id)
 }
 
+// ggmlBlockSize describes how a GGMLType packs elements: quantized types store
+// BlockSize elements in TypeSize bytes; plain types use a block of one.
+type ggmlBlockSize struct {
+	BlockSize uint64
+	TypeSize  uint64
+}
+
+// ggmlTypeBlockSize mirrors ggml's type_traits table (blck_size, type_size).
+var ggmlTypeBlockSize = map[GGMLType]ggmlBlockSize{
+	GGMLTypeF32: {1, 4}, GGMLTypeF16: {1, 2}, GGMLTypeBF16: {1, 2}, GGMLTypeF64: {1, 8},
+	GGMLTypeI8: {1, 1}, GGMLTypeI16: {1, 2}, GGMLTypeI32: {1, 4}, GGMLTypeI64: {1, 8},
+	GGMLTypeQ4_0: {32, 18}, GGMLTypeQ4_1: {32, 20}, GGMLTypeQ5_0: {32, 22}, GGMLTypeQ5_1: {32, 24},
+	GGMLTypeQ8_0: {32, 34}, GGMLTypeQ8_1: {32, 36}, GGMLTypeIQ4NL: {32, 18},
+	GGMLTypeQ2K: {256, 84}, GGMLTypeQ3K: {256, 110}, GGMLTypeQ4K: {256, 144}, GGMLTypeQ5K: {256, 176},
+	GGMLTypeQ6K: {256, 210}, GGMLTypeQ8K: {256, 292},
+	GGMLTypeIQ2XXS: {256, 66}, GGMLTypeIQ2XS: {256, 74}, GGMLTypeIQ2S: {256, 82},
+	GGMLTypeIQ3XXS: {256, 98}, GGMLTypeIQ3S: {256, 110},
+	GGMLTypeIQ1S: {256, 50}, GGMLTypeIQ1M: {256, 56}, GGMLTypeIQ4XS: {256, 136},
+}
+
 // ---------------------------------------------------------------------------
 // Parsed structures
 // ---------------------------------------------------------------------------
@@ -232,6 +253,29 @@ type TensorInfo struct {
 	Offset     uint64
 }
 
+// ElementCount returns the number of elements in the tensor (product of dimensions).
+func (t TensorInfo) ElementCount() uint64 {
+	if len(t.Dimensions) == 0 {
+		return 0
+	}
+	n := uint64(1)
+	for _, d := range t.Dimensions {
+		n *= d
+	}
+	return n
+}
+
+// DataSize returns the number of bytes the tensor occupies on disk, or 0 if
+// the tensor type is not in ggmlTypeBlockSize.
+func (t TensorInfo) DataSize() uint64 {
+	bs, ok := ggmlTypeBlockSize[t.Type]
+	if !ok {
+		return 0
+	}
+	blocks := (t.ElementCount() + bs.BlockSize - 1) / bs.BlockSize
+	return blocks * bs.TypeSize
+}
+
 // GGUFFile is a fully parsed GGUF file (header + metadata + tensor info).
 // Does NOT load tensor data — only the metadata headers.
 type GGUFFile struct {
@@ -244,17 +288,34 @@ type GGUFFile struct {
 // Main parser
 // ---------------------------------------------------------------------------
 
+// parser decodes GGUF structures from r in the file's byte order. Files are
+// little-endian unless written on a big-endian host, which parseHeader detects
+// from the magic and version fields before anything else is read.
+type parser struct {
+	r     io.Reader
+	order binary.ByteOrder
+}
+
+func newParser(r io.Reader) *parser {
+	return &parser{r: r, order: binary.LittleEndian}
+}
+
+func (p *parser) read(v any) error {
+	return binary.Read(p.r, p.order, v)
+}
+
 // Parse reads a GGUF file from any reader (file, buffer, network stream).
 // This only reads the header, metadata, and tensor info — NOT the tensor data.
 func Parse(r io.Reader) (*GGUFFile, error) {
-	header, err := parseHeader(r)
+	p := newParser(r)
+	header, err := p.parseHeader()
 	if err != nil {
 		return nil, err
 	}
 
 	metadata := make([]MetadataKV, 0, min(header.MetadataKVCount, maxPreallocCount))
 	for i := uint64(0); i < header.MetadataKVCount; i++ {
-		kv, err := parseMetadataKV(r)
+		kv, err := p.parseMetadataKV()
 		if err != nil {
 			return nil, fmt.Errorf("metadata kv %d: %w", i, err)
 		}
@@ -263,7 +324,7 @@ func Parse(r io.Reader) (*GGUFFile, error) {
 
 	tensorInfo := make([]TensorInfo, 0, min(header.TensorCount, maxPreallocCount))
 	for i := uint64(0); i < header.TensorCount; i++ {
-		ti, err := parseTensorInfo(r)
+		ti, err := p.parseTensorInfo()
 		if err != nil {
 			return nil, fmt.Errorf("tensor info %d: %w", i, err)
 		}
@@ -281,30 +342,44 @@ func Parse(r io.Reader) (*GGUFFile, error) {
 // Header parsing
 // ---------------------------------------------------------------------------
 
-func parseHeader(r io.Reader) (*GGUFHeader, error) {
+func (p *parser) parseHeader() (*GGUFHeader, error) {
 	var magic uint32
-	if err := binary.Read(r, binary.LittleEndian, &magic); err != nil {
+	if err := p.read(&magic); err != nil {
 		return nil, fmt.Errorf("reading magic: %w", err)
 	}
-	if magic != ggufMagic {
-		return nil, fmt.Errorf("%w: expected 0x46554747, got 0x%08X", ErrInvalidMagic, magic)
+	switch magic {
+	case ggufMagic:
+	case bits.ReverseBytes32(ggufMagic):
+		p.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: expected 0x46554747 (or byte-swapped 0x47475546), got 0x%08X",
+			ErrInvalidMagic, magic)
 	}
 
 	var version uint32
-	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+	if err := p.read(&version); err != nil {
 		return nil, fmt.Errorf("reading version: %w", err)
 	}
+	// llama.cpp writes the magic as the bytes "GGUF" on every host, so a
+	// big-endian file usually shows up here instead: as a version whose low
+	// half is zero. Real versions are tiny, so a swapped one is unambiguous.
+	if p.order == binary.LittleEndian && version&0xFFFF == 0 {
+		if swapped := bits.ReverseBytes32(version); swapped >= 2 && swapped <= 3 {
+			p.order = binary.BigEndian
+			version = swapped
+		}
+	}
 	if version < 2 || version > 3 {
 		return nil, fmt.Errorf("%w: %d (supported: 2, 3)", ErrUnsupportedVersion, version)
 	}
 
 	var tensorCount uint64
-	if err := binary.Read(r, binary.LittleEndian, &tensorCount); err != nil {
+	if err := p.read(&tensorCount); err != nil {
 		return nil, fmt.Errorf("reading tensor count: %w", err)
 	}
 
 	var metadataKVCount uint64
-	if err := binary.Read(r, binary.LittleEndian, &metadataKVCount); err != nil {
+	if err := p.read(&metadataKVCount); err != nil {
 		return nil, fmt.Errorf("reading metadata kv count: %w", err)
 	}
 
@@ -320,9 +395,9 @@ func parseHeader(r io.Reader) (*GGUFHeader, error) {
 // ---------------------------------------------------------------------------
 
 // readString reads a GGUF string: u64 length followed by that many UTF-8 bytes.
-func readString(r io.Reader) (string, error) {
+func (p *parser) readString() (string, error) {
 	var length uint64
-	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+	if err := p.read(&length); err != nil {
 		return "", fmt.Errorf("reading string length: %w", err)
 	}
 	if length > maxStringLength {
@@ -330,7 +405,7 @@ func readString(r io.Reader) (string, error) {
 	}
 
 	buf := make([]byte, length)
-	if _, err := io.ReadFull(r, buf); err != nil {
+	if _, err := io.ReadFull(p.r, buf); err != nil {
 		return "", fmt.Errorf("reading string data: %w", err)
 	}
 
@@ -341,13 +416,13 @@ func readString(r io.Reader) (string, error) {
 // Metadata parsing
 // ---------------------------------------------------------------------------
 
-func parseMetadataKV(r io.Reader) (MetadataKV, error) {
-	key, err := readString(r)
+func (p *parser) parseMetadataKV() (MetadataKV, error) {
+	key, err := p.readString()
 	if err != nil {
 		return MetadataKV{}, fmt.Errorf("reading key: %w", err)
 	}
 
-	value, err := readValue(r)
+	value, err := p.readValue()
 	if err != nil {
 		return MetadataKV{}, fmt.Errorf("reading value for %q: %w", key, err)
 	}
@@ -356,12 +431,12 @@ func parseMetadataKV(r io.Reader) (MetadataKV, error) {
 }
 
 // readValue reads a type tag (u32) followed by value data.
-func readValue(r io.Reader) (GGUFValue, error) {
+func (p *parser) readValue() (GGUFValue, error) {
 	var valueType uint32
-	if err := binary.Read(r, binary.LittleEndian, &valueType); err != nil {
+	if err := p.read(&valueType); err != nil {
 		return nil, fmt.Errorf("reading value type: %w", err)
 	}
-	return readValueData(r, valueType)
+	return p.readValueData(valueType)
 }
 
 // readValueData reads value data for a known type (without reading the type tag).
@@ -369,66 +444,66 @@ func readValue(r io.Reader) (GGUFValue, error) {
 // once in the array header.
 //
 //nolint:gocyclo // Type dispatch on 13 GGUF value types is inherently branchy.
-func readValueData(r io.Reader, valueType uint32) (GGUFValue, error) {
+func (p *parser) readValueData(valueType uint32) (GGUFValue, error) {
 	switch valueType {
 	case valueTypeUint8:
 		var v uint8
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Uint8Val{Value: v}, nil
 
 	case valueTypeInt8:
 		var v int8
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Int8Val{Value: v}, nil
 
 	case valueTypeUint16:
 		var v uint16
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Uint16Val{Value: v}, nil
 
 	case valueTypeInt16:
 		var v int16
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Int16Val{Value: v}, nil
 
 	case valueTypeUint32:
 		var v uint32
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Uint32Val{Value: v}, nil
 
 	case valueTypeInt32:
 		var v int32
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Int32Val{Value: v}, nil
 
 	case valueTypeFloat32:
 		var v float32
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Float32Val{Value: v}, nil
 
 	case valueTypeBool:
 		var v uint8
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return BoolVal{Value: v != 0}, nil
 
 	case valueTypeString:
-		s, err := readString(r)
+		s, err := p.readString()
 		if err != nil {
 			return nil, err
 		}
@@ -436,11 +511,11 @@ func readValueData(r io.Reader, valueType uint32) (GGUFValue, error) {
 
 	case valueTypeArray:
 		var elemType uint32
-		if err := binary.Read(r, binary.LittleEndian, &elemType); err != nil {
+		if err := p.read(&elemType); err != nil {
 			return nil, fmt.Errorf("reading array element type: %w", err)
 		}
 		var count uint64
-		if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		if err := p.read(&count); err != nil {
 			return nil, fmt.Errorf("reading array count: %w", err)
 		}
 		if count > maxArrayCount {
@@ -448,7 +523,7 @@ func readValueData(r io.Reader, valueType uint32) (GGUFValue, error) {
 		}
 		values := make([]GGUFValue, 0, count)
 		for i := uint64(0); i < count; i++ {
-			v, err := readValueData(r, elemType)
+			v, err := p.readValueData(elemType)
 			if err != nil {
 				return nil, fmt.Errorf("array element %d: %w", i, err)
 			}
@@ -458,21 +533,21 @@ func readValueData(r io.Reader, valueType uint32) (GGUFValue, error) {
 
 	case valueTypeUint64:
 		var v uint64
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Uint64Val{Value: v}, nil
 
 	case valueTypeInt64:
 		var v int64
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Int64Val{Value: v}, nil
 
 	case valueTypeFloat64:
 		var v float64
-		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+		if err := p.read(&v); err != nil {
 			return nil, err
 		}
 		return Float64Val{Value: v}, nil
@@ -486,14 +561,14 @@ func readValueData(r io.Reader, valueType uint32) (GGUFValue, error) {
 // Tensor info parsing
 // ---------------------------------------------------------------------------
 
-func parseTensorInfo(r io.Reader) (TensorInfo, error) {
-	name, err := readString(r)
+func (p *parser) parseTensorInfo() (TensorInfo, error) {
+	name, err := p.readString()
 	if err != nil {
 		return TensorInfo{}, fmt.Errorf("reading tensor name: %w", err)
 	}
 
 	var nDimensions uint32
-	if err := binary.Read(r, binary.LittleEndian, &nDimensions); err != nil {
+	if err := p.read(&nDimensions); err != nil {
 		return TensorInfo{}, fmt.Errorf("reading dimension count: %w", err)
 	}
 	if nDimensions > maxDimensions {
@@ -505,18 +580,18 @@ func parseTensorInfo(r io.Reader) (TensorInfo, error) {
 
 	dimensions := make([]uint64, nDimensions)
 	for i := uint32(0); i < nDimensions; i++ {
-		if err := binary.Read(r, binary.LittleEndian, &dimensions[i]); err != nil {
+		if err := p.read(&dimensions[i]); err != nil {
 			return TensorInfo{}, fmt.Errorf("reading dimension %d: %w", i, err)
 		}
 	}
 
 	var typeID uint32
-	if err := binary.Read(r, binary.LittleEndian, &typeID); err != nil {
+	if err := p.read(&typeID); err != nil {
 		return TensorInfo{}, fmt.Errorf("reading tensor type: %w", err)
 	}
 
 	var offset uint64
-	if err := binary.Read(r, binary.LittleEndian, &offset); err != nil {
+	if err := p.read(&offset); err != nil {
 		return TensorInfo{}, fmt.Errorf("reading tensor offset: %w", err)
 	}
 
@@ -649,6 +724,60 @@ func (f *GGUFFile) HeadCount() uint64 {
 	return n
 }
 
+// HeadCountKV returns the number of key/value attention heads. Models using
+// grouped-query attention set this below HeadCount; when the key is absent
+// (plain multi-head attention) it falls back to HeadCount.
+func (f *GGUFFile) HeadCountKV() uint64 {
+	arch := f.Architecture()
+	if arch == "" {
+		return 0
+	}
+	v, ok := f.GetMetadata(arch + ".attention.head_count_kv")
+	if !ok {
+		return f.HeadCount()
+	}
+	n, ok := AsU64(v)
+	if !ok || n == 0 {
+		return f.HeadCount()
+	}
+	return n
+}
+
+// FeedForwardLength returns the hidden size of the feed-forward network.
+func (f *GGUFFile) FeedForwardLength() uint64 {
+	arch := f.Architecture()
+	if arch == "" {
+		return 0
+	}
+	v, ok := f.GetMetadata(arch + ".feed_forward_length")
+	if !ok {
+		return 0
+	}
+	n, ok := AsU64(v)
+	if !ok {
+		return 0
+	}
+	return n
+}
+
+// ExpertCount returns the number of experts per MoE layer, or 0 for dense
+// models.
+func (f *GGUFFile) ExpertCount() uint64 {
+	return f.archU64Metadata("expert_count")
+}
+
+// ExpertUsedCount returns how many experts are routed per token in an MoE
+// model, or 0 for dense models.
+func (f *GGUFFile) ExpertUsedCount() uint64 {
+	return f.archU64Metadata("expert_used_count")
+}
+
+// IsMoE reports whether the model is a mixture-of-experts model, which makes
+// it a candidate for --cpu-moe expert offloading.
+func (f *GGUFFile) IsMoE() bool {
+	return f.ExpertCount() > 0
+}
+
 // License returns the license identifier from the GGUF metadata.
 func (f *GGUFFile) License() string {
 	v, ok := f.GetMetadata("general.license")
@@ -662,6 +791,79 @@ func (f *GGUFFile) License() string {
 	return s
 }
 
+// ChatTemplate returns the Jinja chat template embedded by the converter
+// under tokenizer.chat_template, or "" when the model ships without one.
+func (f *GGUFFile) ChatTemplate() string {
+	return f.stringMetadata("tokenizer.chat_template")
+}
+
+// TokenizerModel returns the tokenizer family (e.g. "llama", "gpt2").
+func (f *GGUFFile) TokenizerModel() string {
+	return f.stringMetadata("tokenizer.ggml.model")
+}
+
+// BOSTokenID returns the beginning-of-sequence token ID, if present.
+func (f *GGUFFile) BOSTokenID() (uint32, bool) {
+	return f.u32Metadata("tokenizer.ggml.bos_token_id")
+}
+
+// EOSTokenID returns the end-of-sequence token ID, if present.
+func (f *GGUFFile) EOSTokenID() (uint32, bool) {
+	return f.u32Metadata("tokenizer.ggml.eos_token_id")
+}
+
+func (f *GGUFFile) stringMetadata(key string) string {
+	v, ok := f.GetMetadata(key)
+	if !ok {
+		return ""
+	}
+	s, _ := AsStr(v)
+	return s
+}
+
+func (f *GGUFFile) u32Metadata(key string) (uint32, bool) {
+	v, ok := f.GetMetadata(key)
+	if !ok {
+		return 0, false
+	}
+	return AsU32(v)
+}
+
+// archU64Metadata reads "<arch>.<suffix>" as an unsigned integer, returning 0
+// when the architecture or key is missing.
+func (f *GGUFFile) archU64Metadata(suffix string) uint64 {
+	arch := f.Architecture()
+	if arch == "" {
+		return 0
+	}
+	v, ok := f.GetMetadata(arch + "." + suffix)
+	if !ok {
+		return 0
+	}
+	n, _ := AsU64(v)
+	return n
+}
+
+// ParameterCount returns the total number of model parameters, summed over
+// every tensor's element count.
+func (f *GGUFFile) ParameterCount() uint64 {
+	var total uint64
+	for _, ti := range f.TensorInfo {
+		total += ti.ElementCount()
+	}
+	return total
+}
+
+// TensorDataSize estimates the on-disk size of all tensor data in bytes using
+// per-type block sizes. Tensors with an unrecognized type contribute 0.
+func (f *GGUFFile) TensorDataSize() uint64 {
+	var total uint64
+	for _, ti := range f.TensorInfo {
+		total += ti.DataSize()
+	}
+	return total
+}
+
 // ---------------------------------------------------------------------------
 // File type → quantization name mapping
 // ---------------------------------------------------------------------------
@@ -25,6 +25,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -50,11 +51,15 @@ type testValue interface {
 
 type testString struct{ s string }
 type testUint32 struct{ v uint32 }
+type testUint16 struct{ v uint16 }
+type testInt32 struct{ v int32 }
 type testBool struct{ v bool }
 type testArray struct{ elements []testValue }
 
 func (t testString) typeTag() uint32 { return 8 }
 func (t testUint32) typeTag() uint32 { return 4 }
+func (t testUint16) typeTag() uint32 { return 2 }
+func (t testInt32) typeTag() uint32  { return 5 }
 func (t testBool) typeTag() uint32   { return 7 }
 func (t testArray) typeTag() uint32  { return 9 }
 
@@ -74,6 +79,22 @@ func (t testUint32) writeData(buf *bytes.Buffer) {
 	writeLE(buf, t.v)
 }
 
+func (t testUint16) writeWithTag(buf *bytes.Buffer) {
+	writeLE(buf, uint32(2))
+	writeLE(buf, t.v)
+}
+func (t testUint16) writeData(buf *bytes.Buffer) {
+	writeLE(buf, t.v)
+}
+
+func (t testInt32) writeWithTag(buf *bytes.Buffer) {
+	writeLE(buf, uint32(5))
+	writeLE(buf, t.v)
+}
+func (t testInt32) writeData(buf *bytes.Buffer) {
+	writeLE(buf, t.v)
+}
+
 func (t testBool) writeWithTag(buf *bytes.Buffer) {
 	writeLE(buf, uint32(7))
 	if t.v {
@@ -116,14 +137,36 @@ type metadataEntry struct {
 	value testValue
 }
 
+// testTensor describes a tensor-info entry written by buildGGUFWithTensors.
+type testTensor struct {
+	name string
+	dims []uint64
+	typ  GGMLType
+}
+
 // buildGGUF constructs a minimal valid GGUF byte buffer.
 func buildGGUF(metadata []metadataEntry, tensorCount uint64) []byte {
+	// Tensor info entries (minimal: 1D tensors of type F32)
+	tensors := make([]testTensor, 0, tensorCount)
+	for i := uint64(0); i < tensorCount; i++ {
+		tensors = append(tensors, testTensor{
+			name: fmt.Sprintf("tensor.%d", i),
+			dims: []uint64{128},
+			typ:  GGMLTypeF32,
+		})
+	}
+	return buildGGUFWithTensors(metadata, tensors)
+}
+
+// buildGGUFWithTensors constructs a GGUF byte buffer with caller-specified
+// tensor shapes and types. Offsets are spaced 512 bytes apart.
+func buildGGUFWithTensors(metadata []metadataEntry, tensors []testTensor) []byte {
 	buf := &bytes.Buffer{}
 
 	// Header
 	writeLE(buf, uint32(0x46554747)) // magic
 	writeLE(buf, uint32(3))          // version
-	writeLE(buf, tensorCount)
+	writeLE(buf, uint64(len(tensors)))
 	writeLE(buf, uint64(len(metadata)))
 
 	// Metadata KV pairs
@@ -132,14 +175,14 @@ func buildGGUF(metadata []metadataEntry, tensorCount uint64) []byte {
 		kv.value.writeWithTag(buf)
 	}
 
-	// Tensor info entries (minimal: 1D tensors of type F32)
-	for i := uint64(0); i < tensorCount; i++ {
-		name := fmt.Sprintf("tensor.%d", i)
-		writeGGUFString(buf, name)
-		writeLE(buf, uint32(1))   // n_dimensions = 1
-		writeLE(buf, uint64(128)) // dimension[0] = 128
-		writeLE(buf, uint32(0))   // type = F32
-		writeLE(buf, i*512)       // offset
+	for i, ti := range tensors {
+		writeGGUFString(buf, ti.name)
+		writeLE(buf, uint32(len(ti.dims)))
+		for _, d := range ti.dims {
+			writeLE(buf, d)
+		}
+		writeLE(buf, uint32(ti.typ))
+		writeLE(buf, uint64(i)*512) // offset
 	}
 
 	return buf.Bytes()
@@ -156,7 +199,7 @@ func TestParseValidHeader(t *testing.T) {
 	writeLE(buf, uint64(10))         // tensor_count
 	writeLE(buf, uint64(5))          // metadata_kv_count
 
-	header, err := parseHeader(buf)
+	header, err := newParser(buf).parseHeader()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -178,7 +221,7 @@ func TestInvalidMagic(t *testing.T) {
 	writeLE(buf, uint64(0))
 	writeLE(buf, uint64(0))
 
-	_, err := parseHeader(buf)
+	_, err := newParser(buf).parseHeader()
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -194,7 +237,7 @@ func TestUnsupportedVersion(t *testing.T) {
 	writeLE(buf, uint64(0))
 	writeLE(buf, uint64(0))
 
-	_, err := parseHeader(buf)
+	_, err := newParser(buf).parseHeader()
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -203,13 +246,105 @@ func TestUnsupportedVersion(t *testing.T) {
 	}
 }
 
+// buildOrderedGGUF writes the same small v2 file in the given byte order.
+// llama.cpp writes the magic as raw "GGUF" bytes on every host; swapMagic
+// instead writes it as a byte-swapped u32 like a naive big-endian writer.
+func buildOrderedGGUF(order binary.ByteOrder, swapMagic bool) []byte {
+	buf := &bytes.Buffer{}
+	w := func(v interface{}) {
+		if err := binary.Write(buf, order, v); err != nil {
+			panic(fmt.Sprintf("binary.Write failed: %v", err))
+		}
+	}
+	str := func(s string) {
+		w(uint64(len(s)))
+		buf.WriteString(s)
+	}
+
+	if swapMagic {
+		w(uint32(0x46554747))
+	} else {
+		buf.WriteString("GGUF")
+	}
+	w(uint32(2))
+	w(uint64(1)) // tensor_count
+	w(uint64(4)) // metadata_kv_count
+
+	str("general.architecture")
+	w(uint32(8))
+	str("llama")
+
+	str("llama.context_length")
+	w(uint32(4))
+	w(uint32(4096))
+
+	str("llama.rope.freq_base")
+	w(uint32(6))
+	w(float32(10000))
+
+	str("tokenizer.ggml.token_type")
+	w(uint32(9))
+	w(uint32(5)) // int32 elements
+	w(uint64(3))
+	w(int32(1))
+	w(int32(-2))
+	w(int32(3))
+
+	str("blk.0.attn_q.weight")
+	w(uint32(2))
+	w(uint64(4096))
+	w(uint64(4096))
+	w(uint32(GGMLTypeQ4K))
+	w(uint64(0))
+
+	return buf.Bytes()
+}
+
+func TestParseBigEndian(t *testing.T) {
+	want, err := Parse(bytes.NewReader(buildOrderedGGUF(binary.LittleEndian, false)))
+	if err != nil {
+		t.Fatalf("little-endian parse: %v", err)
+	}
+	if want.ContextLength() != 4096 || want.Architecture() != "llama" {
+		t.Fatalf("little-endian baseline parsed wrong: ctx=%d arch=%q", want.ContextLength(), want.Architecture())
+	}
+
+	for _, tt := range []struct {
+		name      string
+		swapMagic bool
+	}{
+		{name: "raw GGUF magic", swapMagic: false},
+		{name: "byte-swapped magic", swapMagic: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(bytes.NewReader(buildOrderedGGUF(binary.BigEndian, tt.swapMagic)))
+			if err != nil {
+				t.Fatalf("big-endian parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("big-endian file parsed to\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnknownMagicOrientation(t *testing.T) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("GUFF")
+	writeLE(buf, uint32(3))
+	_, err := Parse(bytes.NewReader(buf.Bytes()))
+	if !errors.Is(err, ErrInvalidMagic) {
+		t.Errorf("expected ErrInvalidMagic, got %v", err)
+	}
+}
+
 func TestReadString(t *testing.T) {
 	buf := &bytes.Buffer{}
 	s := "hello, gguf!"
 	writeLE(buf, uint64(len(s)))
 	buf.WriteString(s)
 
-	result, err := readString(buf)
+	result, err := newParser(buf).readString()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -224,7 +359,7 @@ func TestParseStringValue(t *testing.T) {
 	writeLE(buf, uint64(5)) // length
 	buf.WriteString("llama")
 
-	value, err := readValue(buf)
+	value, err := newParser(buf).readValue()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -242,7 +377,7 @@ func TestParseUint32Value(t *testing.T) {
 	writeLE(buf, uint32(4)) // UINT32 type tag
 	writeLE(buf, uint32(4096))
 
-	value, err := readValue(buf)
+	value, err := newParser(buf).readValue()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -259,7 +394,7 @@ func TestTruncatedInput(t *testing.T) {
 	// Only 2 bytes — not enough for a u32 magic number
 	buf := bytes.NewReader([]byte{0x47, 0x47})
 
-	_, err := parseHeader(buf)
+	_, err := newParser(buf).parseHeader()
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -462,6 +597,280 @@ func TestConvenienceMethods(t *testing.T) {
 	}
 }
 
+func TestHeadCountKVAndFeedForwardLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		metadata  []metadataEntry
+		wantKV    uint64
+		wantFFN   uint64
+		wantHeads uint64
+	}{
+		{
+			name: "GQA model",
+			metadata: []metadataEntry{
+				{key: "general.architecture", value: testString{s: "llama"}},
+				{key: "llama.attention.head_count", value: testUint32{v: 32}},
+				{key: "llama.attention.head_count_kv", value: testUint32{v: 8}},
+				{key: "llama.feed_forward_length", value: testUint32{v: 14336}},
+			},
+			wantKV:    8,
+			wantFFN:   14336,
+			wantHeads: 32,
+		},
+		{
+			name: "MHA model without head_count_kv",
+			metadata: []metadataEntry{
+				{key: "general.architecture", value: testString{s: "phi2"}},
+				{key: "phi2.attention.head_count", value: testUint32{v: 32}},
+				{key: "phi2.feed_forward_length", value: testUint32{v: 10240}},
+			},
+			wantKV:    32,
+			wantFFN:   10240,
+			wantHeads: 32,
+		},
+		{
+			name:     "no architecture",
+			metadata: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gguf, err := Parse(bytes.NewReader(buildGGUF(tt.metadata, 0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := gguf.HeadCount(); got != tt.wantHeads {
+				t.Errorf("HeadCount() = %d, want %d", got, tt.wantHeads)
+			}
+			if got := gguf.HeadCountKV(); got != tt.wantKV {
+				t.Errorf("HeadCountKV() = %d, want %d", got, tt.wantKV)
+			}
+			if got := gguf.FeedForwardLength(); got != tt.wantFFN {
+				t.Errorf("FeedForwardLength() = %d, want %d", got, tt.wantFFN)
+			}
+		})
+	}
+}
+
+func TestTokenizerMetadata(t *testing.T) {
+	const tmpl = "{% for message in messages %}{{ message['content'] }}{% endfor %}"
+	data := buildGGUF([]metadataEntry{
+		{key: "general.architecture", value: testString{s: "llama"}},
+		{key: "tokenizer.ggml.model", value: testString{s: "llama"}},
+		{key: "tokenizer.ggml.bos_token_id", value: testUint32{v: 1}},
+		{key: "tokenizer.ggml.eos_token_id", value: testUint32{v: 2}},
+		{key: "tokenizer.chat_template", value: testString{s: tmpl}},
+	}, 0)
+	gguf, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gguf.ChatTemplate(); got != tmpl {
+		t.Errorf("ChatTemplate() = %q, want %q", got, tmpl)
+	}
+	if got := gguf.TokenizerModel(); got != "llama" {
+		t.Errorf("TokenizerModel() = %q, want %q", got, "llama")
+	}
+	if id, ok := gguf.BOSTokenID(); !ok || id != 1 {
+		t.Errorf("BOSTokenID() = (%d, %v), want (1, true)", id, ok)
+	}
+	if id, ok := gguf.EOSTokenID(); !ok || id != 2 {
+		t.Errorf("EOSTokenID() = (%d, %v), want (2, true)", id, ok)
+	}
+}
+
+func TestMoEMetadata(t *testing.T) {
+	data := buildGGUF([]metadataEntry{
+		{key: "general.architecture", value: testString{s: "qwen3moe"}},
+		{key: "qwen3moe.block_count", value: testUint32{v: 48}},
+		{key: "qwen3moe.expert_count", value: testUint32{v: 128}},
+		{key: "qwen3moe.expert_used_count", value: testUint32{v: 8}},
+	}, 0)
+	gguf, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gguf.ExpertCount(); got != 128 {
+		t.Errorf("ExpertCount() = %d, want 128", got)
+	}
+	if got := gguf.ExpertUsedCount(); got != 8 {
+		t.Errorf("ExpertUsedCount() = %d, want 8", got)
+	}
+	if !gguf.IsMoE() {
+		t.Error("IsMoE() = false, want true")
+	}
+}
+
+func TestMoEMetadataDenseModel(t *testing.T) {
+	data := buildGGUF([]metadataEntry{
+		{key: "general.architecture", value: testString{s: "llama"}},
+		{key: "llama.block_count", value: testUint32{v: 32}},
+	}, 0)
+	gguf, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gguf.ExpertCount(); got != 0 {
+		t.Errorf("ExpertCount() = %d, want 0", got)
+	}
+	if got := gguf.ExpertUsedCount(); got != 0 {
+		t.Errorf("ExpertUsedCount() = %d, want 0", got)
+	}
+	if gguf.IsMoE() {
+		t.Error("IsMoE() = true, want false")
+	}
+}
+
+func TestTokenizerMetadataMissing(t *testing.T) {
+	gguf, err := Parse(bytes.NewReader(buildGGUF(nil, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gguf.ChatTemplate(); got != "" {
+		t.Errorf("ChatTemplate() = %q, want empty", got)
+	}
+	if got := gguf.TokenizerModel(); got != "" {
+		t.Errorf("TokenizerModel() = %q, want empty", got)
+	}
+	if _, ok := gguf.BOSTokenID(); ok {
+		t.Error("BOSTokenID() ok = true, want false")
+	}
+	if _, ok := gguf.EOSTokenID(); ok {
+		t.Error("EOSTokenID() ok = true, want false")
+	}
+}
+
+func splitShard(no, count uint16, totalTensors int32, extra []metadataEntry, tensors []testTensor) []byte {
+	metadata := append([]metadataEntry{
+		{key: "split.no", value: testUint16{v: no}},
+		{key: "split.count", value: testUint16{v: count}},
+		{key: "split.tensors.count", value: testInt32{v: totalTensors}},
+	}, extra...)
+	return buildGGUFWithTensors(metadata, tensors)
+}
+
+func TestParseSplit(t *testing.T) {
+	shard0 := splitShard(0, 2, 3, []metadataEntry{
+		{key: "general.architecture", value: testString{s: "llama"}},
+		{key: "llama.block_count", value: testUint32{v: 32}},
+	}, []testTensor{
+		{name: "token_embd.weight", dims: []uint64{4096, 32000}, typ: GGMLTypeQ4K},
+		{name: "blk.0.attn_q.weight", dims: []uint64{4096, 4096}, typ: GGMLTypeQ4K},
+	})
+	shard1 := splitShard(1, 2, 3, nil, []testTensor{
+		{name: "output.weight", dims: []uint64{4096, 32000}, typ: GGMLTypeQ6K},
+	})
+
+	// Shards are passed out of order; split.no decides the merge order.
+	gguf, err := ParseSplit([]io.Reader{bytes.NewReader(shard1), bytes.NewReader(shard0)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gguf.Architecture() != "llama" {
+		t.Errorf("architecture = %q, want %q", gguf.Architecture(), "llama")
+	}
+	if gguf.BlockCount() != 32 {
+		t.Errorf("block_count = %d, want 32", gguf.BlockCount())
+	}
+	if gguf.SplitCount() != 2 || gguf.SplitNo() != 0 || gguf.SplitTensorsCount() != 3 {
+		t.Errorf("split metadata = (no %d, count %d, tensors %d), want (0, 2, 3)",
+			gguf.SplitNo(), gguf.SplitCount(), gguf.SplitTensorsCount())
+	}
+	if gguf.Header.TensorCount != 3 || len(gguf.TensorInfo) != 3 {
+		t.Fatalf("tensor count = %d (%d infos), want 3", gguf.Header.TensorCount, len(gguf.TensorInfo))
+	}
+	wantNames := []string{"token_embd.weight", "blk.0.attn_q.weight", "output.weight"}
+	for i, want := range wantNames {
+		if gguf.TensorInfo[i].Name != want {
+			t.Errorf("tensor[%d] = %q, want %q", i, gguf.TensorInfo[i].Name, want)
+		}
+	}
+}
+
+func TestParseSplitErrors(t *testing.T) {
+	tensor := []testTensor{{name: "t", dims: []uint64{32}, typ: GGMLTypeF32}}
+	tests := []struct {
+		name   string
+		shards [][]byte
+	}{
+		{name: "no shards"},
+		{
+			name:   "missing shard",
+			shards: [][]byte{splitShard(0, 3, 3, nil, tensor), splitShard(2, 3, 3, nil, tensor)},
+		},
+		{
+			name:   "duplicate shard",
+			shards: [][]byte{splitShard(0, 2, 2, nil, tensor), splitShard(0, 2, 2, nil, tensor)},
+		},
+		{
+			name:   "tensor count mismatch",
+			shards: [][]byte{splitShard(0, 2, 5, nil, tensor), splitShard(1, 2, 5, nil, tensor)},
+		},
+		{
+			name:   "non-split file among shards",
+			shards: [][]byte{splitShard(0, 2, 2, nil, tensor), buildGGUF(nil, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readers := make([]io.Reader, 0, len(tt.shards))
+			for _, s := range tt.shards {
+				readers = append(readers, bytes.NewReader(s))
+			}
+			if _, err := ParseSplit(readers); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseSplitSingleFile(t *testing.T) {
+	gguf, err := ParseSplit([]io.Reader{bytes.NewReader(buildGGUF(nil, 2))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gguf.TensorInfo) != 2 || gguf.SplitCount() != 0 {
+		t.Errorf("got %d tensors, split.count %d; want 2 tensors, no split", len(gguf.TensorInfo), gguf.SplitCount())
+	}
+}
+
+func TestSplitShardNames(t *testing.T) {
+	got, ok := SplitShardNames("qwen-72b-q4_k_m-00001-of-00003.gguf")
+	if !ok {
+		t.Fatal("expected first shard to expand")
+	}
+	want := []string{
+		"qwen-72b-q4_k_m-00001-of-00003.gguf",
+		"qwen-72b-q4_k_m-00002-of-00003.gguf",
+		"qwen-72b-q4_k_m-00003-of-00003.gguf",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("name[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	for _, name := range []string{
+		"model.gguf",
+		"model-00002-of-00003.gguf",
+		"model-1-of-3.gguf",
+	} {
+		if _, ok := SplitShardNames(name); ok {
+			t.Errorf("SplitShardNames(%q) ok = true, want false", name)
+		}
+	}
+}
+
 func TestParseEmptyGGUF(t *testing.T) {
 	data := buildGGUF(nil, 0)
 	gguf, err := Parse(bytes.NewReader(data))
@@ -558,7 +967,7 @@ func TestRejectOversizedString(t *testing.T) {
 	// Write a string length that exceeds the safety limit
 	writeLE(buf, uint64(maxStringLength+1))
 
-	_, err := readString(buf)
+	_, err := newParser(buf).readString()
 	if err == nil {
 		t.Fatal("expected error for oversized string, got nil")
 	}
@@ -576,7 +985,7 @@ func TestRejectOversizedArray(t *testing.T) {
 	// Count: exceeds limit
 	writeLE(buf, uint64(maxArrayCount+1))
 
-	_, err := readValue(buf)
+	_, err := newParser(buf).readValue()
 	if err == nil {
 		t.Fatal("expected error for oversized array, got nil")
 	}
@@ -592,7 +1001,7 @@ func TestRejectOversizedDimensions(t *testing.T) {
 	// n_dimensions: exceeds limit
 	writeLE(buf, uint32(maxDimensions+1))
 
-	_, err := parseTensorInfo(buf)
+	_, err := newParser(buf).parseTensorInfo()
 	if err == nil {
 		t.Fatal("expected error for oversized dimensions, got nil")
 	}
@@ -614,6 +1023,187 @@ func TestGGMLTypeString(t *testing.T) {
 	}
 }
 
+func TestParameterCountAndTensorDataSize(t *testing.T) {
+	data := buildGGUFWithTensors(nil, []testTensor{
+		{name: "token_embd.weight", dims: []uint64{4096, 32000}, typ: GGMLTypeQ4K},
+		{name: "blk.0.attn_q.weight", dims: []uint64{4096, 4096}, typ: GGMLTypeQ6K},
+		{name: "blk.0.attn_norm.weight", dims: []uint64{4096}, typ: GGMLTypeF32},
+		{name: "output.weight", dims: []uint64{4096, 32000}, typ: GGMLTypeF16},
+		{name: "blk.0.ffn_down.weight", dims: []uint64{64, 64}, typ: GGMLTypeQ8_0},
+	})
+
+	gguf, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantParams := uint64(4096*32000 + 4096*4096 + 4096 + 4096*32000 + 64*64)
+	if got := gguf.ParameterCount(); got != wantParams {
+		t.Errorf("ParameterCount() = %d, want %d", got, wantParams)
+	}
+
+	wantBytes := uint64(4096*32000/256*144) + // Q4_K: 144 bytes per 256 elements
+		uint64(4096*4096/256*210) + // Q6_K: 210 bytes per 256 elements
+		uint64(4096*4) + // F32
+		uint64(4096*32000*2) + // F16
+		uint64(64*64/32*34) // Q8_0: 34 bytes per 32 elements
+	if got := gguf.TensorDataSize(); got != wantBytes {
+		t.Errorf("TensorDataSize() = %d, want %d", got, wantBytes)
+	}
+}
+
+func TestTensorDataSizeEdgeCases(t *testing.T) {
+	tests := []struct {
+		name   string
+		tensor TensorInfo
+		want   uint64
+	}{
+		{"partial block rounds up", TensorInfo{Dimensions: []uint64{33}, Type: GGMLTypeQ4_0}, 2 * 18},
+		{"unknown type", TensorInfo{Dimensions: []uint64{128}, Type: GGMLType(999)}, 0},
+		{"no dimensions", TensorInfo{Type: GGMLTypeF32}, 0},
+		{"bf16", TensorInfo{Dimensions: []uint64{10, 10}, Type: GGMLTypeBF16}, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tensor.DataSize(); got != tt.want {
+				t.Errorf("DataSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParameterCountEmpty(t *testing.T) {
+	gguf, err := Parse(bytes.NewReader(buildGGUF(nil, 0)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gguf.ParameterCount() != 0 {
+		t.Errorf("ParameterCount() = %d, want 0", gguf.ParameterCount())
+	}
+	if gguf.TensorDataSize() != 0 {
+		t.Errorf("TensorDataSize() = %d, want 0", gguf.TensorDataSize())
+	}
+}
+
+// llama7BTensors returns the tensor layout of a Llama-2-7B style model
+// (32 blocks, 4096 embedding, 11008 FFN, 32000 vocab) at the given type.
+func llama7BTensors(typ GGMLType) []testTensor {
+	const (
+		embd  = 4096
+		ffn   = 11008
+		vocab = 32000
+	)
+	tensors := []testTensor{
+		{name: "token_embd.weight", dims: []uint64{embd, vocab}, typ: typ},
+		{name: "output_norm.weight", dims: []uint64{embd}, typ: GGMLTypeF32},
+		{name: "output.weight", dims: []uint64{embd, vocab}, typ: typ},
+	}
+	for i := 0; i < 32; i++ {
+		p := fmt.Sprintf("blk.%d.", i)
+		tensors = append(tensors,
+			testTensor{name: p + "attn_norm.weight", dims: []uint64{embd}, typ: GGMLTypeF32},
+			testTensor{name: p + "attn_q.weight", dims: []uint64{embd, embd}, typ: typ},
+			testTensor{name: p + "attn_k.weight", dims: []uint64{embd, embd}, typ: typ},
+			testTensor{name: p + "attn_v.weight", dims: []uint64{embd, embd}, typ: typ},
+			testTensor{name: p + "attn_output.weight", dims: []uint64{embd, embd}, typ: typ},
+			testTensor{name: p + "ffn_norm.weight", dims: []uint64{embd}, typ: GGMLTypeF32},
+			testTensor{name: p + "ffn_gate.weight", dims: []uint64{embd, ffn}, typ: typ},
+			testTensor{name: p + "ffn_up.weight", dims: []uint64{embd, ffn}, typ: typ},
+			testTensor{name: p + "ffn_down.weight", dims: []uint64{ffn, embd}, typ: typ},
+		)
+	}
+	return tensors
+}
+
+func TestEstimateVRAM7B(t *testing.T) {
+	const gib = uint64(1) << 30
+	data := buildGGUFWithTensors([]metadataEntry{
+		{key: "general.architecture", value: testString{s: "llama"}},
+		{key: "llama.context_length", value: testUint32{v: 4096}},
+		{key: "llama.embedding_length", value: testUint32{v: 4096}},
+		{key: "llama.block_count", value: testUint32{v: 32}},
+		{key: "llama.attention.head_count", value: testUint32{v: 32}},
+	}, llama7BTensors(GGMLTypeQ4K))
+
+	gguf, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// ~6.74B params at 4.5 bits/weight is roughly 3.8 GB of weights.
+	params := gguf.ParameterCount()
+	if params < 6_700_000_000 || params > 6_800_000_000 {
+		t.Fatalf("ParameterCount() = %d, want ~6.74B", params)
+	}
+
+	tests := []struct {
+		name    string
+		context uint64
+		wantKV  uint64
+	}{
+		// 2 (K+V) * 32 layers * ctx * 4096 (head_dim 128 * 32 kv heads) * 2 bytes (F16)
+		{"4K context", 4096, 2 * gib},
+		{"32K context", 32768, 16 * gib},
+		{"zero uses trained context", 0, 2 * gib},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est := gguf.EstimateVRAM(tt.context)
+			if est.WeightsBytes != gguf.TensorDataSize() {
+				t.Errorf("WeightsBytes = %d, want TensorDataSize() %d", est.WeightsBytes, gguf.TensorDataSize())
+			}
+			if est.WeightsBytes < 3_700_000_000 || est.WeightsBytes > 3_900_000_000 {
+				t.Errorf("WeightsBytes = %d, want ~3.8 GB for Q4_K 7B", est.WeightsBytes)
+			}
+			if est.KVCacheBytes != tt.wantKV {
+				t.Errorf("KVCacheBytes = %d, want %d", est.KVCacheBytes, tt.wantKV)
+			}
+			if est.TotalBytes != est.WeightsBytes+est.KVCacheBytes {
+				t.Errorf("TotalBytes = %d, want %d", est.TotalBytes, est.WeightsBytes+est.KVCacheBytes)
+			}
+		})
+	}
+}
+
+func TestEstimateVRAMGQAAndCacheType(t *testing.T) {
+	const mib = uint64(1) << 20
+	// Mistral-7B shape: 8 KV heads shrink the cache 4x relative to MHA.
+	data := buildGGUF([]metadataEntry{
+		{key: "general.architecture", value: testString{s: "llama"}},
+		{key: "llama.embedding_length", value: testUint32{v: 4096}},
+		{key: "llama.block_count", value: testUint32{v: 32}},
+		{key: "llama.attention.head_count", value: testUint32{v: 32}},
+		{key: "llama.attention.head_count_kv", value: testUint32{v: 8}},
+	}, 0)
+
+	gguf, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gguf.EstimateVRAM(4096).KVCacheBytes; got != 512*mib {
+		t.Errorf("F16 KVCacheBytes = %d, want %d", got, 512*mib)
+	}
+	// Q8_0 stores 32 elements in 34 bytes.
+	if got := gguf.EstimateVRAMWithCacheType(4096, GGMLTypeQ8_0).KVCacheBytes; got != 256*mib/32*34 {
+		t.Errorf("Q8_0 KVCacheBytes = %d, want %d", got, 256*mib/32*34)
+	}
+}
+
+func TestEstimateVRAMMissingMetadata(t *testing.T) {
+	gguf, err := Parse(bytes.NewReader(buildGGUF(nil, 2)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	est := gguf.EstimateVRAM(4096)
+	if est.KVCacheBytes != 0 {
+		t.Errorf("KVCacheBytes = %d, want 0 without attention metadata", est.KVCacheBytes)
+	}
+	if est.TotalBytes != est.WeightsBytes || est.WeightsBytes != 2*128*4 {
+		t.Errorf("estimate = %+v, want weights-only %d", est, 2*128*4)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Remote header-only read
 // ---------------------------------------------------------------------------
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gguf
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Metadata keys written by llama.cpp's gguf-split tool on every shard.
+const (
+	keySplitNo           = "split.no"
+	keySplitCount        = "split.count"
+	keySplitTensorsCount = "split.tensors.count"
+)
+
+// splitNameRe matches llama.cpp's shard naming, "<prefix>-%05d-of-%05d.gguf".
+var splitNameRe = regexp.MustCompile(`^(.+)-(\d{5})-of-(\d{5})\.gguf$`)
+
+// SplitNo returns this shard's zero-based index within a split model. Files
+// that are not split report 0.
+func (f *GGUFFile) SplitNo() uint64 {
+	n, _ := f.splitValue(keySplitNo)
+	return n
+}
+
+// SplitCount returns the number of shards the model is split into, or 0 if
+// the file carries no split metadata.
+func (f *GGUFFile) SplitCount() uint64 {
+	n, _ := f.splitValue(keySplitCount)
+	return n
+}
+
+// SplitTensorsCount returns the total number of tensors across all shards of
+// a split model, or 0 if the file carries no split metadata.
+func (f *GGUFFile) SplitTensorsCount() uint64 {
+	n, _ := f.splitValue(keySplitTensorsCount)
+	return n
+}
+
+// splitValue reads a split.* counter. gguf-split writes split.no and
+// split.count as uint16 but split.tensors.count as int32, so both unsigned
+// and non-negative signed values are accepted.
+func (f *GGUFFile) splitValue(key string) (uint64, bool) {
+	v, ok := f.GetMetadata(key)
+	if !ok {
+		return 0, false
+	}
+	if n, ok := AsU64(v); ok {
+		return n, true
+	}
+	switch val := v.(type) {
+	case Int32Val:
+		if val.Value >= 0 {
+			return uint64(val.Value), true
+		}
+	case Int16Val:
+		if val.Value >= 0 {
+			return uint64(val.Value), true
+		}
+	case Int64Val:
+		if val.Value >= 0 {
+			return uint64(val.Value), true
+		}
+	}
+	return 0, false
+}
+
+// ParseSplit parses every shard of a split model and merges them into a
+// single GGUFFile. Shards may be given in any order; they are ordered by
+// split.no. Metadata comes from the first shard (the only one gguf-split
+// populates with model KVs) and tensor info is concatenated across shards.
+// A single reader without split metadata is parsed as an ordinary file.
+func ParseSplit(readers []io.Reader) (*GGUFFile, error) {
+	if len(readers) == 0 {
+		return nil, fmt.Errorf("no shards provided")
+	}
+
+	shards := make([]*GGUFFile, 0, len(readers))
+	for i, r := range readers {
+		f, err := Parse(r)
+		if err != nil {
+			return nil, fmt.Errorf("shard %d: %w", i, err)
+		}
+		shards = append(shards, f)
+	}
+
+	if len(shards) == 1 && shards[0].SplitCount() == 0 {
+		return shards[0], nil
+	}
+
+	sort.SliceStable(shards, func(i, j int) bool {
+		return shards[i].SplitNo() < shards[j].SplitNo()
+	})
+
+	count := uint64(len(shards))
+	for i, s := range shards {
+		if _, ok := s.splitValue(keySplitNo); !ok {
+			return nil, fmt.Errorf("shard %d has no %s metadata", i, keySplitNo)
+		}
+		if s.SplitNo() != uint64(i) {
+			return nil, fmt.Errorf("missing or duplicate shard: expected split.no %d, got %d", i, s.SplitNo())
+		}
+		if s.SplitCount() != count {
+			return nil, fmt.Errorf("shard %d reports split.count %d, but %d shards were provided", i, s.SplitCount(), count)
+		}
+	}
+
+	first := shards[0]
+	merged := &GGUFFile{
+		Header:   first.Header,
+		Metadata: first.Metadata,
+	}
+	for _, s := range shards {
+		merged.TensorInfo = append(merged.TensorInfo, s.TensorInfo...)
+	}
+	merged.Header.TensorCount = uint64(len(merged.TensorInfo))
+
+	if want, ok := first.splitValue(keySplitTensorsCount); ok && want != merged.Header.TensorCount {
+		return nil, fmt.Errorf("split.tensors.count is %d, but shards contain %d tensors", want, merged.Header.TensorCount)
+	}
+
+	return merged, nil
+}
+
+// SplitShardNames expands the file name of a split model's first shard
+// ("model-00001-of-00003.gguf") into the names of all its shards, in order.
+// It reports false when name is not the first shard of a split model.
+func SplitShardNames(name string) ([]string, bool) {
+	m := splitNameRe.FindStringSubmatch(name)
+	if m == nil {
+		return nil, false
+	}
+	no, _ := strconv.Atoi(m[2])
+	count, _ := strconv.Atoi(m[3])
+	if no != 1 || count < 1 {
+		return nil, false
+	}
+
+	names := make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		names = append(names, fmt.Sprintf("%s-%05d-of-%05d.gguf", m[1], i, count))
+	}
+	return names, true
+}